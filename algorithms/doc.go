@@ -0,0 +1,4 @@
+// Package algorithms provides operations that are composed of several OpenCL API calls.
+//
+// The functions work on the objects of the cl30 package and do not hold any state of their own.
+package algorithms
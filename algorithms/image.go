@@ -0,0 +1,119 @@
+package algorithms
+
+import (
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+// CopyBufferToImage2D copies a region of width by height pixels from a buffer into a 2D image,
+// without the need for a sampler.
+//
+// The first row of the source starts at srcOffset, and each further row starts srcRowPitch bytes after the
+// previous one. If srcRowPitch is zero, the rows are considered to be tightly packed. In contrast to
+// cl.EnqueueCopyBufferToImage(), source rows with padding are supported; they are copied row by row.
+//
+// The provided event, if not nil, identifies the completion of the complete copy operation.
+func CopyBufferToImage2D(commandQueue cl.CommandQueue, src cl.MemObject, srcOffset, srcRowPitch uintptr,
+	dst cl.MemObject, dstOrigin [2]uintptr, width, height uintptr, waitList []cl.Event, event *cl.Event) error {
+	packedRowPitch, err := packedRowPitchOf(dst, width)
+	if err != nil {
+		return err
+	}
+	if (srcRowPitch == 0) || (srcRowPitch == packedRowPitch) {
+		return cl.EnqueueCopyBufferToImage(commandQueue, src, dst, srcOffset,
+			[3]uintptr{dstOrigin[0], dstOrigin[1], 0}, [3]uintptr{width, height, 1}, waitList, event)
+	}
+	if srcRowPitch < packedRowPitch {
+		return cl.ErrInvalidValue
+	}
+	return copyRows(commandQueue, height, waitList, event, func(row uintptr, rowEvent *cl.Event) error {
+		return cl.EnqueueCopyBufferToImage(commandQueue, src, dst, srcOffset+row*srcRowPitch,
+			[3]uintptr{dstOrigin[0], dstOrigin[1] + row, 0}, [3]uintptr{width, 1, 1}, waitList, rowEvent)
+	})
+}
+
+// CopyImageToBuffer2D copies a region of width by height pixels from a 2D image into a buffer,
+// without the need for a sampler.
+//
+// The first row of the destination starts at dstOffset, and each further row starts dstRowPitch bytes after the
+// previous one. If dstRowPitch is zero, the rows are considered to be tightly packed.
+//
+// The provided event, if not nil, identifies the completion of the complete copy operation.
+func CopyImageToBuffer2D(commandQueue cl.CommandQueue, src cl.MemObject, srcOrigin [2]uintptr, width, height uintptr,
+	dst cl.MemObject, dstOffset, dstRowPitch uintptr, waitList []cl.Event, event *cl.Event) error {
+	packedRowPitch, err := packedRowPitchOf(src, width)
+	if err != nil {
+		return err
+	}
+	if (dstRowPitch == 0) || (dstRowPitch == packedRowPitch) {
+		return cl.EnqueueCopyImageToBuffer(commandQueue, src, dst,
+			[3]uintptr{srcOrigin[0], srcOrigin[1], 0}, [3]uintptr{width, height, 1}, dstOffset, waitList, event)
+	}
+	if dstRowPitch < packedRowPitch {
+		return cl.ErrInvalidValue
+	}
+	return copyRows(commandQueue, height, waitList, event, func(row uintptr, rowEvent *cl.Event) error {
+		return cl.EnqueueCopyImageToBuffer(commandQueue, src, dst,
+			[3]uintptr{srcOrigin[0], srcOrigin[1] + row, 0}, [3]uintptr{width, 1, 1}, dstOffset+row*dstRowPitch,
+			waitList, rowEvent)
+	})
+}
+
+// CreateImage2DFromBuffer creates a 2D image of given format and size, and initializes it with the content of
+// the buffer, as per CopyBufferToImage2D().
+//
+// The provided event, if not nil, identifies the completion of the initialization.
+func CreateImage2DFromBuffer(commandQueue cl.CommandQueue, context cl.Context, flags cl.MemFlags, format cl.ImageFormat,
+	width, height uintptr, src cl.MemObject, srcOffset, srcRowPitch uintptr, waitList []cl.Event, event *cl.Event) (cl.MemObject, error) {
+	image, err := cl.CreateImage(context, flags, format, cl.ImageDesc{
+		ImageType: cl.MemObjectImage2DType,
+		Width:     width,
+		Height:    height,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+	err = CopyBufferToImage2D(commandQueue, src, srcOffset, srcRowPitch, image, [2]uintptr{0, 0}, width, height,
+		waitList, event)
+	if err != nil {
+		_ = cl.ReleaseMemObject(image)
+		return 0, err
+	}
+	return image, nil
+}
+
+func packedRowPitchOf(image cl.MemObject, width uintptr) (uintptr, error) {
+	var elementSize uintptr
+	_, err := cl.ImageInfo(image, cl.ImageElementSizeInfo, unsafe.Sizeof(elementSize), unsafe.Pointer(&elementSize))
+	if err != nil {
+		return 0, err
+	}
+	return elementSize * width, nil
+}
+
+// copyRows enqueues one copy command per row and combines their completion into one marker event.
+func copyRows(commandQueue cl.CommandQueue, height uintptr, waitList []cl.Event, event *cl.Event,
+	copyRow func(row uintptr, rowEvent *cl.Event) error) error {
+	rowEvents := make([]cl.Event, 0, height)
+	defer func() {
+		for _, rowEvent := range rowEvents {
+			_ = cl.ReleaseEvent(rowEvent)
+		}
+	}()
+	for row := uintptr(0); row < height; row++ {
+		var rowEvent cl.Event
+		err := copyRow(row, &rowEvent)
+		if err != nil {
+			return err
+		}
+		rowEvents = append(rowEvents, rowEvent)
+	}
+	if event == nil {
+		return nil
+	}
+	if len(rowEvents) == 0 {
+		return cl.EnqueueMarkerWithWaitList(commandQueue, waitList, event)
+	}
+	return cl.EnqueueMarkerWithWaitList(commandQueue, rowEvents, event)
+}
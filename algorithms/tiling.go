@@ -0,0 +1,27 @@
+package algorithms
+
+import (
+	cl "github.com/opencl-go/cl30"
+)
+
+// SquareTileSize returns the edge length of the largest square tile, in elements, that fits twice into the local
+// memory of the device. Using half of the local memory per tile leaves room for double-buffering.
+// The returned value is a power of two, and at least 1.
+func SquareTileSize(device cl.DeviceID, elementSize uintptr) (uintptr, error) {
+	hierarchy, err := cl.DeviceMemoryHierarchy(device)
+	if err != nil {
+		return 0, err
+	}
+	return squareTileSizeFor(uintptr(hierarchy.LocalMemSize)/2, elementSize), nil
+}
+
+func squareTileSizeFor(available, elementSize uintptr) uintptr {
+	if elementSize == 0 {
+		elementSize = 1
+	}
+	edge := uintptr(1)
+	for (edge*2)*(edge*2)*elementSize <= available {
+		edge *= 2
+	}
+	return edge
+}
@@ -0,0 +1,77 @@
+package cl30
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// Arena is a block of host memory that is pinned for its whole lifetime.
+//
+// An arena serves as destination for reads of streaming consumers, which can not afford allocations per call.
+// As the memory is pinned already, non-blocking reads into an arena do not require the per-call pinning and
+// tracking of EnqueueReadBuffer().
+//
+// Use NewArena() to create an arena, and Release() once it is no longer used.
+type Arena struct {
+	data   []byte
+	pinner runtime.Pinner
+}
+
+// NewArena allocates and pins an arena of given size in bytes.
+func NewArena(size int) (*Arena, error) {
+	if size <= 0 {
+		return nil, ErrInvalidValue
+	}
+	arena := &Arena{data: make([]byte, size)}
+	arena.pinner.Pin(&arena.data[0])
+	return arena, nil
+}
+
+// Release unpins the memory of the arena. The arena must not be used after this call, and there must not be
+// pending operations on it.
+func (arena *Arena) Release() {
+	arena.pinner.Unpin()
+	arena.data = nil
+}
+
+// Size returns the number of bytes of the arena.
+func (arena *Arena) Size() uintptr {
+	return uintptr(len(arena.data))
+}
+
+// Bytes returns the region of the arena at given offset and of given size.
+// ErrArenaBoundsExceeded is returned if the region is not within the arena.
+func (arena *Arena) Bytes(offset, size uintptr) ([]byte, error) {
+	err := arena.checkBounds(offset, size)
+	if err != nil {
+		return nil, err
+	}
+	return arena.data[offset : offset+size : offset+size], nil
+}
+
+func (arena *Arena) checkBounds(offset, size uintptr) error {
+	arenaSize := arena.Size()
+	if (offset > arenaSize) || (size > arenaSize-offset) {
+		return ErrArenaBoundsExceeded
+	}
+	return nil
+}
+
+// EnqueueReadBufferToArena enqueues a command to read from a buffer object into a region of an arena.
+//
+// The region of the arena starts at arenaOffset and has the same size as the region that is read from the buffer.
+// ErrArenaBoundsExceeded is returned if this region is not within the arena.
+//
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueReadBuffer.html
+func EnqueueReadBufferToArena(commandQueue CommandQueue, mem MemObject, blockingRead bool, offset, size uintptr,
+	arena *Arena, arenaOffset uintptr, waitList []Event, event *Event) error {
+	err := arena.checkBounds(arenaOffset, size)
+	if err != nil {
+		return err
+	}
+	if size == 0 {
+		return ErrInvalidValue
+	}
+	data := unsafe.Pointer(&arena.data[arenaOffset])
+	return enqueueReadBuffer(commandQueue, mem, blockingRead, offset, size, data, false, waitList, event)
+}
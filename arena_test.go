@@ -0,0 +1,28 @@
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestArenaBytes(t *testing.T) {
+	t.Parallel()
+	arena, err := cl.NewArena(16)
+	if err != nil {
+		t.Fatalf("failed to create arena: %v", err)
+	}
+	defer arena.Release()
+	region, err := arena.Bytes(4, 12)
+	if err != nil {
+		t.Fatalf("failed to get region: %v", err)
+	}
+	if (len(region) != 12) || (cap(region) != 12) {
+		t.Errorf("unexpected region size: %v/%v", len(region), cap(region))
+	}
+	_, err = arena.Bytes(4, 13)
+	if !errors.Is(err, cl.ErrArenaBoundsExceeded) {
+		t.Errorf("expected bounds error, got %v", err)
+	}
+}
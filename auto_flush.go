@@ -0,0 +1,187 @@
+package cl30
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AutoFlushStat contains the counters of the automatic flush policy of a command-queue.
+type AutoFlushStat struct {
+	// Enqueues is the number of enqueue calls that were counted for the command-queue.
+	Enqueues uint64
+	// Flushes is the number of Flush() calls that were issued automatically.
+	Flushes uint64
+	// FlushErrors is the number of automatically issued Flush() calls that failed.
+	FlushErrors uint64
+}
+
+type autoFlushPolicy struct {
+	every   int
+	pending int
+	ticker  *time.Ticker
+	stop    chan struct{}
+	done    chan struct{}
+	stat    AutoFlushStat
+}
+
+var autoFlush = struct {
+	// active is the number of policies. It lets enqueue calls skip the mutex while no policy exists.
+	active   int32
+	mutex    sync.Mutex
+	policies map[CommandQueue]*autoFlushPolicy
+}{
+	policies: make(map[CommandQueue]*autoFlushPolicy),
+}
+
+// SetAutoFlush configures the command-queue to be flushed automatically after every n-th enqueue call.
+// This smooths latency on implementations that batch commands aggressively and only submit them on a flush.
+//
+// A value of zero, or less, disables the count-based flush. The counters of the command-queue are kept until
+// the policy is removed with ClearAutoFlush().
+func SetAutoFlush(commandQueue CommandQueue, every int) {
+	autoFlush.mutex.Lock()
+	defer autoFlush.mutex.Unlock()
+	policy := autoFlushPolicyFor(commandQueue)
+	policy.every = every
+	policy.pending = 0
+}
+
+// SetAutoFlushInterval configures the command-queue to be flushed periodically with the given interval.
+// A value of zero, or less, stops the periodic flush.
+//
+// The periodic flush must be stopped, for example with ClearAutoFlush(), before the command-queue is released.
+// When this function returns, a previously running periodic flush has completed.
+func SetAutoFlushInterval(commandQueue CommandQueue, interval time.Duration) {
+	autoFlush.mutex.Lock()
+	policy := autoFlushPolicyFor(commandQueue)
+	stopped := policy.stopTicker()
+	if interval > 0 {
+		policy.ticker = time.NewTicker(interval)
+		policy.stop = make(chan struct{})
+		policy.done = make(chan struct{})
+		go policy.flushPeriodically(commandQueue, policy.ticker, policy.stop, policy.done)
+	}
+	autoFlush.mutex.Unlock()
+	awaitStopped(stopped)
+}
+
+// ClearAutoFlush removes any automatic flush policy, including its counters, from the command-queue.
+// When this function returns, no automatic flush of the command-queue is running anymore.
+//
+// ReleaseCommandQueue() does not remove the policy, as the command-queue may still be retained elsewhere.
+// Call this function before the last reference of a command-queue with a policy is released. Otherwise, the
+// periodic flush may operate on a deleted command-queue, and a new command-queue with the same handle inherits
+// the policy.
+func ClearAutoFlush(commandQueue CommandQueue) {
+	autoFlush.mutex.Lock()
+	policy, exists := autoFlush.policies[commandQueue]
+	if !exists {
+		autoFlush.mutex.Unlock()
+		return
+	}
+	stopped := policy.stopTicker()
+	delete(autoFlush.policies, commandQueue)
+	atomic.AddInt32(&autoFlush.active, -1)
+	autoFlush.mutex.Unlock()
+	awaitStopped(stopped)
+}
+
+// AutoFlushStats returns the counters of the automatic flush policy of the command-queue.
+// Only successful enqueue calls are counted.
+// The returned value is zero if the command-queue has no policy.
+func AutoFlushStats(commandQueue CommandQueue) AutoFlushStat {
+	autoFlush.mutex.Lock()
+	defer autoFlush.mutex.Unlock()
+	policy, exists := autoFlush.policies[commandQueue]
+	if !exists {
+		return AutoFlushStat{}
+	}
+	return policy.stat
+}
+
+func autoFlushPolicyFor(commandQueue CommandQueue) *autoFlushPolicy {
+	policy, exists := autoFlush.policies[commandQueue]
+	if !exists {
+		policy = &autoFlushPolicy{}
+		autoFlush.policies[commandQueue] = policy
+		atomic.AddInt32(&autoFlush.active, 1)
+	}
+	return policy
+}
+
+// stopTicker stops the periodic flush and returns the channel that is closed once its goroutine has returned.
+// The returned channel must be awaited without holding autoFlush.mutex, as a running flush needs the mutex.
+func (policy *autoFlushPolicy) stopTicker() <-chan struct{} {
+	if policy.ticker == nil {
+		return nil
+	}
+	done := policy.done
+	policy.ticker.Stop()
+	close(policy.stop)
+	policy.ticker = nil
+	policy.stop = nil
+	policy.done = nil
+	return done
+}
+
+func awaitStopped(done <-chan struct{}) {
+	if done != nil {
+		<-done
+	}
+}
+
+func (policy *autoFlushPolicy) flushPeriodically(commandQueue CommandQueue, ticker *time.Ticker,
+	stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			// select picks randomly among ready cases; a tick must not win over a pending stop.
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			flushAutomatically(commandQueue, policy)
+		}
+	}
+}
+
+// autoFlushAfterEnqueue is called by all enqueue functions after a successful enqueue. It counts the call and
+// flushes the command-queue if its policy demands it. With SetForceBlocking() enabled, it waits for the
+// command-queue to complete.
+func autoFlushAfterEnqueue(commandQueue CommandQueue) {
+	defer finishIfForcedBlocking(commandQueue)
+	if atomic.LoadInt32(&autoFlush.active) == 0 {
+		return
+	}
+	autoFlush.mutex.Lock()
+	policy, exists := autoFlush.policies[commandQueue]
+	if !exists {
+		autoFlush.mutex.Unlock()
+		return
+	}
+	policy.stat.Enqueues++
+	policy.pending++
+	flushNow := (policy.every > 0) && (policy.pending >= policy.every)
+	if flushNow {
+		policy.pending = 0
+	}
+	autoFlush.mutex.Unlock()
+	if flushNow {
+		flushAutomatically(commandQueue, policy)
+	}
+}
+
+func flushAutomatically(commandQueue CommandQueue, policy *autoFlushPolicy) {
+	err := Flush(commandQueue)
+	autoFlush.mutex.Lock()
+	defer autoFlush.mutex.Unlock()
+	policy.stat.Flushes++
+	if err != nil {
+		policy.stat.FlushErrors++
+	}
+}
@@ -0,0 +1,83 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockAutoFlushCountsSuccessfulEnqueues(t *testing.T) {
+	_, _, queue := mockQueue(t)
+	cl.SetAutoFlush(queue, 2)
+	defer cl.ClearAutoFlush(queue)
+	for i := 0; i < 5; i++ {
+		if err := cl.EnqueueMarkerWithWaitList(queue, nil, nil); err != nil {
+			t.Fatalf("EnqueueMarkerWithWaitList failed: %v", err)
+		}
+	}
+	if err := cl.EnqueueNDRangeKernel(queue, 0, nil, nil, nil); err == nil {
+		t.Fatalf("EnqueueNDRangeKernel without dimensions succeeded")
+	}
+	stat := cl.AutoFlushStats(queue)
+	if (stat.Enqueues != 5) || (stat.Flushes != 2) || (stat.FlushErrors != 0) {
+		t.Errorf("unexpected counters: %+v", stat)
+	}
+}
+
+func TestMockReleaseCommandQueueKeepsAutoFlushOfRetainedQueue(t *testing.T) {
+	_, _, queue := mockQueue(t)
+	if err := cl.RetainCommandQueue(queue); err != nil {
+		t.Fatalf("RetainCommandQueue failed: %v", err)
+	}
+	cl.SetAutoFlush(queue, 1)
+	defer cl.ClearAutoFlush(queue)
+	if err := cl.EnqueueMarkerWithWaitList(queue, nil, nil); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList failed: %v", err)
+	}
+	if err := cl.ReleaseCommandQueue(queue); err != nil {
+		t.Fatalf("ReleaseCommandQueue failed: %v", err)
+	}
+	if err := cl.EnqueueMarkerWithWaitList(queue, nil, nil); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList failed: %v", err)
+	}
+	stat := cl.AutoFlushStats(queue)
+	if (stat.Enqueues != 2) || (stat.Flushes != 2) {
+		t.Errorf("policy not kept after release of a retained queue: %+v", stat)
+	}
+}
+
+func TestMockClearAutoFlushWaitsForPeriodicFlush(t *testing.T) {
+	_, _, queue := mockQueue(t)
+	cl.ResetCallStats()
+	cl.EnableCallMetrics(true)
+	defer cl.EnableCallMetrics(false)
+	defer cl.ResetCallStats()
+
+	cl.SetAutoFlushInterval(queue, time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for cl.AutoFlushStats(queue).Flushes == 0 {
+		if time.Now().After(deadline) {
+			cl.ClearAutoFlush(queue)
+			t.Fatalf("periodic flush did not run")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cl.ClearAutoFlush(queue)
+	flushes := flushCalls()
+	time.Sleep(20 * time.Millisecond)
+	if after := flushCalls(); after != flushes {
+		t.Errorf("flush called after ClearAutoFlush returned: %d before, %d after", flushes, after)
+	}
+}
+
+func flushCalls() uint64 {
+	for _, stat := range cl.CallStats() {
+		if stat.Name == "clFlush" {
+			return stat.Count
+		}
+	}
+	return 0
+}
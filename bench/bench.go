@@ -0,0 +1,245 @@
+// Package bench provides programmatic micro-benchmarks for OpenCL implementations.
+//
+// The benchmarks measure typical costs such as host/device bandwidth, kernel launch latency,
+// and map/unmap overhead. They can be run from Go tests or from a regular binary, which allows
+// comparing different drivers, and verifying that the wrapper itself is not the bottleneck.
+//
+// All measurements are based on host wall-clock time and include the overhead of the wrapper calls.
+package bench
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+// EmptyKernelName is the name of the kernel that is used for launch measurements.
+const EmptyKernelName = "cl30BenchEmpty"
+
+const emptyKernelSource = "__kernel void " + EmptyKernelName + "(void) {}\n"
+
+// Environment holds the OpenCL objects the benchmarks operate on.
+type Environment struct {
+	Device       cl.DeviceID
+	Context      cl.Context
+	CommandQueue cl.CommandQueue
+
+	program cl.Program
+}
+
+// NewEnvironment creates a context and command queue for the given device.
+// The returned environment must be released with Release().
+func NewEnvironment(device cl.DeviceID) (*Environment, error) {
+	context, err := cl.CreateContext([]cl.DeviceID{device}, nil)
+	if err != nil {
+		return nil, err
+	}
+	commandQueue, err := cl.CreateCommandQueueWithProperties(context, device)
+	if err != nil {
+		_ = cl.ReleaseContext(context)
+		return nil, err
+	}
+	env := &Environment{
+		Device:       device,
+		Context:      context,
+		CommandQueue: commandQueue,
+	}
+	return env, nil
+}
+
+// Release releases all OpenCL objects of the environment.
+func (env *Environment) Release() error {
+	var firstErr error
+	keep := func(err error) {
+		if (err != nil) && (firstErr == nil) {
+			firstErr = err
+		}
+	}
+	if env.program != 0 {
+		keep(cl.ReleaseProgram(env.program))
+		env.program = 0
+	}
+	keep(cl.ReleaseCommandQueue(env.CommandQueue))
+	keep(cl.ReleaseContext(env.Context))
+	return firstErr
+}
+
+func (env *Environment) emptyKernel() (cl.Kernel, error) {
+	if env.program == 0 {
+		program, err := cl.CreateProgramWithSource(env.Context, []string{emptyKernelSource})
+		if err != nil {
+			return 0, err
+		}
+		err = cl.BuildProgram(program, []cl.DeviceID{env.Device}, "", nil)
+		if err != nil {
+			_ = cl.ReleaseProgram(program)
+			return 0, err
+		}
+		env.program = program
+	}
+	return cl.CreateKernel(env.program, EmptyKernelName)
+}
+
+// Result describes the outcome of one benchmark.
+type Result struct {
+	// Name identifies the benchmark.
+	Name string
+	// Iterations is the number of operations that were measured.
+	Iterations int
+	// Bytes is the number of bytes transferred per operation. It is zero for benchmarks that do not transfer data.
+	Bytes int
+	// Duration is the total wall-clock time of all iterations.
+	Duration time.Duration
+}
+
+// PerOperation returns the average duration of one operation.
+func (result Result) PerOperation() time.Duration {
+	if result.Iterations <= 0 {
+		return 0
+	}
+	return result.Duration / time.Duration(result.Iterations)
+}
+
+// OperationsPerSecond returns the throughput of operations.
+func (result Result) OperationsPerSecond() float64 {
+	if result.Duration <= 0 {
+		return 0
+	}
+	return float64(result.Iterations) / result.Duration.Seconds()
+}
+
+// BytesPerSecond returns the bandwidth of the benchmark.
+func (result Result) BytesPerSecond() float64 {
+	if result.Duration <= 0 {
+		return 0
+	}
+	return float64(result.Bytes) * float64(result.Iterations) / result.Duration.Seconds()
+}
+
+// String returns a human-readable summary of the result.
+func (result Result) String() string {
+	text := fmt.Sprintf("%s: %d iterations, %v/op", result.Name, result.Iterations, result.PerOperation())
+	if result.Bytes > 0 {
+		text += fmt.Sprintf(", %.2f MB/s", result.BytesPerSecond()/1e6)
+	}
+	return text
+}
+
+func measure(name string, iterations, bytes int, op func() error, done func() error) (Result, error) {
+	result := Result{Name: name, Iterations: iterations, Bytes: bytes}
+	if iterations <= 0 {
+		return result, nil
+	}
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		err := op()
+		if err != nil {
+			return result, err
+		}
+	}
+	if done != nil {
+		err := done()
+		if err != nil {
+			return result, err
+		}
+	}
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+func (env *Environment) withBuffer(size int, flags cl.MemFlags, action func(buffer cl.MemObject) error) error {
+	if size <= 0 {
+		return cl.ErrInvalidBufferSize
+	}
+	buffer, err := cl.CreateBuffer(env.Context, flags, size, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+	return action(buffer)
+}
+
+// HostToDeviceBandwidth measures blocking writes of size bytes from host memory into a device buffer.
+func (env *Environment) HostToDeviceBandwidth(size, iterations int) (Result, error) {
+	var result Result
+	err := env.withBuffer(size, cl.MemReadWriteFlag, func(buffer cl.MemObject) error {
+		host := make([]byte, size)
+		var err error
+		result, err = measure("HostToDeviceBandwidth", iterations, size, func() error {
+			return cl.EnqueueWriteBuffer(env.CommandQueue, buffer, true, 0, uintptr(size), unsafe.Pointer(&host[0]), nil, nil)
+		}, nil)
+		return err
+	})
+	return result, err
+}
+
+// DeviceToHostBandwidth measures blocking reads of size bytes from a device buffer into host memory.
+func (env *Environment) DeviceToHostBandwidth(size, iterations int) (Result, error) {
+	var result Result
+	err := env.withBuffer(size, cl.MemReadWriteFlag, func(buffer cl.MemObject) error {
+		host := make([]byte, size)
+		var err error
+		result, err = measure("DeviceToHostBandwidth", iterations, size, func() error {
+			return cl.EnqueueReadBuffer(env.CommandQueue, buffer, true, 0, uintptr(size), unsafe.Pointer(&host[0]), nil, nil)
+		}, nil)
+		return err
+	})
+	return result, err
+}
+
+// KernelLaunchLatency measures the round-trip time of enqueueing an empty kernel and waiting for its completion.
+func (env *Environment) KernelLaunchLatency(iterations int) (Result, error) {
+	kernel, err := env.emptyKernel()
+	if err != nil {
+		return Result{}, err
+	}
+	defer func() { _ = cl.ReleaseKernel(kernel) }()
+	dimensions := []cl.WorkDimension{{GlobalSize: 1}}
+	return measure("KernelLaunchLatency", iterations, 0, func() error {
+		err := cl.EnqueueNDRangeKernel(env.CommandQueue, kernel, dimensions, nil, nil)
+		if err != nil {
+			return err
+		}
+		return cl.Finish(env.CommandQueue)
+	}, nil)
+}
+
+// EmptyKernelThroughput measures how many empty kernels can be enqueued and executed, with only one
+// synchronization at the end.
+func (env *Environment) EmptyKernelThroughput(iterations int) (Result, error) {
+	kernel, err := env.emptyKernel()
+	if err != nil {
+		return Result{}, err
+	}
+	defer func() { _ = cl.ReleaseKernel(kernel) }()
+	dimensions := []cl.WorkDimension{{GlobalSize: 1}}
+	return measure("EmptyKernelThroughput", iterations, 0, func() error {
+		return cl.EnqueueNDRangeKernel(env.CommandQueue, kernel, dimensions, nil, nil)
+	}, func() error {
+		return cl.Finish(env.CommandQueue)
+	})
+}
+
+// MapUnmapCost measures the round-trip time of a blocking map of size bytes, followed by an unmap and
+// waiting for its completion.
+func (env *Environment) MapUnmapCost(size, iterations int) (Result, error) {
+	var result Result
+	err := env.withBuffer(size, cl.MemReadWriteFlag|cl.MemAllocHostPtrFlag, func(buffer cl.MemObject) error {
+		var err error
+		result, err = measure("MapUnmapCost", iterations, size, func() error {
+			ptr, err := cl.EnqueueMapBuffer(env.CommandQueue, buffer, true, cl.MapRead|cl.MapWrite, 0, uintptr(size), nil, nil)
+			if err != nil {
+				return err
+			}
+			err = cl.EnqueueUnmapMemObject(env.CommandQueue, buffer, ptr, nil, nil)
+			if err != nil {
+				return err
+			}
+			return cl.Finish(env.CommandQueue)
+		}, nil)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,104 @@
+package bench_test
+
+import (
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+	"github.com/opencl-go/cl30/bench"
+	"github.com/opencl-go/cl30/cltest"
+)
+
+func TestResultRates(t *testing.T) {
+	t.Parallel()
+	result := bench.Result{Name: "test", Iterations: 4, Bytes: 1000, Duration: 2 * time.Second}
+	if result.PerOperation() != 500*time.Millisecond {
+		t.Errorf("unexpected per-operation duration: %v", result.PerOperation())
+	}
+	if result.OperationsPerSecond() != 2 {
+		t.Errorf("unexpected operations per second: %v", result.OperationsPerSecond())
+	}
+	if result.BytesPerSecond() != 2000 {
+		t.Errorf("unexpected bytes per second: %v", result.BytesPerSecond())
+	}
+}
+
+func TestResultRatesOfEmptyResult(t *testing.T) {
+	t.Parallel()
+	var result bench.Result
+	if (result.PerOperation() != 0) || (result.OperationsPerSecond() != 0) || (result.BytesPerSecond() != 0) {
+		t.Errorf("empty result must have zero rates")
+	}
+}
+
+func newBenchmarkKernel(b *testing.B) (cl.CommandQueue, cl.Kernel) {
+	b.Helper()
+	device := cltest.RequireDevice(b)
+	env, err := bench.NewEnvironment(device.Device)
+	if err != nil {
+		b.Fatalf("NewEnvironment failed: %v", err)
+	}
+	b.Cleanup(func() { _ = env.Release() })
+	program, err := cl.CreateProgramWithSource(env.Context, []string{"__kernel void launch(void) {}\n"})
+	if err != nil {
+		b.Fatalf("CreateProgramWithSource failed: %v", err)
+	}
+	b.Cleanup(func() { _ = cl.ReleaseProgram(program) })
+	if err = cl.BuildProgram(program, nil, "", nil); err != nil {
+		b.Fatalf("BuildProgram failed: %v", err)
+	}
+	kernel, err := cl.CreateKernel(program, "launch")
+	if err != nil {
+		b.Fatalf("CreateKernel failed: %v", err)
+	}
+	b.Cleanup(func() { _ = cl.ReleaseKernel(kernel) })
+	return env.CommandQueue, kernel
+}
+
+// benchmarkLaunches measures the launches, including their allocations. The wrapper does not allocate for
+// launches; reported allocations stem from the OpenCL implementation.
+// The command-queue is drained regularly, which is not part of the measurement.
+func benchmarkLaunches(b *testing.B, commandQueue cl.CommandQueue, launch func() error) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 1; i <= b.N; i++ {
+		if err := launch(); err != nil {
+			b.Fatalf("launch failed: %v", err)
+		}
+		if (i % 1024) == 0 {
+			b.StopTimer()
+			_ = cl.Finish(commandQueue)
+			b.StartTimer()
+		}
+	}
+	b.StopTimer()
+	_ = cl.Finish(commandQueue)
+}
+
+func BenchmarkEnqueueNDRangeKernel(b *testing.B) {
+	commandQueue, kernel := newBenchmarkKernel(b)
+	dimensions := []cl.WorkDimension{{GlobalSize: 64, LocalSize: 1}, {GlobalSize: 4, LocalSize: 1}}
+	benchmarkLaunches(b, commandQueue, func() error {
+		return cl.EnqueueNDRangeKernel(commandQueue, kernel, dimensions, nil, nil)
+	})
+}
+
+func BenchmarkEnqueueKernel(b *testing.B) {
+	commandQueue, kernel := newBenchmarkKernel(b)
+	benchmarkLaunches(b, commandQueue, func() error {
+		return cl.EnqueueKernel(commandQueue, kernel, []uintptr{64, 4})
+	})
+}
+
+func BenchmarkEnqueueKernelWithOptions(b *testing.B) {
+	commandQueue, kernel := newBenchmarkKernel(b)
+	var event cl.Event
+	opts := []cl.KernelEnqueueOption{cl.WithLocalWorkSize([]uintptr{1, 1}), cl.WithEventOut(&event)}
+	benchmarkLaunches(b, commandQueue, func() error {
+		err := cl.EnqueueKernel(commandQueue, kernel, []uintptr{64, 4}, opts...)
+		if err == nil {
+			err = cl.ReleaseEvent(event)
+		}
+		return err
+	})
+}
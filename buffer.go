@@ -10,6 +10,10 @@ import (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateBuffer.html
 func CreateBuffer(context Context, flags MemFlags, size int, hostPtr unsafe.Pointer) (MemObject, error) {
+	defer observeCall("clCreateBuffer")()
+	if err := checkIntSizes(size); err != nil {
+		return 0, err
+	}
 	var status C.cl_int
 	mem := C.clCreateBuffer(
 		context.handle(),
@@ -18,9 +22,9 @@ func CreateBuffer(context Context, flags MemFlags, size int, hostPtr unsafe.Poin
 		hostPtr,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateBuffer", status, "context", context, "flags", flags, "size", size)
 	}
-	return MemObject(*((*uintptr)(unsafe.Pointer(&mem)))), nil
+	return trackCreated(MemObject(*((*uintptr)(unsafe.Pointer(&mem))))), nil
 }
 
 // CreateBufferWithProperties creates a buffer object.
@@ -28,6 +32,10 @@ func CreateBuffer(context Context, flags MemFlags, size int, hostPtr unsafe.Poin
 // Since: 3.0
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateBufferWithProperties.html
 func CreateBufferWithProperties(context Context, flags MemFlags, size int, hostPtr unsafe.Pointer, properties ...MemProperty) (MemObject, error) {
+	defer observeCall("clCreateBufferWithProperties")()
+	if err := checkIntSizes(size); err != nil {
+		return 0, err
+	}
 	var rawPropertyList []uint64
 	for _, property := range properties {
 		rawPropertyList = append(rawPropertyList, property...)
@@ -46,9 +54,9 @@ func CreateBufferWithProperties(context Context, flags MemFlags, size int, hostP
 		hostPtr,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateBufferWithProperties", status, "context", context, "flags", flags, "size", size)
 	}
-	return MemObject(*((*uintptr)(unsafe.Pointer(&mem)))), nil
+	return trackCreated(MemObject(*((*uintptr)(unsafe.Pointer(&mem))))), nil
 }
 
 // BufferCreateType determines the kind of sub-buffer object.
@@ -80,6 +88,7 @@ type BufferRegion struct {
 // Since: 1.1
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateSubBuffer.html
 func CreateSubBuffer(buffer MemObject, flags MemFlags, createType BufferCreateType, createInfo unsafe.Pointer) (MemObject, error) {
+	defer observeCall("clCreateSubBuffer")()
 	var status C.cl_int
 	mem := C.clCreateSubBuffer(
 		buffer.handle(),
@@ -88,9 +97,27 @@ func CreateSubBuffer(buffer MemObject, flags MemFlags, createType BufferCreateTy
 		createInfo,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateSubBuffer", status, "buffer", buffer, "flags", flags)
 	}
-	return MemObject(*((*uintptr)(unsafe.Pointer(&mem)))), nil
+	return trackCreated(MemObject(*((*uintptr)(unsafe.Pointer(&mem))))), nil
+}
+
+// mapBufferArgs are the arguments of clEnqueueMapBuffer() for runBlocking().
+type mapBufferArgs struct {
+	commandQueue C.cl_command_queue
+	buffer       C.cl_mem
+	blocking     C.cl_bool
+	flags        C.cl_map_flags
+	offset       C.size_t
+	size         C.size_t
+	numEvents    C.cl_uint
+	waitList     *C.cl_event
+	event        *C.cl_event
+}
+
+type mapBufferResult struct {
+	ptr    unsafe.Pointer
+	status C.cl_int
 }
 
 // EnqueueMapBuffer enqueues a command to map a region of a buffer object into the host address space and
@@ -100,139 +127,289 @@ func CreateSubBuffer(buffer MemObject, flags MemFlags, createType BufferCreateTy
 func EnqueueMapBuffer(commandQueue CommandQueue,
 	buffer MemObject, blocking bool, flags MapFlags, offset, size uintptr,
 	waitList []Event, event *Event) (unsafe.Pointer, error) {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueMapBuffer")()
+	if err := injectedEnqueueFault(); err != nil {
+		return nil, err
 	}
-	var status C.cl_int
-	ptr := C.clEnqueueMapBuffer(
-		commandQueue.handle(),
-		buffer.handle(),
-		C.cl_bool(BoolFrom(blocking)),
-		C.cl_map_flags(flags),
-		C.size_t(offset),
-		C.size_t(size),
-		C.cl_uint(len(waitList)),
-		(*C.cl_event)(rawWaitList),
-		(*C.cl_event)(unsafe.Pointer(event)),
-		&status)
+	if blocking {
+		err := checkBlockingAllowed()
+		if err != nil {
+			return nil, err
+		}
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseWaitList()
+	result := runBlocking(blocking, mapBufferArgs{
+		commandQueue: commandQueue.handle(),
+		buffer:       buffer.handle(),
+		blocking:     C.cl_bool(BoolFrom(blocking)),
+		flags:        C.cl_map_flags(flags),
+		offset:       C.size_t(offset),
+		size:         C.size_t(size),
+		numEvents:    C.cl_uint(len(waitList)),
+		waitList:     (*C.cl_event)(rawWaitList),
+		event:        (*C.cl_event)(unsafe.Pointer(event)),
+	}, func(args mapBufferArgs) (mapped mapBufferResult) {
+		mapped.ptr = C.clEnqueueMapBuffer(args.commandQueue, args.buffer, args.blocking, args.flags,
+			args.offset, args.size, args.numEvents, args.waitList, args.event, &mapped.status)
+		return mapped
+	})
+	ptr, status := result.ptr, result.status
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, operationError("clEnqueueMapBuffer", status, "commandQueue", commandQueue, "buffer", buffer, "flags", flags, "offset", offset, "size", size)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return ptr, nil
 }
 
+// bufferTransferArgs are the arguments of clEnqueueReadBuffer() and clEnqueueWriteBuffer() for runBlocking().
+type bufferTransferArgs struct {
+	commandQueue C.cl_command_queue
+	mem          C.cl_mem
+	blocking     C.cl_bool
+	offset       C.size_t
+	size         C.size_t
+	data         unsafe.Pointer
+	numEvents    C.cl_uint
+	waitList     *C.cl_event
+	event        *C.cl_event
+}
+
 // EnqueueReadBuffer enqueues a command to read from a buffer object to host memory.
 //
+// For non-blocking calls, the host memory is pinned until the command has completed.
+//
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueReadBuffer.html
 func EnqueueReadBuffer(commandQueue CommandQueue, mem MemObject, blockingRead bool, offset, size uintptr, data unsafe.Pointer,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	return enqueueReadBuffer(commandQueue, mem, blockingRead, offset, size, data, true, waitList, event)
+}
+
+// enqueueReadBuffer is the implementation of EnqueueReadBuffer(). The pinning of data can be skipped for memory that
+// is already pinned.
+func enqueueReadBuffer(commandQueue CommandQueue, mem MemObject, blockingRead bool, offset, size uintptr, data unsafe.Pointer,
+	pinData bool, waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueReadBuffer")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
-	status := C.clEnqueueReadBuffer(
-		commandQueue.handle(),
-		mem.handle(),
-		C.cl_bool(BoolFrom(blockingRead)),
-		C.size_t(offset),
-		C.size_t(size),
-		data,
-		C.cl_uint(len(waitList)),
-		(*C.cl_event)(rawWaitList),
-		(*C.cl_event)(unsafe.Pointer(event)))
+	if blockingRead {
+		err := checkBlockingAllowed()
+		if err != nil {
+			return err
+		}
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	pin, eventOut := pinTransfer(blockingRead || !pinData, data, event)
+	status := runBlocking(blockingRead, bufferTransferArgs{
+		commandQueue: commandQueue.handle(),
+		mem:          mem.handle(),
+		blocking:     C.cl_bool(BoolFrom(blockingRead)),
+		offset:       C.size_t(offset),
+		size:         C.size_t(size),
+		data:         data,
+		numEvents:    C.cl_uint(len(waitList)),
+		waitList:     (*C.cl_event)(rawWaitList),
+		event:        (*C.cl_event)(unsafe.Pointer(eventOut)),
+	}, func(args bufferTransferArgs) C.cl_int {
+		return C.clEnqueueReadBuffer(args.commandQueue, args.mem, args.blocking, args.offset, args.size, args.data,
+			args.numEvents, args.waitList, args.event)
+	})
+	pin.enqueued(status == C.CL_SUCCESS, eventOut)
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueReadBuffer", status, "commandQueue", commandQueue, "mem", mem, "offset", offset, "size", size)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
+// bufferRectTransferArgs are the arguments of clEnqueueReadBufferRect() and clEnqueueWriteBufferRect() for
+// runBlocking().
+type bufferRectTransferArgs struct {
+	commandQueue     C.cl_command_queue
+	mem              C.cl_mem
+	blocking         C.cl_bool
+	bufferOrigin     [3]uintptr
+	hostOrigin       [3]uintptr
+	region           [3]uintptr
+	bufferRowPitch   C.size_t
+	bufferSlicePitch C.size_t
+	hostRowPitch     C.size_t
+	hostSlicePitch   C.size_t
+	data             unsafe.Pointer
+	numEvents        C.cl_uint
+	waitList         *C.cl_event
+	event            *C.cl_event
+}
+
 // EnqueueReadBufferRect enqueues a command to read from a 2D or 3D rectangular region of a buffer object to
 // host memory.
 //
+// For non-blocking calls, the host memory is pinned until the command has completed.
+//
 // Since: 1.1
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueReadBufferRect.html
 func EnqueueReadBufferRect(commandQueue CommandQueue, mem MemObject, blockingRead bool, bufferOrigin, hostOrigin, region [3]uintptr,
 	bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch uintptr, data unsafe.Pointer, waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueReadBufferRect")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
-	status := C.clEnqueueReadBufferRect(
-		commandQueue.handle(),
-		mem.handle(),
-		C.cl_bool(BoolFrom(blockingRead)),
-		(*C.size_t)(unsafe.Pointer(&bufferOrigin[0])),
-		(*C.size_t)(unsafe.Pointer(&hostOrigin[0])),
-		(*C.size_t)(unsafe.Pointer(&region[0])),
-		C.size_t(bufferRowPitch),
-		C.size_t(bufferSlicePitch),
-		C.size_t(hostRowPitch),
-		C.size_t(hostSlicePitch),
-		data,
-		C.cl_uint(len(waitList)),
-		(*C.cl_event)(rawWaitList),
-		(*C.cl_event)(unsafe.Pointer(event)))
+	if blockingRead {
+		err := checkBlockingAllowed()
+		if err != nil {
+			return err
+		}
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	pin, eventOut := pinTransfer(blockingRead, data, event)
+	status := runBlocking(blockingRead, bufferRectTransferArgs{
+		commandQueue:     commandQueue.handle(),
+		mem:              mem.handle(),
+		blocking:         C.cl_bool(BoolFrom(blockingRead)),
+		bufferOrigin:     bufferOrigin,
+		hostOrigin:       hostOrigin,
+		region:           region,
+		bufferRowPitch:   C.size_t(bufferRowPitch),
+		bufferSlicePitch: C.size_t(bufferSlicePitch),
+		hostRowPitch:     C.size_t(hostRowPitch),
+		hostSlicePitch:   C.size_t(hostSlicePitch),
+		data:             data,
+		numEvents:        C.cl_uint(len(waitList)),
+		waitList:         (*C.cl_event)(rawWaitList),
+		event:            (*C.cl_event)(unsafe.Pointer(eventOut)),
+	}, func(args bufferRectTransferArgs) C.cl_int {
+		return C.clEnqueueReadBufferRect(args.commandQueue, args.mem, args.blocking,
+			(*C.size_t)(unsafe.Pointer(&args.bufferOrigin[0])),
+			(*C.size_t)(unsafe.Pointer(&args.hostOrigin[0])),
+			(*C.size_t)(unsafe.Pointer(&args.region[0])),
+			args.bufferRowPitch, args.bufferSlicePitch, args.hostRowPitch, args.hostSlicePitch, args.data,
+			args.numEvents, args.waitList, args.event)
+	})
+	pin.enqueued(status == C.CL_SUCCESS, eventOut)
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueReadBufferRect", status, "commandQueue", commandQueue, "mem", mem)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
 // EnqueueWriteBuffer enqueues a command to write to a buffer object from host memory.
 //
+// For non-blocking calls, the host memory is pinned until the command has completed.
+// If the buffer has a host shadow, see WithHostShadow(), the written data is also copied into the shadow.
+//
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueWriteBuffer.html
 func EnqueueWriteBuffer(commandQueue CommandQueue, mem MemObject, blockingRead bool, offset, size uintptr, data unsafe.Pointer,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueWriteBuffer")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
-	status := C.clEnqueueWriteBuffer(
-		commandQueue.handle(),
-		mem.handle(),
-		C.cl_bool(BoolFrom(blockingRead)),
-		C.size_t(offset),
-		C.size_t(size),
-		data,
-		C.cl_uint(len(waitList)),
-		(*C.cl_event)(rawWaitList),
-		(*C.cl_event)(unsafe.Pointer(event)))
+	if blockingRead {
+		err := checkBlockingAllowed()
+		if err != nil {
+			return err
+		}
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	pin, eventOut := pinTransfer(blockingRead, data, event)
+	status := runBlocking(blockingRead, bufferTransferArgs{
+		commandQueue: commandQueue.handle(),
+		mem:          mem.handle(),
+		blocking:     C.cl_bool(BoolFrom(blockingRead)),
+		offset:       C.size_t(offset),
+		size:         C.size_t(size),
+		data:         data,
+		numEvents:    C.cl_uint(len(waitList)),
+		waitList:     (*C.cl_event)(rawWaitList),
+		event:        (*C.cl_event)(unsafe.Pointer(eventOut)),
+	}, func(args bufferTransferArgs) C.cl_int {
+		return C.clEnqueueWriteBuffer(args.commandQueue, args.mem, args.blocking, args.offset, args.size, args.data,
+			args.numEvents, args.waitList, args.event)
+	})
+	pin.enqueued(status == C.CL_SUCCESS, eventOut)
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueWriteBuffer", status, "commandQueue", commandQueue, "mem", mem, "offset", offset, "size", size)
 	}
+	updateHostShadow(mem, offset, size, data)
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
 // EnqueueWriteBufferRect enqueues a command to write to a 2D or 3D rectangular region of a buffer object from
 // host memory.
 //
+// For non-blocking calls, the host memory is pinned until the command has completed.
+//
 // Since: 1.1
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueWriteBufferRect.html
 func EnqueueWriteBufferRect(commandQueue CommandQueue, mem MemObject, blockingRead bool, bufferOrigin, hostOrigin, region [3]uintptr,
 	bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch uintptr, data unsafe.Pointer, waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueWriteBufferRect")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
-	status := C.clEnqueueWriteBufferRect(
-		commandQueue.handle(),
-		mem.handle(),
-		C.cl_bool(BoolFrom(blockingRead)),
-		(*C.size_t)(unsafe.Pointer(&bufferOrigin[0])),
-		(*C.size_t)(unsafe.Pointer(&hostOrigin[0])),
-		(*C.size_t)(unsafe.Pointer(&region[0])),
-		C.size_t(bufferRowPitch),
-		C.size_t(bufferSlicePitch),
-		C.size_t(hostRowPitch),
-		C.size_t(hostSlicePitch),
-		data,
-		C.cl_uint(len(waitList)),
-		(*C.cl_event)(rawWaitList),
-		(*C.cl_event)(unsafe.Pointer(event)))
+	if blockingRead {
+		err := checkBlockingAllowed()
+		if err != nil {
+			return err
+		}
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	pin, eventOut := pinTransfer(blockingRead, data, event)
+	status := runBlocking(blockingRead, bufferRectTransferArgs{
+		commandQueue:     commandQueue.handle(),
+		mem:              mem.handle(),
+		blocking:         C.cl_bool(BoolFrom(blockingRead)),
+		bufferOrigin:     bufferOrigin,
+		hostOrigin:       hostOrigin,
+		region:           region,
+		bufferRowPitch:   C.size_t(bufferRowPitch),
+		bufferSlicePitch: C.size_t(bufferSlicePitch),
+		hostRowPitch:     C.size_t(hostRowPitch),
+		hostSlicePitch:   C.size_t(hostSlicePitch),
+		data:             data,
+		numEvents:        C.cl_uint(len(waitList)),
+		waitList:         (*C.cl_event)(rawWaitList),
+		event:            (*C.cl_event)(unsafe.Pointer(eventOut)),
+	}, func(args bufferRectTransferArgs) C.cl_int {
+		return C.clEnqueueWriteBufferRect(args.commandQueue, args.mem, args.blocking,
+			(*C.size_t)(unsafe.Pointer(&args.bufferOrigin[0])),
+			(*C.size_t)(unsafe.Pointer(&args.hostOrigin[0])),
+			(*C.size_t)(unsafe.Pointer(&args.region[0])),
+			args.bufferRowPitch, args.bufferSlicePitch, args.hostRowPitch, args.hostSlicePitch, args.data,
+			args.numEvents, args.waitList, args.event)
+	})
+	pin.enqueued(status == C.CL_SUCCESS, eventOut)
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueWriteBufferRect", status, "commandQueue", commandQueue, "mem", mem)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -242,10 +419,15 @@ func EnqueueWriteBufferRect(commandQueue CommandQueue, mem MemObject, blockingRe
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueFillBuffer.html
 func EnqueueFillBuffer(commandQueue CommandQueue, mem MemObject, pattern unsafe.Pointer, patternSize, offset, size uintptr,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueFillBuffer")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
 	status := C.clEnqueueFillBuffer(
 		commandQueue.handle(),
 		mem.handle(),
@@ -257,8 +439,10 @@ func EnqueueFillBuffer(commandQueue CommandQueue, mem MemObject, pattern unsafe.
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueFillBuffer", status, "commandQueue", commandQueue, "mem", mem, "offset", offset, "size", size)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -266,10 +450,15 @@ func EnqueueFillBuffer(commandQueue CommandQueue, mem MemObject, pattern unsafe.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueCopyBuffer.html
 func EnqueueCopyBuffer(commandQueue CommandQueue, src, dst MemObject, srcOffset, dstOffset, size uintptr, waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueCopyBuffer")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
 	}
+	defer releaseWaitList()
 	status := C.clEnqueueCopyBuffer(
 		commandQueue.handle(),
 		src.handle(),
@@ -281,8 +470,10 @@ func EnqueueCopyBuffer(commandQueue CommandQueue, src, dst MemObject, srcOffset,
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueCopyBuffer", status, "commandQueue", commandQueue, "src", src, "dst", dst, "size", size)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -293,10 +484,15 @@ func EnqueueCopyBuffer(commandQueue CommandQueue, src, dst MemObject, srcOffset,
 func EnqueueCopyBufferRect(commandQueue CommandQueue, src, dst MemObject, srcOrigin, dstOrigin, region [3]uintptr,
 	srcRowPitch, srcSlicePitch, dstRowPitch, dstSlicePitch uintptr,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueCopyBufferRect")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
 	}
+	defer releaseWaitList()
 	status := C.clEnqueueCopyBufferRect(
 		commandQueue.handle(),
 		src.handle(),
@@ -312,7 +508,9 @@ func EnqueueCopyBufferRect(commandQueue CommandQueue, src, dst MemObject, srcOri
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueCopyBufferRect", status, "commandQueue", commandQueue, "src", src, "dst", dst)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
@@ -0,0 +1,75 @@
+package cl30
+
+import (
+	"unsafe"
+)
+
+// MappedBuffer is a region of a buffer object that is mapped into host memory with MapBuffer().
+type MappedBuffer struct {
+	buffer MemObject
+	ptr    unsafe.Pointer
+	size   int
+}
+
+// MapBuffer enqueues a blocking command to map a region of a buffer object into the host address space,
+// based on EnqueueMapBuffer(). The returned MappedBuffer provides access to the region until Unmap() is called.
+func MapBuffer(commandQueue CommandQueue, buffer MemObject, flags MapFlags, offset, size int) (*MappedBuffer, error) {
+	if err := checkIntSizes(offset, size); err != nil {
+		return nil, err
+	}
+	ptr, err := EnqueueMapBuffer(commandQueue, buffer, true, flags, uintptr(offset), uintptr(size), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MappedBuffer{buffer: buffer, ptr: ptr, size: size}, nil
+}
+
+// Buffer returns the mapped buffer object.
+func (mapped *MappedBuffer) Buffer() MemObject {
+	return mapped.buffer
+}
+
+// Size returns the size of the mapped region, in bytes.
+func (mapped *MappedBuffer) Size() int {
+	return mapped.size
+}
+
+// Bytes returns a view of the mapped region. The view must not be used after Unmap() was called.
+// nil is returned for an unmapped or empty region.
+func (mapped *MappedBuffer) Bytes() []byte {
+	if (mapped.ptr == nil) || (mapped.size == 0) {
+		return nil
+	}
+	return unsafe.Slice((*byte)(mapped.ptr), mapped.size)
+}
+
+// Unmap enqueues a command to unmap the region, and waits for its completion.
+// The views returned by Bytes() and MappedBufferAsSlice() become invalid. Calling Unmap() again has no effect.
+func (mapped *MappedBuffer) Unmap(commandQueue CommandQueue) error {
+	if mapped.ptr == nil {
+		return nil
+	}
+	var event Event
+	if err := EnqueueUnmapMemObject(commandQueue, mapped.buffer, mapped.ptr, nil, &event); err != nil {
+		return err
+	}
+	mapped.ptr = nil
+	defer func() { _ = ReleaseEvent(event) }()
+	return WaitForEvents([]Event{event})
+}
+
+// MappedBufferAsSlice returns a view of the mapped region as a slice of elements of type T.
+// The type of the elements must not contain any Go pointers. The view must not be used after Unmap() was called.
+//
+// ErrBufferSizeNotMultiple is returned if the size of the region is not a multiple of the size of T.
+func MappedBufferAsSlice[T any](mapped *MappedBuffer) ([]T, error) {
+	var element T
+	elementSize := int(unsafe.Sizeof(element))
+	if (elementSize == 0) || ((mapped.size % elementSize) != 0) {
+		return nil, ErrBufferSizeNotMultiple
+	}
+	if (mapped.ptr == nil) || (mapped.size == 0) {
+		return nil, nil
+	}
+	return unsafe.Slice((*T)(mapped.ptr), mapped.size/elementSize), nil
+}
@@ -0,0 +1,52 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockMapBuffer(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	buffer, err := cl.CreateBufferFromSlice(context, cl.MemReadWriteFlag, []uint32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("CreateBufferFromSlice failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+
+	mapped, err := cl.MapBuffer(queue, buffer, cl.MapRead|cl.MapWrite, 4, 8)
+	if err != nil {
+		t.Fatalf("MapBuffer failed: %v", err)
+	}
+	if len(mapped.Bytes()) != 8 {
+		t.Errorf("unexpected view size: %d", len(mapped.Bytes()))
+	}
+	values, err := cl.MappedBufferAsSlice[uint32](mapped)
+	if (err != nil) || !reflect.DeepEqual(values, []uint32{2, 3}) {
+		t.Errorf("unexpected mapped values: %v, %v", values, err)
+	}
+	values[1] = 30
+	if _, err = cl.MappedBufferAsSlice[[3]byte](mapped); !errors.Is(err, cl.ErrBufferSizeNotMultiple) {
+		t.Errorf("unexpected error for mismatched element size: %v", err)
+	}
+	if err = mapped.Unmap(queue); err != nil {
+		t.Fatalf("Unmap failed: %v", err)
+	}
+	if mapped.Bytes() != nil {
+		t.Errorf("view still available after Unmap")
+	}
+	if err = mapped.Unmap(queue); err != nil {
+		t.Errorf("repeated Unmap failed: %v", err)
+	}
+
+	output := make([]uint32, 4)
+	err = cl.EnqueueReadBuffer(queue, buffer, true, 0, 16, unsafe.Pointer(&output[0]), nil, nil)
+	if (err != nil) || !reflect.DeepEqual(output, []uint32{1, 2, 30, 4}) {
+		t.Errorf("unexpected buffer content: %v, %v", output, err)
+	}
+}
@@ -0,0 +1,145 @@
+package cl30
+
+import (
+	"unsafe"
+)
+
+// BufferRect describes a 2D or 3D rectangular region within linear memory, as used by the rectangular buffer
+// transfer functions, such as EnqueueReadBufferRect().
+//
+// The first components of Origin and Region are given in bytes, the second ones in rows, and the third ones in
+// slices. For 2D regions, Region[2] is 1.
+type BufferRect struct {
+	// Origin is the offset of the region, as (x in bytes, y in rows, z in slices).
+	Origin [3]uintptr
+	// Region is the size of the region, as (width in bytes, height in rows, depth in slices).
+	Region [3]uintptr
+	// RowPitch is the length of each row in bytes. If zero, Region[0] is used.
+	RowPitch uintptr
+	// SlicePitch is the length of each slice in bytes. If zero, RowPitch * Region[1] is used.
+	SlicePitch uintptr
+}
+
+// Pitches returns the effective row pitch and slice pitch, with zero values resolved to their defaults.
+func (rect BufferRect) Pitches() (rowPitch, slicePitch uintptr) {
+	rowPitch = rect.RowPitch
+	if rowPitch == 0 {
+		rowPitch = rect.Region[0]
+	}
+	slicePitch = rect.SlicePitch
+	if slicePitch == 0 {
+		slicePitch = rowPitch * rect.Region[1]
+	}
+	return rowPitch, slicePitch
+}
+
+// Validate checks the consistency of the region and the pitches, following the rules of the OpenCL API.
+// ErrInvalidValue is returned if any component of Region is zero, if RowPitch is less than the width of the region,
+// or if SlicePitch is less than a slice of the region or not a multiple of the row pitch.
+func (rect BufferRect) Validate() error {
+	if (rect.Region[0] == 0) || (rect.Region[1] == 0) || (rect.Region[2] == 0) {
+		return ErrInvalidValue
+	}
+	rowPitch, slicePitch := rect.Pitches()
+	if rowPitch < rect.Region[0] {
+		return ErrInvalidValue
+	}
+	if (slicePitch < rowPitch*rect.Region[1]) || ((slicePitch % rowPitch) != 0) {
+		return ErrInvalidValue
+	}
+	return nil
+}
+
+// Extent returns the number of bytes that the linear memory must provide, up to and including the last byte of the
+// region. The rect must be valid.
+func (rect BufferRect) Extent() uintptr {
+	rowPitch, slicePitch := rect.Pitches()
+	return (rect.Origin[2]+rect.Region[2]-1)*slicePitch + (rect.Origin[1]+rect.Region[1]-1)*rowPitch +
+		rect.Origin[0] + rect.Region[0]
+}
+
+// EnqueueReadBufferRectSlice enqueues a command to read a rectangular region of a buffer object into a
+// rectangular region of the given slice, based on EnqueueReadBufferRect(). The type of the elements must not
+// contain any Go pointers; all offsets and pitches are given in bytes.
+//
+// The region of host may be left zero, to use the region of buffer. ErrInvalidValue is returned if either rect is
+// not valid, if the regions differ, or if the region of host exceeds the slice.
+func EnqueueReadBufferRectSlice[T any](commandQueue CommandQueue, mem MemObject, blocking bool, buffer, host BufferRect,
+	data []T, waitList []Event, event *Event) error {
+	host, ptr, err := hostRectFor(buffer, host, data)
+	if err != nil {
+		return err
+	}
+	bufferRowPitch, bufferSlicePitch := buffer.Pitches()
+	hostRowPitch, hostSlicePitch := host.Pitches()
+	return EnqueueReadBufferRect(commandQueue, mem, blocking, buffer.Origin, host.Origin, buffer.Region,
+		bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch, ptr, waitList, event)
+}
+
+// EnqueueWriteBufferRectSlice enqueues a command to write a rectangular region of the given slice into a
+// rectangular region of a buffer object, based on EnqueueWriteBufferRect(). The type of the elements must not
+// contain any Go pointers; all offsets and pitches are given in bytes.
+//
+// The region of host may be left zero, to use the region of buffer. ErrInvalidValue is returned if either rect is
+// not valid, if the regions differ, or if the region of host exceeds the slice.
+func EnqueueWriteBufferRectSlice[T any](commandQueue CommandQueue, mem MemObject, blocking bool, buffer, host BufferRect,
+	data []T, waitList []Event, event *Event) error {
+	host, ptr, err := hostRectFor(buffer, host, data)
+	if err != nil {
+		return err
+	}
+	bufferRowPitch, bufferSlicePitch := buffer.Pitches()
+	hostRowPitch, hostSlicePitch := host.Pitches()
+	return EnqueueWriteBufferRect(commandQueue, mem, blocking, buffer.Origin, host.Origin, buffer.Region,
+		bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch, ptr, waitList, event)
+}
+
+// EnqueueCopyBufferRects enqueues a command to copy a rectangular region between buffer objects, based on
+// EnqueueCopyBufferRect().
+//
+// The region of dstRect may be left zero, to use the region of srcRect. ErrInvalidValue is returned if either rect
+// is not valid, or if the regions differ.
+func EnqueueCopyBufferRects(commandQueue CommandQueue, src, dst MemObject, srcRect, dstRect BufferRect,
+	waitList []Event, event *Event) error {
+	dstRect, err := matchingRect(srcRect, dstRect)
+	if err != nil {
+		return err
+	}
+	srcRowPitch, srcSlicePitch := srcRect.Pitches()
+	dstRowPitch, dstSlicePitch := dstRect.Pitches()
+	return EnqueueCopyBufferRect(commandQueue, src, dst, srcRect.Origin, dstRect.Origin, srcRect.Region,
+		srcRowPitch, srcSlicePitch, dstRowPitch, dstSlicePitch, waitList, event)
+}
+
+// matchingRect validates both rects, and returns other with the region of rect if its region is zero.
+func matchingRect(rect, other BufferRect) (BufferRect, error) {
+	if other.Region == [3]uintptr{} {
+		other.Region = rect.Region
+	}
+	if other.Region != rect.Region {
+		return other, ErrInvalidValue
+	}
+	if err := rect.Validate(); err != nil {
+		return other, err
+	}
+	if err := other.Validate(); err != nil {
+		return other, err
+	}
+	return other, nil
+}
+
+// hostRectFor validates the rects, and verifies that the host rect is within the data.
+func hostRectFor[T any](buffer, host BufferRect, data []T) (BufferRect, unsafe.Pointer, error) {
+	host, err := matchingRect(buffer, host)
+	if err != nil {
+		return host, nil, err
+	}
+	size, err := elementsSize[T](len(data))
+	if err != nil {
+		return host, nil, err
+	}
+	if host.Extent() > uintptr(size) {
+		return host, nil, ErrInvalidValue
+	}
+	return host, unsafe.Pointer(&data[0]), nil
+}
@@ -0,0 +1,65 @@
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestBufferRectValidate(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		name   string
+		rect   cl.BufferRect
+		valid  bool
+		extent uintptr
+	}{
+		{name: "default pitches", rect: cl.BufferRect{Region: [3]uintptr{16, 4, 1}}, valid: true, extent: 64},
+		{name: "padded rows", rect: cl.BufferRect{Origin: [3]uintptr{4, 1, 0}, Region: [3]uintptr{8, 2, 1}, RowPitch: 32},
+			valid: true, extent: 76},
+		{name: "3D", rect: cl.BufferRect{Origin: [3]uintptr{0, 0, 1}, Region: [3]uintptr{8, 2, 2}, RowPitch: 8, SlicePitch: 32},
+			valid: true, extent: 80},
+		{name: "zero region", rect: cl.BufferRect{Region: [3]uintptr{16, 4, 0}}},
+		{name: "short row pitch", rect: cl.BufferRect{Region: [3]uintptr{16, 4, 1}, RowPitch: 8}},
+		{name: "short slice pitch", rect: cl.BufferRect{Region: [3]uintptr{16, 4, 2}, SlicePitch: 32}},
+		{name: "unaligned slice pitch", rect: cl.BufferRect{Region: [3]uintptr{16, 4, 2}, SlicePitch: 72}},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.rect.Validate()
+			if !tc.valid {
+				if !errors.Is(err, cl.ErrInvalidValue) {
+					t.Errorf("Validate() = %v, want ErrInvalidValue", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() = %v, want nil", err)
+			}
+			if extent := tc.rect.Extent(); extent != tc.extent {
+				t.Errorf("Extent() = %d, want %d", extent, tc.extent)
+			}
+		})
+	}
+}
+
+func TestEnqueueBufferRectSliceValidation(t *testing.T) {
+	t.Parallel()
+	buffer := cl.BufferRect{Region: [3]uintptr{16, 4, 1}}
+	data := make([]uint32, 15)
+	err := cl.EnqueueWriteBufferRectSlice(0, 0, true, buffer, cl.BufferRect{}, data, nil, nil)
+	if !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("unexpected error for too small slice: %v", err)
+	}
+	err = cl.EnqueueReadBufferRectSlice(0, 0, true, buffer, cl.BufferRect{Region: [3]uintptr{8, 8, 1}}, data, nil, nil)
+	if !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("unexpected error for differing regions: %v", err)
+	}
+	err = cl.EnqueueCopyBufferRects(0, 0, 0, buffer, cl.BufferRect{RowPitch: 8}, nil, nil)
+	if !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("unexpected error for invalid destination: %v", err)
+	}
+}
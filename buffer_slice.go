@@ -0,0 +1,124 @@
+package cl30
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// CreateBufferFromSlice creates a buffer object with the size and content of the given slice.
+// The type of the elements must not contain any Go pointers.
+//
+// If flags contain MemUseHostPtrFlag, the buffer uses the backing array of the slice as its storage. The array is
+// pinned, so that the Go runtime neither moves nor collects it, until the buffer is destroyed. This requires
+// SetMemObjectDestructorCallback(). The slice must not be modified by the host while the buffer is in use by
+// the device, as with any buffer of MemUseHostPtrFlag.
+//
+// Otherwise, the content of the slice is copied into the buffer; MemCopyHostPtrFlag is added to the flags if
+// missing. The slice can be reused as soon as the function returns.
+//
+// ErrInvalidBufferSize is returned for an empty slice.
+func CreateBufferFromSlice[T any](context Context, flags MemFlags, data []T) (MemObject, error) {
+	size, err := elementsSize[T](len(data))
+	if err != nil {
+		return 0, err
+	}
+	if size == 0 {
+		return 0, ErrInvalidBufferSize
+	}
+	hostPtr := unsafe.Pointer(&data[0])
+	if (flags & MemUseHostPtrFlag) == 0 {
+		return CreateBuffer(context, flags|MemCopyHostPtrFlag, size, hostPtr)
+	}
+	pinner := &runtime.Pinner{}
+	pinner.Pin(hostPtr)
+	buffer, err := CreateBuffer(context, flags, size, hostPtr)
+	if err != nil {
+		pinner.Unpin()
+		return 0, err
+	}
+	err = SetMemObjectDestructorCallback(buffer, pinner.Unpin)
+	if err != nil {
+		// Without notification of the destruction, the memory could not be unpinned safely.
+		_ = ReleaseMemObject(buffer)
+		pinner.Unpin()
+		return 0, err
+	}
+	return buffer, nil
+}
+
+// EnqueueReadBufferSlice enqueues a command to read from a buffer object into the given slice, based on
+// EnqueueReadBuffer(). The type of the elements must not contain any Go pointers.
+//
+// The offset is given in elements of type T, and the size of the read is the length of the slice.
+// ErrInvalidValue is returned for an empty slice.
+//
+// For non-blocking calls, the backing array of the slice is pinned until the command has completed. The slice
+// must not be accessed by the host before then; wait for the event, or use a blocking call.
+func EnqueueReadBufferSlice[T any](commandQueue CommandQueue, mem MemObject, blocking bool, offset int, data []T,
+	waitList []Event, event *Event) error {
+	byteOffset, size, err := sliceTransferRange[T](offset, len(data))
+	if err != nil {
+		return err
+	}
+	return EnqueueReadBuffer(commandQueue, mem, blocking, byteOffset, size, unsafe.Pointer(&data[0]), waitList, event)
+}
+
+// EnqueueWriteBufferSlice enqueues a command to write the given slice to a buffer object, based on
+// EnqueueWriteBuffer(). The type of the elements must not contain any Go pointers.
+//
+// The offset is given in elements of type T, and the size of the write is the length of the slice.
+// ErrInvalidValue is returned for an empty slice.
+//
+// For non-blocking calls, the backing array of the slice is pinned until the command has completed. The slice
+// must not be modified by the host before then; wait for the event, or use a blocking call.
+func EnqueueWriteBufferSlice[T any](commandQueue CommandQueue, mem MemObject, blocking bool, offset int, data []T,
+	waitList []Event, event *Event) error {
+	byteOffset, size, err := sliceTransferRange[T](offset, len(data))
+	if err != nil {
+		return err
+	}
+	return EnqueueWriteBuffer(commandQueue, mem, blocking, byteOffset, size, unsafe.Pointer(&data[0]), waitList, event)
+}
+
+// sliceTransferRange returns the offset and size in bytes for a transfer of count elements of type T,
+// starting at the element at offset.
+func sliceTransferRange[T any](offset, count int) (uintptr, uintptr, error) {
+	if count == 0 {
+		return 0, 0, ErrInvalidValue
+	}
+	byteOffset, err := elementsSize[T](offset)
+	if err != nil {
+		return 0, 0, err
+	}
+	size, err := elementsSize[T](count)
+	if err != nil {
+		return 0, 0, err
+	}
+	return uintptr(byteOffset), uintptr(size), nil
+}
+
+// FillBuffer enqueues a command to fill count elements of a buffer object with the pattern, starting at the
+// element at offset, based on EnqueueFillBuffer(). The type of the pattern must not contain any Go pointers;
+// for example, a [4]float32 fills with a float4 pattern.
+//
+// The size of T must be one of 1, 2, 4, 8, 16, 32, 64, or 128 bytes. ErrInvalidValue is returned for other sizes,
+// and if count is zero.
+//
+// Since: 1.2
+func FillBuffer[T any](commandQueue CommandQueue, mem MemObject, pattern T, offset, count int,
+	waitList []Event, event *Event) error {
+	patternSize := unsafe.Sizeof(pattern)
+	if !validFillPatternSize(patternSize) {
+		return ErrInvalidValue
+	}
+	byteOffset, size, err := sliceTransferRange[T](offset, count)
+	if err != nil {
+		return err
+	}
+	return EnqueueFillBuffer(commandQueue, mem, unsafe.Pointer(&pattern), patternSize, byteOffset, size, waitList, event)
+}
+
+// validFillPatternSize returns true if the size is accepted for patterns of EnqueueFillBuffer().
+func validFillPatternSize(size uintptr) bool {
+	return (size != 0) && (size <= 128) && ((size & (size - 1)) == 0)
+}
@@ -0,0 +1,105 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockCreateBufferFromSlice(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	copied, err := cl.CreateBufferFromSlice(context, cl.MemReadOnlyFlag, []uint32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("CreateBufferFromSlice failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(copied) }()
+	output := make([]uint32, 4)
+	err = cl.EnqueueReadBuffer(queue, copied, true, 0, 16, unsafe.Pointer(&output[0]), nil, nil)
+	if (err != nil) || !reflect.DeepEqual(output, []uint32{1, 2, 3, 4}) {
+		t.Errorf("unexpected content of copied buffer: %v, %v", output, err)
+	}
+
+	host := make([]uint32, 4)
+	used, err := cl.CreateBufferFromSlice(context, cl.MemReadWriteFlag|cl.MemUseHostPtrFlag, host)
+	if err != nil {
+		t.Fatalf("CreateBufferFromSlice failed: %v", err)
+	}
+	input := []uint32{5, 6, 7, 8}
+	err = cl.EnqueueWriteBuffer(queue, used, true, 0, 16, unsafe.Pointer(&input[0]), nil, nil)
+	if err != nil {
+		t.Fatalf("EnqueueWriteBuffer failed: %v", err)
+	}
+	if !reflect.DeepEqual(host, input) {
+		t.Errorf("buffer does not use host memory: %v", host)
+	}
+	if err = cl.ReleaseMemObject(used); err != nil {
+		t.Errorf("ReleaseMemObject failed: %v", err)
+	}
+
+	if _, err = cl.CreateBufferFromSlice[float32](context, cl.MemReadOnlyFlag, nil); !errors.Is(err, cl.ErrInvalidBufferSize) {
+		t.Errorf("unexpected error for empty slice: %v", err)
+	}
+}
+
+func TestMockEnqueueBufferSlices(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 16, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+
+	if err = cl.EnqueueWriteBufferSlice(queue, buffer, true, 0, []float32{1, 2, 3, 4}, nil, nil); err != nil {
+		t.Fatalf("EnqueueWriteBufferSlice failed: %v", err)
+	}
+	var written cl.Event
+	if err = cl.EnqueueWriteBufferSlice(queue, buffer, false, 2, []float32{30}, nil, &written); err != nil {
+		t.Fatalf("EnqueueWriteBufferSlice failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(written) }()
+	output := make([]float32, 3)
+	if err = cl.EnqueueReadBufferSlice(queue, buffer, true, 1, output, []cl.Event{written}, nil); err != nil {
+		t.Fatalf("EnqueueReadBufferSlice failed: %v", err)
+	}
+	if !reflect.DeepEqual(output, []float32{2, 30, 4}) {
+		t.Errorf("unexpected content: %v", output)
+	}
+	if err = cl.EnqueueReadBufferSlice[float32](queue, buffer, true, 0, nil, nil, nil); !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("unexpected error for empty slice: %v", err)
+	}
+	if err = cl.EnqueueWriteBufferSlice(queue, buffer, true, -1, output, nil, nil); !errors.Is(err, cl.ErrSizeOutOfRange) {
+		t.Errorf("unexpected error for negative offset: %v", err)
+	}
+}
+
+func TestMockFillBuffer(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	buffer, err := cl.CreateBufferFromSlice(context, cl.MemReadWriteFlag, make([][4]float32, 4))
+	if err != nil {
+		t.Fatalf("CreateBufferFromSlice failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+
+	if err = cl.FillBuffer(queue, buffer, [4]float32{1, 2, 3, 4}, 1, 2, nil, nil); err != nil {
+		t.Fatalf("FillBuffer failed: %v", err)
+	}
+	output := make([][4]float32, 4)
+	if err = cl.EnqueueReadBufferSlice(queue, buffer, true, 0, output, nil, nil); err != nil {
+		t.Fatalf("EnqueueReadBufferSlice failed: %v", err)
+	}
+	expected := [][4]float32{{}, {1, 2, 3, 4}, {1, 2, 3, 4}, {}}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("unexpected content: %v", output)
+	}
+	if err = cl.FillBuffer(queue, buffer, [3]float32{}, 0, 1, nil, nil); !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("unexpected error for float3 pattern: %v", err)
+	}
+	if err = cl.FillBuffer(queue, buffer, uint8(0), 0, 0, nil, nil); !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("unexpected error for zero count: %v", err)
+	}
+}
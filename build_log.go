@@ -0,0 +1,96 @@
+package cl30
+
+import (
+	"strings"
+	"unsafe"
+)
+
+// DeviceBuildLog is the build log of a program for one device.
+type DeviceBuildLog struct {
+	// Device is the device the program failed to build for.
+	Device DeviceID
+	// Name is the name of the device, if available.
+	Name string
+	// Log is the content of ProgramBuildLogInfo.
+	Log string
+}
+
+// BuildLogError is returned by BuildProgramWithLog() in case the build failed. It contains the build logs of the
+// devices for which the build failed.
+//
+// The error of BuildProgram(), typically ErrBuildProgramFailure, remains accessible with errors.Is() and errors.As().
+type BuildLogError struct {
+	// Err is the error of BuildProgram().
+	Err error
+	// Logs are the build logs of the devices for which the build failed.
+	Logs []DeviceBuildLog
+}
+
+// Error returns the wrapped error, followed by the build log of each failing device.
+func (err *BuildLogError) Error() string {
+	var text strings.Builder
+	text.WriteString(err.Err.Error())
+	for _, log := range err.Logs {
+		text.WriteString("\n")
+		if len(log.Name) > 0 {
+			text.WriteString(log.Name)
+		} else {
+			text.WriteString(log.Device.String())
+		}
+		text.WriteString(":\n")
+		text.WriteString(strings.TrimRight(log.Log, "\n"))
+	}
+	return text.String()
+}
+
+// Unwrap returns the wrapped error.
+func (err *BuildLogError) Unwrap() error {
+	return err.Err
+}
+
+// BuildProgramWithLog builds the program like BuildProgram(), and blocks until the build has completed.
+//
+// If the build fails, the returned error is a *BuildLogError that includes the build log of each device for which
+// the build status is BuildErrorStatus. If devices is empty, the devices of the program are considered.
+// If no logs can be determined, the error of BuildProgram() is returned as it is.
+//
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clBuildProgram.html
+func BuildProgramWithLog(program Program, devices []DeviceID, options string) error {
+	buildErr := BuildProgram(program, devices, options, nil)
+	if buildErr == nil {
+		return nil
+	}
+	return withBuildLogs(program, devices, buildErr)
+}
+
+// withBuildLogs returns a *BuildLogError for the failed build, compilation, or link of the program, with the logs
+// of those devices for which the build status is BuildErrorStatus. If devices is empty, the devices of the program
+// are considered. If no logs can be determined, buildErr is returned as it is.
+func withBuildLogs(program Program, devices []DeviceID, buildErr error) error {
+	if len(devices) == 0 {
+		var err error
+		devices, err = querySlice[DeviceID](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return ProgramInfo(program, ProgramDevicesInfo, paramSize, paramValue)
+		})
+		if err != nil {
+			return buildErr
+		}
+	}
+	var logs []DeviceBuildLog
+	for _, device := range devices {
+		status, err := ProgramBuildStatus(program, device)
+		if (err != nil) || (status != BuildErrorStatus) {
+			continue
+		}
+		log, err := ProgramBuildLog(program, device)
+		if err != nil {
+			continue
+		}
+		name, _ := DeviceInfoString(device, DeviceNameInfo)
+		logs = append(logs, DeviceBuildLog{Device: device, Name: name, Log: log})
+	}
+	if len(logs) == 0 {
+		return buildErr
+	}
+	return &BuildLogError{Err: buildErr, Logs: logs}
+}
@@ -0,0 +1,81 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockBuildProgramWithLog(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	program, err := cl.CreateProgramWithSource(context, []string{"#error missing feature\nkernel void empty() {}"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	err = cl.BuildProgramWithLog(program, nil, "")
+	var logErr *cl.BuildLogError
+	if !errors.As(err, &logErr) || !errors.Is(err, cl.ErrBuildProgramFailure) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if (len(logErr.Logs) != 1) || !strings.Contains(logErr.Logs[0].Log, "missing feature") {
+		t.Errorf("unexpected logs: %+v", logErr.Logs)
+	}
+	if !strings.Contains(err.Error(), "<source>:1:1: error: #error missing feature") {
+		t.Errorf("log not part of error text: %q", err.Error())
+	}
+}
+
+func TestMockBuildWithHeaders(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	headers := map[string]string{"value.h": "#define VALUE 1"}
+	program, err := cl.BuildWithHeaders(context, nil, "#include \"value.h\"\nkernel void main_kernel() {}", headers, "")
+	if err != nil {
+		t.Fatalf("BuildWithHeaders failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	kernel, err := cl.CreateKernel(program, "main_kernel")
+	if err != nil {
+		t.Fatalf("CreateKernel failed: %v", err)
+	}
+	_ = cl.ReleaseKernel(kernel)
+
+	_, err = cl.BuildWithHeaders(context, nil, "#error broken main\nkernel void main_kernel() {}", headers, "")
+	var logErr *cl.BuildLogError
+	if !errors.As(err, &logErr) || !errors.Is(err, cl.ErrCompileProgramFailure) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if (len(logErr.Logs) != 1) || !strings.Contains(logErr.Logs[0].Log, "broken main") {
+		t.Errorf("unexpected logs: %+v", logErr.Logs)
+	}
+}
+
+func TestMockCompileToLibrary(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	if _, err := cl.CompileToLibrary(context, nil, nil, ""); !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("unexpected error without sources: %v", err)
+	}
+	library, err := cl.CompileToLibrary(context, nil, []string{"kernel void library_kernel() {}"}, "")
+	if err != nil {
+		t.Fatalf("CompileToLibrary failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(library) }()
+	executable, err := cl.LinkWithLibraries(context, nil, nil, []cl.Program{library}, "")
+	if err != nil {
+		t.Fatalf("LinkWithLibraries failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(executable) }()
+
+	_, err = cl.CompileToLibrary(context, nil, []string{"#error broken library\n"}, "")
+	var logErr *cl.BuildLogError
+	if !errors.As(err, &logErr) || !errors.Is(err, cl.ErrCompileProgramFailure) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if (len(logErr.Logs) != 1) || !strings.Contains(logErr.Logs[0].Log, "broken library") {
+		t.Errorf("unexpected logs: %+v", logErr.Logs)
+	}
+}
@@ -0,0 +1,119 @@
+package cl30
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// CallbackDispatchMode determines how callbacks from the OpenCL implementation are delivered to the application.
+type CallbackDispatchMode int32
+
+// These constants are the modes that SetCallbackDispatchMode() accepts.
+const (
+	// CallbackDispatchInline calls the callbacks directly on the thread of the OpenCL implementation.
+	// This is the default mode. Blocking calls are not allowed within such callbacks; see ErrBlockingCallInCallback.
+	CallbackDispatchInline CallbackDispatchMode = iota
+	// CallbackDispatchConcurrent calls each callback on a new goroutine.
+	CallbackDispatchConcurrent
+	// CallbackDispatchSerial calls the callbacks one after the other, in the order of their arrival,
+	// on a single goroutine that is managed by this package.
+	CallbackDispatchSerial
+)
+
+var callbackDispatch = struct {
+	mode         int32
+	panicHandler atomic.Value
+
+	startSerial sync.Once
+	mutex       sync.Mutex
+	pending     sync.Cond
+	queue       []func()
+}{}
+
+// SetCallbackDispatchMode sets how callbacks are delivered. The mode applies to callbacks that arrive after the call.
+//
+// Callbacks of native kernels, see EnqueueNativeKernel(), and of SvmFree commands, see EnqueueSvmFree(), are always
+// called inline, as the respective command completes with the return of the callback.
+// With the asynchronous modes, callbacks may be called after the function that registered them, for example
+// BuildProgram(), has already returned; and blocking calls are allowed within the callbacks.
+func SetCallbackDispatchMode(mode CallbackDispatchMode) {
+	atomic.StoreInt32(&callbackDispatch.mode, int32(mode))
+}
+
+// SetCallbackPanicHandler sets the function that is called with the recovered value and the stack trace of
+// a callback that panicked.
+//
+// A panic in a callback is always recovered, as it would otherwise terminate the process within a thread of the
+// OpenCL implementation. If no handler is set, or nil is set, the panic is reported on the standard error output.
+func SetCallbackPanicHandler(handler func(recovered any, stack []byte)) {
+	callbackDispatch.panicHandler.Store(handler)
+}
+
+// inlineCallback is an internal callback that is always called inline, regardless of the dispatch mode.
+// It is used for bookkeeping that must be done before the implementation may reuse the handle of a destroyed object.
+type inlineCallback func()
+
+// dispatchCallback delivers a callback according to the current dispatch mode.
+// The callback must not refer to memory that is only valid for the duration of the call from the implementation.
+func dispatchCallback(callback func()) {
+	switch CallbackDispatchMode(atomic.LoadInt32(&callbackDispatch.mode)) {
+	case CallbackDispatchConcurrent:
+		go runCallback(callback)
+	case CallbackDispatchSerial:
+		enqueueSerialCallback(callback)
+	default:
+		runInlineCallback(callback)
+	}
+}
+
+// runInlineCallback calls the callback on the current thread of the implementation.
+func runInlineCallback(callback func()) {
+	defer enterCallback()()
+	runCallback(callback)
+}
+
+func runCallback(callback func()) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			reportCallbackPanic(recovered, debug.Stack())
+		}
+	}()
+	callback()
+}
+
+func reportCallbackPanic(recovered any, stack []byte) {
+	handler, _ := callbackDispatch.panicHandler.Load().(func(any, []byte))
+	if handler == nil {
+		_, _ = fmt.Fprintf(os.Stderr, "cl30: recovered panic in callback: %v\n%s", recovered, stack)
+		return
+	}
+	handler(recovered, stack)
+}
+
+func enqueueSerialCallback(callback func()) {
+	callbackDispatch.startSerial.Do(func() {
+		callbackDispatch.pending.L = &callbackDispatch.mutex
+		go runSerialCallbacks()
+	})
+	callbackDispatch.mutex.Lock()
+	callbackDispatch.queue = append(callbackDispatch.queue, callback)
+	callbackDispatch.mutex.Unlock()
+	callbackDispatch.pending.Signal()
+}
+
+func runSerialCallbacks() {
+	for {
+		callbackDispatch.mutex.Lock()
+		for len(callbackDispatch.queue) == 0 {
+			callbackDispatch.pending.Wait()
+		}
+		callback := callbackDispatch.queue[0]
+		callbackDispatch.queue[0] = nil
+		callbackDispatch.queue = callbackDispatch.queue[1:]
+		callbackDispatch.mutex.Unlock()
+		runCallback(callback)
+	}
+}
@@ -0,0 +1,50 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockCallbackDispatch(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	recovered := make(chan any, 1)
+	cl.SetCallbackPanicHandler(func(value any, _ []byte) { recovered <- value })
+	defer cl.SetCallbackPanicHandler(nil)
+	cl.SetCallbackDispatchMode(cl.CallbackDispatchSerial)
+	defer cl.SetCallbackDispatchMode(cl.CallbackDispatchInline)
+
+	gate, err := cl.CreateUserEvent(context)
+	if err != nil {
+		t.Fatalf("CreateUserEvent failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(gate) }()
+	called := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		index := i
+		err = cl.SetEventCallback(gate, cl.EventCommandCompleteStatus, func(error) {
+			called <- index
+			if index == 1 {
+				panic("callback failure")
+			}
+		})
+		if err != nil {
+			t.Fatalf("SetEventCallback failed: %v", err)
+		}
+	}
+	if err = cl.SetUserEventStatus(gate, int(cl.EventCommandCompleteStatus)); err != nil {
+		t.Fatalf("SetUserEventStatus failed: %v", err)
+	}
+	if value := <-recovered; value != "callback failure" {
+		t.Errorf("unexpected recovered value: %v", value)
+	}
+	sum := 0
+	for i := 0; i < 3; i++ {
+		sum += <-called
+	}
+	if sum != 3 {
+		t.Errorf("not all callbacks were called")
+	}
+}
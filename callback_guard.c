@@ -0,0 +1,18 @@
+#include "api.h"
+
+static _Thread_local int cl30CallbackDepth = 0;
+
+void cl30EnterCallback(void)
+{
+    cl30CallbackDepth++;
+}
+
+void cl30LeaveCallback(void)
+{
+    cl30CallbackDepth--;
+}
+
+int cl30InCallback(void)
+{
+    return cl30CallbackDepth > 0;
+}
@@ -0,0 +1,40 @@
+package cl30
+
+// #include "api.h"
+// extern void cl30EnterCallback(void);
+// extern void cl30LeaveCallback(void);
+// extern int cl30InCallback(void);
+import "C"
+import "sync/atomic"
+
+// activeCallbacks counts the goroutines that currently execute a callback from the OpenCL implementation.
+//
+// Callbacks are executed on threads of the OpenCL implementation. Blocking calls on such a thread, for example
+// waiting for an event, can deadlock the implementation. The dispatcher marks the thread while it runs a callback
+// inline, which allows to detect such calls: the goroutine of the callback stays locked to the thread of the
+// implementation until the callback returns, so the mark identifies the goroutine as well.
+var activeCallbacks int32
+
+// enterCallback marks the current thread as executing a callback. The returned function must be called
+// once the callback has completed. Typical use is: defer enterCallback()()
+func enterCallback() func() {
+	C.cl30EnterCallback()
+	atomic.AddInt32(&activeCallbacks, 1)
+	return leaveCallback
+}
+
+func leaveCallback() {
+	atomic.AddInt32(&activeCallbacks, -1)
+	C.cl30LeaveCallback()
+}
+
+// checkBlockingAllowed returns ErrBlockingCallInCallback if the current goroutine executes a callback.
+func checkBlockingAllowed() error {
+	if atomic.LoadInt32(&activeCallbacks) == 0 {
+		return nil
+	}
+	if C.cl30InCallback() != 0 {
+		return ErrBlockingCallInCallback
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockBlockingCallInCallback(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	gate, err := cl.CreateUserEvent(context)
+	if err != nil {
+		t.Fatalf("CreateUserEvent failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(gate) }()
+	waitErr := make(chan error, 1)
+	err = cl.SetEventCallback(gate, cl.EventCommandCompleteStatus, func(error) {
+		waitErr <- cl.WaitForEvents([]cl.Event{gate})
+	})
+	if err != nil {
+		t.Fatalf("SetEventCallback failed: %v", err)
+	}
+	if err = cl.SetUserEventStatus(gate, int(cl.EventCommandCompleteStatus)); err != nil {
+		t.Fatalf("SetUserEventStatus failed: %v", err)
+	}
+	if err = <-waitErr; !errors.Is(err, cl.ErrBlockingCallInCallback) {
+		t.Errorf("expected ErrBlockingCallInCallback within the callback, got %v", err)
+	}
+	if err = cl.WaitForEvents([]cl.Event{gate}); err != nil {
+		t.Errorf("WaitForEvents failed outside of the callback: %v", err)
+	}
+}
@@ -0,0 +1,10 @@
+#include "api.h"
+
+typedef cl_mem (CL_API_CALL *cl30ImportMemoryARM_fn)(cl_context context, cl_mem_flags flags,
+    const intptr_t *properties, void *memory, size_t size, cl_int *errcodeReturn);
+
+cl_mem cl30ExtImportMemoryARM(void *fn, cl_context context, cl_mem_flags flags,
+    const intptr_t *properties, void *memory, size_t size, cl_int *errcodeReturn)
+{
+    return ((cl30ImportMemoryARM_fn)(fn))(context, flags, properties, memory, size, errcodeReturn);
+}
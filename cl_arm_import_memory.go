@@ -0,0 +1,105 @@
+package cl30
+
+import (
+	"unsafe"
+)
+
+// #include "api.h"
+// extern cl_mem cl30ExtImportMemoryARM(void *fn, cl_context context, cl_mem_flags flags,
+//    const intptr_t *properties, void *memory, size_t size, cl_int *errcodeReturn);
+import "C"
+
+// ExtensionImportMemoryArm represents the functionality provided by the "cl_arm_import_memory" extension.
+// Load the extension with LoadExtensionImportMemoryArm().
+//
+// The extension allows memory that was allocated outside OpenCL, such as dma-buf file descriptors of camera and
+// ISP pipelines on Linux, to be used as buffers without copies. The extension is import-only: there is no way
+// to export a buffer as a dma-buf file descriptor.
+//
+// See also: https://registry.khronos.org/OpenCL/extensions/arm/cl_arm_import_memory.html
+// Extension: ArmImportMemoryExtensionName
+type ExtensionImportMemoryArm struct {
+	clImportMemoryArm unsafe.Pointer
+}
+
+// LoadExtensionImportMemoryArm loads the required functions for the extension and returns an instance
+// to ExtensionImportMemoryArm if possible.
+//
+// Extension: ArmImportMemoryExtensionName
+func LoadExtensionImportMemoryArm(id PlatformID) (*ExtensionImportMemoryArm, error) {
+	clImportMemoryArm := ExtensionFunctionAddressForPlatform(id, "clImportMemoryARM")
+	if clImportMemoryArm == nil {
+		return nil, ErrExtensionNotAvailable
+	}
+	return &ExtensionImportMemoryArm{clImportMemoryArm: clImportMemoryArm}, nil
+}
+
+// ImportDmaBuf creates a buffer that refers to the memory of the given dma-buf file descriptor.
+// The file descriptor remains owned by the caller, and must stay valid for the lifetime of the buffer.
+//
+// Extension: ArmImportMemoryDmaBufExtensionName
+func (ext *ExtensionImportMemoryArm) ImportDmaBuf(context Context, flags MemFlags, fd int, size int) (MemObject, error) {
+	properties := []uintptr{uintptr(ImportTypeArmProperty), uintptr(ImportTypeDmaBufArm), 0}
+	rawFd := C.int(fd)
+	return ext.importMemory(context, flags, properties, unsafe.Pointer(&rawFd), size)
+}
+
+// ImportHostMemory creates a buffer that refers to the given host memory. The memory must stay valid for
+// the lifetime of the buffer, and must not be moved. It therefore must not be memory managed by Go.
+//
+// Extension: ArmImportMemoryExtensionName
+func (ext *ExtensionImportMemoryArm) ImportHostMemory(context Context, flags MemFlags, memory unsafe.Pointer, size int) (MemObject, error) {
+	properties := []uintptr{uintptr(ImportTypeArmProperty), uintptr(ImportTypeHostArm), 0}
+	return ext.importMemory(context, flags, properties, memory, size)
+}
+
+func (ext *ExtensionImportMemoryArm) importMemory(context Context, flags MemFlags, properties []uintptr,
+	memory unsafe.Pointer, size int) (MemObject, error) {
+	defer observeCall("clImportMemoryARM")()
+	if (ext == nil) || (ext.clImportMemoryArm == nil) {
+		return 0, ErrExtensionNotLoaded
+	}
+	if err := checkIntSizes(size); err != nil {
+		return 0, err
+	}
+	var status C.cl_int
+	mem := C.cl30ExtImportMemoryARM(
+		ext.clImportMemoryArm,
+		context.handle(),
+		C.cl_mem_flags(flags),
+		(*C.intptr_t)(unsafe.Pointer(&properties[0])),
+		memory,
+		C.size_t(size),
+		&status)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clImportMemoryARM", status, "context", context, "flags", flags, "size", size)
+	}
+	return trackCreated(MemObject(*((*uintptr)(unsafe.Pointer(&mem))))), nil
+}
+
+// ImportPropertyArm is the type of the property names and values for importing memory.
+//
+// Extension: ArmImportMemoryExtensionName
+type ImportPropertyArm uintptr
+
+const (
+	// ArmImportMemoryExtensionName is the official name of the extension
+	// handled by ExtensionImportMemoryArm.
+	ArmImportMemoryExtensionName = "cl_arm_import_memory"
+	// ArmImportMemoryDmaBufExtensionName is the name of the extension that indicates support for importing
+	// dma-buf file descriptors with ExtensionImportMemoryArm.
+	ArmImportMemoryDmaBufExtensionName = "cl_arm_import_memory_dma_buf"
+
+	// ImportTypeArmProperty specifies the type of the imported memory.
+	//
+	// Extension: ArmImportMemoryExtensionName
+	ImportTypeArmProperty ImportPropertyArm = C.CL_IMPORT_TYPE_ARM
+	// ImportTypeHostArm identifies imported host memory.
+	//
+	// Extension: ArmImportMemoryExtensionName
+	ImportTypeHostArm ImportPropertyArm = C.CL_IMPORT_TYPE_HOST_ARM
+	// ImportTypeDmaBufArm identifies an imported dma-buf file descriptor.
+	//
+	// Extension: ArmImportMemoryDmaBufExtensionName
+	ImportTypeDmaBufArm ImportPropertyArm = C.CL_IMPORT_TYPE_DMA_BUF_ARM
+)
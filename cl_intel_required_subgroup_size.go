@@ -0,0 +1,82 @@
+package cl30
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #include "api.h"
+import "C"
+
+const (
+	// IntelRequiredSubgroupSizeExtensionName is the official name of the extension
+	// that allows kernels to require a specific sub-group size.
+	//
+	// A kernel requests its sub-group size with the attribute returned by RequiredSubGroupSizeAttribute().
+	// The size must be one of the values returned by DeviceSubGroupSizes().
+	//
+	// See also: https://registry.khronos.org/OpenCL/extensions/intel/cl_intel_required_subgroup_size.html
+	IntelRequiredSubgroupSizeExtensionName = "cl_intel_required_subgroup_size"
+
+	// DeviceSubGroupSizesIntelInfo returns the list of sub-group sizes supported by the device.
+	//
+	// Use DeviceSubGroupSizes() for convenience.
+	//
+	// Info value type: []uintptr
+	// Extension: IntelRequiredSubgroupSizeExtensionName
+	DeviceSubGroupSizesIntelInfo DeviceInfoName = C.CL_DEVICE_SUB_GROUP_SIZES_INTEL
+
+	// KernelSpillMemSizeIntelInfo returns the amount of spill memory, in bytes, used by the kernel.
+	//
+	// Info value type: uint64
+	// Extension: IntelRequiredSubgroupSizeExtensionName
+	KernelSpillMemSizeIntelInfo KernelWorkGroupInfoName = C.CL_KERNEL_SPILL_MEM_SIZE_INTEL
+
+	// KernelCompileSubGroupSizeIntelInfo returns the sub-group size that was required with the
+	// "intel_reqd_sub_group_size" attribute in the kernel source. If no sub-group size was required, zero is returned.
+	//
+	// Use KernelCompileSubGroupSize() for convenience.
+	//
+	// Input type: (ignored)
+	// Returned type: uintptr
+	// Extension: IntelRequiredSubgroupSizeExtensionName
+	KernelCompileSubGroupSizeIntelInfo KernelSubGroupInfoName = C.CL_KERNEL_COMPILE_SUB_GROUP_SIZE_INTEL
+)
+
+// DeviceSubGroupSizes returns the sub-group sizes that the device supports.
+// A kernel can require one of these sizes with the attribute returned by RequiredSubGroupSizeAttribute().
+//
+// Extension: IntelRequiredSubgroupSizeExtensionName
+func DeviceSubGroupSizes(id DeviceID) ([]int, error) {
+	rawSizes, err := querySlice[uintptr](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return DeviceInfo(id, DeviceSubGroupSizesIntelInfo, paramSize, paramValue)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sizes := make([]int, len(rawSizes))
+	for i, size := range rawSizes {
+		sizes[i] = int(size)
+	}
+	return sizes, nil
+}
+
+// KernelCompileSubGroupSize returns the sub-group size that the kernel requires on the given device.
+// Zero is returned if the kernel does not require a specific sub-group size.
+//
+// Extension: IntelRequiredSubgroupSizeExtensionName
+func KernelCompileSubGroupSize(kernel Kernel, device DeviceID) (int, error) {
+	size, err := queryValue[uintptr](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return KernelSubGroupInfo(kernel, device, KernelCompileSubGroupSizeIntelInfo, 0, nil, paramSize, paramValue)
+	})
+	return int(size), err
+}
+
+// RequiredSubGroupSizeAttribute returns the kernel attribute that requires the given sub-group size, for example
+// "__attribute__((intel_reqd_sub_group_size(16)))". Place the attribute in front of the kernel function in the
+// program source.
+//
+// Extension: IntelRequiredSubgroupSizeExtensionName
+func RequiredSubGroupSizeAttribute(size int) string {
+	return fmt.Sprintf("__attribute__((intel_reqd_sub_group_size(%d)))", size)
+}
@@ -0,0 +1,86 @@
+#include "api.h"
+
+// The properties are declared with their underlying type, as older headers do not provide cl_mem_properties_intel.
+
+typedef void *(CL_API_CALL *cl30HostMemAllocINTEL_fn)(cl_context context, const cl_ulong *properties,
+    size_t size, cl_uint alignment, cl_int *errcodeReturn);
+typedef void *(CL_API_CALL *cl30DeviceMemAllocINTEL_fn)(cl_context context, cl_device_id device,
+    const cl_ulong *properties, size_t size, cl_uint alignment, cl_int *errcodeReturn);
+typedef cl_int (CL_API_CALL *cl30MemFreeINTEL_fn)(cl_context context, void *ptr);
+typedef cl_int (CL_API_CALL *cl30GetMemAllocInfoINTEL_fn)(cl_context context, const void *ptr, cl_uint paramName,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn);
+typedef cl_int (CL_API_CALL *cl30SetKernelArgMemPointerINTEL_fn)(cl_kernel kernel, cl_uint argIndex,
+    const void *argValue);
+typedef cl_int (CL_API_CALL *cl30EnqueueMemFillINTEL_fn)(cl_command_queue commandQueue, void *dstPtr,
+    const void *pattern, size_t patternSize, size_t size,
+    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+typedef cl_int (CL_API_CALL *cl30EnqueueMemcpyINTEL_fn)(cl_command_queue commandQueue, cl_bool blocking,
+    void *dstPtr, const void *srcPtr, size_t size,
+    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+typedef cl_int (CL_API_CALL *cl30EnqueueMigrateMemINTEL_fn)(cl_command_queue commandQueue, const void *ptr,
+    size_t size, cl_mem_migration_flags flags,
+    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+typedef cl_int (CL_API_CALL *cl30EnqueueMemAdviseINTEL_fn)(cl_command_queue commandQueue, const void *ptr,
+    size_t size, cl_uint advice,
+    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+
+void *cl30ExtHostMemAllocINTEL(void *fn, cl_context context, const cl_ulong *properties,
+    size_t size, cl_uint alignment, cl_int *errcodeReturn)
+{
+    return ((cl30HostMemAllocINTEL_fn)(fn))(context, properties, size, alignment, errcodeReturn);
+}
+
+void *cl30ExtDeviceMemAllocINTEL(void *fn, cl_context context, cl_device_id device,
+    const cl_ulong *properties, size_t size, cl_uint alignment, cl_int *errcodeReturn)
+{
+    return ((cl30DeviceMemAllocINTEL_fn)(fn))(context, device, properties, size, alignment, errcodeReturn);
+}
+
+cl_int cl30ExtMemFreeINTEL(void *fn, cl_context context, void *ptr)
+{
+    return ((cl30MemFreeINTEL_fn)(fn))(context, ptr);
+}
+
+cl_int cl30ExtGetMemAllocInfoINTEL(void *fn, cl_context context, const void *ptr, cl_uint paramName,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn)
+{
+    return ((cl30GetMemAllocInfoINTEL_fn)(fn))(context, ptr, paramName,
+        paramValueSize, paramValue, paramValueSizeReturn);
+}
+
+cl_int cl30ExtSetKernelArgMemPointerINTEL(void *fn, cl_kernel kernel, cl_uint argIndex, const void *argValue)
+{
+    return ((cl30SetKernelArgMemPointerINTEL_fn)(fn))(kernel, argIndex, argValue);
+}
+
+cl_int cl30ExtEnqueueMemFillINTEL(void *fn, cl_command_queue commandQueue, void *dstPtr,
+    const void *pattern, size_t patternSize, size_t size,
+    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event)
+{
+    return ((cl30EnqueueMemFillINTEL_fn)(fn))(commandQueue, dstPtr, pattern, patternSize, size,
+        numEventsInWaitList, eventWaitList, event);
+}
+
+cl_int cl30ExtEnqueueMemcpyINTEL(void *fn, cl_command_queue commandQueue, cl_bool blocking,
+    void *dstPtr, const void *srcPtr, size_t size,
+    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event)
+{
+    return ((cl30EnqueueMemcpyINTEL_fn)(fn))(commandQueue, blocking, dstPtr, srcPtr, size,
+        numEventsInWaitList, eventWaitList, event);
+}
+
+cl_int cl30ExtEnqueueMigrateMemINTEL(void *fn, cl_command_queue commandQueue, const void *ptr,
+    size_t size, cl_mem_migration_flags flags,
+    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event)
+{
+    return ((cl30EnqueueMigrateMemINTEL_fn)(fn))(commandQueue, ptr, size, flags,
+        numEventsInWaitList, eventWaitList, event);
+}
+
+cl_int cl30ExtEnqueueMemAdviseINTEL(void *fn, cl_command_queue commandQueue, const void *ptr,
+    size_t size, cl_uint advice,
+    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event)
+{
+    return ((cl30EnqueueMemAdviseINTEL_fn)(fn))(commandQueue, ptr, size, advice,
+        numEventsInWaitList, eventWaitList, event);
+}
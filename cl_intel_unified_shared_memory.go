@@ -0,0 +1,647 @@
+package cl30
+
+import "unsafe"
+
+// #include "api.h"
+// extern void *cl30ExtHostMemAllocINTEL(void *fn, cl_context context, const cl_ulong *properties,
+//    size_t size, cl_uint alignment, cl_int *errcodeReturn);
+// extern void *cl30ExtDeviceMemAllocINTEL(void *fn, cl_context context, cl_device_id device,
+//    const cl_ulong *properties, size_t size, cl_uint alignment, cl_int *errcodeReturn);
+// extern cl_int cl30ExtMemFreeINTEL(void *fn, cl_context context, void *ptr);
+// extern cl_int cl30ExtGetMemAllocInfoINTEL(void *fn, cl_context context, const void *ptr, cl_uint paramName,
+//    size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn);
+// extern cl_int cl30ExtSetKernelArgMemPointerINTEL(void *fn, cl_kernel kernel, cl_uint argIndex, const void *argValue);
+// extern cl_int cl30ExtEnqueueMemFillINTEL(void *fn, cl_command_queue commandQueue, void *dstPtr,
+//    const void *pattern, size_t patternSize, size_t size,
+//    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+// extern cl_int cl30ExtEnqueueMemcpyINTEL(void *fn, cl_command_queue commandQueue, cl_bool blocking,
+//    void *dstPtr, const void *srcPtr, size_t size,
+//    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+// extern cl_int cl30ExtEnqueueMigrateMemINTEL(void *fn, cl_command_queue commandQueue, const void *ptr,
+//    size_t size, cl_mem_migration_flags flags,
+//    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+// extern cl_int cl30ExtEnqueueMemAdviseINTEL(void *fn, cl_command_queue commandQueue, const void *ptr,
+//    size_t size, cl_uint advice,
+//    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+import "C"
+
+// ExtensionUnifiedSharedMemoryIntel represents the functionality provided by the "cl_intel_unified_shared_memory"
+// extension. Load the extension with LoadExtensionUnifiedSharedMemoryIntel().
+//
+// Unified shared memory (USM) provides pointer-based allocations, similar to SVM. Host allocations are accessible
+// by the host and the devices, device allocations only by their device, and shared allocations migrate between
+// the host and a device on demand.
+//
+// See also: https://registry.khronos.org/OpenCL/extensions/intel/cl_intel_unified_shared_memory.html
+// Extension: IntelUnifiedSharedMemoryExtensionName
+type ExtensionUnifiedSharedMemoryIntel struct {
+	clHostMemAllocIntel           unsafe.Pointer
+	clDeviceMemAllocIntel         unsafe.Pointer
+	clSharedMemAllocIntel         unsafe.Pointer
+	clMemFreeIntel                unsafe.Pointer
+	clMemBlockingFreeIntel        unsafe.Pointer
+	clGetMemAllocInfoIntel        unsafe.Pointer
+	clSetKernelArgMemPointerIntel unsafe.Pointer
+	clEnqueueMemFillIntel         unsafe.Pointer
+	clEnqueueMemcpyIntel          unsafe.Pointer
+	clEnqueueMigrateMemIntel      unsafe.Pointer
+	clEnqueueMemAdviseIntel       unsafe.Pointer
+}
+
+// LoadExtensionUnifiedSharedMemoryIntel loads the required functions for the extension and returns an instance
+// to ExtensionUnifiedSharedMemoryIntel if possible.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func LoadExtensionUnifiedSharedMemoryIntel(id PlatformID) (*ExtensionUnifiedSharedMemoryIntel, error) {
+	ext := &ExtensionUnifiedSharedMemoryIntel{}
+	required := []struct {
+		name string
+		fn   *unsafe.Pointer
+	}{
+		{name: "clHostMemAllocINTEL", fn: &ext.clHostMemAllocIntel},
+		{name: "clDeviceMemAllocINTEL", fn: &ext.clDeviceMemAllocIntel},
+		{name: "clSharedMemAllocINTEL", fn: &ext.clSharedMemAllocIntel},
+		{name: "clMemFreeINTEL", fn: &ext.clMemFreeIntel},
+		{name: "clMemBlockingFreeINTEL", fn: &ext.clMemBlockingFreeIntel},
+		{name: "clGetMemAllocInfoINTEL", fn: &ext.clGetMemAllocInfoIntel},
+		{name: "clSetKernelArgMemPointerINTEL", fn: &ext.clSetKernelArgMemPointerIntel},
+		{name: "clEnqueueMemFillINTEL", fn: &ext.clEnqueueMemFillIntel},
+		{name: "clEnqueueMemcpyINTEL", fn: &ext.clEnqueueMemcpyIntel},
+		{name: "clEnqueueMigrateMemINTEL", fn: &ext.clEnqueueMigrateMemIntel},
+		{name: "clEnqueueMemAdviseINTEL", fn: &ext.clEnqueueMemAdviseIntel},
+	}
+	for _, entry := range required {
+		*entry.fn = ExtensionFunctionAddressForPlatform(id, entry.name)
+		if *entry.fn == nil {
+			return nil, ErrExtensionNotAvailable
+		}
+	}
+	return ext, nil
+}
+
+// UsmPropertyIntel is one entry of properties which are taken into account when allocating unified shared memory.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+type UsmPropertyIntel []uint64
+
+// WithUsmAllocFlags is a convenience function to create a valid MemAllocFlagsIntelProperty.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func WithUsmAllocFlags(flags MemAllocFlagsIntel) UsmPropertyIntel {
+	return UsmPropertyIntel{MemAllocFlagsIntelProperty, uint64(flags)}
+}
+
+func rawUsmProperties(properties []UsmPropertyIntel) *C.cl_ulong {
+	if len(properties) == 0 {
+		return nil
+	}
+	var rawPropertyList []uint64
+	for _, property := range properties {
+		rawPropertyList = append(rawPropertyList, property...)
+	}
+	rawPropertyList = append(rawPropertyList, 0)
+	return (*C.cl_ulong)(unsafe.Pointer(&rawPropertyList[0]))
+}
+
+// HostMemAlloc allocates host memory that is accessible by the host and all devices of the context.
+// An alignment of zero selects the default alignment of the implementation.
+//
+// The returned pointer must be freed with MemFree() or MemBlockingFree().
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) HostMemAlloc(context Context, size int, alignment uint32,
+	properties ...UsmPropertyIntel) (unsafe.Pointer, error) {
+	defer observeCall("clHostMemAllocINTEL")()
+	if (ext == nil) || (ext.clHostMemAllocIntel == nil) {
+		return nil, ErrExtensionNotLoaded
+	}
+	if err := checkIntSizes(size); err != nil {
+		return nil, err
+	}
+	rawProperties := rawUsmProperties(properties)
+	var status C.cl_int
+	ptr := C.cl30ExtHostMemAllocINTEL(
+		ext.clHostMemAllocIntel,
+		context.handle(),
+		rawProperties,
+		C.size_t(size),
+		C.cl_uint(alignment),
+		&status)
+	if status != C.CL_SUCCESS {
+		return nil, operationError("clHostMemAllocINTEL", status, "context", context, "size", size)
+	}
+	return ptr, nil
+}
+
+// DeviceMemAlloc allocates memory that is owned by, and only accessible by, the given device.
+// An alignment of zero selects the default alignment of the implementation.
+//
+// The returned pointer must be freed with MemFree() or MemBlockingFree().
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) DeviceMemAlloc(context Context, device DeviceID, size int, alignment uint32,
+	properties ...UsmPropertyIntel) (unsafe.Pointer, error) {
+	defer observeCall("clDeviceMemAllocINTEL")()
+	if (ext == nil) || (ext.clDeviceMemAllocIntel == nil) {
+		return nil, ErrExtensionNotLoaded
+	}
+	return ext.deviceMemAlloc("clDeviceMemAllocINTEL", ext.clDeviceMemAllocIntel, context, device, size, alignment, properties)
+}
+
+// SharedMemAlloc allocates memory that migrates between the host and the given device on demand.
+// The device may be zero, in which case the memory is not associated with a specific device.
+// An alignment of zero selects the default alignment of the implementation.
+//
+// The returned pointer must be freed with MemFree() or MemBlockingFree().
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) SharedMemAlloc(context Context, device DeviceID, size int, alignment uint32,
+	properties ...UsmPropertyIntel) (unsafe.Pointer, error) {
+	defer observeCall("clSharedMemAllocINTEL")()
+	if (ext == nil) || (ext.clSharedMemAllocIntel == nil) {
+		return nil, ErrExtensionNotLoaded
+	}
+	return ext.deviceMemAlloc("clSharedMemAllocINTEL", ext.clSharedMemAllocIntel, context, device, size, alignment, properties)
+}
+
+func (ext *ExtensionUnifiedSharedMemoryIntel) deviceMemAlloc(operation string, fn unsafe.Pointer, context Context, device DeviceID,
+	size int, alignment uint32, properties []UsmPropertyIntel) (unsafe.Pointer, error) {
+	if err := checkIntSizes(size); err != nil {
+		return nil, err
+	}
+	rawProperties := rawUsmProperties(properties)
+	var status C.cl_int
+	ptr := C.cl30ExtDeviceMemAllocINTEL(
+		fn,
+		context.handle(),
+		device.handle(),
+		rawProperties,
+		C.size_t(size),
+		C.cl_uint(alignment),
+		&status)
+	if status != C.CL_SUCCESS {
+		return nil, operationError(operation, status, "context", context, "device", device, "size", size)
+	}
+	return ptr, nil
+}
+
+// MemFree frees unified shared memory. The memory must not be in use by enqueued commands that have not
+// yet completed; use MemBlockingFree() if this can not be guaranteed.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) MemFree(context Context, ptr unsafe.Pointer) error {
+	defer observeCall("clMemFreeINTEL")()
+	if (ext == nil) || (ext.clMemFreeIntel == nil) {
+		return ErrExtensionNotLoaded
+	}
+	status := C.cl30ExtMemFreeINTEL(ext.clMemFreeIntel, context.handle(), ptr)
+	if status != C.CL_SUCCESS {
+		return operationError("clMemFreeINTEL", status, "context", context)
+	}
+	return nil
+}
+
+// usmFreeArgs are the arguments of clMemBlockingFreeINTEL() for runBlocking().
+type usmFreeArgs struct {
+	fn      unsafe.Pointer
+	context C.cl_context
+	ptr     unsafe.Pointer
+}
+
+// MemBlockingFree frees unified shared memory after all enqueued commands that use it have completed.
+// The call blocks until then.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) MemBlockingFree(context Context, ptr unsafe.Pointer) error {
+	defer observeCall("clMemBlockingFreeINTEL")()
+	if (ext == nil) || (ext.clMemBlockingFreeIntel == nil) {
+		return ErrExtensionNotLoaded
+	}
+	err := checkBlockingAllowed()
+	if err != nil {
+		return err
+	}
+	status := runBlocking(true, usmFreeArgs{
+		fn:      ext.clMemBlockingFreeIntel,
+		context: context.handle(),
+		ptr:     ptr,
+	}, func(args usmFreeArgs) C.cl_int {
+		return C.cl30ExtMemFreeINTEL(args.fn, args.context, args.ptr)
+	})
+	if status != C.CL_SUCCESS {
+		return operationError("clMemBlockingFreeINTEL", status, "context", context)
+	}
+	return nil
+}
+
+// MemAllocInfoNameIntel identifies properties of unified shared memory, which can be queried with
+// ExtensionUnifiedSharedMemoryIntel.MemAllocInfo().
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+type MemAllocInfoNameIntel C.cl_uint
+
+// MemAllocInfo queries information about the allocation that contains the given pointer.
+//
+// The provided size need to specify the size of the available space pointed to the provided value in bytes.
+//
+// The returned number is the required size, in bytes, for the queried information.
+// Call the function with a zero size and nil value to request the required size.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) MemAllocInfo(context Context, ptr unsafe.Pointer, paramName MemAllocInfoNameIntel,
+	paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetMemAllocInfoINTEL")()
+	if (ext == nil) || (ext.clGetMemAllocInfoIntel == nil) {
+		return 0, ErrExtensionNotLoaded
+	}
+	sizeReturn := C.size_t(0)
+	status := C.cl30ExtGetMemAllocInfoINTEL(
+		ext.clGetMemAllocInfoIntel,
+		context.handle(),
+		ptr,
+		C.cl_uint(paramName),
+		C.size_t(paramSize),
+		paramValue,
+		&sizeReturn)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clGetMemAllocInfoINTEL", status, "context", context, "paramName", paramName)
+	}
+	return uintptr(sizeReturn), nil
+}
+
+// MemAllocType returns the type of the allocation that contains the given pointer.
+// UsmTypeUnknownIntel is returned for pointers that are not unified shared memory.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) MemAllocType(context Context, ptr unsafe.Pointer) (UsmTypeIntel, error) {
+	return queryValue[UsmTypeIntel](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return ext.MemAllocInfo(context, ptr, MemAllocTypeIntelInfo, paramSize, paramValue)
+	})
+}
+
+// SetKernelArgMemPointer sets the argument at the given index to a pointer into unified shared memory.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) SetKernelArgMemPointer(kernel Kernel, index uint32, ptr unsafe.Pointer) error {
+	defer observeCall("clSetKernelArgMemPointerINTEL")()
+	if (ext == nil) || (ext.clSetKernelArgMemPointerIntel == nil) {
+		return ErrExtensionNotLoaded
+	}
+	status := C.cl30ExtSetKernelArgMemPointerINTEL(ext.clSetKernelArgMemPointerIntel, kernel.handle(), C.cl_uint(index), ptr)
+	if status != C.CL_SUCCESS {
+		return operationError("clSetKernelArgMemPointerINTEL", status, "kernel", kernel, "index", index)
+	}
+	return nil
+}
+
+// EnqueueMemFill enqueues a command to fill a region of unified shared memory with a pattern.
+// The pattern is copied during the call.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) EnqueueMemFill(commandQueue CommandQueue, dstPtr, pattern unsafe.Pointer,
+	patternSize, size int, waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueMemFillINTEL")()
+	if (ext == nil) || (ext.clEnqueueMemFillIntel == nil) {
+		return ErrExtensionNotLoaded
+	}
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	if err := checkIntSizes(patternSize, size); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	status := C.cl30ExtEnqueueMemFillINTEL(
+		ext.clEnqueueMemFillIntel,
+		commandQueue.handle(),
+		dstPtr,
+		pattern,
+		C.size_t(patternSize),
+		C.size_t(size),
+		C.cl_uint(len(waitList)),
+		(*C.cl_event)(rawWaitList),
+		(*C.cl_event)(unsafe.Pointer(event)))
+	if status != C.CL_SUCCESS {
+		return operationError("clEnqueueMemFillINTEL", status, "commandQueue", commandQueue, "size", size)
+	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
+	return nil
+}
+
+// usmMemcpyArgs are the arguments of clEnqueueMemcpyINTEL() for runBlocking().
+type usmMemcpyArgs struct {
+	fn           unsafe.Pointer
+	commandQueue C.cl_command_queue
+	blocking     C.cl_bool
+	dstPtr       unsafe.Pointer
+	srcPtr       unsafe.Pointer
+	size         C.size_t
+	numEvents    C.cl_uint
+	waitList     *C.cl_event
+	event        *C.cl_event
+}
+
+// EnqueueMemcpy enqueues a command to copy between two regions of memory, of which at least one is
+// unified shared memory.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) EnqueueMemcpy(commandQueue CommandQueue, blocking bool, dstPtr, srcPtr unsafe.Pointer,
+	size int, waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueMemcpyINTEL")()
+	if (ext == nil) || (ext.clEnqueueMemcpyIntel == nil) {
+		return ErrExtensionNotLoaded
+	}
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	if err := checkIntSizes(size); err != nil {
+		return err
+	}
+	if blocking {
+		err := checkBlockingAllowed()
+		if err != nil {
+			return err
+		}
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	status := runBlocking(blocking, usmMemcpyArgs{
+		fn:           ext.clEnqueueMemcpyIntel,
+		commandQueue: commandQueue.handle(),
+		blocking:     C.cl_bool(BoolFrom(blocking)),
+		dstPtr:       dstPtr,
+		srcPtr:       srcPtr,
+		size:         C.size_t(size),
+		numEvents:    C.cl_uint(len(waitList)),
+		waitList:     (*C.cl_event)(rawWaitList),
+		event:        (*C.cl_event)(unsafe.Pointer(event)),
+	}, func(args usmMemcpyArgs) C.cl_int {
+		return C.cl30ExtEnqueueMemcpyINTEL(args.fn, args.commandQueue, args.blocking, args.dstPtr, args.srcPtr,
+			args.size, args.numEvents, args.waitList, args.event)
+	})
+	if status != C.CL_SUCCESS {
+		return operationError("clEnqueueMemcpyINTEL", status, "commandQueue", commandQueue, "size", size)
+	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
+	return nil
+}
+
+// EnqueueMigrateMem enqueues a command to migrate a region of shared memory to the device of the command-queue,
+// or to the host if MigrateMemObjectHost is specified.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) EnqueueMigrateMem(commandQueue CommandQueue, ptr unsafe.Pointer, size int,
+	flags MemMigrationFlags, waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueMigrateMemINTEL")()
+	if (ext == nil) || (ext.clEnqueueMigrateMemIntel == nil) {
+		return ErrExtensionNotLoaded
+	}
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	if err := checkIntSizes(size); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	status := C.cl30ExtEnqueueMigrateMemINTEL(
+		ext.clEnqueueMigrateMemIntel,
+		commandQueue.handle(),
+		ptr,
+		C.size_t(size),
+		C.cl_mem_migration_flags(flags),
+		C.cl_uint(len(waitList)),
+		(*C.cl_event)(rawWaitList),
+		(*C.cl_event)(unsafe.Pointer(event)))
+	if status != C.CL_SUCCESS {
+		return operationError("clEnqueueMigrateMemINTEL", status, "commandQueue", commandQueue, "size", size, "flags", flags)
+	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
+	return nil
+}
+
+// EnqueueMemAdvise enqueues a command that provides advice about the use of a region of unified shared memory.
+// The advice values are specific to the implementation.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func (ext *ExtensionUnifiedSharedMemoryIntel) EnqueueMemAdvise(commandQueue CommandQueue, ptr unsafe.Pointer, size int,
+	advice uint32, waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueMemAdviseINTEL")()
+	if (ext == nil) || (ext.clEnqueueMemAdviseIntel == nil) {
+		return ErrExtensionNotLoaded
+	}
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	if err := checkIntSizes(size); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	status := C.cl30ExtEnqueueMemAdviseINTEL(
+		ext.clEnqueueMemAdviseIntel,
+		commandQueue.handle(),
+		ptr,
+		C.size_t(size),
+		C.cl_uint(advice),
+		C.cl_uint(len(waitList)),
+		(*C.cl_event)(rawWaitList),
+		(*C.cl_event)(unsafe.Pointer(event)))
+	if status != C.CL_SUCCESS {
+		return operationError("clEnqueueMemAdviseINTEL", status, "commandQueue", commandQueue, "size", size)
+	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
+	return nil
+}
+
+// DeviceUsmCapabilitiesIntel returns the capabilities of the device for the given kind of unified shared memory.
+// The info name must be one of DeviceHostMemCapabilitiesIntelInfo, DeviceDeviceMemCapabilitiesIntelInfo,
+// DeviceSingleDeviceSharedMemCapabilitiesIntelInfo, DeviceCrossDeviceSharedMemCapabilitiesIntelInfo,
+// or DeviceSharedSystemMemCapabilitiesIntelInfo.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func DeviceUsmCapabilitiesIntel(id DeviceID, paramName DeviceInfoName) (UsmCapabilitiesIntelFlags, error) {
+	return queryValue[UsmCapabilitiesIntelFlags](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return DeviceInfo(id, paramName, paramSize, paramValue)
+	})
+}
+
+// MemAllocFlagsIntel are flags for the allocation of unified shared memory.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+type MemAllocFlagsIntel C.cl_ulong
+
+// UsmTypeIntel describes the type of unified shared memory.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+type UsmTypeIntel C.cl_uint
+
+// UsmCapabilitiesIntelFlags describes the capabilities of a device for a kind of unified shared memory.
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+type UsmCapabilitiesIntelFlags C.cl_ulong
+
+const (
+	// IntelUnifiedSharedMemoryExtensionName is the official name of the extension
+	// handled by ExtensionUnifiedSharedMemoryIntel.
+	IntelUnifiedSharedMemoryExtensionName = "cl_intel_unified_shared_memory"
+
+	// DeviceHostMemCapabilitiesIntelInfo describes the capabilities of the device for host allocations.
+	//
+	// Info value type: UsmCapabilitiesIntelFlags
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	DeviceHostMemCapabilitiesIntelInfo DeviceInfoName = C.CL_DEVICE_HOST_MEM_CAPABILITIES_INTEL
+	// DeviceDeviceMemCapabilitiesIntelInfo describes the capabilities of the device for device allocations.
+	//
+	// Info value type: UsmCapabilitiesIntelFlags
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	DeviceDeviceMemCapabilitiesIntelInfo DeviceInfoName = C.CL_DEVICE_DEVICE_MEM_CAPABILITIES_INTEL
+	// DeviceSingleDeviceSharedMemCapabilitiesIntelInfo describes the capabilities of the device for shared
+	// allocations that are associated with this device.
+	//
+	// Info value type: UsmCapabilitiesIntelFlags
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	DeviceSingleDeviceSharedMemCapabilitiesIntelInfo DeviceInfoName = C.CL_DEVICE_SINGLE_DEVICE_SHARED_MEM_CAPABILITIES_INTEL
+	// DeviceCrossDeviceSharedMemCapabilitiesIntelInfo describes the capabilities of the device for shared
+	// allocations that may be migrated between this device and other devices.
+	//
+	// Info value type: UsmCapabilitiesIntelFlags
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	DeviceCrossDeviceSharedMemCapabilitiesIntelInfo DeviceInfoName = C.CL_DEVICE_CROSS_DEVICE_SHARED_MEM_CAPABILITIES_INTEL
+	// DeviceSharedSystemMemCapabilitiesIntelInfo describes the capabilities of the device for memory allocated
+	// by the system allocator of the host.
+	//
+	// Info value type: UsmCapabilitiesIntelFlags
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	DeviceSharedSystemMemCapabilitiesIntelInfo DeviceInfoName = C.CL_DEVICE_SHARED_SYSTEM_MEM_CAPABILITIES_INTEL
+
+	// UsmAccessIntel indicates that the device can access the memory.
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	UsmAccessIntel UsmCapabilitiesIntelFlags = C.CL_UNIFIED_SHARED_MEMORY_ACCESS_INTEL
+	// UsmAtomicAccessIntel indicates that the device can access the memory atomically.
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	UsmAtomicAccessIntel UsmCapabilitiesIntelFlags = C.CL_UNIFIED_SHARED_MEMORY_ATOMIC_ACCESS_INTEL
+	// UsmConcurrentAccessIntel indicates that the device can access the memory concurrently with the host,
+	// or other devices.
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	UsmConcurrentAccessIntel UsmCapabilitiesIntelFlags = C.CL_UNIFIED_SHARED_MEMORY_CONCURRENT_ACCESS_INTEL
+	// UsmConcurrentAtomicAccessIntel indicates that the device can access the memory atomically, concurrently with
+	// the host, or other devices.
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	UsmConcurrentAtomicAccessIntel UsmCapabilitiesIntelFlags = C.CL_UNIFIED_SHARED_MEMORY_CONCURRENT_ATOMIC_ACCESS_INTEL
+
+	// MemAllocFlagsIntelProperty specifies flags for the allocation.
+	//
+	// Use WithUsmAllocFlags() for convenience.
+	//
+	// Property value type: MemAllocFlagsIntel
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	MemAllocFlagsIntelProperty uint64 = C.CL_MEM_ALLOC_FLAGS_INTEL
+
+	// MemAllocWriteCombinedIntel requests write-combined memory, which is faster to write by the host, yet slower
+	// to read.
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	MemAllocWriteCombinedIntel MemAllocFlagsIntel = C.CL_MEM_ALLOC_WRITE_COMBINED_INTEL
+
+	// MemAllocTypeIntelInfo returns the type of the allocation.
+	//
+	// Returned type: UsmTypeIntel
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	MemAllocTypeIntelInfo MemAllocInfoNameIntel = C.CL_MEM_ALLOC_TYPE_INTEL
+	// MemAllocBasePtrIntelInfo returns the base address of the allocation.
+	//
+	// Returned type: unsafe.Pointer
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	MemAllocBasePtrIntelInfo MemAllocInfoNameIntel = C.CL_MEM_ALLOC_BASE_PTR_INTEL
+	// MemAllocSizeIntelInfo returns the size of the allocation, in bytes.
+	//
+	// Returned type: uintptr
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	MemAllocSizeIntelInfo MemAllocInfoNameIntel = C.CL_MEM_ALLOC_SIZE_INTEL
+	// MemAllocDeviceIntelInfo returns the device of the allocation, or zero for host allocations.
+	//
+	// Returned type: DeviceID
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	MemAllocDeviceIntelInfo MemAllocInfoNameIntel = C.CL_MEM_ALLOC_DEVICE_INTEL
+
+	// UsmTypeUnknownIntel is the type of memory that is not unified shared memory.
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	UsmTypeUnknownIntel UsmTypeIntel = C.CL_MEM_TYPE_UNKNOWN_INTEL
+	// UsmTypeHostIntel is the type of host allocations.
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	UsmTypeHostIntel UsmTypeIntel = C.CL_MEM_TYPE_HOST_INTEL
+	// UsmTypeDeviceIntel is the type of device allocations.
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	UsmTypeDeviceIntel UsmTypeIntel = C.CL_MEM_TYPE_DEVICE_INTEL
+	// UsmTypeSharedIntel is the type of shared allocations.
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	UsmTypeSharedIntel UsmTypeIntel = C.CL_MEM_TYPE_SHARED_INTEL
+
+	// KernelExecInfoIndirectHostAccessIntel specifies whether the kernel may access host allocations that are
+	// not passed as arguments.
+	//
+	// Required type: Bool
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	KernelExecInfoIndirectHostAccessIntel KernelExecInfoName = C.CL_KERNEL_EXEC_INFO_INDIRECT_HOST_ACCESS_INTEL
+	// KernelExecInfoIndirectDeviceAccessIntel specifies whether the kernel may access device allocations that are
+	// not passed as arguments.
+	//
+	// Required type: Bool
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	KernelExecInfoIndirectDeviceAccessIntel KernelExecInfoName = C.CL_KERNEL_EXEC_INFO_INDIRECT_DEVICE_ACCESS_INTEL
+	// KernelExecInfoIndirectSharedAccessIntel specifies whether the kernel may access shared allocations that are
+	// not passed as arguments.
+	//
+	// Required type: Bool
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	KernelExecInfoIndirectSharedAccessIntel KernelExecInfoName = C.CL_KERNEL_EXEC_INFO_INDIRECT_SHARED_ACCESS_INTEL
+	// KernelExecInfoUsmPtrsIntel specifies the unified shared memory pointers that the kernel may access
+	// indirectly.
+	//
+	// Required type: []unsafe.Pointer
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	KernelExecInfoUsmPtrsIntel KernelExecInfoName = C.CL_KERNEL_EXEC_INFO_USM_PTRS_INTEL
+
+	// CommandMemFillIntel events are created by ExtensionUnifiedSharedMemoryIntel.EnqueueMemFill().
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	CommandMemFillIntel EventCommandType = C.CL_COMMAND_MEMFILL_INTEL
+	// CommandMemcpyIntel events are created by ExtensionUnifiedSharedMemoryIntel.EnqueueMemcpy().
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	CommandMemcpyIntel EventCommandType = C.CL_COMMAND_MEMCPY_INTEL
+	// CommandMigrateMemIntel events are created by ExtensionUnifiedSharedMemoryIntel.EnqueueMigrateMem().
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	CommandMigrateMemIntel EventCommandType = C.CL_COMMAND_MIGRATEMEM_INTEL
+	// CommandMemAdviseIntel events are created by ExtensionUnifiedSharedMemoryIntel.EnqueueMemAdvise().
+	//
+	// Extension: IntelUnifiedSharedMemoryExtensionName
+	CommandMemAdviseIntel EventCommandType = C.CL_COMMAND_MEMADVISE_INTEL
+)
@@ -0,0 +1,176 @@
+#include "api.h"
+
+// The command-buffer types are declared with their underlying types, as older headers do not provide them.
+// A command-buffer is a pointer, a sync point is a cl_uint, and properties are cl_ulong values.
+
+typedef void *(CL_API_CALL *cl30CreateCommandBufferKHR_fn)(cl_uint numQueues, const cl_command_queue *queues,
+    const cl_ulong *properties, cl_int *errcodeReturn);
+typedef cl_int (CL_API_CALL *cl30CommandBufferKHR_fn)(void *commandBuffer);
+typedef cl_int (CL_API_CALL *cl30EnqueueCommandBufferKHR_fn)(cl_uint numQueues, cl_command_queue *queues,
+    void *commandBuffer, cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+typedef cl_int (CL_API_CALL *cl30GetCommandBufferInfoKHR_fn)(void *commandBuffer, cl_uint paramName,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn);
+typedef cl_int (CL_API_CALL *cl30CommandBarrierWithWaitListKHR_fn)(void *commandBuffer, cl_command_queue commandQueue,
+    const cl_ulong *properties,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint, void **mutableHandle);
+typedef cl_int (CL_API_CALL *cl30CommandCopyBufferKHR_fn)(void *commandBuffer, cl_command_queue commandQueue,
+    const cl_ulong *properties, cl_mem srcBuffer, cl_mem dstBuffer, size_t srcOffset, size_t dstOffset, size_t size,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint, void **mutableHandle);
+typedef cl_int (CL_API_CALL *cl30CommandCopyBufferRectKHR_fn)(void *commandBuffer, cl_command_queue commandQueue,
+    const cl_ulong *properties, cl_mem srcBuffer, cl_mem dstBuffer,
+    const size_t *srcOrigin, const size_t *dstOrigin, const size_t *region,
+    size_t srcRowPitch, size_t srcSlicePitch, size_t dstRowPitch, size_t dstSlicePitch,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint, void **mutableHandle);
+typedef cl_int (CL_API_CALL *cl30CommandCopyBufferToImageKHR_fn)(void *commandBuffer, cl_command_queue commandQueue,
+    const cl_ulong *properties, cl_mem srcBuffer, cl_mem dstImage, size_t srcOffset,
+    const size_t *dstOrigin, const size_t *region,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint, void **mutableHandle);
+typedef cl_int (CL_API_CALL *cl30CommandCopyImageKHR_fn)(void *commandBuffer, cl_command_queue commandQueue,
+    const cl_ulong *properties, cl_mem srcImage, cl_mem dstImage,
+    const size_t *srcOrigin, const size_t *dstOrigin, const size_t *region,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint, void **mutableHandle);
+typedef cl_int (CL_API_CALL *cl30CommandCopyImageToBufferKHR_fn)(void *commandBuffer, cl_command_queue commandQueue,
+    const cl_ulong *properties, cl_mem srcImage, cl_mem dstBuffer,
+    const size_t *srcOrigin, const size_t *region, size_t dstOffset,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint, void **mutableHandle);
+typedef cl_int (CL_API_CALL *cl30CommandFillBufferKHR_fn)(void *commandBuffer, cl_command_queue commandQueue,
+    const cl_ulong *properties, cl_mem buffer, const void *pattern, size_t patternSize, size_t offset, size_t size,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint, void **mutableHandle);
+typedef cl_int (CL_API_CALL *cl30CommandFillImageKHR_fn)(void *commandBuffer, cl_command_queue commandQueue,
+    const cl_ulong *properties, cl_mem image, const void *fillColor, const size_t *origin, const size_t *region,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint, void **mutableHandle);
+typedef cl_int (CL_API_CALL *cl30CommandNDRangeKernelKHR_fn)(void *commandBuffer, cl_command_queue commandQueue,
+    const cl_ulong *properties, cl_kernel kernel, cl_uint workDim,
+    const size_t *globalWorkOffset, const size_t *globalWorkSize, const size_t *localWorkSize,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint, void **mutableHandle);
+typedef cl_int (CL_API_CALL *cl30CommandSVMMemcpyKHR_fn)(void *commandBuffer, cl_command_queue commandQueue,
+    const cl_ulong *properties, void *dstPtr, const void *srcPtr, size_t size,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint, void **mutableHandle);
+typedef cl_int (CL_API_CALL *cl30CommandSVMMemFillKHR_fn)(void *commandBuffer, cl_command_queue commandQueue,
+    const cl_ulong *properties, void *svmPtr, const void *pattern, size_t patternSize, size_t size,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint, void **mutableHandle);
+
+void *cl30ExtCreateCommandBufferKHR(void *fn, cl_uint numQueues, const cl_command_queue *queues,
+    const cl_ulong *properties, cl_int *errcodeReturn)
+{
+    return ((cl30CreateCommandBufferKHR_fn)(fn))(numQueues, queues, properties, errcodeReturn);
+}
+
+cl_int cl30ExtCommandBufferKHR(void *fn, void *commandBuffer)
+{
+    return ((cl30CommandBufferKHR_fn)(fn))(commandBuffer);
+}
+
+cl_int cl30ExtEnqueueCommandBufferKHR(void *fn, cl_uint numQueues, cl_command_queue *queues,
+    void *commandBuffer, cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event)
+{
+    return ((cl30EnqueueCommandBufferKHR_fn)(fn))(numQueues, queues, commandBuffer,
+        numEventsInWaitList, eventWaitList, event);
+}
+
+cl_int cl30ExtGetCommandBufferInfoKHR(void *fn, void *commandBuffer, cl_uint paramName,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn)
+{
+    return ((cl30GetCommandBufferInfoKHR_fn)(fn))(commandBuffer, paramName,
+        paramValueSize, paramValue, paramValueSizeReturn);
+}
+
+cl_int cl30ExtCommandBarrierWithWaitListKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint)
+{
+    return ((cl30CommandBarrierWithWaitListKHR_fn)(fn))(commandBuffer, commandQueue, NULL,
+        numSyncPointsInWaitList, syncPointWaitList, syncPoint, NULL);
+}
+
+cl_int cl30ExtCommandCopyBufferKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+    cl_mem srcBuffer, cl_mem dstBuffer, size_t srcOffset, size_t dstOffset, size_t size,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint)
+{
+    return ((cl30CommandCopyBufferKHR_fn)(fn))(commandBuffer, commandQueue, NULL,
+        srcBuffer, dstBuffer, srcOffset, dstOffset, size,
+        numSyncPointsInWaitList, syncPointWaitList, syncPoint, NULL);
+}
+
+cl_int cl30ExtCommandCopyBufferRectKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+    cl_mem srcBuffer, cl_mem dstBuffer, const size_t *srcOrigin, const size_t *dstOrigin, const size_t *region,
+    size_t srcRowPitch, size_t srcSlicePitch, size_t dstRowPitch, size_t dstSlicePitch,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint)
+{
+    return ((cl30CommandCopyBufferRectKHR_fn)(fn))(commandBuffer, commandQueue, NULL,
+        srcBuffer, dstBuffer, srcOrigin, dstOrigin, region,
+        srcRowPitch, srcSlicePitch, dstRowPitch, dstSlicePitch,
+        numSyncPointsInWaitList, syncPointWaitList, syncPoint, NULL);
+}
+
+cl_int cl30ExtCommandCopyBufferToImageKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+    cl_mem srcBuffer, cl_mem dstImage, size_t srcOffset, const size_t *dstOrigin, const size_t *region,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint)
+{
+    return ((cl30CommandCopyBufferToImageKHR_fn)(fn))(commandBuffer, commandQueue, NULL,
+        srcBuffer, dstImage, srcOffset, dstOrigin, region,
+        numSyncPointsInWaitList, syncPointWaitList, syncPoint, NULL);
+}
+
+cl_int cl30ExtCommandCopyImageKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+    cl_mem srcImage, cl_mem dstImage, const size_t *srcOrigin, const size_t *dstOrigin, const size_t *region,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint)
+{
+    return ((cl30CommandCopyImageKHR_fn)(fn))(commandBuffer, commandQueue, NULL,
+        srcImage, dstImage, srcOrigin, dstOrigin, region,
+        numSyncPointsInWaitList, syncPointWaitList, syncPoint, NULL);
+}
+
+cl_int cl30ExtCommandCopyImageToBufferKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+    cl_mem srcImage, cl_mem dstBuffer, const size_t *srcOrigin, const size_t *region, size_t dstOffset,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint)
+{
+    return ((cl30CommandCopyImageToBufferKHR_fn)(fn))(commandBuffer, commandQueue, NULL,
+        srcImage, dstBuffer, srcOrigin, region, dstOffset,
+        numSyncPointsInWaitList, syncPointWaitList, syncPoint, NULL);
+}
+
+cl_int cl30ExtCommandFillBufferKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+    cl_mem buffer, const void *pattern, size_t patternSize, size_t offset, size_t size,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint)
+{
+    return ((cl30CommandFillBufferKHR_fn)(fn))(commandBuffer, commandQueue, NULL,
+        buffer, pattern, patternSize, offset, size,
+        numSyncPointsInWaitList, syncPointWaitList, syncPoint, NULL);
+}
+
+cl_int cl30ExtCommandFillImageKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+    cl_mem image, const void *fillColor, const size_t *origin, const size_t *region,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint)
+{
+    return ((cl30CommandFillImageKHR_fn)(fn))(commandBuffer, commandQueue, NULL,
+        image, fillColor, origin, region,
+        numSyncPointsInWaitList, syncPointWaitList, syncPoint, NULL);
+}
+
+cl_int cl30ExtCommandNDRangeKernelKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+    cl_kernel kernel, cl_uint workDim,
+    const size_t *globalWorkOffset, const size_t *globalWorkSize, const size_t *localWorkSize,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint)
+{
+    return ((cl30CommandNDRangeKernelKHR_fn)(fn))(commandBuffer, commandQueue, NULL,
+        kernel, workDim, globalWorkOffset, globalWorkSize, localWorkSize,
+        numSyncPointsInWaitList, syncPointWaitList, syncPoint, NULL);
+}
+
+cl_int cl30ExtCommandSVMMemcpyKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+    void *dstPtr, const void *srcPtr, size_t size,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint)
+{
+    return ((cl30CommandSVMMemcpyKHR_fn)(fn))(commandBuffer, commandQueue, NULL,
+        dstPtr, srcPtr, size,
+        numSyncPointsInWaitList, syncPointWaitList, syncPoint, NULL);
+}
+
+cl_int cl30ExtCommandSVMMemFillKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+    void *svmPtr, const void *pattern, size_t patternSize, size_t size,
+    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint)
+{
+    return ((cl30CommandSVMMemFillKHR_fn)(fn))(commandBuffer, commandQueue, NULL,
+        svmPtr, pattern, patternSize, size,
+        numSyncPointsInWaitList, syncPointWaitList, syncPoint, NULL);
+}
@@ -0,0 +1,793 @@
+package cl30
+
+import "unsafe"
+
+// #include "api.h"
+// extern void *cl30ExtCreateCommandBufferKHR(void *fn, cl_uint numQueues, const cl_command_queue *queues,
+//    const cl_ulong *properties, cl_int *errcodeReturn);
+// extern cl_int cl30ExtCommandBufferKHR(void *fn, void *commandBuffer);
+// extern cl_int cl30ExtEnqueueCommandBufferKHR(void *fn, cl_uint numQueues, cl_command_queue *queues,
+//    void *commandBuffer, cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+// extern cl_int cl30ExtGetCommandBufferInfoKHR(void *fn, void *commandBuffer, cl_uint paramName,
+//    size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn);
+// extern cl_int cl30ExtCommandBarrierWithWaitListKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+//    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint);
+// extern cl_int cl30ExtCommandCopyBufferKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+//    cl_mem srcBuffer, cl_mem dstBuffer, size_t srcOffset, size_t dstOffset, size_t size,
+//    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint);
+// extern cl_int cl30ExtCommandCopyBufferRectKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+//    cl_mem srcBuffer, cl_mem dstBuffer, const size_t *srcOrigin, const size_t *dstOrigin, const size_t *region,
+//    size_t srcRowPitch, size_t srcSlicePitch, size_t dstRowPitch, size_t dstSlicePitch,
+//    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint);
+// extern cl_int cl30ExtCommandCopyBufferToImageKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+//    cl_mem srcBuffer, cl_mem dstImage, size_t srcOffset, const size_t *dstOrigin, const size_t *region,
+//    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint);
+// extern cl_int cl30ExtCommandCopyImageKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+//    cl_mem srcImage, cl_mem dstImage, const size_t *srcOrigin, const size_t *dstOrigin, const size_t *region,
+//    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint);
+// extern cl_int cl30ExtCommandCopyImageToBufferKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+//    cl_mem srcImage, cl_mem dstBuffer, const size_t *srcOrigin, const size_t *region, size_t dstOffset,
+//    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint);
+// extern cl_int cl30ExtCommandFillBufferKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+//    cl_mem buffer, const void *pattern, size_t patternSize, size_t offset, size_t size,
+//    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint);
+// extern cl_int cl30ExtCommandFillImageKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+//    cl_mem image, const void *fillColor, const size_t *origin, const size_t *region,
+//    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint);
+// extern cl_int cl30ExtCommandNDRangeKernelKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+//    cl_kernel kernel, cl_uint workDim,
+//    const size_t *globalWorkOffset, const size_t *globalWorkSize, const size_t *localWorkSize,
+//    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint);
+// extern cl_int cl30ExtCommandSVMMemcpyKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+//    void *dstPtr, const void *srcPtr, size_t size,
+//    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint);
+// extern cl_int cl30ExtCommandSVMMemFillKHR(void *fn, void *commandBuffer, cl_command_queue commandQueue,
+//    void *svmPtr, const void *pattern, size_t patternSize, size_t size,
+//    cl_uint numSyncPointsInWaitList, const cl_uint *syncPointWaitList, cl_uint *syncPoint);
+import "C"
+
+// CommandBufferKhr is a recorded sequence of commands that can be enqueued repeatedly.
+// Create a new command-buffer with ExtensionCommandBufferKhr.CreateCommandBuffer().
+//
+// Extension: KhrCommandBufferExtensionName
+type CommandBufferKhr uintptr
+
+func (commandBuffer CommandBufferKhr) handle() unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&commandBuffer))
+}
+
+// String provides a readable presentation of the command-buffer identifier.
+// It is based on the numerical value of the underlying pointer, followed by the name set with SetDebugName(), if any.
+func (commandBuffer CommandBufferKhr) String() string {
+	return handleString(commandBuffer, uintptr(commandBuffer))
+}
+
+// SyncPointKhr identifies a command within a command-buffer. Sync points express the dependencies between
+// the commands of the same command-buffer.
+//
+// Extension: KhrCommandBufferExtensionName
+type SyncPointKhr uint32
+
+// ExtensionCommandBufferKhr represents the functionality provided by the "cl_khr_command_buffer" extension.
+// Load the extension with LoadExtensionCommandBufferKhr().
+//
+// Command-buffers record a sequence of commands once, which are then enqueued repeatedly with a single call.
+// This removes the per-command overhead of the enqueue calls for workloads that replay the same graph of
+// commands many times.
+//
+// The recording functions take an optional command-queue. If it is zero, the command is recorded for the single
+// command-queue the command-buffer was created with. Mutable command handles are not supported.
+//
+// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_command_buffer
+// Extension: KhrCommandBufferExtensionName
+type ExtensionCommandBufferKhr struct {
+	clCreateCommandBufferKhr        unsafe.Pointer
+	clFinalizeCommandBufferKhr      unsafe.Pointer
+	clRetainCommandBufferKhr        unsafe.Pointer
+	clReleaseCommandBufferKhr       unsafe.Pointer
+	clEnqueueCommandBufferKhr       unsafe.Pointer
+	clGetCommandBufferInfoKhr       unsafe.Pointer
+	clCommandBarrierWithWaitListKhr unsafe.Pointer
+	clCommandCopyBufferKhr          unsafe.Pointer
+	clCommandCopyBufferRectKhr      unsafe.Pointer
+	clCommandCopyBufferToImageKhr   unsafe.Pointer
+	clCommandCopyImageKhr           unsafe.Pointer
+	clCommandCopyImageToBufferKhr   unsafe.Pointer
+	clCommandFillBufferKhr          unsafe.Pointer
+	clCommandFillImageKhr           unsafe.Pointer
+	clCommandNDRangeKernelKhr       unsafe.Pointer
+	clCommandSvmMemcpyKhr           unsafe.Pointer
+	clCommandSvmMemFillKhr          unsafe.Pointer
+}
+
+// LoadExtensionCommandBufferKhr loads the required functions for the extension and returns an instance
+// to ExtensionCommandBufferKhr if possible.
+//
+// The SVM recording functions were added in a later revision of the extension. They are loaded if available;
+// CommandSvmMemcpy() and CommandSvmMemFill() return ErrExtensionNotAvailable otherwise.
+//
+// Extension: KhrCommandBufferExtensionName
+func LoadExtensionCommandBufferKhr(id PlatformID) (*ExtensionCommandBufferKhr, error) {
+	ext := &ExtensionCommandBufferKhr{}
+	required := []struct {
+		name string
+		fn   *unsafe.Pointer
+	}{
+		{name: "clCreateCommandBufferKHR", fn: &ext.clCreateCommandBufferKhr},
+		{name: "clFinalizeCommandBufferKHR", fn: &ext.clFinalizeCommandBufferKhr},
+		{name: "clRetainCommandBufferKHR", fn: &ext.clRetainCommandBufferKhr},
+		{name: "clReleaseCommandBufferKHR", fn: &ext.clReleaseCommandBufferKhr},
+		{name: "clEnqueueCommandBufferKHR", fn: &ext.clEnqueueCommandBufferKhr},
+		{name: "clGetCommandBufferInfoKHR", fn: &ext.clGetCommandBufferInfoKhr},
+		{name: "clCommandBarrierWithWaitListKHR", fn: &ext.clCommandBarrierWithWaitListKhr},
+		{name: "clCommandCopyBufferKHR", fn: &ext.clCommandCopyBufferKhr},
+		{name: "clCommandCopyBufferRectKHR", fn: &ext.clCommandCopyBufferRectKhr},
+		{name: "clCommandCopyBufferToImageKHR", fn: &ext.clCommandCopyBufferToImageKhr},
+		{name: "clCommandCopyImageKHR", fn: &ext.clCommandCopyImageKhr},
+		{name: "clCommandCopyImageToBufferKHR", fn: &ext.clCommandCopyImageToBufferKhr},
+		{name: "clCommandFillBufferKHR", fn: &ext.clCommandFillBufferKhr},
+		{name: "clCommandFillImageKHR", fn: &ext.clCommandFillImageKhr},
+		{name: "clCommandNDRangeKernelKHR", fn: &ext.clCommandNDRangeKernelKhr},
+	}
+	for _, entry := range required {
+		*entry.fn = ExtensionFunctionAddressForPlatform(id, entry.name)
+		if *entry.fn == nil {
+			return nil, ErrExtensionNotAvailable
+		}
+	}
+	ext.clCommandSvmMemcpyKhr = ExtensionFunctionAddressForPlatform(id, "clCommandSVMMemcpyKHR")
+	ext.clCommandSvmMemFillKhr = ExtensionFunctionAddressForPlatform(id, "clCommandSVMMemFillKHR")
+	return ext, nil
+}
+
+// CommandBufferPropertyKhr is one entry of properties which are taken into account when creating command-buffers.
+//
+// Extension: KhrCommandBufferExtensionName
+type CommandBufferPropertyKhr []uint64
+
+// WithCommandBufferFlags is a convenience function to create a valid CommandBufferFlagsKhrProperty.
+// Use it in combination with ExtensionCommandBufferKhr.CreateCommandBuffer().
+//
+// Extension: KhrCommandBufferExtensionName
+func WithCommandBufferFlags(flags CommandBufferFlagsKhr) CommandBufferPropertyKhr {
+	return CommandBufferPropertyKhr{CommandBufferFlagsKhrProperty, uint64(flags)}
+}
+
+// CreateCommandBuffer creates a command-buffer that replays commands on the given command-queues.
+// The command-buffer is in the recording state after creation.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CreateCommandBuffer(commandQueues []CommandQueue, properties ...CommandBufferPropertyKhr) (CommandBufferKhr, error) {
+	defer observeCall("clCreateCommandBufferKHR")()
+	if (ext == nil) || (ext.clCreateCommandBufferKhr == nil) {
+		return 0, ErrExtensionNotLoaded
+	}
+	if len(commandQueues) == 0 {
+		return 0, ErrInvalidValue
+	}
+	var rawPropertyList []uint64
+	for _, property := range properties {
+		rawPropertyList = append(rawPropertyList, property...)
+	}
+	var rawProperties unsafe.Pointer
+	if len(properties) > 0 {
+		rawPropertyList = append(rawPropertyList, 0)
+		rawProperties = unsafe.Pointer(&rawPropertyList[0])
+	}
+	var status C.cl_int
+	commandBuffer := C.cl30ExtCreateCommandBufferKHR(
+		ext.clCreateCommandBufferKhr,
+		C.cl_uint(len(commandQueues)),
+		(*C.cl_command_queue)(unsafe.Pointer(&commandQueues[0])),
+		(*C.cl_ulong)(rawProperties),
+		&status)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clCreateCommandBufferKHR", status)
+	}
+	return CommandBufferKhr(uintptr(commandBuffer)), nil
+}
+
+// FinalizeCommandBuffer finishes the recording of the command-buffer. Afterwards, the command-buffer can be
+// enqueued, and no more commands can be recorded.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) FinalizeCommandBuffer(commandBuffer CommandBufferKhr) error {
+	defer observeCall("clFinalizeCommandBufferKHR")()
+	if (ext == nil) || (ext.clFinalizeCommandBufferKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	return ext.commandBufferCall("clFinalizeCommandBufferKHR", ext.clFinalizeCommandBufferKhr, commandBuffer)
+}
+
+// RetainCommandBuffer increments the reference count of the command-buffer.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) RetainCommandBuffer(commandBuffer CommandBufferKhr) error {
+	defer observeCall("clRetainCommandBufferKHR")()
+	if (ext == nil) || (ext.clRetainCommandBufferKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	err := ext.commandBufferCall("clRetainCommandBufferKHR", ext.clRetainCommandBufferKhr, commandBuffer)
+	if err != nil {
+		return err
+	}
+	retainNamed(commandBuffer)
+	return nil
+}
+
+// ReleaseCommandBuffer decrements the reference count of the command-buffer.
+// The command-buffer is deleted once the count reaches zero and all of its pending submissions have completed.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) ReleaseCommandBuffer(commandBuffer CommandBufferKhr) error {
+	defer observeCall("clReleaseCommandBufferKHR")()
+	if (ext == nil) || (ext.clReleaseCommandBufferKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	err := ext.commandBufferCall("clReleaseCommandBufferKHR", ext.clReleaseCommandBufferKhr, commandBuffer)
+	if err != nil {
+		return err
+	}
+	unnameReleased(commandBuffer)
+	return nil
+}
+
+func (ext *ExtensionCommandBufferKhr) commandBufferCall(operation string, fn unsafe.Pointer, commandBuffer CommandBufferKhr) error {
+	status := C.cl30ExtCommandBufferKHR(fn, commandBuffer.handle())
+	if status != C.CL_SUCCESS {
+		return operationError(operation, status, "commandBuffer", commandBuffer)
+	}
+	return nil
+}
+
+// EnqueueCommandBuffer enqueues all commands of the finalized command-buffer with a single call.
+//
+// The command-queues may be empty, in which case the command-queues the command-buffer was created with are used.
+// Otherwise, they replace these command-queues for this submission, and must be compatible with them.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) EnqueueCommandBuffer(commandQueues []CommandQueue, commandBuffer CommandBufferKhr,
+	waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueCommandBufferKHR")()
+	if (ext == nil) || (ext.clEnqueueCommandBufferKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	var rawQueues unsafe.Pointer
+	var waitListQueue CommandQueue
+	if len(commandQueues) > 0 {
+		rawQueues = unsafe.Pointer(&commandQueues[0])
+		waitListQueue = commandQueues[0]
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(waitListQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	status := C.cl30ExtEnqueueCommandBufferKHR(
+		ext.clEnqueueCommandBufferKhr,
+		C.cl_uint(len(commandQueues)),
+		(*C.cl_command_queue)(rawQueues),
+		commandBuffer.handle(),
+		C.cl_uint(len(waitList)),
+		(*C.cl_event)(rawWaitList),
+		(*C.cl_event)(unsafe.Pointer(event)))
+	if status != C.CL_SUCCESS {
+		return operationError("clEnqueueCommandBufferKHR", status, "commandBuffer", commandBuffer)
+	}
+	trackEnqueuedEvent(event)
+	for _, commandQueue := range commandQueues {
+		autoFlushAfterEnqueue(commandQueue)
+	}
+	return nil
+}
+
+// CommandBufferInfoNameKhr identifies properties of a command-buffer, which can be queried with
+// ExtensionCommandBufferKhr.CommandBufferInfo().
+//
+// Extension: KhrCommandBufferExtensionName
+type CommandBufferInfoNameKhr C.cl_uint
+
+// CommandBufferInfo queries information about a command-buffer.
+//
+// The provided size need to specify the size of the available space pointed to the provided value in bytes.
+//
+// The returned number is the required size, in bytes, for the queried information.
+// Call the function with a zero size and nil value to request the required size. This helps in determining
+// the necessary space for dynamic information, such as arrays.
+//
+// Raw strings are with a terminating NUL character.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandBufferInfo(commandBuffer CommandBufferKhr, paramName CommandBufferInfoNameKhr,
+	paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetCommandBufferInfoKHR")()
+	if (ext == nil) || (ext.clGetCommandBufferInfoKhr == nil) {
+		return 0, ErrExtensionNotLoaded
+	}
+	sizeReturn := C.size_t(0)
+	status := C.cl30ExtGetCommandBufferInfoKHR(
+		ext.clGetCommandBufferInfoKhr,
+		commandBuffer.handle(),
+		C.cl_uint(paramName),
+		C.size_t(paramSize),
+		paramValue,
+		&sizeReturn)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clGetCommandBufferInfoKHR", status, "commandBuffer", commandBuffer, "paramName", paramName)
+	}
+	return uintptr(sizeReturn), nil
+}
+
+// CommandBufferState returns the current state of the command-buffer.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandBufferState(commandBuffer CommandBufferKhr) (CommandBufferStateKhr, error) {
+	return queryValue[CommandBufferStateKhr](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return ext.CommandBufferInfo(commandBuffer, CommandBufferStateKhrInfo, paramSize, paramValue)
+	})
+}
+
+func rawSyncPointWaitList(waitList []SyncPointKhr) *C.cl_uint {
+	if len(waitList) == 0 {
+		return nil
+	}
+	return (*C.cl_uint)(unsafe.Pointer(&waitList[0]))
+}
+
+func recordedCommandStatus(operation string, status C.cl_int) error {
+	if status != C.CL_SUCCESS {
+		return operationError(operation, status)
+	}
+	return nil
+}
+
+// CommandBarrierWithWaitList records a barrier. The commands recorded after the barrier only execute once
+// the commands of the wait list, or all previously recorded commands if the wait list is empty, have completed.
+//
+// If syncPoint is not nil, it receives the sync point of the recorded command.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandBarrierWithWaitList(commandBuffer CommandBufferKhr, commandQueue CommandQueue,
+	waitList []SyncPointKhr, syncPoint *SyncPointKhr) error {
+	defer observeCall("clCommandBarrierWithWaitListKHR")()
+	if (ext == nil) || (ext.clCommandBarrierWithWaitListKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	return recordedCommandStatus("clCommandBarrierWithWaitListKHR", C.cl30ExtCommandBarrierWithWaitListKHR(
+		ext.clCommandBarrierWithWaitListKhr,
+		commandBuffer.handle(),
+		commandQueue.handle(),
+		C.cl_uint(len(waitList)),
+		rawSyncPointWaitList(waitList),
+		(*C.cl_uint)(unsafe.Pointer(syncPoint))))
+}
+
+// CommandCopyBuffer records a copy from one buffer object to another. See EnqueueCopyBuffer() for details.
+//
+// If syncPoint is not nil, it receives the sync point of the recorded command.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandCopyBuffer(commandBuffer CommandBufferKhr, commandQueue CommandQueue,
+	src, dst MemObject, srcOffset, dstOffset, size uintptr,
+	waitList []SyncPointKhr, syncPoint *SyncPointKhr) error {
+	defer observeCall("clCommandCopyBufferKHR")()
+	if (ext == nil) || (ext.clCommandCopyBufferKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	return recordedCommandStatus("clCommandCopyBufferKHR", C.cl30ExtCommandCopyBufferKHR(
+		ext.clCommandCopyBufferKhr,
+		commandBuffer.handle(),
+		commandQueue.handle(),
+		src.handle(),
+		dst.handle(),
+		C.size_t(srcOffset),
+		C.size_t(dstOffset),
+		C.size_t(size),
+		C.cl_uint(len(waitList)),
+		rawSyncPointWaitList(waitList),
+		(*C.cl_uint)(unsafe.Pointer(syncPoint))))
+}
+
+// CommandCopyBufferRect records a copy of a 2D or 3D rectangular region from one buffer object to another.
+// See EnqueueCopyBufferRect() for details.
+//
+// If syncPoint is not nil, it receives the sync point of the recorded command.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandCopyBufferRect(commandBuffer CommandBufferKhr, commandQueue CommandQueue,
+	src, dst MemObject, srcOrigin, dstOrigin, region [3]uintptr,
+	srcRowPitch, srcSlicePitch, dstRowPitch, dstSlicePitch uintptr,
+	waitList []SyncPointKhr, syncPoint *SyncPointKhr) error {
+	defer observeCall("clCommandCopyBufferRectKHR")()
+	if (ext == nil) || (ext.clCommandCopyBufferRectKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	return recordedCommandStatus("clCommandCopyBufferRectKHR", C.cl30ExtCommandCopyBufferRectKHR(
+		ext.clCommandCopyBufferRectKhr,
+		commandBuffer.handle(),
+		commandQueue.handle(),
+		src.handle(),
+		dst.handle(),
+		(*C.size_t)(unsafe.Pointer(&srcOrigin[0])),
+		(*C.size_t)(unsafe.Pointer(&dstOrigin[0])),
+		(*C.size_t)(unsafe.Pointer(&region[0])),
+		C.size_t(srcRowPitch),
+		C.size_t(srcSlicePitch),
+		C.size_t(dstRowPitch),
+		C.size_t(dstSlicePitch),
+		C.cl_uint(len(waitList)),
+		rawSyncPointWaitList(waitList),
+		(*C.cl_uint)(unsafe.Pointer(syncPoint))))
+}
+
+// CommandCopyBufferToImage records a copy from a buffer object to an image object.
+// See EnqueueCopyBufferToImage() for details.
+//
+// If syncPoint is not nil, it receives the sync point of the recorded command.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandCopyBufferToImage(commandBuffer CommandBufferKhr, commandQueue CommandQueue,
+	srcBuffer, dstImage MemObject, srcOffset uintptr, dstOrigin, region [3]uintptr,
+	waitList []SyncPointKhr, syncPoint *SyncPointKhr) error {
+	defer observeCall("clCommandCopyBufferToImageKHR")()
+	if (ext == nil) || (ext.clCommandCopyBufferToImageKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	return recordedCommandStatus("clCommandCopyBufferToImageKHR", C.cl30ExtCommandCopyBufferToImageKHR(
+		ext.clCommandCopyBufferToImageKhr,
+		commandBuffer.handle(),
+		commandQueue.handle(),
+		srcBuffer.handle(),
+		dstImage.handle(),
+		C.size_t(srcOffset),
+		(*C.size_t)(unsafe.Pointer(&dstOrigin[0])),
+		(*C.size_t)(unsafe.Pointer(&region[0])),
+		C.cl_uint(len(waitList)),
+		rawSyncPointWaitList(waitList),
+		(*C.cl_uint)(unsafe.Pointer(syncPoint))))
+}
+
+// CommandCopyImage records a copy from one image object to another. See EnqueueCopyImage() for details.
+//
+// If syncPoint is not nil, it receives the sync point of the recorded command.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandCopyImage(commandBuffer CommandBufferKhr, commandQueue CommandQueue,
+	srcImage, dstImage MemObject, srcOrigin, dstOrigin, region [3]uintptr,
+	waitList []SyncPointKhr, syncPoint *SyncPointKhr) error {
+	defer observeCall("clCommandCopyImageKHR")()
+	if (ext == nil) || (ext.clCommandCopyImageKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	return recordedCommandStatus("clCommandCopyImageKHR", C.cl30ExtCommandCopyImageKHR(
+		ext.clCommandCopyImageKhr,
+		commandBuffer.handle(),
+		commandQueue.handle(),
+		srcImage.handle(),
+		dstImage.handle(),
+		(*C.size_t)(unsafe.Pointer(&srcOrigin[0])),
+		(*C.size_t)(unsafe.Pointer(&dstOrigin[0])),
+		(*C.size_t)(unsafe.Pointer(&region[0])),
+		C.cl_uint(len(waitList)),
+		rawSyncPointWaitList(waitList),
+		(*C.cl_uint)(unsafe.Pointer(syncPoint))))
+}
+
+// CommandCopyImageToBuffer records a copy from an image object to a buffer object.
+// See EnqueueCopyImageToBuffer() for details.
+//
+// If syncPoint is not nil, it receives the sync point of the recorded command.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandCopyImageToBuffer(commandBuffer CommandBufferKhr, commandQueue CommandQueue,
+	srcImage, dstBuffer MemObject, srcOrigin, region [3]uintptr, dstOffset uintptr,
+	waitList []SyncPointKhr, syncPoint *SyncPointKhr) error {
+	defer observeCall("clCommandCopyImageToBufferKHR")()
+	if (ext == nil) || (ext.clCommandCopyImageToBufferKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	return recordedCommandStatus("clCommandCopyImageToBufferKHR", C.cl30ExtCommandCopyImageToBufferKHR(
+		ext.clCommandCopyImageToBufferKhr,
+		commandBuffer.handle(),
+		commandQueue.handle(),
+		srcImage.handle(),
+		dstBuffer.handle(),
+		(*C.size_t)(unsafe.Pointer(&srcOrigin[0])),
+		(*C.size_t)(unsafe.Pointer(&region[0])),
+		C.size_t(dstOffset),
+		C.cl_uint(len(waitList)),
+		rawSyncPointWaitList(waitList),
+		(*C.cl_uint)(unsafe.Pointer(syncPoint))))
+}
+
+// CommandFillBuffer records a fill of a buffer object with a pattern. See EnqueueFillBuffer() for details.
+// The pattern is copied during the call.
+//
+// If syncPoint is not nil, it receives the sync point of the recorded command.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandFillBuffer(commandBuffer CommandBufferKhr, commandQueue CommandQueue,
+	mem MemObject, pattern unsafe.Pointer, patternSize, offset, size uintptr,
+	waitList []SyncPointKhr, syncPoint *SyncPointKhr) error {
+	defer observeCall("clCommandFillBufferKHR")()
+	if (ext == nil) || (ext.clCommandFillBufferKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	return recordedCommandStatus("clCommandFillBufferKHR", C.cl30ExtCommandFillBufferKHR(
+		ext.clCommandFillBufferKhr,
+		commandBuffer.handle(),
+		commandQueue.handle(),
+		mem.handle(),
+		pattern,
+		C.size_t(patternSize),
+		C.size_t(offset),
+		C.size_t(size),
+		C.cl_uint(len(waitList)),
+		rawSyncPointWaitList(waitList),
+		(*C.cl_uint)(unsafe.Pointer(syncPoint))))
+}
+
+// CommandFillImage records a fill of an image object with a color. See EnqueueFillImage() for details.
+// The color is copied during the call.
+//
+// If syncPoint is not nil, it receives the sync point of the recorded command.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandFillImage(commandBuffer CommandBufferKhr, commandQueue CommandQueue,
+	image MemObject, fillColor unsafe.Pointer, origin, region [3]uintptr,
+	waitList []SyncPointKhr, syncPoint *SyncPointKhr) error {
+	defer observeCall("clCommandFillImageKHR")()
+	if (ext == nil) || (ext.clCommandFillImageKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	return recordedCommandStatus("clCommandFillImageKHR", C.cl30ExtCommandFillImageKHR(
+		ext.clCommandFillImageKhr,
+		commandBuffer.handle(),
+		commandQueue.handle(),
+		image.handle(),
+		fillColor,
+		(*C.size_t)(unsafe.Pointer(&origin[0])),
+		(*C.size_t)(unsafe.Pointer(&region[0])),
+		C.cl_uint(len(waitList)),
+		rawSyncPointWaitList(waitList),
+		(*C.cl_uint)(unsafe.Pointer(syncPoint))))
+}
+
+// CommandNDRangeKernel records the execution of a kernel. See EnqueueNDRangeKernel() for details.
+//
+// The kernel arguments are captured during the call. Later changes to the arguments of the kernel object
+// do not affect the recorded command.
+//
+// The global offset and the local work size are optional and may be nil. If provided, their number of entries
+// must match the one of the global work size.
+// If syncPoint is not nil, it receives the sync point of the recorded command.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandNDRangeKernel(commandBuffer CommandBufferKhr, commandQueue CommandQueue,
+	kernel Kernel, globalWorkOffset, globalWorkSize, localWorkSize []uintptr,
+	waitList []SyncPointKhr, syncPoint *SyncPointKhr) error {
+	defer observeCall("clCommandNDRangeKernelKHR")()
+	if (ext == nil) || (ext.clCommandNDRangeKernelKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	if len(globalWorkSize) == 0 {
+		return ErrInvalidWorkDimension
+	}
+	var rawGlobalOffset unsafe.Pointer
+	if globalWorkOffset != nil {
+		if len(globalWorkOffset) != len(globalWorkSize) {
+			return ErrInvalidGlobalOffset
+		}
+		rawGlobalOffset = unsafe.Pointer(&globalWorkOffset[0])
+	}
+	var rawLocalSize unsafe.Pointer
+	if localWorkSize != nil {
+		if len(localWorkSize) != len(globalWorkSize) {
+			return ErrInvalidWorkGroupSize
+		}
+		rawLocalSize = unsafe.Pointer(&localWorkSize[0])
+	}
+	return recordedCommandStatus("clCommandNDRangeKernelKHR", C.cl30ExtCommandNDRangeKernelKHR(
+		ext.clCommandNDRangeKernelKhr,
+		commandBuffer.handle(),
+		commandQueue.handle(),
+		kernel.handle(),
+		C.cl_uint(len(globalWorkSize)),
+		(*C.size_t)(rawGlobalOffset),
+		(*C.size_t)(unsafe.Pointer(&globalWorkSize[0])),
+		(*C.size_t)(rawLocalSize),
+		C.cl_uint(len(waitList)),
+		rawSyncPointWaitList(waitList),
+		(*C.cl_uint)(unsafe.Pointer(syncPoint))))
+}
+
+// CommandSvmMemcpy records a copy between two SVM regions. See EnqueueSvmMemcpy() for details.
+//
+// If syncPoint is not nil, it receives the sync point of the recorded command.
+// ErrExtensionNotAvailable is returned if the implementation provides an earlier revision of the extension,
+// which does not support recording SVM commands.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandSvmMemcpy(commandBuffer CommandBufferKhr, commandQueue CommandQueue,
+	dstPtr, srcPtr unsafe.Pointer, size int,
+	waitList []SyncPointKhr, syncPoint *SyncPointKhr) error {
+	defer observeCall("clCommandSVMMemcpyKHR")()
+	if ext == nil {
+		return ErrExtensionNotLoaded
+	}
+	if ext.clCommandSvmMemcpyKhr == nil {
+		return ErrExtensionNotAvailable
+	}
+	return recordedCommandStatus("clCommandSVMMemcpyKHR", C.cl30ExtCommandSVMMemcpyKHR(
+		ext.clCommandSvmMemcpyKhr,
+		commandBuffer.handle(),
+		commandQueue.handle(),
+		dstPtr,
+		srcPtr,
+		C.size_t(size),
+		C.cl_uint(len(waitList)),
+		rawSyncPointWaitList(waitList),
+		(*C.cl_uint)(unsafe.Pointer(syncPoint))))
+}
+
+// CommandSvmMemFill records a fill of an SVM region with a pattern. See EnqueueSvmMemFill() for details.
+// The pattern is copied during the call.
+//
+// If syncPoint is not nil, it receives the sync point of the recorded command.
+// ErrExtensionNotAvailable is returned if the implementation provides an earlier revision of the extension,
+// which does not support recording SVM commands.
+//
+// Extension: KhrCommandBufferExtensionName
+func (ext *ExtensionCommandBufferKhr) CommandSvmMemFill(commandBuffer CommandBufferKhr, commandQueue CommandQueue,
+	svmPtr, pattern unsafe.Pointer, patternSize, size int,
+	waitList []SyncPointKhr, syncPoint *SyncPointKhr) error {
+	defer observeCall("clCommandSVMMemFillKHR")()
+	if ext == nil {
+		return ErrExtensionNotLoaded
+	}
+	if ext.clCommandSvmMemFillKhr == nil {
+		return ErrExtensionNotAvailable
+	}
+	return recordedCommandStatus("clCommandSVMMemFillKHR", C.cl30ExtCommandSVMMemFillKHR(
+		ext.clCommandSvmMemFillKhr,
+		commandBuffer.handle(),
+		commandQueue.handle(),
+		svmPtr,
+		pattern,
+		C.size_t(patternSize),
+		C.size_t(size),
+		C.cl_uint(len(waitList)),
+		rawSyncPointWaitList(waitList),
+		(*C.cl_uint)(unsafe.Pointer(syncPoint))))
+}
+
+// CommandBufferFlagsKhr is used with CommandBufferFlagsKhrProperty when creating a command-buffer.
+//
+// Extension: KhrCommandBufferExtensionName
+type CommandBufferFlagsKhr C.cl_ulong
+
+// CommandBufferStateKhr describes the state of a command-buffer.
+//
+// Extension: KhrCommandBufferExtensionName
+type CommandBufferStateKhr C.cl_uint
+
+// DeviceCommandBufferCapabilitiesKhrFlags describes the optional command-buffer features that a device supports.
+//
+// Extension: KhrCommandBufferExtensionName
+type DeviceCommandBufferCapabilitiesKhrFlags C.cl_ulong
+
+const (
+	// KhrCommandBufferExtensionName is the official name of the extension
+	// handled by ExtensionCommandBufferKhr.
+	KhrCommandBufferExtensionName = "cl_khr_command_buffer"
+
+	// ErrInvalidCommandBufferKhr is returned for invalid command-buffers, or for command-buffers that are not
+	// in the state required for the operation.
+	//
+	// Extension: KhrCommandBufferExtensionName
+	ErrInvalidCommandBufferKhr StatusError = C.CL_INVALID_COMMAND_BUFFER_KHR
+	// ErrInvalidSyncPointWaitListKhr is returned for invalid sync point wait lists.
+	//
+	// Extension: KhrCommandBufferExtensionName
+	ErrInvalidSyncPointWaitListKhr StatusError = C.CL_INVALID_SYNC_POINT_WAIT_LIST_KHR
+	// ErrIncompatibleCommandQueueKhr is returned if a command-queue does not match the properties of the
+	// command-queue the command-buffer was recorded for.
+	//
+	// Extension: KhrCommandBufferExtensionName
+	ErrIncompatibleCommandQueueKhr StatusError = C.CL_INCOMPATIBLE_COMMAND_QUEUE_KHR
+
+	// CommandCommandBufferKhr events are created by ExtensionCommandBufferKhr.EnqueueCommandBuffer().
+	//
+	// Extension: KhrCommandBufferExtensionName
+	CommandCommandBufferKhr EventCommandType = C.CL_COMMAND_COMMAND_BUFFER_KHR
+
+	// DeviceCommandBufferCapabilitiesKhrInfo describes the optional command-buffer features that the device supports.
+	//
+	// Info value type: DeviceCommandBufferCapabilitiesKhrFlags
+	// Extension: KhrCommandBufferExtensionName
+	DeviceCommandBufferCapabilitiesKhrInfo DeviceInfoName = C.CL_DEVICE_COMMAND_BUFFER_CAPABILITIES_KHR
+	// DeviceCommandBufferRequiredQueuePropertiesKhrInfo describes the properties that command-queues must have
+	// to be used with a command-buffer.
+	//
+	// Info value type: CommandQueuePropertiesFlags
+	// Extension: KhrCommandBufferExtensionName
+	DeviceCommandBufferRequiredQueuePropertiesKhrInfo DeviceInfoName = C.CL_DEVICE_COMMAND_BUFFER_REQUIRED_QUEUE_PROPERTIES_KHR
+
+	// DeviceCommandBufferCapabilitiesKhrKernelPrintf indicates support for kernels that call printf().
+	//
+	// Extension: KhrCommandBufferExtensionName
+	DeviceCommandBufferCapabilitiesKhrKernelPrintf DeviceCommandBufferCapabilitiesKhrFlags = C.CL_COMMAND_BUFFER_CAPABILITY_KERNEL_PRINTF_KHR
+	// DeviceCommandBufferCapabilitiesKhrDeviceSideEnqueue indicates support for kernels that enqueue kernels.
+	//
+	// Extension: KhrCommandBufferExtensionName
+	DeviceCommandBufferCapabilitiesKhrDeviceSideEnqueue DeviceCommandBufferCapabilitiesKhrFlags = C.CL_COMMAND_BUFFER_CAPABILITY_DEVICE_SIDE_ENQUEUE_KHR
+	// DeviceCommandBufferCapabilitiesKhrSimultaneousUse indicates support for CommandBufferSimultaneousUseKhr.
+	//
+	// Extension: KhrCommandBufferExtensionName
+	DeviceCommandBufferCapabilitiesKhrSimultaneousUse DeviceCommandBufferCapabilitiesKhrFlags = C.CL_COMMAND_BUFFER_CAPABILITY_SIMULTANEOUS_USE_KHR
+	// DeviceCommandBufferCapabilitiesKhrOutOfOrder indicates support for out-of-order command-queues.
+	//
+	// Extension: KhrCommandBufferExtensionName
+	DeviceCommandBufferCapabilitiesKhrOutOfOrder DeviceCommandBufferCapabilitiesKhrFlags = C.CL_COMMAND_BUFFER_CAPABILITY_OUT_OF_ORDER_KHR
+
+	// CommandBufferFlagsKhrProperty is a bitfield of CommandBufferFlagsKhr values.
+	//
+	// Use WithCommandBufferFlags() for convenience.
+	//
+	// Property value type: CommandBufferFlagsKhr
+	// Extension: KhrCommandBufferExtensionName
+	CommandBufferFlagsKhrProperty uint64 = C.CL_COMMAND_BUFFER_FLAGS_KHR
+
+	// CommandBufferSimultaneousUseKhr allows the command-buffer to be enqueued again while a previous
+	// submission is still pending.
+	//
+	// Extension: KhrCommandBufferExtensionName
+	CommandBufferSimultaneousUseKhr CommandBufferFlagsKhr = C.CL_COMMAND_BUFFER_SIMULTANEOUS_USE_KHR
+
+	// CommandBufferQueuesKhrInfo returns the command-queues the command-buffer was created with.
+	//
+	// Returned type: []CommandQueue
+	// Extension: KhrCommandBufferExtensionName
+	CommandBufferQueuesKhrInfo CommandBufferInfoNameKhr = C.CL_COMMAND_BUFFER_QUEUES_KHR
+	// CommandBufferNumQueuesKhrInfo returns the number of command-queues the command-buffer was created with.
+	//
+	// Returned type: uint32
+	// Extension: KhrCommandBufferExtensionName
+	CommandBufferNumQueuesKhrInfo CommandBufferInfoNameKhr = C.CL_COMMAND_BUFFER_NUM_QUEUES_KHR
+	// CommandBufferReferenceCountKhrInfo returns the reference count of the command-buffer.
+	//
+	// Returned type: uint32
+	// Extension: KhrCommandBufferExtensionName
+	CommandBufferReferenceCountKhrInfo CommandBufferInfoNameKhr = C.CL_COMMAND_BUFFER_REFERENCE_COUNT_KHR
+	// CommandBufferStateKhrInfo returns the current state of the command-buffer.
+	//
+	// Returned type: CommandBufferStateKhr
+	// Extension: KhrCommandBufferExtensionName
+	CommandBufferStateKhrInfo CommandBufferInfoNameKhr = C.CL_COMMAND_BUFFER_STATE_KHR
+	// CommandBufferPropertiesArrayKhrInfo returns the properties the command-buffer was created with.
+	//
+	// Returned type: []uint64
+	// Extension: KhrCommandBufferExtensionName
+	CommandBufferPropertiesArrayKhrInfo CommandBufferInfoNameKhr = C.CL_COMMAND_BUFFER_PROPERTIES_ARRAY_KHR
+	// CommandBufferContextKhrInfo returns the context of the command-buffer.
+	//
+	// Returned type: Context
+	// Extension: KhrCommandBufferExtensionName
+	CommandBufferContextKhrInfo CommandBufferInfoNameKhr = C.CL_COMMAND_BUFFER_CONTEXT_KHR
+
+	// CommandBufferStateRecordingKhr is the state of a command-buffer that commands can be recorded into.
+	//
+	// Extension: KhrCommandBufferExtensionName
+	CommandBufferStateRecordingKhr CommandBufferStateKhr = C.CL_COMMAND_BUFFER_STATE_RECORDING_KHR
+	// CommandBufferStateExecutableKhr is the state of a finalized command-buffer that can be enqueued.
+	//
+	// Extension: KhrCommandBufferExtensionName
+	CommandBufferStateExecutableKhr CommandBufferStateKhr = C.CL_COMMAND_BUFFER_STATE_EXECUTABLE_KHR
+	// CommandBufferStatePendingKhr is the state of a command-buffer that was enqueued, and has not yet completed.
+	//
+	// Extension: KhrCommandBufferExtensionName
+	CommandBufferStatePendingKhr CommandBufferStateKhr = C.CL_COMMAND_BUFFER_STATE_PENDING_KHR
+)
@@ -0,0 +1,196 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func mockCommandBufferExtension(t *testing.T) (*cl.ExtensionCommandBufferKhr, cl.Context, cl.CommandQueue) {
+	t.Helper()
+	context, _, queue := mockQueueOn(t, []cl.MockPlatform{{
+		Extensions: cl.KhrCommandBufferExtensionName,
+		Devices:    []cl.MockDevice{{}},
+	}})
+	platforms, err := cl.PlatformIDs()
+	if err != nil {
+		t.Fatalf("PlatformIDs failed: %v", err)
+	}
+	ext, err := cl.LoadExtensionCommandBufferKhr(platforms[0])
+	if err != nil {
+		t.Fatalf("LoadExtensionCommandBufferKhr failed: %v", err)
+	}
+	return ext, context, queue
+}
+
+func TestMockCommandBufferKhrNotAvailable(t *testing.T) {
+	cl.SetMockPlatforms(nil)
+	platforms, err := cl.PlatformIDs()
+	if err != nil {
+		t.Fatalf("PlatformIDs failed: %v", err)
+	}
+	if _, err = cl.LoadExtensionCommandBufferKhr(platforms[0]); !errors.Is(err, cl.ErrExtensionNotAvailable) {
+		t.Errorf("expected ErrExtensionNotAvailable, got %v", err)
+	}
+}
+
+func TestMockCommandBufferKhrRecordAndReplay(t *testing.T) {
+	ext, context, queue := mockCommandBufferExtension(t)
+	src, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 16, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(src) }()
+	dst, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 16, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(dst) }()
+
+	if _, err = ext.CreateCommandBuffer(nil); !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("expected ErrInvalidValue without command-queues, got %v", err)
+	}
+	commandBuffer, err := ext.CreateCommandBuffer([]cl.CommandQueue{queue},
+		cl.WithCommandBufferFlags(cl.CommandBufferSimultaneousUseKhr))
+	if err != nil {
+		t.Fatalf("CreateCommandBuffer failed: %v", err)
+	}
+	var properties [3]uint64
+	size, err := ext.CommandBufferInfo(commandBuffer, cl.CommandBufferPropertiesArrayKhrInfo,
+		unsafe.Sizeof(properties), unsafe.Pointer(&properties))
+	if err != nil {
+		t.Fatalf("CommandBufferInfo failed: %v", err)
+	}
+	expectedProperties := [3]uint64{cl.CommandBufferFlagsKhrProperty, uint64(cl.CommandBufferSimultaneousUseKhr), 0}
+	if (size != unsafe.Sizeof(properties)) || (properties != expectedProperties) {
+		t.Errorf("unexpected properties: %v (%d bytes)", properties, size)
+	}
+
+	pattern := uint32(0x04030201)
+	var fillPoint, copyPoint cl.SyncPointKhr
+	err = ext.CommandFillBuffer(commandBuffer, 0, src, unsafe.Pointer(&pattern), unsafe.Sizeof(pattern), 0, 16,
+		nil, &fillPoint)
+	if err != nil {
+		t.Fatalf("CommandFillBuffer failed: %v", err)
+	}
+	err = ext.CommandCopyBuffer(commandBuffer, 0, src, dst, 4, 0, 8, []cl.SyncPointKhr{fillPoint}, &copyPoint)
+	if err != nil {
+		t.Fatalf("CommandCopyBuffer failed: %v", err)
+	}
+	if (fillPoint == 0) || (copyPoint == fillPoint) {
+		t.Errorf("unexpected sync points: %d, %d", fillPoint, copyPoint)
+	}
+	err = ext.CommandBarrierWithWaitList(commandBuffer, 0, []cl.SyncPointKhr{copyPoint + 1}, nil)
+	if !errors.Is(err, cl.ErrInvalidSyncPointWaitListKhr) {
+		t.Errorf("expected ErrInvalidSyncPointWaitListKhr for unknown sync point, got %v", err)
+	}
+	if err = ext.CommandNDRangeKernel(commandBuffer, 0, 0, nil, nil, nil, nil, nil); !errors.Is(err, cl.ErrInvalidWorkDimension) {
+		t.Errorf("expected ErrInvalidWorkDimension, got %v", err)
+	}
+	err = ext.CommandNDRangeKernel(commandBuffer, 0, 0, []uintptr{0, 0}, []uintptr{4}, nil, nil, nil)
+	if !errors.Is(err, cl.ErrInvalidGlobalOffset) {
+		t.Errorf("expected ErrInvalidGlobalOffset, got %v", err)
+	}
+	err = ext.CommandNDRangeKernel(commandBuffer, 0, 0, nil, []uintptr{4}, []uintptr{2, 2}, nil, nil)
+	if !errors.Is(err, cl.ErrInvalidWorkGroupSize) {
+		t.Errorf("expected ErrInvalidWorkGroupSize, got %v", err)
+	}
+	if err = ext.CommandSvmMemcpy(commandBuffer, 0, nil, nil, 4, nil, nil); !errors.Is(err, cl.ErrExtensionNotAvailable) {
+		t.Errorf("expected ErrExtensionNotAvailable for SVM recording, got %v", err)
+	}
+	if err = ext.EnqueueCommandBuffer(nil, commandBuffer, nil, nil); !errors.Is(err, cl.ErrInvalidOperation) {
+		t.Errorf("expected ErrInvalidOperation for recording command-buffer, got %v", err)
+	}
+	if err = ext.FinalizeCommandBuffer(commandBuffer); err != nil {
+		t.Fatalf("FinalizeCommandBuffer failed: %v", err)
+	}
+	if state, err := ext.CommandBufferState(commandBuffer); (err != nil) || (state != cl.CommandBufferStateExecutableKhr) {
+		t.Errorf("unexpected state: %v, %v", state, err)
+	}
+	if err = ext.CommandBarrierWithWaitList(commandBuffer, 0, nil, nil); !errors.Is(err, cl.ErrInvalidOperation) {
+		t.Errorf("expected ErrInvalidOperation for finalized command-buffer, got %v", err)
+	}
+
+	var event cl.Event
+	if err = ext.EnqueueCommandBuffer([]cl.CommandQueue{queue}, commandBuffer, nil, &event); err != nil {
+		t.Fatalf("EnqueueCommandBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(event) }()
+	if err = cl.WaitForEvents([]cl.Event{event}); err != nil {
+		t.Fatalf("WaitForEvents failed: %v", err)
+	}
+	result := make([]byte, 16)
+	if err = cl.EnqueueReadBuffer(queue, dst, true, 0, 16, unsafe.Pointer(&result[0]), nil, nil); err != nil {
+		t.Fatalf("EnqueueReadBuffer failed: %v", err)
+	}
+	expected := []byte{1, 2, 3, 4, 1, 2, 3, 4, 0, 0, 0, 0, 0, 0, 0, 0}
+	if !bytes.Equal(result, expected) {
+		t.Errorf("unexpected content after replay: %v", result)
+	}
+
+	if err = ext.RetainCommandBuffer(commandBuffer); err != nil {
+		t.Fatalf("RetainCommandBuffer failed: %v", err)
+	}
+	var refCount uint32
+	_, err = ext.CommandBufferInfo(commandBuffer, cl.CommandBufferReferenceCountKhrInfo,
+		unsafe.Sizeof(refCount), unsafe.Pointer(&refCount))
+	if (err != nil) || (refCount != 2) {
+		t.Errorf("unexpected reference count: %d, %v", refCount, err)
+	}
+	for i := 0; i < 2; i++ {
+		if err = ext.ReleaseCommandBuffer(commandBuffer); err != nil {
+			t.Fatalf("ReleaseCommandBuffer failed: %v", err)
+		}
+	}
+	if err = ext.FinalizeCommandBuffer(commandBuffer); !errors.Is(err, cl.ErrInvalidCommandBufferKhr) {
+		t.Errorf("expected ErrInvalidCommandBufferKhr after release, got %v", err)
+	}
+}
+
+func TestMockCommandBufferKhrRecordsKernelArguments(t *testing.T) {
+	ext, context, queue := mockCommandBufferExtension(t)
+	kernel := mockKernel(t, context, "kernel void store(uint value) {}", "store")
+	var values []uint32
+	cl.SetMockKernel("store", func(call cl.MockKernelCall) error {
+		values = append(values, *(*uint32)(unsafe.Pointer(&call.Args[0].Value[0])))
+		return nil
+	})
+	defer cl.SetMockKernel("store", nil)
+
+	commandBuffer, err := ext.CreateCommandBuffer([]cl.CommandQueue{queue})
+	if err != nil {
+		t.Fatalf("CreateCommandBuffer failed: %v", err)
+	}
+	defer func() { _ = ext.ReleaseCommandBuffer(commandBuffer) }()
+	if err = ext.CommandNDRangeKernel(commandBuffer, 0, kernel, nil, []uintptr{4}, nil, nil, nil); !errors.Is(err, cl.ErrInvalidKernelArgs) {
+		t.Errorf("expected ErrInvalidKernelArgs for unset argument, got %v", err)
+	}
+	for _, value := range []uint32{7, 9} {
+		if err = cl.SetKernelArg(kernel, 0, unsafe.Sizeof(value), unsafe.Pointer(&value)); err != nil {
+			t.Fatalf("SetKernelArg failed: %v", err)
+		}
+		err = ext.CommandNDRangeKernel(commandBuffer, 0, kernel, []uintptr{0}, []uintptr{4}, []uintptr{2}, nil, nil)
+		if err != nil {
+			t.Fatalf("CommandNDRangeKernel failed: %v", err)
+		}
+	}
+	if err = ext.FinalizeCommandBuffer(commandBuffer); err != nil {
+		t.Fatalf("FinalizeCommandBuffer failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err = ext.EnqueueCommandBuffer(nil, commandBuffer, nil, nil); err != nil {
+			t.Fatalf("EnqueueCommandBuffer failed: %v", err)
+		}
+	}
+	if err = cl.Finish(queue); err != nil {
+		t.Fatalf("Finish failed: %v", err)
+	}
+	if (len(values) != 4) || (values[0] != 7) || (values[1] != 9) || (values[2] != 7) || (values[3] != 9) {
+		t.Errorf("kernel arguments not captured at recording: %v", values)
+	}
+}
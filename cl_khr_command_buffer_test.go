@@ -0,0 +1,65 @@
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestCommandBufferKhrWithoutExtension(t *testing.T) {
+	t.Parallel()
+	var ext *cl.ExtensionCommandBufferKhr
+	var region [3]uintptr
+	tt := []struct {
+		name string
+		call func() error
+	}{
+		{name: "CreateCommandBuffer", call: func() error {
+			_, err := ext.CreateCommandBuffer([]cl.CommandQueue{1})
+			return err
+		}},
+		{name: "FinalizeCommandBuffer", call: func() error { return ext.FinalizeCommandBuffer(1) }},
+		{name: "RetainCommandBuffer", call: func() error { return ext.RetainCommandBuffer(1) }},
+		{name: "ReleaseCommandBuffer", call: func() error { return ext.ReleaseCommandBuffer(1) }},
+		{name: "EnqueueCommandBuffer", call: func() error { return ext.EnqueueCommandBuffer(nil, 1, nil, nil) }},
+		{name: "CommandBufferInfo", call: func() error {
+			_, err := ext.CommandBufferInfo(1, cl.CommandBufferStateKhrInfo, 0, nil)
+			return err
+		}},
+		{name: "CommandBufferState", call: func() error {
+			_, err := ext.CommandBufferState(1)
+			return err
+		}},
+		{name: "CommandBarrierWithWaitList", call: func() error { return ext.CommandBarrierWithWaitList(1, 0, nil, nil) }},
+		{name: "CommandCopyBuffer", call: func() error { return ext.CommandCopyBuffer(1, 0, 1, 2, 0, 0, 4, nil, nil) }},
+		{name: "CommandCopyBufferRect", call: func() error {
+			return ext.CommandCopyBufferRect(1, 0, 1, 2, region, region, region, 0, 0, 0, 0, nil, nil)
+		}},
+		{name: "CommandCopyBufferToImage", call: func() error {
+			return ext.CommandCopyBufferToImage(1, 0, 1, 2, 0, region, region, nil, nil)
+		}},
+		{name: "CommandCopyImage", call: func() error {
+			return ext.CommandCopyImage(1, 0, 1, 2, region, region, region, nil, nil)
+		}},
+		{name: "CommandCopyImageToBuffer", call: func() error {
+			return ext.CommandCopyImageToBuffer(1, 0, 1, 2, region, region, 0, nil, nil)
+		}},
+		{name: "CommandFillBuffer", call: func() error { return ext.CommandFillBuffer(1, 0, 1, nil, 1, 0, 4, nil, nil) }},
+		{name: "CommandFillImage", call: func() error { return ext.CommandFillImage(1, 0, 1, nil, region, region, nil, nil) }},
+		{name: "CommandNDRangeKernel", call: func() error {
+			return ext.CommandNDRangeKernel(1, 0, 1, nil, []uintptr{1}, nil, nil, nil)
+		}},
+		{name: "CommandSvmMemcpy", call: func() error { return ext.CommandSvmMemcpy(1, 0, nil, nil, 4, nil, nil) }},
+		{name: "CommandSvmMemFill", call: func() error { return ext.CommandSvmMemFill(1, 0, nil, nil, 1, 4, nil, nil) }},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tc.call(); !errors.Is(err, cl.ErrExtensionNotLoaded) {
+				t.Errorf("expected ErrExtensionNotLoaded, got %v", err)
+			}
+		})
+	}
+}
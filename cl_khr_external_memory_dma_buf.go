@@ -0,0 +1,31 @@
+package cl30
+
+// #include "api.h"
+import "C"
+
+const (
+	// KhrExternalMemoryDmaBufExtensionName is the official name of the extension that allows dma-buf file
+	// descriptors to be imported as memory objects with CreateBufferWithProperties().
+	//
+	// Use WithDmaBuf() to create the respective property. The extension is import-only: memory objects can not
+	// be exported as dma-buf file descriptors.
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_external_memory_dma_buf
+	KhrExternalMemoryDmaBufExtensionName = "cl_khr_external_memory_dma_buf"
+
+	// ExternalMemoryHandleDmaBufKhrProperty is the memory property that specifies a dma-buf file descriptor
+	// to import.
+	//
+	// Property value type: int (file descriptor)
+	// Extension: KhrExternalMemoryDmaBufExtensionName
+	ExternalMemoryHandleDmaBufKhrProperty uint64 = C.CL_EXTERNAL_MEMORY_HANDLE_DMA_BUF_KHR
+)
+
+// WithDmaBuf is a convenience function to create a valid ExternalMemoryHandleDmaBufKhrProperty.
+// Use it in combination with CreateBufferWithProperties(). The file descriptor remains owned by the caller.
+// Importing is the only direction; memory objects can not be exported as dma-buf.
+//
+// Extension: KhrExternalMemoryDmaBufExtensionName
+func WithDmaBuf(fd int) MemProperty {
+	return MemProperty{ExternalMemoryHandleDmaBufKhrProperty, uint64(fd)}
+}
@@ -0,0 +1,11 @@
+#include "api.h"
+
+typedef cl_int (CL_API_CALL *cl30GetSemaphoreHandleForTypeKHR_fn)(void *semaphore, cl_device_id device,
+    cl_uint handleType, size_t handleSize, void *handle, size_t *handleSizeReturn);
+
+cl_int cl30ExtGetSemaphoreHandleForTypeKHR(void *fn, void *semaphore, cl_device_id device,
+    cl_uint handleType, size_t handleSize, void *handle, size_t *handleSizeReturn)
+{
+    return ((cl30GetSemaphoreHandleForTypeKHR_fn)(fn))(semaphore, device, handleType,
+        handleSize, handle, handleSizeReturn);
+}
@@ -0,0 +1,183 @@
+package cl30
+
+import "unsafe"
+
+// #include "api.h"
+// extern cl_int cl30ExtGetSemaphoreHandleForTypeKHR(void *fn, void *semaphore, cl_device_id device,
+//    cl_uint handleType, size_t handleSize, void *handle, size_t *handleSizeReturn);
+import "C"
+
+// ExternalSemaphoreHandleTypeKhr identifies the type of an external semaphore handle. The values are used as
+// property names to import a handle with ExtensionSemaphoreKhr.CreateSemaphoreWithProperties(), as well as to
+// request the export of handles.
+//
+// Extension: KhrExternalSemaphoreExtensionName
+type ExternalSemaphoreHandleTypeKhr C.cl_uint
+
+// ExtensionExternalSemaphoreKhr represents the functionality provided by the "cl_khr_external_semaphore" extension.
+// Load the extension with LoadExtensionExternalSemaphoreKhr().
+//
+// Semaphores are imported with ExtensionSemaphoreKhr.CreateSemaphoreWithProperties() and one of the import
+// properties, such as WithSemaphoreImportFd(). Exported handles are retrieved with SemaphoreHandleForType().
+//
+// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_external_semaphore
+// Extension: KhrExternalSemaphoreExtensionName
+type ExtensionExternalSemaphoreKhr struct {
+	clGetSemaphoreHandleForTypeKhr unsafe.Pointer
+}
+
+// LoadExtensionExternalSemaphoreKhr loads the required functions for the extension and returns an instance
+// to ExtensionExternalSemaphoreKhr if possible.
+//
+// Extension: KhrExternalSemaphoreExtensionName
+func LoadExtensionExternalSemaphoreKhr(id PlatformID) (*ExtensionExternalSemaphoreKhr, error) {
+	clGetSemaphoreHandleForTypeKhr := ExtensionFunctionAddressForPlatform(id, "clGetSemaphoreHandleForTypeKHR")
+	if clGetSemaphoreHandleForTypeKhr == nil {
+		return nil, ErrExtensionNotAvailable
+	}
+	return &ExtensionExternalSemaphoreKhr{clGetSemaphoreHandleForTypeKhr: clGetSemaphoreHandleForTypeKhr}, nil
+}
+
+// SemaphoreHandleForType queries the handle of the given type for an exportable semaphore.
+//
+// The provided size need to specify the size of the available space pointed to the provided value in bytes.
+// The returned number is the required size, in bytes, for the handle.
+//
+// Use SemaphoreFd() for handles that are file descriptors.
+//
+// Extension: KhrExternalSemaphoreExtensionName
+func (ext *ExtensionExternalSemaphoreKhr) SemaphoreHandleForType(semaphore SemaphoreKhr, device DeviceID,
+	handleType ExternalSemaphoreHandleTypeKhr, handleSize uintptr, handle unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetSemaphoreHandleForTypeKHR")()
+	if (ext == nil) || (ext.clGetSemaphoreHandleForTypeKhr == nil) {
+		return 0, ErrExtensionNotLoaded
+	}
+	sizeReturn := C.size_t(0)
+	status := C.cl30ExtGetSemaphoreHandleForTypeKHR(
+		ext.clGetSemaphoreHandleForTypeKhr,
+		semaphore.handle(),
+		device.handle(),
+		C.cl_uint(handleType),
+		C.size_t(handleSize),
+		handle,
+		&sizeReturn)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clGetSemaphoreHandleForTypeKHR", status, "semaphore", semaphore, "device", device)
+	}
+	return uintptr(sizeReturn), nil
+}
+
+// SemaphoreFd returns the file descriptor of the given handle type, such as ExternalSemaphoreHandleOpaqueFdKhr
+// or ExternalSemaphoreHandleSyncFdKhr. The caller owns the returned file descriptor.
+//
+// Extension: KhrExternalSemaphoreExtensionName
+func (ext *ExtensionExternalSemaphoreKhr) SemaphoreFd(semaphore SemaphoreKhr, device DeviceID,
+	handleType ExternalSemaphoreHandleTypeKhr) (int, error) {
+	var fd C.int
+	_, err := ext.SemaphoreHandleForType(semaphore, device, handleType, unsafe.Sizeof(fd), unsafe.Pointer(&fd))
+	return int(fd), err
+}
+
+// WithSemaphoreImportFd is a convenience function to create the property that imports the file descriptor
+// of the given handle type, such as one that was exported by Vulkan.
+// Use it in combination with ExtensionSemaphoreKhr.CreateSemaphoreWithProperties().
+//
+// Extension: KhrExternalSemaphoreExtensionName
+func WithSemaphoreImportFd(handleType ExternalSemaphoreHandleTypeKhr, fd int) SemaphorePropertyKhr {
+	return SemaphorePropertyKhr{uint64(handleType), uint64(fd)}
+}
+
+// WithSemaphoreImportHandle is a convenience function to create the property that imports a handle of the given
+// type, such as a Win32 handle.
+// Use it in combination with ExtensionSemaphoreKhr.CreateSemaphoreWithProperties().
+//
+// Extension: KhrExternalSemaphoreExtensionName
+func WithSemaphoreImportHandle(handleType ExternalSemaphoreHandleTypeKhr, handle uintptr) SemaphorePropertyKhr {
+	return SemaphorePropertyKhr{uint64(handleType), uint64(handle)}
+}
+
+// WithSemaphoreExportHandleTypes is a convenience function to create a valid SemaphoreExportHandleTypesKhrProperty.
+// Use it in combination with ExtensionSemaphoreKhr.CreateSemaphoreWithProperties().
+//
+// Extension: KhrExternalSemaphoreExtensionName
+func WithSemaphoreExportHandleTypes(handleTypes ...ExternalSemaphoreHandleTypeKhr) SemaphorePropertyKhr {
+	property := SemaphorePropertyKhr{SemaphoreExportHandleTypesKhrProperty}
+	for _, handleType := range handleTypes {
+		property = append(property, uint64(handleType))
+	}
+	return append(property, SemaphoreExportHandleTypesListEndKhr)
+}
+
+const (
+	// KhrExternalSemaphoreExtensionName is the official name of the extension
+	// handled by ExtensionExternalSemaphoreKhr.
+	KhrExternalSemaphoreExtensionName = "cl_khr_external_semaphore"
+	// KhrExternalSemaphoreOpaqueFdExtensionName is the name of the extension that indicates support for
+	// ExternalSemaphoreHandleOpaqueFdKhr.
+	KhrExternalSemaphoreOpaqueFdExtensionName = "cl_khr_external_semaphore_opaque_fd"
+	// KhrExternalSemaphoreSyncFdExtensionName is the name of the extension that indicates support for
+	// ExternalSemaphoreHandleSyncFdKhr.
+	KhrExternalSemaphoreSyncFdExtensionName = "cl_khr_external_semaphore_sync_fd"
+	// KhrExternalSemaphoreWin32ExtensionName is the name of the extension that indicates support for
+	// ExternalSemaphoreHandleOpaqueWin32Khr and ExternalSemaphoreHandleOpaqueWin32KmtKhr.
+	KhrExternalSemaphoreWin32ExtensionName = "cl_khr_external_semaphore_win32"
+
+	// ExternalSemaphoreHandleOpaqueFdKhr identifies an opaque POSIX file descriptor handle.
+	//
+	// Extension: KhrExternalSemaphoreOpaqueFdExtensionName
+	ExternalSemaphoreHandleOpaqueFdKhr ExternalSemaphoreHandleTypeKhr = C.CL_SEMAPHORE_HANDLE_OPAQUE_FD_KHR
+	// ExternalSemaphoreHandleOpaqueWin32Khr identifies an opaque Win32 NT handle.
+	//
+	// Extension: KhrExternalSemaphoreWin32ExtensionName
+	ExternalSemaphoreHandleOpaqueWin32Khr ExternalSemaphoreHandleTypeKhr = C.CL_SEMAPHORE_HANDLE_OPAQUE_WIN32_KHR
+	// ExternalSemaphoreHandleOpaqueWin32KmtKhr identifies an opaque, global share, Win32 handle.
+	//
+	// Extension: KhrExternalSemaphoreWin32ExtensionName
+	ExternalSemaphoreHandleOpaqueWin32KmtKhr ExternalSemaphoreHandleTypeKhr = C.CL_SEMAPHORE_HANDLE_OPAQUE_WIN32_KMT_KHR
+	// ExternalSemaphoreHandleSyncFdKhr identifies a POSIX file descriptor of a sync file.
+	//
+	// Extension: KhrExternalSemaphoreSyncFdExtensionName
+	ExternalSemaphoreHandleSyncFdKhr ExternalSemaphoreHandleTypeKhr = C.CL_SEMAPHORE_HANDLE_SYNC_FD_KHR
+
+	// SemaphoreExportHandleTypesKhrProperty specifies the handle types that the semaphore can be exported as.
+	// The list is terminated with SemaphoreExportHandleTypesListEndKhr.
+	//
+	// Use WithSemaphoreExportHandleTypes() for convenience.
+	//
+	// Property value type: []ExternalSemaphoreHandleTypeKhr
+	// Extension: KhrExternalSemaphoreExtensionName
+	SemaphoreExportHandleTypesKhrProperty uint64 = C.CL_SEMAPHORE_EXPORT_HANDLE_TYPES_KHR
+	// SemaphoreExportHandleTypesListEndKhr terminates the list of SemaphoreExportHandleTypesKhrProperty.
+	//
+	// Extension: KhrExternalSemaphoreExtensionName
+	SemaphoreExportHandleTypesListEndKhr uint64 = C.CL_SEMAPHORE_EXPORT_HANDLE_TYPES_LIST_END_KHR
+
+	// SemaphoreExportHandleTypesKhrInfo returns the handle types the semaphore can be exported as.
+	//
+	// Returned type: []ExternalSemaphoreHandleTypeKhr
+	// Extension: KhrExternalSemaphoreExtensionName
+	SemaphoreExportHandleTypesKhrInfo SemaphoreInfoNameKhr = C.CL_SEMAPHORE_EXPORT_HANDLE_TYPES_KHR
+
+	// PlatformSemaphoreImportHandleTypesKhrInfo returns the handle types that all devices of the platform
+	// can import.
+	//
+	// Returned type: []ExternalSemaphoreHandleTypeKhr
+	// Extension: KhrExternalSemaphoreExtensionName
+	PlatformSemaphoreImportHandleTypesKhrInfo PlatformInfoName = C.CL_PLATFORM_SEMAPHORE_IMPORT_HANDLE_TYPES_KHR
+	// PlatformSemaphoreExportHandleTypesKhrInfo returns the handle types that all devices of the platform
+	// can export.
+	//
+	// Returned type: []ExternalSemaphoreHandleTypeKhr
+	// Extension: KhrExternalSemaphoreExtensionName
+	PlatformSemaphoreExportHandleTypesKhrInfo PlatformInfoName = C.CL_PLATFORM_SEMAPHORE_EXPORT_HANDLE_TYPES_KHR
+	// DeviceSemaphoreImportHandleTypesKhrInfo returns the handle types that the device can import.
+	//
+	// Info value type: []ExternalSemaphoreHandleTypeKhr
+	// Extension: KhrExternalSemaphoreExtensionName
+	DeviceSemaphoreImportHandleTypesKhrInfo DeviceInfoName = C.CL_DEVICE_SEMAPHORE_IMPORT_HANDLE_TYPES_KHR
+	// DeviceSemaphoreExportHandleTypesKhrInfo returns the handle types that the device can export.
+	//
+	// Info value type: []ExternalSemaphoreHandleTypeKhr
+	// Extension: KhrExternalSemaphoreExtensionName
+	DeviceSemaphoreExportHandleTypesKhrInfo DeviceInfoName = C.CL_DEVICE_SEMAPHORE_EXPORT_HANDLE_TYPES_KHR
+)
@@ -0,0 +1,305 @@
+package cl30
+
+// #include "api.h"
+// #ifdef __APPLE__
+// #include <OpenCL/cl_gl.h>
+// #else
+// #include <CL/cl_gl.h>
+// #endif
+import "C"
+import "unsafe"
+
+const (
+	// KhrGlSharingExtensionName is the official name of the extension that allows sharing of OpenGL buffers,
+	// textures, and renderbuffers with OpenCL.
+	//
+	// The context must be created with the properties of the OpenGL context, see WithGlContext() and the
+	// functions for the display handles.
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_gl_sharing
+	KhrGlSharingExtensionName = "cl_khr_gl_sharing"
+
+	// GlContextKhrProperty specifies the OpenGL context handle to share with.
+	//
+	// Use WithGlContext() for convenience.
+	//
+	// Property value type: OpenGL context handle
+	// Extension: KhrGlSharingExtensionName
+	GlContextKhrProperty uintptr = C.CL_GL_CONTEXT_KHR
+	// EglDisplayKhrProperty specifies the EGLDisplay handle of the OpenGL context.
+	//
+	// Use WithEglDisplay() for convenience.
+	//
+	// Property value type: EGLDisplay
+	// Extension: KhrGlSharingExtensionName
+	EglDisplayKhrProperty uintptr = C.CL_EGL_DISPLAY_KHR
+	// GlxDisplayKhrProperty specifies the X11 Display handle of the OpenGL context.
+	//
+	// Use WithGlxDisplay() for convenience.
+	//
+	// Property value type: Display*
+	// Extension: KhrGlSharingExtensionName
+	GlxDisplayKhrProperty uintptr = C.CL_GLX_DISPLAY_KHR
+	// WglHdcKhrProperty specifies the device context handle (HDC) of the OpenGL context on Windows.
+	//
+	// Use WithWglHdc() for convenience.
+	//
+	// Property value type: HDC
+	// Extension: KhrGlSharingExtensionName
+	WglHdcKhrProperty uintptr = C.CL_WGL_HDC_KHR
+	// CglSharegroupKhrProperty specifies the CGL share group of the OpenGL context on macOS.
+	//
+	// Use WithCglSharegroup() for convenience.
+	//
+	// Property value type: CGLShareGroupObj
+	// Extension: KhrGlSharingExtensionName
+	CglSharegroupKhrProperty uintptr = C.CL_CGL_SHAREGROUP_KHR
+)
+
+// WithGlContext is a convenience function to create a valid GlContextKhrProperty.
+// Use it in combination with CreateContext() or CreateContextFromType().
+//
+// Extension: KhrGlSharingExtensionName
+func WithGlContext(handle uintptr) ContextProperty {
+	return ContextProperty{GlContextKhrProperty, handle}
+}
+
+// WithEglDisplay is a convenience function to create a valid EglDisplayKhrProperty.
+// Use it in combination with CreateContext() or CreateContextFromType().
+//
+// Extension: KhrGlSharingExtensionName
+func WithEglDisplay(display uintptr) ContextProperty {
+	return ContextProperty{EglDisplayKhrProperty, display}
+}
+
+// WithGlxDisplay is a convenience function to create a valid GlxDisplayKhrProperty.
+// Use it in combination with CreateContext() or CreateContextFromType().
+//
+// Extension: KhrGlSharingExtensionName
+func WithGlxDisplay(display uintptr) ContextProperty {
+	return ContextProperty{GlxDisplayKhrProperty, display}
+}
+
+// WithWglHdc is a convenience function to create a valid WglHdcKhrProperty.
+// Use it in combination with CreateContext() or CreateContextFromType().
+//
+// Extension: KhrGlSharingExtensionName
+func WithWglHdc(hdc uintptr) ContextProperty {
+	return ContextProperty{WglHdcKhrProperty, hdc}
+}
+
+// WithCglSharegroup is a convenience function to create a valid CglSharegroupKhrProperty.
+// Use it in combination with CreateContext() or CreateContextFromType().
+//
+// Extension: KhrGlSharingExtensionName
+func WithCglSharegroup(sharegroup uintptr) ContextProperty {
+	return ContextProperty{CglSharegroupKhrProperty, sharegroup}
+}
+
+// CreateFromGlBuffer creates an OpenCL buffer object from an OpenGL buffer object.
+//
+// Extension: KhrGlSharingExtensionName
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateFromGLBuffer.html
+func CreateFromGlBuffer(context Context, flags MemFlags, buffer uint32) (MemObject, error) {
+	defer observeCall("clCreateFromGLBuffer")()
+	var status C.cl_int
+	mem := C.clCreateFromGLBuffer(
+		context.handle(),
+		C.cl_mem_flags(flags),
+		C.cl_GLuint(buffer),
+		&status)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clCreateFromGLBuffer", status, "context", context, "flags", flags)
+	}
+	return trackCreated(MemObject(*((*uintptr)(unsafe.Pointer(&mem))))), nil
+}
+
+// CreateFromGlTexture creates an OpenCL image object from an OpenGL texture object, such as a 2D texture,
+// a cube map face, or a texture array.
+//
+// The target is the OpenGL texture target, for example GL_TEXTURE_2D, and mipLevel the mipmap level to use.
+//
+// Extension: KhrGlSharingExtensionName
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateFromGLTexture.html
+func CreateFromGlTexture(context Context, flags MemFlags, target uint32, mipLevel int32, texture uint32) (MemObject, error) {
+	defer observeCall("clCreateFromGLTexture")()
+	var status C.cl_int
+	mem := C.clCreateFromGLTexture(
+		context.handle(),
+		C.cl_mem_flags(flags),
+		C.cl_GLenum(target),
+		C.cl_GLint(mipLevel),
+		C.cl_GLuint(texture),
+		&status)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clCreateFromGLTexture", status, "context", context, "flags", flags)
+	}
+	return trackCreated(MemObject(*((*uintptr)(unsafe.Pointer(&mem))))), nil
+}
+
+// CreateFromGlRenderbuffer creates an OpenCL 2D image object from an OpenGL renderbuffer object.
+//
+// Extension: KhrGlSharingExtensionName
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateFromGLRenderbuffer.html
+func CreateFromGlRenderbuffer(context Context, flags MemFlags, renderbuffer uint32) (MemObject, error) {
+	defer observeCall("clCreateFromGLRenderbuffer")()
+	var status C.cl_int
+	mem := C.clCreateFromGLRenderbuffer(
+		context.handle(),
+		C.cl_mem_flags(flags),
+		C.cl_GLuint(renderbuffer),
+		&status)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clCreateFromGLRenderbuffer", status, "context", context, "flags", flags)
+	}
+	return trackCreated(MemObject(*((*uintptr)(unsafe.Pointer(&mem))))), nil
+}
+
+// GlObjectType identifies the type of OpenGL object that a memory object was created from.
+//
+// Extension: KhrGlSharingExtensionName
+type GlObjectType C.cl_gl_object_type
+
+const (
+	// GlObjectBuffer identifies an OpenGL buffer object.
+	GlObjectBuffer GlObjectType = C.CL_GL_OBJECT_BUFFER
+	// GlObjectTexture2D identifies an OpenGL 2D texture.
+	GlObjectTexture2D GlObjectType = C.CL_GL_OBJECT_TEXTURE2D
+	// GlObjectTexture3D identifies an OpenGL 3D texture.
+	GlObjectTexture3D GlObjectType = C.CL_GL_OBJECT_TEXTURE3D
+	// GlObjectRenderbuffer identifies an OpenGL renderbuffer.
+	GlObjectRenderbuffer GlObjectType = C.CL_GL_OBJECT_RENDERBUFFER
+	// GlObjectTexture2DArray identifies an OpenGL 2D texture array.
+	GlObjectTexture2DArray GlObjectType = C.CL_GL_OBJECT_TEXTURE2D_ARRAY
+	// GlObjectTexture1D identifies an OpenGL 1D texture.
+	GlObjectTexture1D GlObjectType = C.CL_GL_OBJECT_TEXTURE1D
+	// GlObjectTexture1DArray identifies an OpenGL 1D texture array.
+	GlObjectTexture1DArray GlObjectType = C.CL_GL_OBJECT_TEXTURE1D_ARRAY
+	// GlObjectTextureBuffer identifies an OpenGL texture buffer.
+	GlObjectTextureBuffer GlObjectType = C.CL_GL_OBJECT_TEXTURE_BUFFER
+)
+
+// GlObjectInfo returns the type and the name of the OpenGL object that the memory object was created from.
+//
+// Extension: KhrGlSharingExtensionName
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetGLObjectInfo.html
+func GlObjectInfo(mem MemObject) (GlObjectType, uint32, error) {
+	defer observeCall("clGetGLObjectInfo")()
+	var objectType C.cl_gl_object_type
+	var objectName C.cl_GLuint
+	status := C.clGetGLObjectInfo(mem.handle(), &objectType, &objectName)
+	if status != C.CL_SUCCESS {
+		return 0, 0, operationError("clGetGLObjectInfo", status, "mem", mem)
+	}
+	return GlObjectType(objectType), uint32(objectName), nil
+}
+
+// GlTextureInfoName identifies properties of an image created from an OpenGL texture, which can be queried with
+// GlTextureInfo().
+//
+// Extension: KhrGlSharingExtensionName
+type GlTextureInfoName C.cl_gl_texture_info
+
+const (
+	// GlTextureTargetInfo returns the texture target of the OpenGL texture object.
+	//
+	// Returned type: uint32
+	GlTextureTargetInfo GlTextureInfoName = C.CL_GL_TEXTURE_TARGET
+	// GlMipmapLevelInfo returns the mipmap level of the OpenGL texture object.
+	//
+	// Returned type: int32
+	GlMipmapLevelInfo GlTextureInfoName = C.CL_GL_MIPMAP_LEVEL
+)
+
+// GlTextureInfo queries information about an image that was created from an OpenGL texture.
+//
+// The provided size need to specify the size of the available space pointed to the provided value in bytes.
+//
+// The returned number is the required size, in bytes, for the queried information.
+//
+// Extension: KhrGlSharingExtensionName
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetGLTextureInfo.html
+func GlTextureInfo(mem MemObject, paramName GlTextureInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetGLTextureInfo")()
+	sizeReturn := C.size_t(0)
+	status := C.clGetGLTextureInfo(
+		mem.handle(),
+		C.cl_gl_texture_info(paramName),
+		C.size_t(paramSize),
+		paramValue,
+		&sizeReturn)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clGetGLTextureInfo", status, "mem", mem, "paramName", paramName)
+	}
+	return uintptr(sizeReturn), nil
+}
+
+// EnqueueAcquireGlObjects acquires OpenCL memory objects that have been created from OpenGL objects.
+// The OpenGL objects must not be used by OpenGL while they are acquired, and the application must make sure that
+// pending OpenGL operations on them have completed, for example with glFinish().
+//
+// Extension: KhrGlSharingExtensionName
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueAcquireGLObjects.html
+func EnqueueAcquireGlObjects(commandQueue CommandQueue, memObjects []MemObject, waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueAcquireGLObjects")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	var rawMemObjects unsafe.Pointer
+	if len(memObjects) > 0 {
+		rawMemObjects = unsafe.Pointer(&memObjects[0])
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	status := C.clEnqueueAcquireGLObjects(
+		commandQueue.handle(),
+		C.cl_uint(len(memObjects)),
+		(*C.cl_mem)(rawMemObjects),
+		C.cl_uint(len(waitList)),
+		(*C.cl_event)(rawWaitList),
+		(*C.cl_event)(unsafe.Pointer(event)))
+	if status != C.CL_SUCCESS {
+		return operationError("clEnqueueAcquireGLObjects", status, "commandQueue", commandQueue)
+	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
+	return nil
+}
+
+// EnqueueReleaseGlObjects releases OpenCL memory objects that have been created from OpenGL objects, and that were
+// acquired with EnqueueAcquireGlObjects(). The application must make sure that the release has completed before
+// OpenGL uses the objects again.
+//
+// Extension: KhrGlSharingExtensionName
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueReleaseGLObjects.html
+func EnqueueReleaseGlObjects(commandQueue CommandQueue, memObjects []MemObject, waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueReleaseGLObjects")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	var rawMemObjects unsafe.Pointer
+	if len(memObjects) > 0 {
+		rawMemObjects = unsafe.Pointer(&memObjects[0])
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	status := C.clEnqueueReleaseGLObjects(
+		commandQueue.handle(),
+		C.cl_uint(len(memObjects)),
+		(*C.cl_mem)(rawMemObjects),
+		C.cl_uint(len(waitList)),
+		(*C.cl_event)(rawWaitList),
+		(*C.cl_event)(unsafe.Pointer(event)))
+	if status != C.CL_SUCCESS {
+		return operationError("clEnqueueReleaseGLObjects", status, "commandQueue", commandQueue)
+	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
+	return nil
+}
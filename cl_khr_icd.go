@@ -0,0 +1,38 @@
+package cl30
+
+// #include "api.h"
+import "C"
+
+const (
+	// KhrIcdExtensionName is the official name of the extension that describes the installable client driver (ICD)
+	// loader, which dispatches the calls of the OpenCL API to the implementations of several vendors.
+	//
+	// Extension functions must be retrieved with ExtensionFunctionAddressForPlatform(), which returns the entry
+	// point of the ICD that serves the given platform.
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_icd
+	KhrIcdExtensionName = "cl_khr_icd"
+
+	// PlatformIcdSuffixKhrInfo refers to the function name suffix used to identify extension functions to be
+	// directed to this platform by the ICD loader.
+	//
+	// Use PlatformIcdSuffixKhr() for convenience.
+	//
+	// Returned type: string
+	// Extension: KhrIcdExtensionName
+	PlatformIcdSuffixKhrInfo PlatformInfoName = C.CL_PLATFORM_ICD_SUFFIX_KHR
+
+	// ErrPlatformNotFoundKhr is returned by PlatformIDs() in case the ICD loader found no platforms.
+	//
+	// Extension: KhrIcdExtensionName
+	ErrPlatformNotFoundKhr StatusError = C.CL_PLATFORM_NOT_FOUND_KHR
+)
+
+// PlatformIcdSuffixKhr is a convenience function for PlatformInfo() to query the PlatformIcdSuffixKhrInfo.
+// The suffix identifies the installable client driver (ICD) that serves the platform, which is useful to log in
+// setups with implementations of several vendors.
+//
+// Extension: KhrIcdExtensionName
+func PlatformIcdSuffixKhr(id PlatformID) (string, error) {
+	return PlatformInfoString(id, PlatformIcdSuffixKhrInfo)
+}
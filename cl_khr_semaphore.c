@@ -0,0 +1,38 @@
+#include "api.h"
+
+// The semaphore types are declared with their underlying types, as older headers do not provide them.
+// A semaphore is a pointer, while properties and payloads are cl_ulong values.
+
+typedef void *(CL_API_CALL *cl30CreateSemaphoreWithPropertiesKHR_fn)(cl_context context,
+    const cl_ulong *properties, cl_int *errcodeReturn);
+typedef cl_int (CL_API_CALL *cl30EnqueueSemaphoresKHR_fn)(cl_command_queue commandQueue,
+    cl_uint numSemaphores, void *const *semaphores, const cl_ulong *payloads,
+    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+typedef cl_int (CL_API_CALL *cl30GetSemaphoreInfoKHR_fn)(void *semaphore, cl_uint paramName,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn);
+typedef cl_int (CL_API_CALL *cl30SemaphoreKHR_fn)(void *semaphore);
+
+void *cl30ExtCreateSemaphoreWithPropertiesKHR(void *fn, cl_context context,
+    const cl_ulong *properties, cl_int *errcodeReturn)
+{
+    return ((cl30CreateSemaphoreWithPropertiesKHR_fn)(fn))(context, properties, errcodeReturn);
+}
+
+cl_int cl30ExtEnqueueSemaphoresKHR(void *fn, cl_command_queue commandQueue,
+    cl_uint numSemaphores, void *const *semaphores, const cl_ulong *payloads,
+    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event)
+{
+    return ((cl30EnqueueSemaphoresKHR_fn)(fn))(commandQueue, numSemaphores, semaphores, payloads,
+        numEventsInWaitList, eventWaitList, event);
+}
+
+cl_int cl30ExtGetSemaphoreInfoKHR(void *fn, void *semaphore, cl_uint paramName,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn)
+{
+    return ((cl30GetSemaphoreInfoKHR_fn)(fn))(semaphore, paramName, paramValueSize, paramValue, paramValueSizeReturn);
+}
+
+cl_int cl30ExtSemaphoreKHR(void *fn, void *semaphore)
+{
+    return ((cl30SemaphoreKHR_fn)(fn))(semaphore);
+}
@@ -0,0 +1,359 @@
+package cl30
+
+import "unsafe"
+
+// #include "api.h"
+// extern void *cl30ExtCreateSemaphoreWithPropertiesKHR(void *fn, cl_context context,
+//    const cl_ulong *properties, cl_int *errcodeReturn);
+// extern cl_int cl30ExtEnqueueSemaphoresKHR(void *fn, cl_command_queue commandQueue,
+//    cl_uint numSemaphores, void *const *semaphores, const cl_ulong *payloads,
+//    cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event);
+// extern cl_int cl30ExtGetSemaphoreInfoKHR(void *fn, void *semaphore, cl_uint paramName,
+//    size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn);
+// extern cl_int cl30ExtSemaphoreKHR(void *fn, void *semaphore);
+import "C"
+
+// SemaphoreKhr is a synchronization primitive that can be waited on and signaled by command-queues.
+// Together with the external semaphore extensions, semaphores synchronize OpenCL with other APIs, such as Vulkan.
+// Create a new semaphore with ExtensionSemaphoreKhr.CreateSemaphoreWithProperties().
+//
+// Extension: KhrSemaphoreExtensionName
+type SemaphoreKhr uintptr
+
+func (semaphore SemaphoreKhr) handle() unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&semaphore))
+}
+
+// String provides a readable presentation of the semaphore identifier.
+// It is based on the numerical value of the underlying pointer, followed by the name set with SetDebugName(), if any.
+func (semaphore SemaphoreKhr) String() string {
+	return handleString(semaphore, uintptr(semaphore))
+}
+
+// ExtensionSemaphoreKhr represents the functionality provided by the "cl_khr_semaphore" extension.
+// Load the extension with LoadExtensionSemaphoreKhr().
+//
+// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_semaphore
+// Extension: KhrSemaphoreExtensionName
+type ExtensionSemaphoreKhr struct {
+	clCreateSemaphoreWithPropertiesKhr unsafe.Pointer
+	clEnqueueWaitSemaphoresKhr         unsafe.Pointer
+	clEnqueueSignalSemaphoresKhr       unsafe.Pointer
+	clGetSemaphoreInfoKhr              unsafe.Pointer
+	clRetainSemaphoreKhr               unsafe.Pointer
+	clReleaseSemaphoreKhr              unsafe.Pointer
+}
+
+// LoadExtensionSemaphoreKhr loads the required functions for the extension and returns an instance
+// to ExtensionSemaphoreKhr if possible.
+//
+// Extension: KhrSemaphoreExtensionName
+func LoadExtensionSemaphoreKhr(id PlatformID) (*ExtensionSemaphoreKhr, error) {
+	ext := &ExtensionSemaphoreKhr{}
+	required := []struct {
+		name string
+		fn   *unsafe.Pointer
+	}{
+		{name: "clCreateSemaphoreWithPropertiesKHR", fn: &ext.clCreateSemaphoreWithPropertiesKhr},
+		{name: "clEnqueueWaitSemaphoresKHR", fn: &ext.clEnqueueWaitSemaphoresKhr},
+		{name: "clEnqueueSignalSemaphoresKHR", fn: &ext.clEnqueueSignalSemaphoresKhr},
+		{name: "clGetSemaphoreInfoKHR", fn: &ext.clGetSemaphoreInfoKhr},
+		{name: "clRetainSemaphoreKHR", fn: &ext.clRetainSemaphoreKhr},
+		{name: "clReleaseSemaphoreKHR", fn: &ext.clReleaseSemaphoreKhr},
+	}
+	for _, entry := range required {
+		*entry.fn = ExtensionFunctionAddressForPlatform(id, entry.name)
+		if *entry.fn == nil {
+			return nil, ErrExtensionNotAvailable
+		}
+	}
+	return ext, nil
+}
+
+// SemaphorePropertyKhr is one entry of properties which are taken into account when creating semaphores.
+//
+// Extension: KhrSemaphoreExtensionName
+type SemaphorePropertyKhr []uint64
+
+// WithSemaphoreType is a convenience function to create a valid SemaphoreTypeKhrProperty.
+// Use it in combination with ExtensionSemaphoreKhr.CreateSemaphoreWithProperties().
+//
+// Extension: KhrSemaphoreExtensionName
+func WithSemaphoreType(semaphoreType SemaphoreTypeKhr) SemaphorePropertyKhr {
+	return SemaphorePropertyKhr{SemaphoreTypeKhrProperty, uint64(semaphoreType)}
+}
+
+// WithSemaphoreDevices is a convenience function to create a valid SemaphoreDeviceHandleListKhrProperty,
+// which restricts the semaphore to the given devices.
+// Use it in combination with ExtensionSemaphoreKhr.CreateSemaphoreWithProperties().
+//
+// Extension: KhrSemaphoreExtensionName
+func WithSemaphoreDevices(devices ...DeviceID) SemaphorePropertyKhr {
+	property := SemaphorePropertyKhr{SemaphoreDeviceHandleListKhrProperty}
+	for _, device := range devices {
+		property = append(property, uint64(device))
+	}
+	return append(property, SemaphoreDeviceHandleListEndKhr)
+}
+
+// CreateSemaphoreWithProperties creates a semaphore in the given context.
+// The properties typically contain WithSemaphoreType(SemaphoreTypeBinaryKhr), and, for external semaphores,
+// the handle to import or the handle types to export.
+//
+// Extension: KhrSemaphoreExtensionName
+func (ext *ExtensionSemaphoreKhr) CreateSemaphoreWithProperties(context Context, properties ...SemaphorePropertyKhr) (SemaphoreKhr, error) {
+	defer observeCall("clCreateSemaphoreWithPropertiesKHR")()
+	if (ext == nil) || (ext.clCreateSemaphoreWithPropertiesKhr == nil) {
+		return 0, ErrExtensionNotLoaded
+	}
+	var rawPropertyList []uint64
+	for _, property := range properties {
+		rawPropertyList = append(rawPropertyList, property...)
+	}
+	var rawProperties unsafe.Pointer
+	if len(properties) > 0 {
+		rawPropertyList = append(rawPropertyList, 0)
+		rawProperties = unsafe.Pointer(&rawPropertyList[0])
+	}
+	var status C.cl_int
+	semaphore := C.cl30ExtCreateSemaphoreWithPropertiesKHR(
+		ext.clCreateSemaphoreWithPropertiesKhr,
+		context.handle(),
+		(*C.cl_ulong)(rawProperties),
+		&status)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clCreateSemaphoreWithPropertiesKHR", status, "context", context)
+	}
+	return trackCreated(SemaphoreKhr(uintptr(semaphore))), nil
+}
+
+// EnqueueWaitSemaphores enqueues a command that waits for the given semaphores to be signaled.
+// Commands enqueued after it only execute once the semaphores were signaled.
+//
+// The payloads are only required for semaphore types that have a payload; they may be nil otherwise.
+// If provided, there must be one payload per semaphore.
+//
+// Extension: KhrSemaphoreExtensionName
+func (ext *ExtensionSemaphoreKhr) EnqueueWaitSemaphores(commandQueue CommandQueue, semaphores []SemaphoreKhr,
+	payloads []uint64, waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueWaitSemaphoresKHR")()
+	if (ext == nil) || (ext.clEnqueueWaitSemaphoresKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	return ext.enqueueSemaphores("clEnqueueWaitSemaphoresKHR", ext.clEnqueueWaitSemaphoresKhr, commandQueue, semaphores, payloads, waitList, event)
+}
+
+// EnqueueSignalSemaphores enqueues a command that signals the given semaphores once all previously enqueued
+// commands, respectively the commands of the wait list, have completed.
+//
+// The payloads are only required for semaphore types that have a payload; they may be nil otherwise.
+// If provided, there must be one payload per semaphore.
+//
+// Extension: KhrSemaphoreExtensionName
+func (ext *ExtensionSemaphoreKhr) EnqueueSignalSemaphores(commandQueue CommandQueue, semaphores []SemaphoreKhr,
+	payloads []uint64, waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueSignalSemaphoresKHR")()
+	if (ext == nil) || (ext.clEnqueueSignalSemaphoresKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	return ext.enqueueSemaphores("clEnqueueSignalSemaphoresKHR", ext.clEnqueueSignalSemaphoresKhr, commandQueue, semaphores, payloads, waitList, event)
+}
+
+func (ext *ExtensionSemaphoreKhr) enqueueSemaphores(operation string, fn unsafe.Pointer, commandQueue CommandQueue,
+	semaphores []SemaphoreKhr, payloads []uint64, waitList []Event, event *Event) error {
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	if len(semaphores) == 0 {
+		return ErrInvalidValue
+	}
+	var rawPayloads unsafe.Pointer
+	if payloads != nil {
+		if len(payloads) != len(semaphores) {
+			return ErrInvalidValue
+		}
+		rawPayloads = unsafe.Pointer(&payloads[0])
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	status := C.cl30ExtEnqueueSemaphoresKHR(
+		fn,
+		commandQueue.handle(),
+		C.cl_uint(len(semaphores)),
+		(*unsafe.Pointer)(unsafe.Pointer(&semaphores[0])),
+		(*C.cl_ulong)(rawPayloads),
+		C.cl_uint(len(waitList)),
+		(*C.cl_event)(rawWaitList),
+		(*C.cl_event)(unsafe.Pointer(event)))
+	if status != C.CL_SUCCESS {
+		return operationError(operation, status, "commandQueue", commandQueue)
+	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
+	return nil
+}
+
+// SemaphoreInfoNameKhr identifies properties of a semaphore, which can be queried with
+// ExtensionSemaphoreKhr.SemaphoreInfo().
+//
+// Extension: KhrSemaphoreExtensionName
+type SemaphoreInfoNameKhr C.cl_uint
+
+// SemaphoreInfo queries information about a semaphore.
+//
+// The provided size need to specify the size of the available space pointed to the provided value in bytes.
+//
+// The returned number is the required size, in bytes, for the queried information.
+// Call the function with a zero size and nil value to request the required size. This helps in determining
+// the necessary space for dynamic information, such as arrays.
+//
+// Extension: KhrSemaphoreExtensionName
+func (ext *ExtensionSemaphoreKhr) SemaphoreInfo(semaphore SemaphoreKhr, paramName SemaphoreInfoNameKhr,
+	paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetSemaphoreInfoKHR")()
+	if (ext == nil) || (ext.clGetSemaphoreInfoKhr == nil) {
+		return 0, ErrExtensionNotLoaded
+	}
+	sizeReturn := C.size_t(0)
+	status := C.cl30ExtGetSemaphoreInfoKHR(
+		ext.clGetSemaphoreInfoKhr,
+		semaphore.handle(),
+		C.cl_uint(paramName),
+		C.size_t(paramSize),
+		paramValue,
+		&sizeReturn)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clGetSemaphoreInfoKHR", status, "semaphore", semaphore, "paramName", paramName)
+	}
+	return uintptr(sizeReturn), nil
+}
+
+// RetainSemaphore increments the reference count of the semaphore.
+//
+// Extension: KhrSemaphoreExtensionName
+func (ext *ExtensionSemaphoreKhr) RetainSemaphore(semaphore SemaphoreKhr) error {
+	defer observeCall("clRetainSemaphoreKHR")()
+	if (ext == nil) || (ext.clRetainSemaphoreKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	status := C.cl30ExtSemaphoreKHR(ext.clRetainSemaphoreKhr, semaphore.handle())
+	if status != C.CL_SUCCESS {
+		return operationError("clRetainSemaphoreKHR", status, "semaphore", semaphore)
+	}
+	trackRetained(semaphore)
+	retainNamed(semaphore)
+	return nil
+}
+
+// ReleaseSemaphore decrements the reference count of the semaphore.
+// The semaphore is deleted once the count reaches zero and all commands that use it have completed.
+//
+// Extension: KhrSemaphoreExtensionName
+func (ext *ExtensionSemaphoreKhr) ReleaseSemaphore(semaphore SemaphoreKhr) error {
+	defer observeCall("clReleaseSemaphoreKHR")()
+	if (ext == nil) || (ext.clReleaseSemaphoreKhr == nil) {
+		return ErrExtensionNotLoaded
+	}
+	status := C.cl30ExtSemaphoreKHR(ext.clReleaseSemaphoreKhr, semaphore.handle())
+	if status != C.CL_SUCCESS {
+		return operationError("clReleaseSemaphoreKHR", status, "semaphore", semaphore)
+	}
+	trackReleased(semaphore)
+	unnameReleased(semaphore)
+	return nil
+}
+
+// SemaphoreTypeKhr describes the type of a semaphore.
+//
+// Extension: KhrSemaphoreExtensionName
+type SemaphoreTypeKhr C.cl_uint
+
+const (
+	// KhrSemaphoreExtensionName is the official name of the extension
+	// handled by ExtensionSemaphoreKhr.
+	KhrSemaphoreExtensionName = "cl_khr_semaphore"
+
+	// ErrInvalidSemaphoreKhr is returned for invalid semaphores.
+	//
+	// Extension: KhrSemaphoreExtensionName
+	ErrInvalidSemaphoreKhr StatusError = C.CL_INVALID_SEMAPHORE_KHR
+
+	// CommandSemaphoreWaitKhr events are created by ExtensionSemaphoreKhr.EnqueueWaitSemaphores().
+	//
+	// Extension: KhrSemaphoreExtensionName
+	CommandSemaphoreWaitKhr EventCommandType = C.CL_COMMAND_SEMAPHORE_WAIT_KHR
+	// CommandSemaphoreSignalKhr events are created by ExtensionSemaphoreKhr.EnqueueSignalSemaphores().
+	//
+	// Extension: KhrSemaphoreExtensionName
+	CommandSemaphoreSignalKhr EventCommandType = C.CL_COMMAND_SEMAPHORE_SIGNAL_KHR
+
+	// PlatformSemaphoreTypesKhrInfo returns the semaphore types that all devices of the platform support.
+	//
+	// Returned type: []SemaphoreTypeKhr
+	// Extension: KhrSemaphoreExtensionName
+	PlatformSemaphoreTypesKhrInfo PlatformInfoName = C.CL_PLATFORM_SEMAPHORE_TYPES_KHR
+	// DeviceSemaphoreTypesKhrInfo returns the semaphore types that the device supports.
+	//
+	// Info value type: []SemaphoreTypeKhr
+	// Extension: KhrSemaphoreExtensionName
+	DeviceSemaphoreTypesKhrInfo DeviceInfoName = C.CL_DEVICE_SEMAPHORE_TYPES_KHR
+
+	// SemaphoreTypeBinaryKhr is the type of semaphores that are either signaled or not, without payload.
+	//
+	// Extension: KhrSemaphoreExtensionName
+	SemaphoreTypeBinaryKhr SemaphoreTypeKhr = C.CL_SEMAPHORE_TYPE_BINARY_KHR
+
+	// SemaphoreTypeKhrProperty specifies the type of the semaphore.
+	//
+	// Use WithSemaphoreType() for convenience.
+	//
+	// Property value type: SemaphoreTypeKhr
+	// Extension: KhrSemaphoreExtensionName
+	SemaphoreTypeKhrProperty uint64 = C.CL_SEMAPHORE_TYPE_KHR
+	// SemaphoreDeviceHandleListKhrProperty specifies the list of devices the semaphore is associated with.
+	// The list is terminated with SemaphoreDeviceHandleListEndKhr.
+	//
+	// Use WithSemaphoreDevices() for convenience.
+	//
+	// Property value type: []DeviceID
+	// Extension: KhrSemaphoreExtensionName
+	SemaphoreDeviceHandleListKhrProperty uint64 = C.CL_SEMAPHORE_DEVICE_HANDLE_LIST_KHR
+	// SemaphoreDeviceHandleListEndKhr terminates the list of SemaphoreDeviceHandleListKhrProperty.
+	//
+	// Extension: KhrSemaphoreExtensionName
+	SemaphoreDeviceHandleListEndKhr uint64 = C.CL_SEMAPHORE_DEVICE_HANDLE_LIST_END_KHR
+
+	// SemaphoreContextKhrInfo returns the context of the semaphore.
+	//
+	// Returned type: Context
+	// Extension: KhrSemaphoreExtensionName
+	SemaphoreContextKhrInfo SemaphoreInfoNameKhr = C.CL_SEMAPHORE_CONTEXT_KHR
+	// SemaphoreReferenceCountKhrInfo returns the reference count of the semaphore.
+	//
+	// Returned type: uint32
+	// Extension: KhrSemaphoreExtensionName
+	SemaphoreReferenceCountKhrInfo SemaphoreInfoNameKhr = C.CL_SEMAPHORE_REFERENCE_COUNT_KHR
+	// SemaphorePropertiesKhrInfo returns the properties the semaphore was created with.
+	//
+	// Returned type: []uint64
+	// Extension: KhrSemaphoreExtensionName
+	SemaphorePropertiesKhrInfo SemaphoreInfoNameKhr = C.CL_SEMAPHORE_PROPERTIES_KHR
+	// SemaphorePayloadKhrInfo returns the current payload of the semaphore.
+	//
+	// Returned type: uint64
+	// Extension: KhrSemaphoreExtensionName
+	SemaphorePayloadKhrInfo SemaphoreInfoNameKhr = C.CL_SEMAPHORE_PAYLOAD_KHR
+	// SemaphoreTypeKhrInfo returns the type of the semaphore.
+	//
+	// Returned type: SemaphoreTypeKhr
+	// Extension: KhrSemaphoreExtensionName
+	SemaphoreTypeKhrInfo SemaphoreInfoNameKhr = C.CL_SEMAPHORE_TYPE_KHR
+	// SemaphoreDeviceHandleListKhrInfo returns the devices the semaphore is associated with.
+	//
+	// Returned type: []DeviceID
+	// Extension: KhrSemaphoreExtensionName
+	SemaphoreDeviceHandleListKhrInfo SemaphoreInfoNameKhr = C.CL_SEMAPHORE_DEVICE_HANDLE_LIST_KHR
+)
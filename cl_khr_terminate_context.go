@@ -1,8 +1,6 @@
 package cl30
 
-import (
-	"unsafe"
-)
+import "unsafe"
 
 // #include "api.h"
 // extern cl_int cl30ExtTerminateContextKHR(void *fn, cl_context context);
@@ -36,16 +34,81 @@ func LoadExtensionTerminateContextKhr(id PlatformID) (*ExtensionTerminateContext
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clTerminateContextKHR.html
 // Extension: KhrTerminateContextExtensionName
 func (ext *ExtensionTerminateContextKhr) TerminateContext(context Context) error {
+	defer observeCall("clTerminateContextKHR")()
 	if (ext == nil) || (ext.clTerminateContextKhr == nil) {
 		return ErrExtensionNotLoaded
 	}
 	status := C.cl30ExtTerminateContextKHR(ext.clTerminateContextKhr, context.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clTerminateContextKHR", status, "context", context)
 	}
 	return nil
 }
 
+// TryTerminateContext terminates the context, like TerminateContext(), if all devices of the context are capable of
+// termination. It returns false, without an error, if at least one device lacks the capability. This allows
+// services to terminate a wedged context where possible, and to fall back to other measures otherwise.
+//
+// The context must have been created with WithTermination(true) for the termination to succeed.
+//
+// Extension: KhrTerminateContextExtensionName
+func (ext *ExtensionTerminateContextKhr) TryTerminateContext(context Context) (bool, error) {
+	supported, err := ContextTerminationSupported(context)
+	if err != nil {
+		return false, err
+	}
+	if !supported {
+		return false, nil
+	}
+	err = ext.TerminateContext(context)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeviceTerminateCapability returns the termination capability of the device.
+// A zero value is returned if the device does not support the extension.
+//
+// Extension: KhrTerminateContextExtensionName
+func DeviceTerminateCapability(id DeviceID) (DeviceTerminateCapabilityKhrFlags, error) {
+	extensions, err := DeviceInfoString(id, DeviceExtensionsInfo)
+	if err != nil {
+		return 0, err
+	}
+	if !containsExtension(extensions, KhrTerminateContextExtensionName) {
+		return 0, nil
+	}
+	return queryValue[DeviceTerminateCapabilityKhrFlags](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return DeviceInfo(id, DeviceTerminateCapabilityKhrInfo, paramSize, paramValue)
+	})
+}
+
+// ContextTerminationSupported returns true if all devices of the context are capable of context termination.
+//
+// Extension: KhrTerminateContextExtensionName
+func ContextTerminationSupported(context Context) (bool, error) {
+	devices, err := querySlice[DeviceID](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return ContextInfo(context, ContextDevicesInfo, paramSize, paramValue)
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(devices) == 0 {
+		return false, nil
+	}
+	for _, device := range devices {
+		capability, err := DeviceTerminateCapability(device)
+		if err != nil {
+			return false, err
+		}
+		if (capability & DeviceTerminateCapabilityKhrContext) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 const (
 	// KhrTerminateContextExtensionName is the official name of the extension
 	// handled by ExtensionTerminateContextKhr.
@@ -0,0 +1,162 @@
+// Package cltest provides helpers for writing integration tests against OpenCL implementations.
+//
+// Tests that need a device call RequireDevice() first, which skips the test with a uniform message if no device is
+// available. Results are compared against golden values with AssertSliceEqual(), AssertBufferEqual(), and
+// AssertImageEqual(). Mismatching images are written as PNG files for inspection; the directory is taken from the
+// environment variable named by DumpDirEnvVar, and defaults to the temporary directory of the system.
+package cltest
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+// SkipMessage is the message with which tests are skipped if no OpenCL device is available.
+const SkipMessage = "cltest: no OpenCL device available"
+
+// DumpDirEnvVar is the name of the environment variable that specifies the directory for image dumps.
+const DumpDirEnvVar = "CLTEST_DUMP_DIR"
+
+// maxReportedMismatches limits the number of individual mismatches that are reported per assertion.
+const maxReportedMismatches = 10
+
+// Number is the set of element types that can be compared with a tolerance.
+type Number interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~int |
+		~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uint |
+		~float32 | ~float64
+}
+
+// RequireDevice returns the first available OpenCL device. The test is skipped with SkipMessage if the platforms
+// cannot be queried, or if no device is available.
+func RequireDevice(t testing.TB) cl.PlatformDevice {
+	t.Helper()
+	devices, err := cl.AllDevices()
+	if (err != nil) || (len(devices) == 0) {
+		t.Skip(SkipMessage)
+	}
+	return devices[0]
+}
+
+// AssertSliceEqual verifies that got has the same length as want, and that each element differs by at most
+// tolerance from the corresponding element in want. Mismatches are reported as errors on t.
+// The function returns true if the slices are considered equal.
+func AssertSliceEqual[T Number](t testing.TB, got, want []T, tolerance T) bool {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("length mismatch: got %d elements, want %d", len(got), len(want))
+		return false
+	}
+	mismatches := 0
+	for i := range want {
+		if difference(got[i], want[i]) <= tolerance {
+			continue
+		}
+		if mismatches < maxReportedMismatches {
+			t.Errorf("element %d: got %v, want %v (tolerance %v)", i, got[i], want[i], tolerance)
+		}
+		mismatches++
+	}
+	if mismatches > maxReportedMismatches {
+		t.Errorf("%d further mismatches not reported", mismatches-maxReportedMismatches)
+	}
+	return mismatches == 0
+}
+
+func difference[T Number](a, b T) T {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// AssertBufferEqual reads len(want) elements from the start of the buffer, with a blocking read, and verifies them
+// with AssertSliceEqual().
+func AssertBufferEqual[T Number](t testing.TB, commandQueue cl.CommandQueue, buffer cl.MemObject, want []T, tolerance T) bool {
+	t.Helper()
+	got := make([]T, len(want))
+	if len(got) > 0 {
+		var zero T
+		size := uintptr(len(got)) * unsafe.Sizeof(zero)
+		err := cl.EnqueueReadBuffer(commandQueue, buffer, true, 0, size, unsafe.Pointer(&got[0]), nil, nil)
+		if err != nil {
+			t.Errorf("failed to read buffer: %v", err)
+			return false
+		}
+	}
+	return AssertSliceEqual(t, got, want, tolerance)
+}
+
+// AssertImageEqual reads a two-dimensional image with four 8-bit channels, for example of
+// the channel order RGBA and the channel type UnsignedInt8, and verifies it against want with AssertSliceEqual().
+// If the images differ, both the read image and the expected image are written as PNG files with DumpPNG().
+func AssertImageEqual(t testing.TB, commandQueue cl.CommandQueue, img cl.MemObject, width, height int, want []byte, tolerance uint8) bool {
+	t.Helper()
+	got := make([]byte, width*height*4)
+	if len(got) > 0 {
+		region := [3]uintptr{uintptr(width), uintptr(height), 1}
+		err := cl.EnqueueReadImage(commandQueue, img, true, [3]uintptr{}, region, uintptr(width*4), 0,
+			unsafe.Pointer(&got[0]), nil, nil)
+		if err != nil {
+			t.Errorf("failed to read image: %v", err)
+			return false
+		}
+	}
+	if AssertSliceEqual(t, got, want, tolerance) {
+		return true
+	}
+	if len(want) == len(got) {
+		DumpPNG(t, "got", width, height, got)
+		DumpPNG(t, "want", width, height, want)
+	}
+	return false
+}
+
+// DumpPNG writes the given RGBA pixels, with 8 bits per channel, as a PNG file and returns the path of the file.
+// The file name is derived from the name of the test and the given name. Failures are logged, and an empty path is
+// returned in that case.
+func DumpPNG(t testing.TB, name string, width, height int, rgba []byte) string {
+	t.Helper()
+	if len(rgba) != width*height*4 {
+		t.Logf("cannot dump %q: %d bytes do not match %dx%d pixels", name, len(rgba), width, height)
+		return ""
+	}
+	img := &image.RGBA{Pix: rgba, Stride: width * 4, Rect: image.Rect(0, 0, width, height)}
+	dir := os.Getenv(DumpDirEnvVar)
+	if len(dir) == 0 {
+		dir = os.TempDir()
+	}
+	fileName := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name()) + "_" + name + ".png"
+	path := filepath.Join(dir, fileName)
+	err := writePNG(path, img)
+	if err != nil {
+		t.Logf("cannot dump %q: %v", name, err)
+		return ""
+	}
+	t.Logf("dumped %q to %s", name, path)
+	return path
+}
+
+func writePNG(path string, img image.Image) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = png.Encode(file, img)
+	closeErr := file.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return fmt.Errorf("closing %s: %w", path, closeErr)
+	}
+	return nil
+}
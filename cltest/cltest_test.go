@@ -0,0 +1,39 @@
+package cltest_test
+
+import (
+	"image/png"
+	"os"
+	"testing"
+
+	"github.com/opencl-go/cl30/cltest"
+)
+
+func TestAssertSliceEqualAcceptsTolerance(t *testing.T) {
+	t.Parallel()
+	if !cltest.AssertSliceEqual(t, []float32{1.0, 2.05}, []float32{1.0, 2.0}, 0.1) {
+		t.Errorf("expected slices to be equal")
+	}
+	if !cltest.AssertSliceEqual(t, []uint8{10, 200}, []uint8{12, 199}, 2) {
+		t.Errorf("expected slices to be equal")
+	}
+}
+
+func TestDumpPNG(t *testing.T) {
+	t.Setenv(cltest.DumpDirEnvVar, t.TempDir())
+	path := cltest.DumpPNG(t, "pixels", 2, 1, []byte{255, 0, 0, 255, 0, 255, 0, 255})
+	if len(path) == 0 {
+		t.Fatalf("no file written")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open dump: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatalf("failed to decode dump: %v", err)
+	}
+	if bounds := img.Bounds(); (bounds.Dx() != 2) || (bounds.Dy() != 1) {
+		t.Errorf("unexpected bounds: %v", bounds)
+	}
+}
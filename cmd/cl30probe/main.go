@@ -0,0 +1,129 @@
+// Command cl30probe prints the SystemReport of the host and runs quick self-tests on all devices.
+//
+// For each device, the self-test allocates a buffer, builds a trivial kernel, launches it, and reads back the
+// result. The command exits with a non-zero status if no device is available or any self-test fails.
+// This allows deployments to validate a host before using it, for example with:
+//
+//	go run github.com/opencl-go/cl30/cmd/cl30probe
+//
+// It can also be referenced from a go:generate directive.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+const probeKernelSource = `
+__kernel void cl30Probe(__global uint *values) {
+	size_t id = get_global_id(0);
+	values[id] = (uint)(id * 3u + 1u);
+}
+`
+
+const probeItemCount = 1024
+
+func main() {
+	quiet := flag.Bool("quiet", false, "print only failures")
+	flag.Parse()
+	out := io.Writer(os.Stdout)
+	if *quiet {
+		out = io.Discard
+	}
+	os.Exit(run(out, os.Stderr))
+}
+
+func run(out, errOut io.Writer) int {
+	report, err := cl.DescribeSystem()
+	if err != nil {
+		fmt.Fprintf(errOut, "failed to describe the system: %v\n", err)
+		return 1
+	}
+	if report.DeviceCount() == 0 {
+		fmt.Fprintln(errOut, "no OpenCL devices available")
+		return 1
+	}
+	_, _ = report.WriteTo(out)
+	failures := 0
+	for _, platform := range report.Platforms {
+		for _, device := range platform.Devices {
+			err := selfTest(device.Device)
+			if err != nil {
+				fmt.Fprintf(errOut, "self-test failed on %q (%v): %v\n", device.Name, device.Device, err)
+				failures++
+				continue
+			}
+			fmt.Fprintf(out, "self-test on %q: ok\n", device.Name)
+		}
+	}
+	if failures > 0 {
+		return 1
+	}
+	return 0
+}
+
+func selfTest(deviceID cl.DeviceID) (err error) {
+	keep := func(releaseErr error) {
+		if (releaseErr != nil) && (err == nil) {
+			err = releaseErr
+		}
+	}
+	context, err := cl.CreateContext([]cl.DeviceID{deviceID}, nil)
+	if err != nil {
+		return fmt.Errorf("create context: %w", err)
+	}
+	defer func() { keep(cl.ReleaseContext(context)) }()
+	commandQueue, err := cl.CreateCommandQueueWithProperties(context, deviceID)
+	if err != nil {
+		return fmt.Errorf("create command queue: %w", err)
+	}
+	defer func() { keep(cl.ReleaseCommandQueue(commandQueue)) }()
+
+	values := make([]uint32, probeItemCount)
+	hostMem := cl.Slice(values)
+	buffer, err := cl.CreateBuffer(context, cl.MemWriteOnlyFlag, int(hostMem.Size()), nil)
+	if err != nil {
+		return fmt.Errorf("allocate buffer: %w", err)
+	}
+	defer func() { keep(cl.ReleaseMemObject(buffer)) }()
+
+	program, err := cl.CreateProgramWithSource(context, []string{probeKernelSource})
+	if err != nil {
+		return fmt.Errorf("create program: %w", err)
+	}
+	defer func() { keep(cl.ReleaseProgram(program)) }()
+	err = cl.BuildProgram(program, []cl.DeviceID{deviceID}, "", nil)
+	if err != nil {
+		buildLog, _ := cl.ProgramBuildInfoString(program, deviceID, cl.ProgramBuildLogInfo)
+		return fmt.Errorf("build program: %w\n%s", err, buildLog)
+	}
+	kernel, err := cl.CreateKernel(program, "cl30Probe")
+	if err != nil {
+		return fmt.Errorf("create kernel: %w", err)
+	}
+	defer func() { keep(cl.ReleaseKernel(kernel)) }()
+	err = cl.SetKernelArg(kernel, 0, unsafe.Sizeof(buffer), unsafe.Pointer(&buffer))
+	if err != nil {
+		return fmt.Errorf("set kernel argument: %w", err)
+	}
+	err = cl.EnqueueKernel(commandQueue, kernel, []uintptr{probeItemCount})
+	if err != nil {
+		return fmt.Errorf("launch kernel: %w", err)
+	}
+	err = cl.EnqueueReadBuffer(commandQueue, buffer, true, 0, hostMem.Size(), hostMem.Pointer(), nil, nil)
+	if err != nil {
+		return fmt.Errorf("read back: %w", err)
+	}
+	for i, value := range values {
+		expected := uint32(i*3 + 1)
+		if value != expected {
+			return fmt.Errorf("unexpected value at index %d: got %d, expected %d", i, value, expected)
+		}
+	}
+	return nil
+}
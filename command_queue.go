@@ -3,7 +3,7 @@ package cl30
 // #include "api.h"
 import "C"
 import (
-	"fmt"
+	"sync"
 	"unsafe"
 )
 
@@ -16,9 +16,9 @@ func (cq CommandQueue) handle() C.cl_command_queue {
 }
 
 // String provides a readable presentation of the command-queue identifier.
-// It is based on the numerical value of the underlying pointer.
+// It is based on the numerical value of the underlying pointer, followed by the name set with SetDebugName(), if any.
 func (cq CommandQueue) String() string {
-	return fmt.Sprintf("0x%X", uintptr(cq))
+	return handleString(cq, uintptr(cq))
 }
 
 const (
@@ -86,14 +86,20 @@ func WithQueuePropertyFlags(flags CommandQueuePropertiesFlags) CommandQueuePrope
 // CreateCommandQueueWithProperties create a host or device command-queue on a specific device.
 //
 // If QueuePropertiesProperty is not specified in the properties, an in-order host command-queue is created for
-// the specified device.
+// the specified device. In deterministic mode, host command-queues are always in-order; see SetDeterministicMode().
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateCommandQueueWithProperties.html
 func CreateCommandQueueWithProperties(context Context, deviceID DeviceID, properties ...CommandQueueProperty) (CommandQueue, error) {
+	defer observeCall("clCreateCommandQueueWithProperties")()
 	var rawPropertyList []uint64
 	for _, property := range properties {
 		rawPropertyList = append(rawPropertyList, property...)
 	}
+	for i := 0; i+1 < len(rawPropertyList); i += 2 {
+		if rawPropertyList[i] == QueuePropertiesProperty {
+			rawPropertyList[i+1] = uint64(deterministicQueueFlags(CommandQueuePropertiesFlags(rawPropertyList[i+1])))
+		}
+	}
 	var rawProperties unsafe.Pointer
 	if len(properties) > 0 {
 		rawPropertyList = append(rawPropertyList, 0)
@@ -106,9 +112,9 @@ func CreateCommandQueueWithProperties(context Context, deviceID DeviceID, proper
 		(*C.cl_command_queue_properties)(rawProperties),
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateCommandQueueWithProperties", status, "context", context, "deviceID", deviceID)
 	}
-	return CommandQueue(*((*uintptr)(unsafe.Pointer(&commandQueue)))), nil
+	return trackCreated(CommandQueue(*((*uintptr)(unsafe.Pointer(&commandQueue))))), nil
 }
 
 // RetainCommandQueue increments the commandQueue reference count.
@@ -121,10 +127,14 @@ func CreateCommandQueueWithProperties(context Context, deviceID DeviceID, proper
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clRetainCommandQueue.html
 func RetainCommandQueue(commandQueue CommandQueue) error {
+	defer observeCall("clRetainCommandQueue")()
 	status := C.clRetainCommandQueue(commandQueue.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clRetainCommandQueue", status, "commandQueue", commandQueue)
 	}
+	trackRetained(commandQueue)
+	retainOwned(commandQueue)
+	retainNamed(commandQueue)
 	return nil
 }
 
@@ -134,13 +144,18 @@ func RetainCommandQueue(commandQueue CommandQueue) error {
 // (eg. kernel-instances, memory object updates etc.), the command-queue is deleted.
 //
 // ReleaseCommandQueue() performs an implicit flush to issue any previously queued OpenCL commands in commandQueue.
+// An automatic flush policy of commandQueue is not removed; call ClearAutoFlush() before the last release.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clReleaseCommandQueue.html
 func ReleaseCommandQueue(commandQueue CommandQueue) error {
+	defer observeCall("clReleaseCommandQueue")()
 	status := C.clReleaseCommandQueue(commandQueue.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clReleaseCommandQueue", status, "commandQueue", commandQueue)
 	}
+	trackReleased(commandQueue)
+	disownReleased(commandQueue)
+	unnameReleased(commandQueue)
 	return nil
 }
 
@@ -199,6 +214,7 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetCommandQueueInfo.html
 func CommandQueueInfo(commandQueue CommandQueue, paramName CommandQueueInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetCommandQueueInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetCommandQueueInfo(
 		commandQueue.handle(),
@@ -207,7 +223,7 @@ func CommandQueueInfo(commandQueue CommandQueue, paramName CommandQueueInfoName,
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetCommandQueueInfo", status, "commandQueue", commandQueue, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
@@ -221,9 +237,10 @@ func CommandQueueInfo(commandQueue CommandQueue, paramName CommandQueueInfoName,
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clFlush.html
 func Flush(commandQueue CommandQueue) error {
+	defer observeCall("clFlush")()
 	status := C.clFlush(commandQueue.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clFlush", status, "commandQueue", commandQueue)
 	}
 	return nil
 }
@@ -235,11 +252,21 @@ func Flush(commandQueue CommandQueue) error {
 // until all previously queued commands have completed. Finish() does not return until all previously queued commands
 // in commandQueue have been processed and completed. Finish() is also a synchronization point.
 //
+// ErrBlockingCallInCallback is returned if Finish() is called from within a callback of the OpenCL implementation.
+//
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clFinish.html
 func Finish(commandQueue CommandQueue) error {
-	status := C.clFinish(commandQueue.handle())
+	defer observeCall("clFinish")()
+	err := checkBlockingAllowed()
+	if err != nil {
+		return err
+	}
+	injectedEventDelay()
+	status := runBlocking(true, commandQueue.handle(), func(commandQueue C.cl_command_queue) C.cl_int {
+		return C.clFinish(commandQueue)
+	})
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clFinish", status, "commandQueue", commandQueue)
 	}
 	return nil
 }
@@ -252,9 +279,48 @@ func Finish(commandQueue CommandQueue) error {
 // Since: 2.1
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSetDefaultDeviceCommandQueue.html
 func SetDefaultDeviceCommandQueue(context Context, deviceID DeviceID, commandQueue CommandQueue) error {
+	defer observeCall("clSetDefaultDeviceCommandQueue")()
 	status := C.clSetDefaultDeviceCommandQueue(context.handle(), deviceID.handle(), commandQueue.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clSetDefaultDeviceCommandQueue", status, "context", context, "deviceID", deviceID, "commandQueue", commandQueue)
 	}
 	return nil
 }
+
+// defaultDeviceQueueMutex serializes the calls of SwapDefaultDeviceQueue().
+var defaultDeviceQueueMutex sync.Mutex
+
+// SwapDefaultDeviceQueue replaces the default command-queue on the device, and returns the previous default.
+// The previous default can be restored with another call.
+//
+// The previous default is determined with the QueueDeviceDefaultInfo query of the new command-queue, which must
+// therefore belong to the device. Calls of this function are serialized, which makes the swap atomic with respect
+// to other swaps of this process; they are not atomic with respect to direct calls of
+// SetDefaultDeviceCommandQueue().
+//
+// The previous default is retained before it is replaced, so that it stays valid. If the returned command-queue is
+// not zero, the caller must release it with ReleaseCommandQueue().
+//
+// Since: 2.1
+func SwapDefaultDeviceQueue(context Context, deviceID DeviceID, commandQueue CommandQueue) (CommandQueue, error) {
+	defaultDeviceQueueMutex.Lock()
+	defer defaultDeviceQueueMutex.Unlock()
+	previous, err := queryValue[CommandQueue](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return CommandQueueInfo(commandQueue, QueueDeviceDefaultInfo, paramSize, paramValue)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if previous != 0 {
+		if err = RetainCommandQueue(previous); err != nil {
+			return 0, err
+		}
+	}
+	if err = SetDefaultDeviceCommandQueue(context, deviceID, commandQueue); err != nil {
+		if previous != 0 {
+			_ = ReleaseCommandQueue(previous)
+		}
+		return 0, err
+	}
+	return previous, nil
+}
@@ -0,0 +1,50 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func queueReferenceCount(t *testing.T, queue cl.CommandQueue) uint32 {
+	t.Helper()
+	var count uint32
+	if _, err := cl.CommandQueueInfo(queue, cl.QueueReferenceCountInfo, unsafe.Sizeof(count), unsafe.Pointer(&count)); err != nil {
+		t.Fatalf("CommandQueueInfo failed: %v", err)
+	}
+	return count
+}
+
+func TestMockSwapDefaultDeviceQueue(t *testing.T) {
+	context, device, first := mockQueue(t)
+	second, err := cl.CreateCommandQueueWithProperties(context, device)
+	if err != nil {
+		t.Fatalf("CreateCommandQueueWithProperties failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseCommandQueue(second) }()
+
+	previous, err := cl.SwapDefaultDeviceQueue(context, device, first)
+	if (err != nil) || (previous != 0) {
+		t.Fatalf("unexpected first swap: %v, %v", previous, err)
+	}
+	previous, err = cl.SwapDefaultDeviceQueue(context, device, second)
+	if (err != nil) || (previous != first) {
+		t.Fatalf("unexpected second swap: %v, %v", previous, err)
+	}
+	if count := queueReferenceCount(t, previous); count != 2 {
+		t.Errorf("previous default not retained: %v", count)
+	}
+	if err = cl.ReleaseCommandQueue(previous); err != nil {
+		t.Errorf("ReleaseCommandQueue failed: %v", err)
+	}
+
+	if _, err = cl.SwapDefaultDeviceQueue(context, 0, first); err == nil {
+		t.Errorf("swap with invalid device succeeded")
+	}
+	if count := queueReferenceCount(t, second); count != 1 {
+		t.Errorf("reference of failed swap kept: %v", count)
+	}
+}
@@ -12,7 +12,6 @@ package cl30
 // extern cl_int cl30SetContextDestructorCallback(cl_context context, uintptr_t *userData);
 import "C"
 import (
-	"fmt"
 	"sync"
 	"unsafe"
 )
@@ -26,9 +25,9 @@ func (c Context) handle() C.cl_context {
 }
 
 // String provides a readable presentation of the platform identifier.
-// It is based on the numerical value of the underlying pointer.
+// It is based on the numerical value of the underlying pointer, followed by the name set with SetDebugName(), if any.
 func (c Context) String() string {
-	return fmt.Sprintf("0x%X", uintptr(c))
+	return handleString(c, uintptr(c))
 }
 
 const (
@@ -64,6 +63,23 @@ func WithInteropUserSync(value bool) ContextProperty {
 	return ContextProperty{ContextInteropUserSyncProperty, uintptr(BoolFrom(value))}
 }
 
+// CustomContextProperty creates a property from an arbitrary key and value.
+// Use it in combination with CreateContext() or CreateContextFromType().
+//
+// This allows the use of properties that this package does not provide a convenience function for, such as those of
+// extensions. The key is the value of the respective CL_* constant, as defined by the extension specification or
+// its header file. Handles of other APIs, for example an EGL display or a Direct3D device, are passed as their
+// pointer-sized value:
+//
+//	const contextD3d11DeviceKhr = 0x401D // CL_CONTEXT_D3D11_DEVICE_KHR of cl_khr_d3d11_sharing
+//	context, err := cl.CreateContext(devices, nil, cl.CustomContextProperty(contextD3d11DeviceKhr, uintptr(device)))
+//
+// The OpenCL implementation validates the key. Keys of unsupported extensions typically result in
+// ErrInvalidProperty. Memory that a value points to must remain valid for as long as the implementation requires.
+func CustomContextProperty(key, value uintptr) ContextProperty {
+	return ContextProperty{key, value}
+}
+
 // CreateContext creates an OpenCL context for the specified devices.
 //
 // The callback is an optional receiver for any errors that happen during creation or execution of the context.
@@ -74,6 +90,7 @@ func WithInteropUserSync(value bool) ContextProperty {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateContext.html
 func CreateContext(deviceIds []DeviceID, callback *ContextErrorCallback, properties ...ContextProperty) (Context, error) {
+	defer observeCall("clCreateContext")()
 	var rawPropertyList []uintptr
 	for _, property := range properties {
 		rawPropertyList = append(rawPropertyList, property...)
@@ -99,9 +116,9 @@ func CreateContext(deviceIds []DeviceID, callback *ContextErrorCallback, propert
 		callbackKey,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateContext", status)
 	}
-	return Context(*((*uintptr)(unsafe.Pointer(&context)))), nil
+	return trackCreated(Context(*((*uintptr)(unsafe.Pointer(&context))))), nil
 }
 
 // CreateContextFromType creates an OpenCL context for devices that match the given device type.
@@ -115,6 +132,7 @@ func CreateContext(deviceIds []DeviceID, callback *ContextErrorCallback, propert
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateContextFromType.html
 func CreateContextFromType(deviceType DeviceTypeFlags, callback *ContextErrorCallback, properties ...ContextProperty) (Context, error) {
+	defer observeCall("clCreateContextFromType")()
 	var rawPropertyList []uintptr
 	for _, property := range properties {
 		rawPropertyList = append(rawPropertyList, property...)
@@ -135,9 +153,9 @@ func CreateContextFromType(deviceType DeviceTypeFlags, callback *ContextErrorCal
 		callbackKey,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateContextFromType", status)
 	}
-	return Context(*((*uintptr)(unsafe.Pointer(&context)))), nil
+	return trackCreated(Context(*((*uintptr)(unsafe.Pointer(&context))))), nil
 }
 
 // ContextErrorHandler is informed about an error that occurred within the processing of a context.
@@ -208,14 +226,18 @@ var (
 
 //export cl30GoContextErrorCallback
 func cl30GoContextErrorCallback(errorInfo *C.char, privateInfoPtr *C.uint8_t, privateInfoLen C.size_t, key *C.uintptr_t) {
-	contextErrorCallbackMutex.RLock()
-	defer contextErrorCallbackMutex.RUnlock()
-	cb, known := contextErrorCallbacksByPtr[key]
-	if !known {
-		return
-	}
-	privateInfo := unsafe.Slice((*byte)(privateInfoPtr), uintptr(privateInfoLen))
-	cb.handler.Handle(C.GoString(errorInfo), privateInfo)
+	// The information is only valid for the duration of this call, which is why it is copied for the dispatch.
+	info := C.GoString(errorInfo)
+	privateInfo := C.GoBytes(unsafe.Pointer(privateInfoPtr), C.int(privateInfoLen))
+	dispatchCallback(func() {
+		contextErrorCallbackMutex.RLock()
+		defer contextErrorCallbackMutex.RUnlock()
+		cb, known := contextErrorCallbacksByPtr[key]
+		if !known {
+			return
+		}
+		cb.handler.Handle(info, privateInfo)
+	})
 }
 
 // RetainContext increments the context reference count.
@@ -227,10 +249,14 @@ func cl30GoContextErrorCallback(errorInfo *C.char, privateInfoPtr *C.uint8_t, pr
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clRetainContext.html
 func RetainContext(context Context) error {
+	defer observeCall("clRetainContext")()
 	status := C.clRetainContext(context.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clRetainContext", status, "context", context)
 	}
+	trackRetained(context)
+	retainOwned(context)
+	retainNamed(context)
 	return nil
 }
 
@@ -241,10 +267,14 @@ func RetainContext(context Context) error {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clReleaseContext.html
 func ReleaseContext(context Context) error {
+	defer observeCall("clReleaseContext")()
 	status := C.clReleaseContext(context.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clReleaseContext", status, "context", context)
 	}
+	trackReleased(context)
+	disownReleased(context)
+	unnameReleased(context)
 	return nil
 }
 
@@ -289,6 +319,7 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetContextInfo.html
 func ContextInfo(context Context, paramName ContextInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetContextInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetContextInfo(
 		context.handle(),
@@ -297,7 +328,7 @@ func ContextInfo(context Context, paramName ContextInfoName, paramSize uintptr,
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetContextInfo", status, "context", context, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
@@ -312,6 +343,35 @@ func ContextInfoString(context Context, paramName ContextInfoName) (string, erro
 	})
 }
 
+// ContextProperties queries ContextPropertiesInfo of the context, and decodes the list with
+// DecodeContextProperties(). The returned properties can be passed to CreateContext() to create a compatible
+// context. An empty list is returned for a context that was created without properties.
+func ContextProperties(context Context) ([]ContextProperty, error) {
+	raw, err := querySlice[uintptr](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return ContextInfo(context, ContextPropertiesInfo, paramSize, paramValue)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeContextProperties(raw)
+}
+
+// DecodeContextProperties splits a raw, zero-terminated list of context properties into its entries, in the form
+// used for CreateContext(). Each entry is a key, followed by its value. Entries after the terminating zero are
+// ignored; the terminator itself is optional.
+//
+// ErrInvalidValue is returned if the value of the last key is missing.
+func DecodeContextProperties(raw []uintptr) ([]ContextProperty, error) {
+	var properties []ContextProperty
+	for i := 0; (i < len(raw)) && (raw[i] != 0); i += 2 {
+		if i+1 >= len(raw) {
+			return nil, ErrInvalidValue
+		}
+		properties = append(properties, ContextProperty{raw[i], raw[i+1]})
+	}
+	return properties, nil
+}
+
 // SetContextDestructorCallback registers a destructor callback function with a context.
 //
 // Each call to SetContextDestructorCallback() registers the specified callback function on a destructor callback
@@ -325,6 +385,12 @@ func ContextInfoString(context Context, paramName ContextInfoName) (string, erro
 // Since: 3.0
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSetContextDestructorCallback.html
 func SetContextDestructorCallback(context Context, callback func()) error {
+	return setContextDestructorCallback(context, callback)
+}
+
+// setContextDestructorCallback registers either a func() that is dispatched, or an inlineCallback.
+func setContextDestructorCallback(context Context, callback any) error {
+	defer observeCall("clSetContextDestructorCallback")()
 	callbackUserData, err := userDataFor(callback)
 	if err != nil {
 		return err
@@ -332,7 +398,7 @@ func SetContextDestructorCallback(context Context, callback func()) error {
 	status := C.cl30SetContextDestructorCallback(context.handle(), callbackUserData.ptr)
 	if status != C.CL_SUCCESS {
 		callbackUserData.Delete()
-		return StatusError(status)
+		return operationError("clSetContextDestructorCallback", status, "context", context)
 	}
 	return nil
 }
@@ -340,7 +406,12 @@ func SetContextDestructorCallback(context Context, callback func()) error {
 //export cl30GoContextDestructorCallback
 func cl30GoContextDestructorCallback(_ Context, userData *C.uintptr_t) {
 	callbackUserData := userDataFrom(userData)
-	callback := callbackUserData.Value().(func())
+	value := callbackUserData.Value()
 	callbackUserData.Delete()
-	callback()
+	switch callback := value.(type) {
+	case inlineCallback:
+		runInlineCallback(callback)
+	case func():
+		dispatchCallback(callback)
+	}
 }
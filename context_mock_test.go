@@ -0,0 +1,50 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockCustomContextProperty(t *testing.T) {
+	_, device, _ := mockQueue(t)
+	const customKey = 0x7FFF
+	context, err := cl.CreateContext([]cl.DeviceID{device}, nil, cl.CustomContextProperty(customKey, 42))
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseContext(context) }()
+	properties := make([]uintptr, 3)
+	size, err := cl.ContextInfo(context, cl.ContextPropertiesInfo, unsafe.Sizeof(uintptr(0))*3, unsafe.Pointer(&properties[0]))
+	if err != nil {
+		t.Fatalf("ContextInfo failed: %v", err)
+	}
+	if (size != unsafe.Sizeof(uintptr(0))*3) || (properties[0] != customKey) || (properties[1] != 42) {
+		t.Errorf("unexpected properties: %v (%d bytes)", properties, size)
+	}
+}
+
+func TestMockContextProperties(t *testing.T) {
+	cl.SetMockPlatforms(nil)
+	platforms, err := cl.PlatformIDs()
+	if err != nil {
+		t.Fatalf("PlatformIDs failed: %v", err)
+	}
+	context, err := cl.CreateContextFromType(cl.DeviceTypeAll, nil, cl.OnPlatform(platforms[0]), cl.WithInteropUserSync(true))
+	if err != nil {
+		t.Fatalf("CreateContextFromType failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseContext(context) }()
+	properties, err := cl.ContextProperties(context)
+	if err != nil {
+		t.Fatalf("ContextProperties failed: %v", err)
+	}
+	expected := []cl.ContextProperty{cl.OnPlatform(platforms[0]), cl.WithInteropUserSync(true)}
+	if !reflect.DeepEqual(properties, expected) {
+		t.Errorf("unexpected properties: %v", properties)
+	}
+}
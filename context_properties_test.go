@@ -0,0 +1,39 @@
+package cl30_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestDecodeContextProperties(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		name     string
+		raw      []uintptr
+		expected []cl.ContextProperty
+		err      error
+	}{
+		{name: "empty", raw: nil, expected: nil},
+		{name: "only terminator", raw: []uintptr{0}, expected: nil},
+		{name: "pairs", raw: []uintptr{1, 2, 3, 4, 0}, expected: []cl.ContextProperty{{1, 2}, {3, 4}}},
+		{name: "without terminator", raw: []uintptr{1, 2}, expected: []cl.ContextProperty{{1, 2}}},
+		{name: "after terminator", raw: []uintptr{1, 2, 0, 3, 4}, expected: []cl.ContextProperty{{1, 2}}},
+		{name: "missing value", raw: []uintptr{1, 2, 3}, err: cl.ErrInvalidValue},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			properties, err := cl.DecodeContextProperties(tc.raw)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("DecodeContextProperties() error = %v, want %v", err, tc.err)
+			}
+			if !reflect.DeepEqual(properties, tc.expected) {
+				t.Errorf("DecodeContextProperties() = %v, want %v", properties, tc.expected)
+			}
+		})
+	}
+}
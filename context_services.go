@@ -0,0 +1,114 @@
+package cl30
+
+import (
+	"io"
+	"sync"
+)
+
+// Services is a registry of helper objects that belong to one context.
+//
+// Helpers that keep state per context attach themselves to the registry of the context, instead of maintaining
+// their own maps of contexts. The registry is cleaned up once the context is destroyed: values that implement
+// io.Closer are closed, and the registry is removed.
+//
+// As the context is only destroyed after all its objects were released, stored values must not hold references to
+// OpenCL objects of the context, such as programs or buffers. They would keep the context alive. Suitable values
+// are, for example, queried capabilities or compiled program binaries.
+//
+// Within this package, the registry keeps the read-write image formats of SupportsReadWriteImages(), the SVM
+// capabilities of KernelSvmCapabilities(), and the comparison program of VerifyBuffersEqual(). Helpers that are
+// not bound to a context do not use it: a ProgramCache stores binaries per device and serves any context, and
+// quirks are enabled for the whole process with EnableQuirks().
+//
+// Use ContextServices() to get the registry of a context.
+type Services struct {
+	mutex  sync.Mutex
+	values map[any]any
+}
+
+var contextServices = struct {
+	mutex      sync.Mutex
+	registries map[Context]*Services
+}{
+	registries: make(map[Context]*Services),
+}
+
+// ContextServices returns the registry of helper objects for the given context.
+//
+// The registry is created lazily with the first call for a context. It is removed with a destructor callback of
+// the context, which is why this function requires SetContextDestructorCallback() to be supported.
+//
+// Since: 3.0
+func ContextServices(context Context) (*Services, error) {
+	contextServices.mutex.Lock()
+	defer contextServices.mutex.Unlock()
+	if services, known := contextServices.registries[context]; known {
+		return services, nil
+	}
+	services := &Services{values: make(map[any]any)}
+	err := setContextDestructorCallback(context, inlineCallback(func() { releaseContextServices(context, services) }))
+	if err != nil {
+		return nil, err
+	}
+	contextServices.registries[context] = services
+	return services, nil
+}
+
+// releaseContextServices removes the registry of a destroyed context. This happens inline with the destructor
+// callback, so that a new context, which may receive the same handle, does not get the registry of the destroyed
+// one. The stored values are closed according to the dispatch mode, as closing them may involve application code.
+func releaseContextServices(context Context, services *Services) {
+	contextServices.mutex.Lock()
+	if contextServices.registries[context] == services {
+		delete(contextServices.registries, context)
+	}
+	contextServices.mutex.Unlock()
+	dispatchCallback(services.close)
+}
+
+// Load returns the value that is stored for the given key.
+func (services *Services) Load(key any) (value any, ok bool) {
+	services.mutex.Lock()
+	defer services.mutex.Unlock()
+	value, ok = services.values[key]
+	return
+}
+
+// Store sets the value for the given key. A previously stored value is replaced, without being closed.
+func (services *Services) Store(key, value any) {
+	services.mutex.Lock()
+	defer services.mutex.Unlock()
+	services.values[key] = value
+}
+
+// LoadOrCreate returns the value that is stored for the given key. If there is no value yet, the create function
+// is called and its result is stored and returned.
+func (services *Services) LoadOrCreate(key any, create func() any) any {
+	services.mutex.Lock()
+	defer services.mutex.Unlock()
+	if value, known := services.values[key]; known {
+		return value
+	}
+	value := create()
+	services.values[key] = value
+	return value
+}
+
+// Delete removes the value for the given key, without closing it.
+func (services *Services) Delete(key any) {
+	services.mutex.Lock()
+	defer services.mutex.Unlock()
+	delete(services.values, key)
+}
+
+func (services *Services) close() {
+	services.mutex.Lock()
+	values := services.values
+	services.values = make(map[any]any)
+	services.mutex.Unlock()
+	for _, value := range values {
+		if closer, isCloser := value.(io.Closer); isCloser {
+			_ = closer.Close()
+		}
+	}
+}
@@ -0,0 +1,120 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+type closeCounter struct {
+	closed int32
+}
+
+func (counter *closeCounter) Close() error {
+	atomic.AddInt32(&counter.closed, 1)
+	return nil
+}
+
+func TestMockContextServices(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	services, err := cl.ContextServices(context)
+	if err != nil {
+		t.Fatalf("ContextServices failed: %v", err)
+	}
+	if again, _ := cl.ContextServices(context); again != services {
+		t.Errorf("registry not reused for the same context")
+	}
+	creations := 0
+	create := func() any {
+		creations++
+		return creations
+	}
+	if value := services.LoadOrCreate("key", create); value != 1 {
+		t.Errorf("unexpected created value: %v", value)
+	}
+	if value := services.LoadOrCreate("key", create); (value != 1) || (creations != 1) {
+		t.Errorf("value created again: %v", value)
+	}
+	services.Delete("key")
+	if _, ok := services.Load("key"); ok {
+		t.Errorf("value still available after Delete")
+	}
+}
+
+func TestMockContextServicesCloseWithContext(t *testing.T) {
+	_, device, _ := mockQueue(t)
+	context, err := cl.CreateContext([]cl.DeviceID{device}, nil)
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	services, err := cl.ContextServices(context)
+	if err != nil {
+		t.Fatalf("ContextServices failed: %v", err)
+	}
+	var counter closeCounter
+	services.Store("closer", &counter)
+	if err = cl.ReleaseContext(context); err != nil {
+		t.Fatalf("ReleaseContext failed: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for (atomic.LoadInt32(&counter.closed) == 0) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if closed := atomic.LoadInt32(&counter.closed); closed != 1 {
+		t.Errorf("value closed %d times, want 1", closed)
+	}
+}
+
+func TestMockContextServicesNotReusedWithLateDestructorCallbacks(t *testing.T) {
+	_, device, _ := mockQueue(t)
+	cl.SetCallbackDispatchMode(cl.CallbackDispatchSerial)
+	defer cl.SetCallbackDispatchMode(cl.CallbackDispatchInline)
+
+	oldContext, err := cl.CreateContext([]cl.DeviceID{device}, nil)
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	oldServices, err := cl.ContextServices(oldContext)
+	if err != nil {
+		t.Fatalf("ContextServices failed: %v", err)
+	}
+	var counter closeCounter
+	oldServices.Store("closer", &counter)
+	unblock := make(chan struct{})
+	// Destructor callbacks are called in reverse order; this one holds up the serial dispatch of the closing.
+	if err = cl.SetContextDestructorCallback(oldContext, func() { <-unblock }); err != nil {
+		t.Fatalf("SetContextDestructorCallback failed: %v", err)
+	}
+	if err = cl.ReleaseContext(oldContext); err != nil {
+		t.Fatalf("ReleaseContext failed: %v", err)
+	}
+
+	newContext, err := cl.CreateContext([]cl.DeviceID{device}, nil)
+	if err != nil {
+		close(unblock)
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseContext(newContext) }()
+	newServices, err := cl.ContextServices(newContext)
+	close(unblock)
+	if err != nil {
+		t.Fatalf("ContextServices failed: %v", err)
+	}
+	if newServices == oldServices {
+		t.Fatalf("registry of the released context served for a new context")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for (atomic.LoadInt32(&counter.closed) == 0) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if closed := atomic.LoadInt32(&counter.closed); closed != 1 {
+		t.Errorf("value closed %d times, want 1", closed)
+	}
+	if again, _ := cl.ContextServices(newContext); again != newServices {
+		t.Errorf("late destructor callback removed the registry of the new context")
+	}
+}
@@ -0,0 +1,124 @@
+package cl30
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var debugNames = struct {
+	// count is the number of named handles. It lets retain and release functions skip the mutex while nothing
+	// is named.
+	count int32
+	mutex sync.RWMutex
+	names map[any]*debugName
+}{
+	names: make(map[any]*debugName),
+}
+
+type debugName struct {
+	name string
+	// retains is the number of references that were retained while the handle is named, and that were not yet
+	// released again.
+	retains int
+}
+
+// SetDebugName attaches a name to an OpenCL object, to identify it in diagnostic output.
+// The name is part of the String() presentation of the handle, for example `0x7F3A2C ("input buffer")`.
+//
+// Supported handle types are PlatformID, DeviceID, Context, CommandQueue, MemObject, Program, Kernel, Event,
+// Sampler, CommandBufferKhr, and SemaphoreKhr. ErrUnsupportedHandleType is returned for any other type.
+//
+// The names are kept in a registry of the package. The release functions, such as ReleaseMemObject(), remove
+// the name with the release of the last reference. For this, retains of a named handle are counted in the same
+// way as for owned handles; see Owned. References that were retained before the name was set, or by the
+// OpenCL implementation, are not known. Set an empty name to remove the entry explicitly.
+// Names of PlatformID and DeviceID are kept until they are removed explicitly, as root devices are not
+// reference counted.
+func SetDebugName(handle any, name string) error {
+	if !isHandle(handle) {
+		return ErrUnsupportedHandleType
+	}
+	debugNames.mutex.Lock()
+	defer debugNames.mutex.Unlock()
+	entry, exists := debugNames.names[handle]
+	if len(name) == 0 {
+		if exists {
+			delete(debugNames.names, handle)
+			atomic.AddInt32(&debugNames.count, -1)
+		}
+		return nil
+	}
+	if !exists {
+		entry = &debugName{}
+		debugNames.names[handle] = entry
+		atomic.AddInt32(&debugNames.count, 1)
+	}
+	entry.name = name
+	return nil
+}
+
+// DebugName returns the name that was set with SetDebugName() for the given handle.
+// An empty string is returned if the handle has no name.
+func DebugName(handle any) string {
+	if atomic.LoadInt32(&debugNames.count) == 0 {
+		return ""
+	}
+	debugNames.mutex.RLock()
+	defer debugNames.mutex.RUnlock()
+	if entry, exists := debugNames.names[handle]; exists {
+		return entry.name
+	}
+	return ""
+}
+
+// retainNamed is called by the retain functions. If the retained handle is named, the retain is counted.
+func retainNamed(handle any) {
+	if atomic.LoadInt32(&debugNames.count) == 0 {
+		return
+	}
+	debugNames.mutex.Lock()
+	defer debugNames.mutex.Unlock()
+	if entry, exists := debugNames.names[handle]; exists {
+		entry.retains++
+	}
+}
+
+// unnameReleased is called by the release functions. If the released handle is named, and the release does not
+// balance a counted retain, the name is removed, so that a later object with the same handle value does not
+// inherit it.
+func unnameReleased(handle any) {
+	if atomic.LoadInt32(&debugNames.count) == 0 {
+		return
+	}
+	debugNames.mutex.Lock()
+	defer debugNames.mutex.Unlock()
+	entry, exists := debugNames.names[handle]
+	if !exists {
+		return
+	}
+	if entry.retains > 0 {
+		entry.retains--
+		return
+	}
+	delete(debugNames.names, handle)
+	atomic.AddInt32(&debugNames.count, -1)
+}
+
+func isHandle(handle any) bool {
+	switch handle.(type) {
+	case PlatformID, DeviceID, Context, CommandQueue, MemObject, Program, Kernel, Event, Sampler, CommandBufferKhr, SemaphoreKhr:
+		return true
+	default:
+		return false
+	}
+}
+
+// handleString provides the common String() presentation of handles, based on the numerical value of the
+// underlying pointer. The debug name is appended if one is set.
+func handleString(handle any, value uintptr) string {
+	if name := DebugName(handle); len(name) > 0 {
+		return fmt.Sprintf("0x%X (%q)", value, name)
+	}
+	return fmt.Sprintf("0x%X", value)
+}
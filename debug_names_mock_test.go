@@ -0,0 +1,36 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockDebugNameRemovedWithLastRelease(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 16, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	if err = cl.SetDebugName(buffer, "named"); err != nil {
+		t.Fatalf("SetDebugName failed: %v", err)
+	}
+	defer func() { _ = cl.SetDebugName(buffer, "") }()
+	if err = cl.RetainMemObject(buffer); err != nil {
+		t.Fatalf("RetainMemObject failed: %v", err)
+	}
+	if err = cl.ReleaseMemObject(buffer); err != nil {
+		t.Fatalf("ReleaseMemObject failed: %v", err)
+	}
+	if cl.DebugName(buffer) != "named" {
+		t.Errorf("name removed while a reference is left")
+	}
+	if err = cl.ReleaseMemObject(buffer); err != nil {
+		t.Fatalf("ReleaseMemObject failed: %v", err)
+	}
+	if name := cl.DebugName(buffer); name != "" {
+		t.Errorf("name kept after the last release: %q", name)
+	}
+}
@@ -0,0 +1,30 @@
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestDebugNameInString(t *testing.T) {
+	t.Parallel()
+	buffer := cl.MemObject(0x1234)
+	if err := cl.SetDebugName(buffer, "input"); err != nil {
+		t.Fatalf("failed to set name: %v", err)
+	}
+	defer func() { _ = cl.SetDebugName(buffer, "") }()
+	if got := buffer.String(); got != `0x1234 ("input")` {
+		t.Errorf("unexpected presentation: %s", got)
+	}
+	if got := cl.Kernel(0x1234).String(); got != "0x1234" {
+		t.Errorf("name leaked to other handle type: %s", got)
+	}
+}
+
+func TestDebugNameRejectsUnknownTypes(t *testing.T) {
+	t.Parallel()
+	if err := cl.SetDebugName(42, "answer"); !errors.Is(err, cl.ErrUnsupportedHandleType) {
+		t.Errorf("expected ErrUnsupportedHandleType, got %v", err)
+	}
+}
@@ -1,8 +1,8 @@
 package cl30
 
-// #cgo CFLAGS: -DCL_USE_DEPRECATED_OPENCL_1_2_APIS -DCL_USE_DEPRECATED_OPENCL_2_2_APIS
-// #cgo CXXFLAGS: -DCL_USE_DEPRECATED_OPENCL_1_2_APIS -DCL_USE_DEPRECATED_OPENCL_2_2_APIS
-// #cgo CPPFLAGS: -DCL_USE_DEPRECATED_OPENCL_1_2_APIS -DCL_USE_DEPRECATED_OPENCL_2_2_APIS
+// #cgo CFLAGS: -DCL_USE_DEPRECATED_OPENCL_1_0_APIS -DCL_USE_DEPRECATED_OPENCL_1_2_APIS -DCL_USE_DEPRECATED_OPENCL_2_2_APIS
+// #cgo CXXFLAGS: -DCL_USE_DEPRECATED_OPENCL_1_0_APIS -DCL_USE_DEPRECATED_OPENCL_1_2_APIS -DCL_USE_DEPRECATED_OPENCL_2_2_APIS
+// #cgo CPPFLAGS: -DCL_USE_DEPRECATED_OPENCL_1_0_APIS -DCL_USE_DEPRECATED_OPENCL_1_2_APIS -DCL_USE_DEPRECATED_OPENCL_2_2_APIS
 // #include "api.h"
 // extern cl_int cl30SetProgramReleaseCallback(cl_program program, uintptr_t *userData);
 import "C"
@@ -13,16 +13,58 @@ import "unsafe"
 // Deprecated: 1.2; Use CreateCommandQueueWithProperties() instead.
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateCommandQueue.html
 func CreateCommandQueue(context Context, deviceID DeviceID, properties CommandQueuePropertiesFlags) (CommandQueue, error) {
+	defer observeCall("clCreateCommandQueue")()
+	err := checkDeprecatedUse(deprecatedItem{name: "CreateCommandQueue", deprecatedIn: VersionOf(1, 2, 0)}, deviceID)
+	if err != nil {
+		return 0, err
+	}
 	var status C.cl_int
 	commandQueue := C.clCreateCommandQueue(
 		context.handle(),
 		deviceID.handle(),
-		C.cl_command_queue_properties(properties),
+		C.cl_command_queue_properties(deterministicQueueFlags(properties)),
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateCommandQueue", status, "context", context, "deviceID", deviceID)
+	}
+	return trackCreated(CommandQueue(*((*uintptr)(unsafe.Pointer(&commandQueue))))), nil
+}
+
+// SetCommandQueueProperty enables or disables properties of an existing command-queue, and returns the properties
+// that were set before the call. This allows profiling to be toggled on command-queues that were created by code
+// which cannot be changed to create them with the required properties.
+//
+// The function exists only in OpenCL 1.0. It is called only if the device of the command-queue reports OpenCL 1.0,
+// or if QuirkLegacyQueueProperties is enabled. Otherwise, ErrLegacyCallNotAllowed is returned.
+//
+// Deprecated: 1.1; Create the command-queue with the required properties instead.
+// See also: https://registry.khronos.org/OpenCL/sdk/1.0/docs/man/xhtml/clSetCommandQueueProperty.html
+func SetCommandQueueProperty(commandQueue CommandQueue, properties CommandQueuePropertiesFlags, enable bool) (CommandQueuePropertiesFlags, error) {
+	defer observeCall("clSetCommandQueueProperty")()
+	err := checkDeprecatedQueueUse(deprecatedItem{name: "SetCommandQueueProperty", deprecatedIn: VersionOf(1, 1, 0)}, commandQueue)
+	if err != nil {
+		return 0, err
+	}
+	if !QuirksEnabled(QuirkLegacyQueueProperties) {
+		var device DeviceID
+		_, err = CommandQueueInfo(commandQueue, QueueDeviceInfo, unsafe.Sizeof(device), unsafe.Pointer(&device))
+		if err != nil {
+			return 0, err
+		}
+		if cachedDeviceVersion(device) != VersionOf(1, 0, 0) {
+			return 0, ErrLegacyCallNotAllowed
+		}
+	}
+	var oldProperties C.cl_command_queue_properties
+	status := C.clSetCommandQueueProperty(
+		commandQueue.handle(),
+		C.cl_command_queue_properties(properties),
+		C.cl_bool(BoolFrom(enable)),
+		&oldProperties)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clSetCommandQueueProperty", status, "commandQueue", commandQueue)
 	}
-	return CommandQueue(*((*uintptr)(unsafe.Pointer(&commandQueue)))), nil
+	return CommandQueuePropertiesFlags(oldProperties), nil
 }
 
 // CreateSampler creates a sampler object.
@@ -30,6 +72,11 @@ func CreateCommandQueue(context Context, deviceID DeviceID, properties CommandQu
 // Deprecated: 1.2; Use CreateSamplerWithProperties() instead.
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateSampler.html
 func CreateSampler(context Context, normalizedCoords bool, addressingMode SamplerAddressingMode, filterMode SamplerFilterMode) (Sampler, error) {
+	defer observeCall("clCreateSampler")()
+	err := checkDeprecatedUse(deprecatedItem{name: "CreateSampler", deprecatedIn: VersionOf(1, 2, 0)}, 0)
+	if err != nil {
+		return 0, err
+	}
 	var status C.cl_int
 	sampler := C.clCreateSampler(
 		context.handle(),
@@ -38,9 +85,9 @@ func CreateSampler(context Context, normalizedCoords bool, addressingMode Sample
 		C.cl_filter_mode(filterMode),
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateSampler", status, "context", context)
 	}
-	return Sampler(*((*uintptr)(unsafe.Pointer(&sampler)))), nil
+	return trackCreated(Sampler(*((*uintptr)(unsafe.Pointer(&sampler))))), nil
 }
 
 // EnqueueTask enqueues a command to execute a kernel, using a single work-item, on a device.
@@ -51,10 +98,19 @@ func CreateSampler(context Context, normalizedCoords bool, addressingMode Sample
 // Deprecated: 1.2; Use EnqueueNDRangeKernel() instead.
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueTask.html
 func EnqueueTask(commandQueue CommandQueue, kernel Kernel, waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueTask")()
+	err := checkDeprecatedQueueUse(deprecatedItem{name: "EnqueueTask", deprecatedIn: VersionOf(1, 2, 0)}, commandQueue)
+	if err != nil {
+		return err
+	}
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
 	status := C.clEnqueueTask(
 		commandQueue.handle(),
 		kernel.handle(),
@@ -62,8 +118,10 @@ func EnqueueTask(commandQueue CommandQueue, kernel Kernel, waitList []Event, eve
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueTask", status, "commandQueue", commandQueue, "kernel", kernel)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -84,6 +142,11 @@ func EnqueueTask(commandQueue CommandQueue, kernel Kernel, waitList []Event, eve
 // Deprecated: 2.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSetProgramReleaseCallback.html
 func SetProgramReleaseCallback(program Program, callback func()) error {
+	defer observeCall("clSetProgramReleaseCallback")()
+	err := checkDeprecatedUse(deprecatedItem{name: "SetProgramReleaseCallback", deprecatedIn: VersionOf(2, 2, 0)}, 0)
+	if err != nil {
+		return err
+	}
 	callbackUserData, err := userDataFor(callback)
 	if err != nil {
 		return err
@@ -91,7 +154,7 @@ func SetProgramReleaseCallback(program Program, callback func()) error {
 	status := C.cl30SetProgramReleaseCallback(program.handle(), callbackUserData.ptr)
 	if status != C.CL_SUCCESS {
 		callbackUserData.Delete()
-		return StatusError(status)
+		return operationError("clSetProgramReleaseCallback", status, "program", program)
 	}
 	return nil
 }
@@ -101,5 +164,5 @@ func cl30GoProgramReleaseCallback(_ Program, userData *C.uintptr_t) {
 	callbackUserData := userDataFrom(userData)
 	callback := callbackUserData.Value().(func())
 	callbackUserData.Delete()
-	callback()
+	dispatchCallback(callback)
 }
@@ -0,0 +1,169 @@
+package cl30
+
+import (
+	"log"
+	"sync"
+	"unsafe"
+)
+
+// DeprecationSeverity classifies the use of deprecated functionality.
+type DeprecationSeverity int
+
+const (
+	// DeprecationMinor is the severity of a use of functionality that is deprecated in a later version than the
+	// one of the used device, or in case the version of the device is not known.
+	// The use is valid, yet code that also targets newer implementations should migrate.
+	DeprecationMinor DeprecationSeverity = iota
+	// DeprecationMajor is the severity of a use of functionality that is deprecated in the version of the used
+	// device, or in an earlier one.
+	DeprecationMajor
+)
+
+// DeprecationAction describes the reaction on the use of deprecated functionality.
+type DeprecationAction int
+
+const (
+	// DeprecationIgnore does not react on the use. This is the default for all severities.
+	DeprecationIgnore DeprecationAction = iota
+	// DeprecationReport forwards the use to the handler set with SetDeprecationHandler(). The call continues.
+	DeprecationReport
+	// DeprecationFail forwards the use to the handler, and makes the call fail with ErrDeprecatedUse.
+	DeprecationFail
+)
+
+// DeprecationUse describes one use of deprecated functionality.
+type DeprecationUse struct {
+	// Name is the name of the function or constant, such as "DeviceQueuePropertiesInfo".
+	Name string
+	// DeprecatedIn is the version of OpenCL that deprecated the functionality.
+	DeprecatedIn Version
+	// DeviceVersion is the version of the device that the functionality was used with.
+	// It is zero if the version is not known.
+	DeviceVersion Version
+	// Severity classifies the use.
+	Severity DeprecationSeverity
+}
+
+type deprecatedItem struct {
+	name         string
+	deprecatedIn Version
+}
+
+// deprecatedDeviceInfoNames lists the deprecated device queries.
+//
+// DeviceQueuePropertiesInfo is not part of this list: it has the same value as DeviceQueueOnHostPropertiesInfo,
+// and its use can therefore not be detected.
+var deprecatedDeviceInfoNames = map[DeviceInfoName]deprecatedItem{
+	DeviceHostUnifiedMemoryInfo: {name: "DeviceHostUnifiedMemoryInfo", deprecatedIn: VersionOf(1, 2, 0)},
+	DeviceMaxClockFrequencyInfo: {name: "DeviceMaxClockFrequencyInfo", deprecatedIn: VersionOf(2, 2, 0)},
+	DeviceOpenClCVersionInfo:    {name: "DeviceOpenClCVersionInfo", deprecatedIn: VersionOf(3, 0, 0)},
+}
+
+var deprecatedImageInfoNames = map[ImageInfoName]deprecatedItem{
+	ImageBufferInfo: {name: "ImageBufferInfo", deprecatedIn: VersionOf(2, 0, 0)},
+}
+
+var deprecation = struct {
+	mutex          sync.RWMutex
+	minorAction    DeprecationAction
+	majorAction    DeprecationAction
+	handler        func(DeprecationUse)
+	deviceVersions sync.Map
+}{
+	handler: logDeprecationUse,
+}
+
+// SetDeprecationActions configures how the wrapper reacts on the use of deprecated functions and query constants,
+// separately for each severity. Use this to help code bases migrate, while still supporting old implementations.
+//
+// To determine the severity, the version of the involved device is queried, if there is one. The version is
+// cached per device.
+func SetDeprecationActions(minor, major DeprecationAction) {
+	deprecation.mutex.Lock()
+	defer deprecation.mutex.Unlock()
+	deprecation.minorAction = minor
+	deprecation.majorAction = major
+}
+
+// SetDeprecationHandler sets the function that is called for reported uses of deprecated functionality.
+// The default handler writes a message with the standard logger of package log. A nil handler restores the default.
+//
+// The handler may be called concurrently.
+func SetDeprecationHandler(handler func(DeprecationUse)) {
+	deprecation.mutex.Lock()
+	defer deprecation.mutex.Unlock()
+	if handler == nil {
+		handler = logDeprecationUse
+	}
+	deprecation.handler = handler
+}
+
+func logDeprecationUse(use DeprecationUse) {
+	deviceVersion := "unknown"
+	if use.DeviceVersion != 0 {
+		deviceVersion = use.DeviceVersion.String()
+	}
+	log.Printf("cl30: %s is deprecated since OpenCL %d.%d (device version: %s)",
+		use.Name, use.DeprecatedIn.Major(), use.DeprecatedIn.Minor(), deviceVersion)
+}
+
+func deprecationEnabled() bool {
+	deprecation.mutex.RLock()
+	defer deprecation.mutex.RUnlock()
+	return (deprecation.minorAction != DeprecationIgnore) || (deprecation.majorAction != DeprecationIgnore)
+}
+
+// checkDeprecatedUse applies the configured action for the use of a deprecated item with the given device.
+// The device may be zero if it is not known.
+func checkDeprecatedUse(item deprecatedItem, device DeviceID) error {
+	if !deprecationEnabled() {
+		return nil
+	}
+	use := DeprecationUse{Name: item.name, DeprecatedIn: item.deprecatedIn, Severity: DeprecationMinor}
+	if device != 0 {
+		use.DeviceVersion = cachedDeviceVersion(device)
+	}
+	if (use.DeviceVersion != 0) && (use.DeviceVersion >= item.deprecatedIn) {
+		use.Severity = DeprecationMajor
+	}
+	deprecation.mutex.RLock()
+	action := deprecation.minorAction
+	if use.Severity == DeprecationMajor {
+		action = deprecation.majorAction
+	}
+	handler := deprecation.handler
+	deprecation.mutex.RUnlock()
+	if action == DeprecationIgnore {
+		return nil
+	}
+	handler(use)
+	if action == DeprecationFail {
+		return ErrDeprecatedUse
+	}
+	return nil
+}
+
+func checkDeprecatedQueueUse(item deprecatedItem, commandQueue CommandQueue) error {
+	if !deprecationEnabled() {
+		return nil
+	}
+	var device DeviceID
+	_, err := CommandQueueInfo(commandQueue, QueueDeviceInfo, unsafe.Sizeof(device), unsafe.Pointer(&device))
+	if err != nil {
+		device = 0
+	}
+	return checkDeprecatedUse(item, device)
+}
+
+func cachedDeviceVersion(device DeviceID) Version {
+	if cached, known := deprecation.deviceVersions.Load(device); known {
+		return cached.(Version)
+	}
+	versionString, err := DeviceInfoString(device, DeviceVersionInfo)
+	if err != nil {
+		return 0
+	}
+	version, _ := ParseOpenClVersion(versionString)
+	deprecation.deviceVersions.Store(device, version)
+	return version
+}
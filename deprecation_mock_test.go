@@ -0,0 +1,63 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+// failOnDeprecatedUse lets any use of deprecated functionality fail for the duration of the test.
+// The returned slice collects the reported uses.
+func failOnDeprecatedUse(t *testing.T) *[]cl.DeprecationUse {
+	var uses []cl.DeprecationUse
+	cl.SetDeprecationHandler(func(use cl.DeprecationUse) { uses = append(uses, use) })
+	cl.SetDeprecationActions(cl.DeprecationFail, cl.DeprecationFail)
+	t.Cleanup(func() {
+		cl.SetDeprecationActions(cl.DeprecationIgnore, cl.DeprecationIgnore)
+		cl.SetDeprecationHandler(nil)
+	})
+	return &uses
+}
+
+func TestDeprecationFailReportsUse(t *testing.T) {
+	uses := failOnDeprecatedUse(t)
+
+	_, err := cl.ImageInfo(0, cl.ImageBufferInfo, 0, nil)
+	if !errors.Is(err, cl.ErrDeprecatedUse) {
+		t.Errorf("expected ErrDeprecatedUse, got %v", err)
+	}
+	if (len(*uses) != 1) || ((*uses)[0].Name != "ImageBufferInfo") || ((*uses)[0].Severity != cl.DeprecationMinor) {
+		t.Errorf("unexpected reported uses: %v", *uses)
+	}
+}
+
+func TestMockDeprecationIgnoresInternalQueries(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{
+		{Devices: []cl.MockDevice{{Name: "small", MaxComputeUnits: 4}, {Name: "large", MaxComputeUnits: 32}}},
+	})
+	defer cl.SetMockPlatforms(nil)
+	uses := failOnDeprecatedUse(t)
+
+	devices, err := cl.SelectDevices(cl.DeviceCriteria{})
+	if (err != nil) || (len(devices) != 2) || (devices[0].Name != "large") {
+		t.Fatalf("unexpected selection: %v, %v", devices, err)
+	}
+	desc, err := cl.DescribeDevice(devices[0].Device)
+	if err != nil {
+		t.Fatalf("DescribeDevice failed: %v", err)
+	}
+	if (desc.MaxClockFrequency == 0) || (desc.OpenClCVersion == "") {
+		t.Errorf("deprecated values missing in description: %+v", desc)
+	}
+	if len(*uses) != 0 {
+		t.Errorf("internal queries reported as deprecated use: %v", *uses)
+	}
+
+	_, err = cl.DeviceInfoUint32(devices[0].Device, cl.DeviceMaxClockFrequencyInfo)
+	if !errors.Is(err, cl.ErrDeprecatedUse) || (len(*uses) != 1) {
+		t.Errorf("deprecated query of the application not reported: %v, %v", err, *uses)
+	}
+}
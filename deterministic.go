@@ -0,0 +1,36 @@
+package cl30
+
+import "sync/atomic"
+
+var deterministicMode int32
+
+// SetDeterministicMode enables or disables the deterministic mode.
+//
+// In deterministic mode, all host command-queues created through this package are in-order queues, even if
+// QueueOutOfOrderExecModeEnable is requested. Furthermore, helpers that distribute work across multiple
+// command-queues, such as Scheduler, execute the work one after the other.
+// This helps to debug nondeterministic results, before concurrency is enabled again.
+//
+// Device queues are not affected, as they only support out-of-order execution.
+// The mode only applies to objects that are created, or work that is started, while it is enabled.
+func SetDeterministicMode(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&deterministicMode, value)
+}
+
+// DeterministicMode returns true if the deterministic mode is enabled.
+func DeterministicMode() bool {
+	return atomic.LoadInt32(&deterministicMode) != 0
+}
+
+// deterministicQueueFlags removes the out-of-order flag from the properties of host queues if the deterministic
+// mode is enabled.
+func deterministicQueueFlags(flags CommandQueuePropertiesFlags) CommandQueuePropertiesFlags {
+	if !DeterministicMode() || ((flags & QueueOnDevice) != 0) {
+		return flags
+	}
+	return flags &^ QueueOutOfOrderExecModeEnable
+}
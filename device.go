@@ -3,7 +3,8 @@ package cl30
 // #include "api.h"
 import "C"
 import (
-	"fmt"
+	"errors"
+	"strings"
 	"unsafe"
 )
 
@@ -15,9 +16,9 @@ func (id DeviceID) handle() C.cl_device_id {
 }
 
 // String provides a readable presentation of the device identifier.
-// It is based on the numerical value of the underlying pointer.
+// It is based on the numerical value of the underlying pointer, followed by the name set with SetDebugName(), if any.
 func (id DeviceID) String() string {
-	return fmt.Sprintf("0x%X", uintptr(id))
+	return handleString(id, uintptr(id))
 }
 
 // DeviceTypeFlags is a bitfield that identifies the type of OpenCL device.
@@ -53,10 +54,11 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetDeviceIDs.html
 func DeviceIDs(platformID PlatformID, deviceType DeviceTypeFlags) ([]DeviceID, error) {
+	defer observeCall("clGetDeviceIDs")()
 	count := C.cl_uint(0)
 	status := C.clGetDeviceIDs(platformID.handle(), C.cl_device_type(deviceType), 0, nil, &count)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, operationError("clGetDeviceIDs", status, "platformID", platformID)
 	}
 	if count == 0 {
 		return nil, nil
@@ -64,11 +66,59 @@ func DeviceIDs(platformID PlatformID, deviceType DeviceTypeFlags) ([]DeviceID, e
 	ids := make([]DeviceID, count)
 	status = C.clGetDeviceIDs(platformID.handle(), C.cl_device_type(deviceType), count, (*C.cl_device_id)(unsafe.Pointer(&ids[0])), &count)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, operationError("clGetDeviceIDs", status, "platformID", platformID)
 	}
 	return ids[:count], nil
 }
 
+// PlatformDevice identifies a device together with the platform it belongs to.
+// Name and Type are cached at the time the device was enumerated.
+type PlatformDevice struct {
+	Platform PlatformID
+	Device   DeviceID
+	Name     string
+	Type     DeviceTypeFlags
+}
+
+// AllDevices queries the devices of all available platforms.
+//
+// Platforms that do not provide any devices are skipped. The returned entries keep the association to the
+// respective platform, which is required for calls such as ExtensionFunctionAddressForPlatform().
+func AllDevices() ([]PlatformDevice, error) {
+	platformIDs, err := PlatformIDs()
+	if err != nil {
+		return nil, err
+	}
+	var devices []PlatformDevice
+	for _, platformID := range platformIDs {
+		deviceIDs, err := DeviceIDs(platformID, DeviceTypeAll)
+		if errors.Is(err, ErrDeviceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, deviceID := range deviceIDs {
+			name, err := DeviceInfoString(deviceID, DeviceNameInfo)
+			if err != nil {
+				return nil, err
+			}
+			var deviceType DeviceTypeFlags
+			_, err = DeviceInfo(deviceID, DeviceTypeInfo, unsafe.Sizeof(deviceType), unsafe.Pointer(&deviceType))
+			if err != nil {
+				return nil, err
+			}
+			devices = append(devices, PlatformDevice{
+				Platform: platformID,
+				Device:   deviceID,
+				Name:     name,
+				Type:     deviceType,
+			})
+		}
+	}
+	return devices, nil
+}
+
 // DeviceInfoName identifies properties of a device, which can be queried with DeviceInfo().
 type DeviceInfoName C.cl_device_info
 
@@ -157,6 +207,8 @@ const (
 	// extension name must not be reported more than once. The list of extensions reported must match the list
 	// reported via DeviceExtensionsInfo.
 	//
+	// Use DeviceExtensionsWithVersion() for convenience.
+	//
 	// Returned type: []NameVersion
 	// Since: 3.0
 	DeviceExtensionsWithVersionInfo DeviceInfoName = C.CL_DEVICE_EXTENSIONS_WITH_VERSION
@@ -214,6 +266,8 @@ const (
 	//
 	// For an OpenCL 2.1 or 2.2 device, at least one version of SPIR-V must be reported.
 	//
+	// Use DeviceIlsWithVersion() for convenience.
+	//
 	// Returned type: []NameVersion
 	// Since: 3.0
 	// Extension: cl_khr_il_program
@@ -488,6 +542,14 @@ const (
 	// Returned type: Bool
 	// Since: 3.0
 	DeviceNonUniformWorkGroupSupportInfo DeviceInfoName = C.CL_DEVICE_NON_UNIFORM_WORK_GROUP_SUPPORT
+	// DeviceNumericVersionInfo returns the detailed (major, minor, patch) version supported by the device.
+	// The major and minor version numbers returned must match those returned via DeviceVersionInfo.
+	//
+	// Use DeviceVersion() for convenience.
+	//
+	// Returned type: Version
+	// Since: 3.0
+	DeviceNumericVersionInfo DeviceInfoName = C.CL_DEVICE_NUMERIC_VERSION
 	// DeviceOpenClCAllVersionsInfo returns an array of name, version descriptions listing all the versions of OpenCL C
 	// supported by the compiler for the device. In each returned description structure, the name field is required
 	// to be "OpenCL C". The list may include both newer non-backwards compatible OpenCL C versions, such as
@@ -511,6 +573,8 @@ const (
 	//
 	// For devices that do not support compilation from OpenCL C source, this query may return an empty array.
 	//
+	// Use DeviceOpenClCAllVersions() for convenience.
+	//
 	// Returned type: []NameVersion
 	// Since: 3.0
 	DeviceOpenClCAllVersionsInfo DeviceInfoName = C.CL_DEVICE_OPENCL_C_ALL_VERSIONS
@@ -521,6 +585,8 @@ const (
 	//
 	// For devices that do not support compilation from OpenCL C source, this query may return an empty array.
 	//
+	// Use DeviceOpenClCFeatures() for convenience.
+	//
 	// Returned type: []NameVersion
 	// Since: 3.0
 	DeviceOpenClCFeaturesInfo DeviceInfoName = C.CL_DEVICE_OPENCL_C_FEATURES
@@ -834,6 +900,61 @@ const (
 	DeviceAtomicScopeAllDevices DeviceAtomicCapabilitiesFlags = C.CL_DEVICE_ATOMIC_SCOPE_ALL_DEVICES
 )
 
+// AtomicCapabilities describes the memory orders and scopes that a device supports for one kind of
+// atomic operations.
+type AtomicCapabilities struct {
+	OrderRelaxed    bool
+	OrderAcqRel     bool
+	OrderSeqCst     bool
+	ScopeWorkItem   bool
+	ScopeWorkGroup  bool
+	ScopeDevice     bool
+	ScopeAllDevices bool
+}
+
+// AtomicCapabilitiesFrom decodes the given flags.
+func AtomicCapabilitiesFrom(flags DeviceAtomicCapabilitiesFlags) AtomicCapabilities {
+	has := func(flag DeviceAtomicCapabilitiesFlags) bool { return (flags & flag) == flag }
+	return AtomicCapabilities{
+		OrderRelaxed:    has(DeviceAtomicOrderRelaxed),
+		OrderAcqRel:     has(DeviceAtomicOrderAcqRel),
+		OrderSeqCst:     has(DeviceAtomicOrderSeqCst),
+		ScopeWorkItem:   has(DeviceAtomicScopeWorkItem),
+		ScopeWorkGroup:  has(DeviceAtomicScopeWorkGroup),
+		ScopeDevice:     has(DeviceAtomicScopeDevice),
+		ScopeAllDevices: has(DeviceAtomicScopeAllDevices),
+	}
+}
+
+// AtomicSupport describes the support of a device for atomic operations.
+type AtomicSupport struct {
+	// Memory describes the capabilities for atomic memory operations.
+	Memory AtomicCapabilities
+	// Fence describes the capabilities for atomic fence operations.
+	Fence AtomicCapabilities
+}
+
+// DeviceAtomicSupport is a convenience function for DeviceInfo() to query and decode both
+// DeviceAtomicMemoryCapabilitiesInfo and DeviceAtomicFenceCapabilitiesInfo.
+//
+// Since: 3.0
+func DeviceAtomicSupport(id DeviceID) (AtomicSupport, error) {
+	var memoryFlags DeviceAtomicCapabilitiesFlags
+	_, err := DeviceInfo(id, DeviceAtomicMemoryCapabilitiesInfo, unsafe.Sizeof(memoryFlags), unsafe.Pointer(&memoryFlags))
+	if err != nil {
+		return AtomicSupport{}, err
+	}
+	var fenceFlags DeviceAtomicCapabilitiesFlags
+	_, err = DeviceInfo(id, DeviceAtomicFenceCapabilitiesInfo, unsafe.Sizeof(fenceFlags), unsafe.Pointer(&fenceFlags))
+	if err != nil {
+		return AtomicSupport{}, err
+	}
+	return AtomicSupport{
+		Memory: AtomicCapabilitiesFrom(memoryFlags),
+		Fence:  AtomicCapabilitiesFrom(fenceFlags),
+	}, nil
+}
+
 // DeviceDeviceEnqueueCapabilitiesFlags are used to determine the DeviceDeviceEnqueueCapabilitiesInfo with DeviceInfo().
 type DeviceDeviceEnqueueCapabilitiesFlags C.cl_device_device_enqueue_capabilities
 
@@ -948,6 +1069,19 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetDeviceInfo.html
 func DeviceInfo(id DeviceID, paramName DeviceInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	if item, deprecated := deprecatedDeviceInfoNames[paramName]; deprecated {
+		err := checkDeprecatedUse(item, id)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return deviceInfo(id, paramName, paramSize, paramValue)
+}
+
+// deviceInfo is DeviceInfo() without the check for deprecated queries. The library uses it for its own queries,
+// which must not be reported as deprecated use of the application.
+func deviceInfo(id DeviceID, paramName DeviceInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetDeviceInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetDeviceInfo(
 		id.handle(),
@@ -956,7 +1090,7 @@ func DeviceInfo(id DeviceID, paramName DeviceInfoName, paramSize uintptr, paramV
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetDeviceInfo", status, "id", id, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
@@ -971,6 +1105,173 @@ func DeviceInfoString(id DeviceID, paramName DeviceInfoName) (string, error) {
 	})
 }
 
+// DeviceInfoUint32 is a convenience method for DeviceInfo() to query information values of type uint32,
+// which are those of the C type cl_uint.
+func DeviceInfoUint32(id DeviceID, paramName DeviceInfoName) (uint32, error) {
+	return queryValue[uint32](deviceInfoLoader(id, paramName))
+}
+
+// DeviceInfoUint64 is a convenience method for DeviceInfo() to query information values of type uint64,
+// which are those of the C type cl_ulong, as well as bitfields such as DeviceTypeFlags.
+func DeviceInfoUint64(id DeviceID, paramName DeviceInfoName) (uint64, error) {
+	return queryValue[uint64](deviceInfoLoader(id, paramName))
+}
+
+// DeviceInfoBool is a convenience method for DeviceInfo() to query information values of type Bool.
+func DeviceInfoBool(id DeviceID, paramName DeviceInfoName) (bool, error) {
+	value, err := queryValue[Bool](deviceInfoLoader(id, paramName))
+	return value.ToGoBool(), err
+}
+
+// DeviceInfoSize is a convenience method for DeviceInfo() to query information values of type uintptr,
+// which are those of the C type size_t.
+func DeviceInfoSize(id DeviceID, paramName DeviceInfoName) (uintptr, error) {
+	return queryValue[uintptr](deviceInfoLoader(id, paramName))
+}
+
+// DeviceInfoSizes is a convenience method for DeviceInfo() to query information values of type []uintptr,
+// such as DeviceMaxWorkItemSizesInfo.
+func DeviceInfoSizes(id DeviceID, paramName DeviceInfoName) ([]uintptr, error) {
+	return querySlice[uintptr](deviceInfoLoader(id, paramName))
+}
+
+// DeviceInfoNameVersions is a convenience method for DeviceInfo() to query information values of type
+// []NameVersion, such as DeviceExtensionsWithVersionInfo.
+//
+// Since: 3.0
+func DeviceInfoNameVersions(id DeviceID, paramName DeviceInfoName) ([]NameVersion, error) {
+	return querySlice[NameVersion](deviceInfoLoader(id, paramName))
+}
+
+func deviceInfoLoader(id DeviceID, paramName DeviceInfoName) infoLoader {
+	return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return DeviceInfo(id, paramName, paramSize, paramValue)
+	}
+}
+
+// internalDeviceInfoLoader is deviceInfoLoader() for queries of the library, see deviceInfo().
+func internalDeviceInfoLoader(id DeviceID, paramName DeviceInfoName) infoLoader {
+	return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return deviceInfo(id, paramName, paramSize, paramValue)
+	}
+}
+
+// DeviceBuiltInKernels is a convenience function for DeviceInfo() to query the DeviceBuiltInKernelsInfo.
+// It returns the names of the built-in kernels as a list. Empty entries are skipped.
+//
+// Since: 1.2
+func DeviceBuiltInKernels(id DeviceID) ([]string, error) {
+	list, err := DeviceInfoString(id, DeviceBuiltInKernelsInfo)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, name := range strings.Split(list, ";") {
+		name = strings.TrimSpace(name)
+		if len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// DeviceVersion returns the OpenCL version supported by the device.
+//
+// The version is queried with DeviceNumericVersionInfo. For devices that do not support this query, which was
+// introduced with OpenCL 3.0, the version is parsed from DeviceVersionInfo; see ParseOpenClVersion().
+func DeviceVersion(id DeviceID) (Version, error) {
+	if version, err := queryValue[Version](deviceInfoLoader(id, DeviceNumericVersionInfo)); err == nil {
+		return version, nil
+	}
+	versionString, err := DeviceInfoString(id, DeviceVersionInfo)
+	if err != nil {
+		return 0, err
+	}
+	return ParseOpenClVersion(versionString)
+}
+
+// DeviceExtensionsWithVersion is a convenience function for DeviceInfo() to query the
+// DeviceExtensionsWithVersionInfo. It returns the supported extensions together with their versions.
+//
+// Since: 3.0
+func DeviceExtensionsWithVersion(id DeviceID) ([]NameVersion, error) {
+	return DeviceInfoNameVersions(id, DeviceExtensionsWithVersionInfo)
+}
+
+// DeviceIlsWithVersion is a convenience function for DeviceInfo() to query the DeviceIlsWithVersionInfo.
+// It returns the supported intermediate languages together with their versions.
+//
+// Since: 3.0
+func DeviceIlsWithVersion(id DeviceID) ([]NameVersion, error) {
+	return DeviceInfoNameVersions(id, DeviceIlsWithVersionInfo)
+}
+
+// DeviceOpenClCAllVersions is a convenience function for DeviceInfo() to query the DeviceOpenClCAllVersionsInfo.
+// It returns all the versions of OpenCL C that are supported by the compiler of the device.
+//
+// Since: 3.0
+func DeviceOpenClCAllVersions(id DeviceID) ([]NameVersion, error) {
+	return DeviceInfoNameVersions(id, DeviceOpenClCAllVersionsInfo)
+}
+
+// DeviceOpenClCFeatures is a convenience function for DeviceInfo() to query the DeviceOpenClCFeaturesInfo.
+// It returns the optional OpenCL C features that are supported by the compiler of the device, such as
+// "__opencl_c_images", together with their versions.
+//
+// Since: 3.0
+func DeviceOpenClCFeatures(id DeviceID) ([]NameVersion, error) {
+	return DeviceInfoNameVersions(id, DeviceOpenClCFeaturesInfo)
+}
+
+// containsExtension returns true if the space separated list of extension names contains the given name.
+func containsExtension(extensions string, name string) bool {
+	for _, extension := range strings.Fields(extensions) {
+		if extension == name {
+			return true
+		}
+	}
+	return false
+}
+
+// MemoryHierarchy summarizes the memory related properties of a device.
+type MemoryHierarchy struct {
+	GlobalMemCacheType     DeviceMemCacheTypeEnum
+	GlobalMemCacheSize     uint64
+	GlobalMemCachelineSize uint32
+	GlobalMemSize          uint64
+	LocalMemType           DeviceLocalMemTypeEnum
+	LocalMemSize           uint64
+	MaxConstantBufferSize  uint64
+	MaxConstantArgs        uint32
+}
+
+// DeviceMemoryHierarchy is a convenience function for DeviceInfo() to query the memory related properties of a
+// device in one go. This is useful to select tile sizes or other tuning parameters.
+func DeviceMemoryHierarchy(id DeviceID) (MemoryHierarchy, error) {
+	var hierarchy MemoryHierarchy
+	queries := []struct {
+		paramName  DeviceInfoName
+		paramSize  uintptr
+		paramValue unsafe.Pointer
+	}{
+		{DeviceGlobalMemCacheTypeInfo, unsafe.Sizeof(hierarchy.GlobalMemCacheType), unsafe.Pointer(&hierarchy.GlobalMemCacheType)},
+		{DeviceGlobalMemCacheSizeInfo, unsafe.Sizeof(hierarchy.GlobalMemCacheSize), unsafe.Pointer(&hierarchy.GlobalMemCacheSize)},
+		{DeviceGlobalMemCachelineSizeInfo, unsafe.Sizeof(hierarchy.GlobalMemCachelineSize), unsafe.Pointer(&hierarchy.GlobalMemCachelineSize)},
+		{DeviceGlobalMemSizeInfo, unsafe.Sizeof(hierarchy.GlobalMemSize), unsafe.Pointer(&hierarchy.GlobalMemSize)},
+		{DeviceLocalMemTypeInfo, unsafe.Sizeof(hierarchy.LocalMemType), unsafe.Pointer(&hierarchy.LocalMemType)},
+		{DeviceLocalMemSizeInfo, unsafe.Sizeof(hierarchy.LocalMemSize), unsafe.Pointer(&hierarchy.LocalMemSize)},
+		{DeviceMaxConstantBufferSizeInfo, unsafe.Sizeof(hierarchy.MaxConstantBufferSize), unsafe.Pointer(&hierarchy.MaxConstantBufferSize)},
+		{DeviceMaxConstantArgsInfo, unsafe.Sizeof(hierarchy.MaxConstantArgs), unsafe.Pointer(&hierarchy.MaxConstantArgs)},
+	}
+	for _, query := range queries {
+		_, err := DeviceInfo(id, query.paramName, query.paramSize, query.paramValue)
+		if err != nil {
+			return MemoryHierarchy{}, err
+		}
+	}
+	return hierarchy, nil
+}
+
 // DeviceAndHostTimer returns a reasonably synchronized pair of timestamps from the device timer and the host timer
 // as seen by device.
 //
@@ -980,9 +1281,10 @@ func DeviceInfoString(id DeviceID, paramName DeviceInfoName) (string, error) {
 // Since: 2.1
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetDeviceAndHostTimer.html
 func DeviceAndHostTimer(id DeviceID) (device uint64, host uint64, err error) {
+	defer observeCall("clGetDeviceAndHostTimer")()
 	status := C.clGetDeviceAndHostTimer(id.handle(), (*C.cl_ulong)(&device), (*C.cl_ulong)(&host))
 	if status != C.CL_SUCCESS {
-		return 0, 0, StatusError(status)
+		return 0, 0, operationError("clGetDeviceAndHostTimer", status, "id", id)
 	}
 	return
 }
@@ -993,10 +1295,11 @@ func DeviceAndHostTimer(id DeviceID) (device uint64, host uint64, err error) {
 // Since: 2.1
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetHostTimer.html
 func HostTimer(id DeviceID) (uint64, error) {
+	defer observeCall("clGetHostTimer")()
 	var host uint64
 	status := C.clGetHostTimer(id.handle(), (*C.cl_ulong)(&host))
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetHostTimer", status, "id", id)
 	}
 	return host, nil
 }
@@ -1118,6 +1421,7 @@ func PartitionedByAffinityDomain(domain DeviceAffinityDomainFlags) DevicePartiti
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateSubDevices.html
 func CreateSubDevices(id DeviceID, properties ...DevicePartitionProperty) ([]DeviceID, error) {
+	defer observeCall("clCreateSubDevices")()
 	var rawPropertyList []uintptr
 	for _, property := range properties {
 		rawPropertyList = append(rawPropertyList, property...)
@@ -1135,7 +1439,7 @@ func CreateSubDevices(id DeviceID, properties ...DevicePartitionProperty) ([]Dev
 		0, nil,
 		&requiredCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, operationError("clCreateSubDevices", status, "id", id)
 	}
 	ids := make([]DeviceID, requiredCount)
 	reportedCount := C.cl_uint(0)
@@ -1146,7 +1450,7 @@ func CreateSubDevices(id DeviceID, properties ...DevicePartitionProperty) ([]Dev
 		(*C.cl_device_id)(unsafe.Pointer(&ids[0])),
 		&reportedCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, operationError("clCreateSubDevices", status, "id", id)
 	}
 	return ids[:reportedCount], nil
 }
@@ -1158,9 +1462,10 @@ func CreateSubDevices(id DeviceID, properties ...DevicePartitionProperty) ([]Dev
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clRetainDevice.html
 func RetainDevice(id DeviceID) error {
+	defer observeCall("clRetainDevice")()
 	status := C.clRetainDevice(id.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clRetainDevice", status, "id", id)
 	}
 	return nil
 }
@@ -1172,9 +1477,10 @@ func RetainDevice(id DeviceID) error {
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clReleaseDevice.html
 func ReleaseDevice(id DeviceID) error {
+	defer observeCall("clReleaseDevice")()
 	status := C.clReleaseDevice(id.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clReleaseDevice", status, "id", id)
 	}
 	return nil
 }
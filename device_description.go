@@ -0,0 +1,158 @@
+package cl30
+
+import (
+	"strings"
+)
+
+// DeviceDescription contains the standard information of a device, as returned by DescribeDevice().
+//
+// The fields have JSON tags, which allows the description to be logged or attached to reports as it is.
+// Bitfields, such as Type, are represented by their numeric value.
+type DeviceDescription struct {
+	Name           string          `json:"name"`
+	Vendor         string          `json:"vendor"`
+	VendorID       uint32          `json:"vendorId"`
+	Type           DeviceTypeFlags `json:"type"`
+	Version        string          `json:"version"`
+	DriverVersion  string          `json:"driverVersion"`
+	OpenClCVersion string          `json:"openClCVersion"`
+	Profile        string          `json:"profile"`
+	IlVersion      string          `json:"ilVersion,omitempty"`
+
+	Available         bool `json:"available"`
+	CompilerAvailable bool `json:"compilerAvailable"`
+	LinkerAvailable   bool `json:"linkerAvailable"`
+	EndianLittle      bool `json:"endianLittle"`
+
+	MaxComputeUnits          uint32    `json:"maxComputeUnits"`
+	MaxClockFrequency        uint32    `json:"maxClockFrequency"`
+	AddressBits              uint32    `json:"addressBits"`
+	MaxWorkItemDimensions    uint32    `json:"maxWorkItemDimensions"`
+	MaxWorkItemSizes         []uintptr `json:"maxWorkItemSizes"`
+	MaxWorkGroupSize         uintptr   `json:"maxWorkGroupSize"`
+	GlobalMemSize            uint64    `json:"globalMemSize"`
+	GlobalMemCacheSize       uint64    `json:"globalMemCacheSize"`
+	GlobalMemCachelineSize   uint32    `json:"globalMemCachelineSize"`
+	LocalMemSize             uint64    `json:"localMemSize"`
+	MaxMemAllocSize          uint64    `json:"maxMemAllocSize"`
+	MaxConstantBufferSize    uint64    `json:"maxConstantBufferSize"`
+	MaxParameterSize         uintptr   `json:"maxParameterSize"`
+	MemBaseAddrAlign         uint32    `json:"memBaseAddrAlign"`
+	ProfilingTimerResolution uintptr   `json:"profilingTimerResolution"`
+
+	ImageSupport           bool    `json:"imageSupport"`
+	Image2dMaxWidth        uintptr `json:"image2dMaxWidth"`
+	Image2dMaxHeight       uintptr `json:"image2dMaxHeight"`
+	ErrorCorrectionSupport bool    `json:"errorCorrectionSupport"`
+	HostUnifiedMemory      bool    `json:"hostUnifiedMemory"`
+
+	SvmCapabilities       DeviceSvmCapabilitiesFlags  `json:"svmCapabilities"`
+	SingleFpConfig        DeviceFpConfigFlags         `json:"singleFpConfig"`
+	DoubleFpConfig        DeviceFpConfigFlags         `json:"doubleFpConfig"`
+	QueueOnHostProperties CommandQueuePropertiesFlags `json:"queueOnHostProperties"`
+
+	Extensions     []string `json:"extensions"`
+	BuiltInKernels []string `json:"builtInKernels,omitempty"`
+}
+
+// DescribeDevice queries the standard information of the device into one DeviceDescription.
+//
+// Information that can not be queried, for example because it was introduced with a later version of OpenCL than
+// the one of the device, remains at its zero value. An error is returned only if not a single value could be queried.
+// Use Snapshot() for an exhaustive set of information.
+func DescribeDevice(id DeviceID) (DeviceDescription, error) {
+	describer := deviceDescriber{id: id}
+	var desc DeviceDescription
+	describer.string(DeviceNameInfo, &desc.Name)
+	describer.string(DeviceVendorInfo, &desc.Vendor)
+	describeDeviceValue(&describer, DeviceVendorIDInfo, &desc.VendorID)
+	describeDeviceValue(&describer, DeviceTypeInfo, &desc.Type)
+	describer.string(DeviceVersionInfo, &desc.Version)
+	describer.string(DriverVersionInfo, &desc.DriverVersion)
+	describer.string(DeviceOpenClCVersionInfo, &desc.OpenClCVersion)
+	describer.string(DeviceProfileInfo, &desc.Profile)
+	describer.string(DeviceIlVersionInfo, &desc.IlVersion)
+
+	describer.bool(DeviceAvailableInfo, &desc.Available)
+	describer.bool(DeviceCompilerAvailableInfo, &desc.CompilerAvailable)
+	describer.bool(DeviceLinkerAvailableInfo, &desc.LinkerAvailable)
+	describer.bool(DeviceEndianLittleInfo, &desc.EndianLittle)
+
+	describeDeviceValue(&describer, DeviceMaxComputeUnitsInfo, &desc.MaxComputeUnits)
+	describeDeviceValue(&describer, DeviceMaxClockFrequencyInfo, &desc.MaxClockFrequency)
+	describeDeviceValue(&describer, DeviceAddressBitsInfo, &desc.AddressBits)
+	describeDeviceValue(&describer, DeviceMaxWorkItemDimensionsInfo, &desc.MaxWorkItemDimensions)
+	if sizes, err := querySlice[uintptr](internalDeviceInfoLoader(id, DeviceMaxWorkItemSizesInfo)); describer.record(err) {
+		desc.MaxWorkItemSizes = sizes
+	}
+	describeDeviceValue(&describer, DeviceMaxWorkGroupSizeInfo, &desc.MaxWorkGroupSize)
+	describeDeviceValue(&describer, DeviceGlobalMemSizeInfo, &desc.GlobalMemSize)
+	describeDeviceValue(&describer, DeviceGlobalMemCacheSizeInfo, &desc.GlobalMemCacheSize)
+	describeDeviceValue(&describer, DeviceGlobalMemCachelineSizeInfo, &desc.GlobalMemCachelineSize)
+	describeDeviceValue(&describer, DeviceLocalMemSizeInfo, &desc.LocalMemSize)
+	describeDeviceValue(&describer, DeviceMaxMemAllocSizeInfo, &desc.MaxMemAllocSize)
+	describeDeviceValue(&describer, DeviceMaxConstantBufferSizeInfo, &desc.MaxConstantBufferSize)
+	describeDeviceValue(&describer, DeviceMaxParameterSizeInfo, &desc.MaxParameterSize)
+	describeDeviceValue(&describer, DeviceMemBaseAddrAlignInfo, &desc.MemBaseAddrAlign)
+	describeDeviceValue(&describer, DeviceProfilingTimerResolutionInfo, &desc.ProfilingTimerResolution)
+
+	describer.bool(DeviceImageSupportInfo, &desc.ImageSupport)
+	describeDeviceValue(&describer, DeviceImage2dMaxWidthInfo, &desc.Image2dMaxWidth)
+	describeDeviceValue(&describer, DeviceImage2dMaxHeightInfo, &desc.Image2dMaxHeight)
+	describer.bool(DeviceErrorCorrectionSupportInfo, &desc.ErrorCorrectionSupport)
+	describer.bool(DeviceHostUnifiedMemoryInfo, &desc.HostUnifiedMemory)
+
+	describeDeviceValue(&describer, DeviceSvmCapabilitiesInfo, &desc.SvmCapabilities)
+	describeDeviceValue(&describer, DeviceSingleFpConfigInfo, &desc.SingleFpConfig)
+	describeDeviceValue(&describer, DeviceDoubleFpConfigInfo, &desc.DoubleFpConfig)
+	describeDeviceValue(&describer, DeviceQueueOnHostPropertiesInfo, &desc.QueueOnHostProperties)
+
+	var extensions string
+	describer.string(DeviceExtensionsInfo, &extensions)
+	desc.Extensions = strings.Fields(extensions)
+	if kernels, err := DeviceBuiltInKernels(id); describer.record(err) {
+		desc.BuiltInKernels = kernels
+	}
+
+	if describer.queried == 0 {
+		return DeviceDescription{}, describer.firstErr
+	}
+	return desc, nil
+}
+
+// deviceDescriber collects the information values for DescribeDevice().
+type deviceDescriber struct {
+	id       DeviceID
+	queried  int
+	firstErr error
+}
+
+// record returns true if the query was successful, and keeps the first error otherwise.
+func (describer *deviceDescriber) record(err error) bool {
+	if err != nil {
+		if describer.firstErr == nil {
+			describer.firstErr = err
+		}
+		return false
+	}
+	describer.queried++
+	return true
+}
+
+func (describer *deviceDescriber) string(paramName DeviceInfoName, target *string) {
+	if value, err := queryString(internalDeviceInfoLoader(describer.id, paramName)); describer.record(err) {
+		*target = value
+	}
+}
+
+func (describer *deviceDescriber) bool(paramName DeviceInfoName, target *bool) {
+	if value, err := queryValue[Bool](internalDeviceInfoLoader(describer.id, paramName)); describer.record(err) {
+		*target = value.ToGoBool()
+	}
+}
+
+func describeDeviceValue[T any](describer *deviceDescriber, paramName DeviceInfoName, target *T) {
+	if value, err := queryValue[T](internalDeviceInfoLoader(describer.id, paramName)); describer.record(err) {
+		*target = value
+	}
+}
@@ -0,0 +1,40 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockDescribeDevice(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{
+		{Devices: []cl.MockDevice{{Name: "described", Extensions: "cl_khr_fp64 cl_khr_il_program", MaxComputeUnits: 4}}},
+	})
+	defer cl.SetMockPlatforms(nil)
+	devices, err := cl.AllDevices()
+	if (err != nil) || (len(devices) != 1) {
+		t.Fatalf("unexpected devices: %v, %v", devices, err)
+	}
+	desc, err := cl.DescribeDevice(devices[0].Device)
+	if err != nil {
+		t.Fatalf("DescribeDevice failed: %v", err)
+	}
+	if (desc.Name != "described") || (desc.MaxComputeUnits != 4) || !desc.Available {
+		t.Errorf("unexpected description: %+v", desc)
+	}
+	if !reflect.DeepEqual(desc.Extensions, []string{"cl_khr_fp64", "cl_khr_il_program"}) {
+		t.Errorf("unexpected extensions: %v", desc.Extensions)
+	}
+	encoded, err := json.Marshal(desc)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"name":"described"`) || !strings.Contains(string(encoded), `"maxComputeUnits":4`) {
+		t.Errorf("unexpected JSON: %s", encoded)
+	}
+}
@@ -0,0 +1,97 @@
+package cl30
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DeviceDiff is the result of DiffDevices(). It lists the differences of the capabilities of two devices.
+type DeviceDiff struct {
+	// OnlyInA lists the extensions that only the first device supports, in ascending order.
+	OnlyInA []string
+	// OnlyInB lists the extensions that only the second device supports, in ascending order.
+	OnlyInB []string
+	// Values lists the information values that differ, in the order of the fields of DeviceDescription.
+	Values []DeviceValueDiff
+}
+
+// DeviceValueDiff is a single differing information value of two devices.
+type DeviceValueDiff struct {
+	// Name is the name of the field in DeviceDescription, for example "MaxWorkGroupSize".
+	Name string
+	// A is the value of the first device.
+	A any
+	// B is the value of the second device.
+	B any
+}
+
+// Empty returns true if no differences were found.
+func (diff DeviceDiff) Empty() bool {
+	return (len(diff.OnlyInA) == 0) && (len(diff.OnlyInB) == 0) && (len(diff.Values) == 0)
+}
+
+// String returns a line per difference, suitable for logs.
+func (diff DeviceDiff) String() string {
+	var text strings.Builder
+	for _, value := range diff.Values {
+		_, _ = fmt.Fprintf(&text, "%s: %v != %v\n", value.Name, value.A, value.B)
+	}
+	for _, extension := range diff.OnlyInA {
+		_, _ = fmt.Fprintf(&text, "-%s\n", extension)
+	}
+	for _, extension := range diff.OnlyInB {
+		_, _ = fmt.Fprintf(&text, "+%s\n", extension)
+	}
+	return text.String()
+}
+
+// DiffDevices compares the capabilities of two devices, as described by DescribeDevice().
+// This helps to find the cause if a workload succeeds on one device and fails on another.
+//
+// The extensions are compared as sets; all other information values of DeviceDescription are compared by value.
+func DiffDevices(a, b DeviceID) (DeviceDiff, error) {
+	descA, err := DescribeDevice(a)
+	if err != nil {
+		return DeviceDiff{}, err
+	}
+	descB, err := DescribeDevice(b)
+	if err != nil {
+		return DeviceDiff{}, err
+	}
+	var diff DeviceDiff
+	diff.OnlyInA = missingExtensions(descA.Extensions, descB.Extensions)
+	diff.OnlyInB = missingExtensions(descB.Extensions, descA.Extensions)
+	valuesA := reflect.ValueOf(descA)
+	valuesB := reflect.ValueOf(descB)
+	descType := valuesA.Type()
+	for i := 0; i < descType.NumField(); i++ {
+		name := descType.Field(i).Name
+		if name == "Extensions" {
+			continue
+		}
+		valueA := valuesA.Field(i).Interface()
+		valueB := valuesB.Field(i).Interface()
+		if !reflect.DeepEqual(valueA, valueB) {
+			diff.Values = append(diff.Values, DeviceValueDiff{Name: name, A: valueA, B: valueB})
+		}
+	}
+	return diff, nil
+}
+
+// missingExtensions returns the sorted entries of extensions that are not contained in others.
+func missingExtensions(extensions, others []string) []string {
+	known := make(map[string]struct{}, len(others))
+	for _, extension := range others {
+		known[extension] = struct{}{}
+	}
+	var missing []string
+	for _, extension := range extensions {
+		if _, found := known[extension]; !found {
+			missing = append(missing, extension)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,42 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"reflect"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockDiffDevices(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{
+		{Devices: []cl.MockDevice{
+			{Name: "gpu", MaxWorkGroupSize: 256, Extensions: "cl_khr_fp64 cl_khr_fp16"},
+			{Name: "gpu", MaxWorkGroupSize: 1024, Extensions: "cl_khr_fp16 cl_khr_subgroups"},
+		}},
+	})
+	defer cl.SetMockPlatforms(nil)
+	devices, err := cl.AllDevices()
+	if (err != nil) || (len(devices) != 2) {
+		t.Fatalf("unexpected devices: %v, %v", devices, err)
+	}
+	diff, err := cl.DiffDevices(devices[0].Device, devices[1].Device)
+	if err != nil {
+		t.Fatalf("DiffDevices failed: %v", err)
+	}
+	if !reflect.DeepEqual(diff.OnlyInA, []string{"cl_khr_fp64"}) || !reflect.DeepEqual(diff.OnlyInB, []string{"cl_khr_subgroups"}) {
+		t.Errorf("unexpected extension diff: %v, %v", diff.OnlyInA, diff.OnlyInB)
+	}
+	var names []string
+	for _, value := range diff.Values {
+		names = append(names, value.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"MaxWorkItemSizes", "MaxWorkGroupSize"}) {
+		t.Errorf("unexpected value diff: %s", diff)
+	}
+	same, err := cl.DiffDevices(devices[0].Device, devices[0].Device)
+	if (err != nil) || !same.Empty() {
+		t.Errorf("unexpected diff for same device: %s, %v", same, err)
+	}
+}
@@ -0,0 +1,159 @@
+package cl30
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DeviceSelectorEnvVar is the name of the environment variable of the oneAPI device selector, which
+// FindDevices(), DeviceFromEnv(), and SelectDevices() interpret for the OpenCL backend.
+//
+// Other common variables, such as ROCR_VISIBLE_DEVICES or CUDA_VISIBLE_DEVICES, are applied by the respective
+// vendor runtimes beneath their OpenCL implementation. The devices they hide are not reported by DeviceIDs()
+// in the first place, and are therefore not interpreted again by this package.
+const DeviceSelectorEnvVar = "ONEAPI_DEVICE_SELECTOR"
+
+// FindDevices returns the devices of all platforms that are visible according to the environment variable
+// DeviceSelectorEnvVar, in the order of AllDevices(). Use SelectDevices() to find devices with specific properties.
+func FindDevices() ([]PlatformDevice, error) {
+	devices, err := AllDevices()
+	if err != nil {
+		return nil, err
+	}
+	return FilterDevicesByEnvironment(devices)
+}
+
+// DeviceFromEnv returns the preferred device among those that are visible according to the environment variable
+// DeviceSelectorEnvVar, ranked by RankDevicesByCapacity(). ErrDeviceNotFound is returned if no device is visible.
+func DeviceFromEnv() (PlatformDevice, error) {
+	devices, err := SelectDevices(DeviceCriteria{})
+	if err != nil {
+		return PlatformDevice{}, err
+	}
+	if len(devices) == 0 {
+		return PlatformDevice{}, ErrDeviceNotFound
+	}
+	return devices[0], nil
+}
+
+// FilterDevicesByEnvironment applies the device selector of the environment variable DeviceSelectorEnvVar to
+// the given devices, see FilterDevicesBySelector(). The devices are returned unchanged if the variable is not set.
+func FilterDevicesByEnvironment(devices []PlatformDevice) ([]PlatformDevice, error) {
+	return FilterDevicesBySelector(devices, os.Getenv(DeviceSelectorEnvVar))
+}
+
+// FilterDevicesBySelector returns those of the given devices that are visible according to the selector, which
+// follows the syntax of the oneAPI device selector. The devices are expected in the order of AllDevices().
+//
+// The selector is a list of terms, separated by semicolons, in the form "backend:device,device,...". A term that
+// starts with an exclamation mark hides the devices it matches. Only terms of the backends "opencl" and "*" match
+// OpenCL devices; the device lists of other backends are not interpreted. A device is either "*", a device type of
+// "cpu", "gpu", or "fpga" (for accelerators), or the index of the device within the given list. If the selector
+// contains only hiding terms, all other devices are visible.
+//
+// An empty selector returns the devices unchanged. ErrInvalidDeviceSelector is returned for a malformed selector,
+// and for the selection of sub-devices, which is not supported.
+func FilterDevicesBySelector(devices []PlatformDevice, selector string) ([]PlatformDevice, error) {
+	if len(strings.TrimSpace(selector)) == 0 {
+		return devices, nil
+	}
+	var accepting, hiding []deviceSelectorTerm
+	anyAccepting := false
+	for _, text := range strings.Split(selector, ";") {
+		term, err := parseDeviceSelectorTerm(text)
+		if err != nil {
+			return nil, err
+		}
+		if !term.hiding {
+			anyAccepting = true
+		}
+		if !term.openCl {
+			continue
+		}
+		if term.hiding {
+			hiding = append(hiding, term)
+		} else {
+			accepting = append(accepting, term)
+		}
+	}
+	var visible []PlatformDevice
+	for index, device := range devices {
+		if anyAccepting && !anySelectorTermMatches(accepting, index, device) {
+			continue
+		}
+		if anySelectorTermMatches(hiding, index, device) {
+			continue
+		}
+		visible = append(visible, device)
+	}
+	return visible, nil
+}
+
+type deviceSelectorTerm struct {
+	hiding  bool
+	openCl  bool
+	all     bool
+	types   DeviceTypeFlags
+	indices []int
+}
+
+func parseDeviceSelectorTerm(text string) (deviceSelectorTerm, error) {
+	var term deviceSelectorTerm
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "!") {
+		term.hiding = true
+		text = text[1:]
+	}
+	backend, deviceList, found := strings.Cut(text, ":")
+	if !found || (len(strings.TrimSpace(deviceList)) == 0) {
+		return term, ErrInvalidDeviceSelector
+	}
+	backend = strings.ToLower(strings.TrimSpace(backend))
+	term.openCl = (backend == "opencl") || (backend == "*")
+	if !term.openCl {
+		// Other backends may use their own device syntax, such as sub-devices, which is not relevant here.
+		return term, nil
+	}
+	for _, device := range strings.Split(deviceList, ",") {
+		device = strings.ToLower(strings.TrimSpace(device))
+		switch device {
+		case "*":
+			term.all = true
+		case "cpu":
+			term.types |= DeviceTypeCPU
+		case "gpu":
+			term.types |= DeviceTypeGpu
+		case "fpga":
+			term.types |= DeviceTypeAccelerator
+		default:
+			index, err := strconv.Atoi(device)
+			if (err != nil) || (index < 0) {
+				return term, ErrInvalidDeviceSelector
+			}
+			term.indices = append(term.indices, index)
+		}
+	}
+	return term, nil
+}
+
+func anySelectorTermMatches(terms []deviceSelectorTerm, index int, device PlatformDevice) bool {
+	for _, term := range terms {
+		if term.matches(index, device) {
+			return true
+		}
+	}
+	return false
+}
+
+func (term deviceSelectorTerm) matches(index int, device PlatformDevice) bool {
+	if term.all || ((device.Type & term.types) != 0) {
+		return true
+	}
+	for _, selected := range term.indices {
+		if selected == index {
+			return true
+		}
+	}
+	return false
+}
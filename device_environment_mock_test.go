@@ -0,0 +1,40 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockDevicesFromEnvironment(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{
+		{Devices: []cl.MockDevice{
+			{Name: "cpu", Type: cl.DeviceTypeCPU, MaxComputeUnits: 16},
+			{Name: "gpu", MaxComputeUnits: 4},
+		}},
+	})
+	defer cl.SetMockPlatforms(nil)
+
+	t.Setenv(cl.DeviceSelectorEnvVar, "opencl:cpu")
+	devices, err := cl.FindDevices()
+	if (err != nil) || (len(devices) != 1) || (devices[0].Name != "cpu") {
+		t.Errorf("unexpected visible devices: %v, %v", devices, err)
+	}
+	device, err := cl.DeviceFromEnv()
+	if (err != nil) || (device.Name != "cpu") {
+		t.Errorf("unexpected device from environment: %v, %v", device, err)
+	}
+
+	t.Setenv(cl.DeviceSelectorEnvVar, "")
+	if device, err = cl.DeviceFromEnv(); (err != nil) || (device.Name != "gpu") {
+		t.Errorf("unexpected preferred device: %v, %v", device, err)
+	}
+
+	t.Setenv(cl.DeviceSelectorEnvVar, "!opencl:*")
+	if _, err = cl.DeviceFromEnv(); !errors.Is(err, cl.ErrDeviceNotFound) {
+		t.Errorf("expected ErrDeviceNotFound, got %v", err)
+	}
+}
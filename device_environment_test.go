@@ -0,0 +1,62 @@
+package cl30_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestFilterDevicesBySelector(t *testing.T) {
+	t.Parallel()
+	devices := []cl.PlatformDevice{
+		{Device: 1, Type: cl.DeviceTypeCPU},
+		{Device: 2, Type: cl.DeviceTypeGpu},
+		{Device: 3, Type: cl.DeviceTypeGpu},
+		{Device: 4, Type: cl.DeviceTypeAccelerator},
+	}
+	tt := []struct {
+		name     string
+		selector string
+		expected []cl.DeviceID
+	}{
+		{name: "empty", selector: "", expected: []cl.DeviceID{1, 2, 3, 4}},
+		{name: "all", selector: "opencl:*", expected: []cl.DeviceID{1, 2, 3, 4}},
+		{name: "type", selector: "opencl:gpu", expected: []cl.DeviceID{2, 3}},
+		{name: "indices", selector: "opencl:0,2", expected: []cl.DeviceID{1, 3}},
+		{name: "any backend", selector: "*:fpga", expected: []cl.DeviceID{4}},
+		{name: "other backend", selector: "level_zero:gpu", expected: nil},
+		{name: "several terms", selector: "level_zero:*;opencl:cpu", expected: []cl.DeviceID{1}},
+		{name: "other backend sub-device", selector: "level_zero:0.0;opencl:gpu", expected: []cl.DeviceID{2, 3}},
+		{name: "hidden other backend", selector: "!cuda:0.1", expected: []cl.DeviceID{1, 2, 3, 4}},
+		{name: "hiding only", selector: "!opencl:1", expected: []cl.DeviceID{1, 3, 4}},
+		{name: "accepting and hiding", selector: "opencl:gpu;!opencl:2", expected: []cl.DeviceID{2}},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			visible, err := cl.FilterDevicesBySelector(devices, tc.selector)
+			if err != nil {
+				t.Fatalf("FilterDevicesBySelector() failed: %v", err)
+			}
+			var ids []cl.DeviceID
+			for _, device := range visible {
+				ids = append(ids, device.Device)
+			}
+			if !reflect.DeepEqual(ids, tc.expected) {
+				t.Errorf("FilterDevicesBySelector() = %v, want %v", ids, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFilterDevicesBySelectorErrors(t *testing.T) {
+	t.Parallel()
+	for _, selector := range []string{"opencl", "opencl:", "opencl:dsp", "opencl:-1", "opencl:0.1"} {
+		if _, err := cl.FilterDevicesBySelector(nil, selector); !errors.Is(err, cl.ErrInvalidDeviceSelector) {
+			t.Errorf("FilterDevicesBySelector(%q) = %v, want ErrInvalidDeviceSelector", selector, err)
+		}
+	}
+}
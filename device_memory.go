@@ -0,0 +1,283 @@
+package cl30
+
+import (
+	"unsafe"
+)
+
+// DeviceMemoryKind identifies the memory model that a DeviceMemory is based on.
+type DeviceMemoryKind int
+
+// These constants are the kinds of DeviceMemory that this package provides.
+const (
+	// BufferMemoryKind is memory based on a buffer object; see NewBufferMemory().
+	BufferMemoryKind DeviceMemoryKind = iota
+	// SvmMemoryKind is memory based on shared virtual memory; see NewSvmMemory().
+	SvmMemoryKind
+	// UsmMemoryKind is memory based on unified shared memory of the cl_intel_unified_shared_memory extension;
+	// see NewUsmMemory().
+	UsmMemoryKind
+)
+
+// String returns the name of the kind.
+func (kind DeviceMemoryKind) String() string {
+	switch kind {
+	case BufferMemoryKind:
+		return "buffer"
+	case SvmMemoryKind:
+		return "svm"
+	case UsmMemoryKind:
+		return "usm"
+	default:
+		return "unknown"
+	}
+}
+
+// DeviceMemory is a block of memory that kernels can access, independent of the memory model it is based on.
+//
+// Algorithms that are written against this interface run with buffer objects, shared virtual memory, and
+// unified shared memory alike. Use NewPreferredDeviceMemory() to allocate memory of the model that a device
+// supports best.
+//
+// For non-blocking transfers, the host memory is pinned until the command has completed.
+type DeviceMemory interface {
+	// Kind returns the memory model of the memory.
+	Kind() DeviceMemoryKind
+	// Size returns the size of the memory, in bytes.
+	Size() int
+	// Read enqueues a command to read from the memory, starting at offset, into the host memory.
+	Read(commandQueue CommandQueue, blocking bool, offset int, data HostMemory, waitList []Event, event *Event) error
+	// Write enqueues a command to write the host memory into the memory, starting at offset.
+	Write(commandQueue CommandQueue, blocking bool, offset int, data HostMemory, waitList []Event, event *Event) error
+	// SetAsKernelArg sets the memory as the argument value for a specific argument of a kernel.
+	SetAsKernelArg(kernel Kernel, index uint32) error
+	// Release frees the memory. It must no longer be used by enqueued commands.
+	Release() error
+}
+
+// NewPreferredDeviceMemory allocates device memory of the model that the device supports best.
+//
+// Unified shared memory is used if usm is loaded and the device supports device allocations. Otherwise, shared
+// virtual memory is used if the device supports coarse-grain buffers. Otherwise, a buffer object is created.
+// The usm parameter may be nil.
+func NewPreferredDeviceMemory(context Context, device DeviceID, size int, usm *ExtensionUnifiedSharedMemoryIntel) (DeviceMemory, error) {
+	if (usm != nil) && (usm.clDeviceMemAllocIntel != nil) {
+		capabilities, err := DeviceUsmCapabilitiesIntel(device, DeviceDeviceMemCapabilitiesIntelInfo)
+		if (err == nil) && ((capabilities & UsmAccessIntel) != 0) {
+			return NewUsmMemory(usm, context, device, size)
+		}
+	}
+	var svmCapabilities DeviceSvmCapabilitiesFlags
+	_, err := DeviceInfo(device, DeviceSvmCapabilitiesInfo, unsafe.Sizeof(svmCapabilities), unsafe.Pointer(&svmCapabilities))
+	if (err == nil) && ((svmCapabilities & DeviceSvmCoarseGrainBuffer) != 0) {
+		return NewSvmMemory(context, SvmMemFlags(MemReadWriteFlag), size)
+	}
+	return NewBufferMemory(context, MemReadWriteFlag, size)
+}
+
+// checkDeviceMemoryRange verifies that the transfer of data at offset is within the memory.
+func checkDeviceMemoryRange(memory DeviceMemory, offset int, data HostMemory) error {
+	if (offset < 0) || (offset > memory.Size()) || (data.Size() > uintptr(memory.Size()-offset)) {
+		return ErrInvalidValue
+	}
+	return nil
+}
+
+// BufferMemory is DeviceMemory based on a buffer object.
+type BufferMemory struct {
+	mem  MemObject
+	size int
+}
+
+// NewBufferMemory creates a buffer object of given size and wraps it as DeviceMemory.
+func NewBufferMemory(context Context, flags MemFlags, size int) (*BufferMemory, error) {
+	mem, err := CreateBuffer(context, flags, size, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BufferMemory{mem: mem, size: size}, nil
+}
+
+// MemObject returns the underlying buffer object.
+func (memory *BufferMemory) MemObject() MemObject {
+	return memory.mem
+}
+
+// Kind returns BufferMemoryKind.
+func (memory *BufferMemory) Kind() DeviceMemoryKind {
+	return BufferMemoryKind
+}
+
+// Size returns the size of the buffer, in bytes.
+func (memory *BufferMemory) Size() int {
+	return memory.size
+}
+
+// Read enqueues a command to read from the buffer. See EnqueueReadBuffer().
+func (memory *BufferMemory) Read(commandQueue CommandQueue, blocking bool, offset int, data HostMemory,
+	waitList []Event, event *Event) error {
+	if err := checkDeviceMemoryRange(memory, offset, data); err != nil {
+		return err
+	}
+	return EnqueueReadBufferInto(commandQueue, memory.mem, blocking, uintptr(offset), data, waitList, event)
+}
+
+// Write enqueues a command to write to the buffer. See EnqueueWriteBuffer().
+func (memory *BufferMemory) Write(commandQueue CommandQueue, blocking bool, offset int, data HostMemory,
+	waitList []Event, event *Event) error {
+	if err := checkDeviceMemoryRange(memory, offset, data); err != nil {
+		return err
+	}
+	return EnqueueWriteBufferFrom(commandQueue, memory.mem, blocking, uintptr(offset), data, waitList, event)
+}
+
+// SetAsKernelArg sets the buffer as the argument value of the kernel. See SetKernelArg().
+func (memory *BufferMemory) SetAsKernelArg(kernel Kernel, index uint32) error {
+	return SetKernelArg(kernel, index, unsafe.Sizeof(memory.mem), unsafe.Pointer(&memory.mem))
+}
+
+// Release releases the buffer object.
+func (memory *BufferMemory) Release() error {
+	return ReleaseMemObject(memory.mem)
+}
+
+// SvmMemory is DeviceMemory based on shared virtual memory.
+type SvmMemory struct {
+	context Context
+	ptr     unsafe.Pointer
+	size    int
+}
+
+// NewSvmMemory allocates shared virtual memory of given size and wraps it as DeviceMemory. See SvmAlloc().
+//
+// Since: 2.0
+func NewSvmMemory(context Context, flags SvmMemFlags, size int) (*SvmMemory, error) {
+	ptr, err := SvmAlloc(context, flags, size, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &SvmMemory{context: context, ptr: ptr, size: size}, nil
+}
+
+// Pointer returns the address of the shared virtual memory.
+func (memory *SvmMemory) Pointer() unsafe.Pointer {
+	return memory.ptr
+}
+
+// Kind returns SvmMemoryKind.
+func (memory *SvmMemory) Kind() DeviceMemoryKind {
+	return SvmMemoryKind
+}
+
+// Size returns the size of the memory, in bytes.
+func (memory *SvmMemory) Size() int {
+	return memory.size
+}
+
+// Read enqueues a command to copy from the memory. See EnqueueSvmMemcpy().
+func (memory *SvmMemory) Read(commandQueue CommandQueue, blocking bool, offset int, data HostMemory,
+	waitList []Event, event *Event) error {
+	if err := checkDeviceMemoryRange(memory, offset, data); err != nil {
+		return err
+	}
+	pin, eventOut := pinTransfer(blocking, data.Pointer(), event)
+	err := EnqueueSvmMemcpy(commandQueue, blocking, data.Pointer(), unsafe.Add(memory.ptr, offset), int(data.Size()),
+		waitList, eventOut)
+	pin.enqueued(err == nil, eventOut)
+	return err
+}
+
+// Write enqueues a command to copy into the memory. See EnqueueSvmMemcpy().
+func (memory *SvmMemory) Write(commandQueue CommandQueue, blocking bool, offset int, data HostMemory,
+	waitList []Event, event *Event) error {
+	if err := checkDeviceMemoryRange(memory, offset, data); err != nil {
+		return err
+	}
+	pin, eventOut := pinTransfer(blocking, data.Pointer(), event)
+	err := EnqueueSvmMemcpy(commandQueue, blocking, unsafe.Add(memory.ptr, offset), data.Pointer(), int(data.Size()),
+		waitList, eventOut)
+	pin.enqueued(err == nil, eventOut)
+	return err
+}
+
+// SetAsKernelArg sets the memory as the argument value of the kernel. See SetKernelArgSvmPointer().
+func (memory *SvmMemory) SetAsKernelArg(kernel Kernel, index uint32) error {
+	return SetKernelArgSvmPointer(kernel, index, memory.ptr)
+}
+
+// Release frees the memory. See SvmFree().
+func (memory *SvmMemory) Release() error {
+	SvmFree(memory.context, memory.ptr)
+	return nil
+}
+
+// UsmMemory is DeviceMemory based on device allocations of unified shared memory.
+type UsmMemory struct {
+	ext     *ExtensionUnifiedSharedMemoryIntel
+	context Context
+	ptr     unsafe.Pointer
+	size    int
+}
+
+// NewUsmMemory allocates unified shared memory for the device and wraps it as DeviceMemory.
+// See ExtensionUnifiedSharedMemoryIntel.DeviceMemAlloc().
+//
+// Extension: IntelUnifiedSharedMemoryExtensionName
+func NewUsmMemory(ext *ExtensionUnifiedSharedMemoryIntel, context Context, device DeviceID, size int) (*UsmMemory, error) {
+	ptr, err := ext.DeviceMemAlloc(context, device, size, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &UsmMemory{ext: ext, context: context, ptr: ptr, size: size}, nil
+}
+
+// Pointer returns the address of the unified shared memory.
+func (memory *UsmMemory) Pointer() unsafe.Pointer {
+	return memory.ptr
+}
+
+// Kind returns UsmMemoryKind.
+func (memory *UsmMemory) Kind() DeviceMemoryKind {
+	return UsmMemoryKind
+}
+
+// Size returns the size of the memory, in bytes.
+func (memory *UsmMemory) Size() int {
+	return memory.size
+}
+
+// Read enqueues a command to copy from the memory. See ExtensionUnifiedSharedMemoryIntel.EnqueueMemcpy().
+func (memory *UsmMemory) Read(commandQueue CommandQueue, blocking bool, offset int, data HostMemory,
+	waitList []Event, event *Event) error {
+	if err := checkDeviceMemoryRange(memory, offset, data); err != nil {
+		return err
+	}
+	pin, eventOut := pinTransfer(blocking, data.Pointer(), event)
+	err := memory.ext.EnqueueMemcpy(commandQueue, blocking, data.Pointer(), unsafe.Add(memory.ptr, offset),
+		int(data.Size()), waitList, eventOut)
+	pin.enqueued(err == nil, eventOut)
+	return err
+}
+
+// Write enqueues a command to copy into the memory. See ExtensionUnifiedSharedMemoryIntel.EnqueueMemcpy().
+func (memory *UsmMemory) Write(commandQueue CommandQueue, blocking bool, offset int, data HostMemory,
+	waitList []Event, event *Event) error {
+	if err := checkDeviceMemoryRange(memory, offset, data); err != nil {
+		return err
+	}
+	pin, eventOut := pinTransfer(blocking, data.Pointer(), event)
+	err := memory.ext.EnqueueMemcpy(commandQueue, blocking, unsafe.Add(memory.ptr, offset), data.Pointer(),
+		int(data.Size()), waitList, eventOut)
+	pin.enqueued(err == nil, eventOut)
+	return err
+}
+
+// SetAsKernelArg sets the memory as the argument value of the kernel.
+// See ExtensionUnifiedSharedMemoryIntel.SetKernelArgMemPointer().
+func (memory *UsmMemory) SetAsKernelArg(kernel Kernel, index uint32) error {
+	return memory.ext.SetKernelArgMemPointer(kernel, index, memory.ptr)
+}
+
+// Release frees the memory. See ExtensionUnifiedSharedMemoryIntel.MemFree().
+func (memory *UsmMemory) Release() error {
+	return memory.ext.MemFree(memory.context, memory.ptr)
+}
@@ -0,0 +1,33 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockDeviceMemory(t *testing.T) {
+	context, device, queue := mockQueue(t)
+	memory, err := cl.NewPreferredDeviceMemory(context, device, 8, nil)
+	if err != nil {
+		t.Fatalf("NewPreferredDeviceMemory failed: %v", err)
+	}
+	defer func() { _ = memory.Release() }()
+	if memory.Kind() != cl.BufferMemoryKind {
+		t.Errorf("unexpected kind: %v", memory.Kind())
+	}
+	input := []byte("abcd")
+	if err = memory.Write(queue, true, 4, cl.Slice(input), nil, nil); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err = memory.Write(queue, true, 6, cl.Slice(input), nil, nil); !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("unexpected error for write beyond memory: %v", err)
+	}
+	output := make([]byte, 2)
+	if err = memory.Read(queue, true, 5, cl.Slice(output), nil, nil); (err != nil) || (string(output) != "bc") {
+		t.Errorf("unexpected read result: %q, %v", output, err)
+	}
+}
@@ -0,0 +1,59 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"reflect"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockTypedDeviceInfo(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{
+		{Devices: []cl.MockDevice{{MaxComputeUnits: 12, MaxWorkGroupSize: 64, GlobalMemSize: 1 << 30}}},
+	})
+	defer cl.SetMockPlatforms(nil)
+	devices, err := cl.AllDevices()
+	if (err != nil) || (len(devices) != 1) {
+		t.Fatalf("unexpected devices: %v, %v", devices, err)
+	}
+	device := devices[0].Device
+	if units, err := cl.DeviceInfoUint32(device, cl.DeviceMaxComputeUnitsInfo); (err != nil) || (units != 12) {
+		t.Errorf("unexpected compute units: %d, %v", units, err)
+	}
+	if size, err := cl.DeviceInfoUint64(device, cl.DeviceGlobalMemSizeInfo); (err != nil) || (size != 1<<30) {
+		t.Errorf("unexpected global memory size: %d, %v", size, err)
+	}
+	if available, err := cl.DeviceInfoBool(device, cl.DeviceAvailableInfo); (err != nil) || !available {
+		t.Errorf("unexpected availability: %v, %v", available, err)
+	}
+	if size, err := cl.DeviceInfoSize(device, cl.DeviceMaxWorkGroupSizeInfo); (err != nil) || (size != 64) {
+		t.Errorf("unexpected work-group size: %d, %v", size, err)
+	}
+	sizes, err := cl.DeviceInfoSizes(device, cl.DeviceMaxWorkItemSizesInfo)
+	if (err != nil) || !reflect.DeepEqual(sizes, []uintptr{64, 64, 64}) {
+		t.Errorf("unexpected work-item sizes: %v, %v", sizes, err)
+	}
+	if _, err = cl.DeviceInfoNameVersions(device, cl.DeviceExtensionsWithVersionInfo); err != nil {
+		t.Errorf("DeviceInfoNameVersions failed: %v", err)
+	}
+}
+
+func TestMockExtensionsWithVersion(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{
+		{Devices: []cl.MockDevice{{Extensions: "cl_khr_fp64 cl_khr_il_program"}}},
+	})
+	defer cl.SetMockPlatforms(nil)
+	devices, err := cl.AllDevices()
+	if (err != nil) || (len(devices) != 1) {
+		t.Fatalf("unexpected devices: %v, %v", devices, err)
+	}
+	extensions, err := cl.DeviceExtensionsWithVersion(devices[0].Device)
+	if (err != nil) || (len(extensions) != 2) {
+		t.Fatalf("unexpected extensions: %v, %v", extensions, err)
+	}
+	if (extensions[1].Name.String() != "cl_khr_il_program") || (extensions[1].Version != cl.VersionOf(1, 0, 0)) {
+		t.Errorf("unexpected extension: %s %v", extensions[1].Name, extensions[1].Version)
+	}
+}
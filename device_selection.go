@@ -0,0 +1,154 @@
+package cl30
+
+import (
+	"sort"
+)
+
+// DeviceCriteria describes the requirements for SelectDevices(). Zero values of the fields impose no requirement.
+type DeviceCriteria struct {
+	// Type is a bitfield of the accepted device types, such as DeviceTypeGpu|DeviceTypeAccelerator.
+	Type DeviceTypeFlags
+	// MinVersion is the lowest accepted OpenCL version of the device, such as Version20.
+	// The version of the device is determined with DeviceVersion().
+	MinVersion Version
+	// Extensions lists the names of extensions that the device must support.
+	Extensions []string
+	// MinGlobalMemSize is the lowest accepted value of DeviceGlobalMemSizeInfo, in bytes.
+	MinGlobalMemSize uint64
+	// MinMaxMemAllocSize is the lowest accepted value of DeviceMaxMemAllocSizeInfo, in bytes.
+	MinMaxMemAllocSize uint64
+	// MinComputeUnits is the lowest accepted value of DeviceMaxComputeUnitsInfo.
+	MinComputeUnits uint32
+	// ImageSupport requires DeviceImageSupportInfo to be true.
+	ImageSupport bool
+	// SvmCapabilities are the shared virtual memory capabilities that the device must all provide.
+	SvmCapabilities DeviceSvmCapabilitiesFlags
+	// Accept is an optional function for additional requirements. It is called only for devices that
+	// fulfill all other criteria.
+	Accept func(device PlatformDevice) bool
+	// IgnoreEnvironment disregards the device selector of the environment variable DeviceSelectorEnvVar.
+	// By default, only the devices visible according to FilterDevicesByEnvironment() are considered.
+	IgnoreEnvironment bool
+	// Less is an optional function that ranks the selected devices. If nil, the devices are ranked by
+	// RankDevicesByCapacity().
+	Less func(a, b PlatformDevice) bool
+}
+
+// SelectDevices returns the devices of all platforms that fulfill the given criteria, with the preferred
+// device first. An empty list is returned if no device fulfills the criteria.
+//
+// Devices for which a required property can not be queried are considered to not fulfill the criteria.
+// Devices that are hidden by the environment variable DeviceSelectorEnvVar are not considered, unless
+// IgnoreEnvironment is set.
+func SelectDevices(criteria DeviceCriteria) ([]PlatformDevice, error) {
+	devices, err := AllDevices()
+	if err != nil {
+		return nil, err
+	}
+	if !criteria.IgnoreEnvironment {
+		devices, err = FilterDevicesByEnvironment(devices)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var selected []PlatformDevice
+	for _, device := range devices {
+		if criteria.matches(device) {
+			selected = append(selected, device)
+		}
+	}
+	less := criteria.Less
+	if less == nil {
+		less = RankDevicesByCapacity
+	}
+	sort.SliceStable(selected, func(a, b int) bool { return less(selected[a], selected[b]) })
+	return selected, nil
+}
+
+// RankDevicesByCapacity is the default ranking of SelectDevices(). GPU devices rank before accelerators, which
+// rank before all other devices. Devices of the same rank are ordered by the descending product of their
+// compute units and clock frequency, followed by their global memory size.
+func RankDevicesByCapacity(a, b PlatformDevice) bool {
+	rankA, rankB := deviceTypeRank(a.Type), deviceTypeRank(b.Type)
+	if rankA != rankB {
+		return rankA < rankB
+	}
+	throughputA, throughputB := deviceThroughput(a.Device), deviceThroughput(b.Device)
+	if throughputA != throughputB {
+		return throughputA > throughputB
+	}
+	memA, _ := DeviceInfoUint64(a.Device, DeviceGlobalMemSizeInfo)
+	memB, _ := DeviceInfoUint64(b.Device, DeviceGlobalMemSizeInfo)
+	return memA > memB
+}
+
+func (criteria DeviceCriteria) matches(device PlatformDevice) bool {
+	if (criteria.Type != 0) && ((device.Type & criteria.Type) == 0) {
+		return false
+	}
+	if criteria.MinVersion != 0 {
+		version, err := DeviceVersion(device.Device)
+		if (err != nil) || (version < criteria.MinVersion) {
+			return false
+		}
+	}
+	if len(criteria.Extensions) > 0 {
+		extensions, err := DeviceInfoString(device.Device, DeviceExtensionsInfo)
+		if err != nil {
+			return false
+		}
+		for _, name := range criteria.Extensions {
+			if !containsExtension(extensions, name) {
+				return false
+			}
+		}
+	}
+	if !deviceInfoAtLeast(device.Device, DeviceGlobalMemSizeInfo, criteria.MinGlobalMemSize) ||
+		!deviceInfoAtLeast(device.Device, DeviceMaxMemAllocSizeInfo, criteria.MinMaxMemAllocSize) {
+		return false
+	}
+	if criteria.MinComputeUnits != 0 {
+		units, err := DeviceInfoUint32(device.Device, DeviceMaxComputeUnitsInfo)
+		if (err != nil) || (units < criteria.MinComputeUnits) {
+			return false
+		}
+	}
+	if criteria.ImageSupport {
+		supported, err := DeviceInfoBool(device.Device, DeviceImageSupportInfo)
+		if (err != nil) || !supported {
+			return false
+		}
+	}
+	if criteria.SvmCapabilities != 0 {
+		capabilities, err := queryValue[DeviceSvmCapabilitiesFlags](deviceInfoLoader(device.Device, DeviceSvmCapabilitiesInfo))
+		if (err != nil) || ((capabilities & criteria.SvmCapabilities) != criteria.SvmCapabilities) {
+			return false
+		}
+	}
+	return (criteria.Accept == nil) || criteria.Accept(device)
+}
+
+func deviceInfoAtLeast(id DeviceID, paramName DeviceInfoName, limit uint64) bool {
+	if limit == 0 {
+		return true
+	}
+	value, err := DeviceInfoUint64(id, paramName)
+	return (err == nil) && (value >= limit)
+}
+
+func deviceTypeRank(deviceType DeviceTypeFlags) int {
+	switch {
+	case (deviceType & DeviceTypeGpu) != 0:
+		return 0
+	case (deviceType & DeviceTypeAccelerator) != 0:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func deviceThroughput(id DeviceID) uint64 {
+	units, _ := queryValue[uint32](internalDeviceInfoLoader(id, DeviceMaxComputeUnitsInfo))
+	frequency, _ := queryValue[uint32](internalDeviceInfoLoader(id, DeviceMaxClockFrequencyInfo))
+	return uint64(units) * uint64(frequency)
+}
@@ -0,0 +1,44 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"reflect"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockSelectDevices(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{
+		{Devices: []cl.MockDevice{
+			{Name: "cpu", Type: cl.DeviceTypeCPU, MaxComputeUnits: 16},
+			{Name: "small gpu", MaxComputeUnits: 4, Extensions: "cl_khr_fp64"},
+			{Name: "large gpu", MaxComputeUnits: 32, Extensions: "cl_khr_fp64", Version: "OpenCL 2.1 mock"},
+		}},
+	})
+	defer cl.SetMockPlatforms(nil)
+	names := func(criteria cl.DeviceCriteria) []string {
+		devices, err := cl.SelectDevices(criteria)
+		if err != nil {
+			t.Fatalf("SelectDevices failed: %v", err)
+		}
+		var result []string
+		for _, device := range devices {
+			result = append(result, device.Name)
+		}
+		return result
+	}
+	if got := names(cl.DeviceCriteria{}); !reflect.DeepEqual(got, []string{"large gpu", "small gpu", "cpu"}) {
+		t.Errorf("unexpected default ranking: %v", got)
+	}
+	if got := names(cl.DeviceCriteria{Extensions: []string{"cl_khr_fp64"}, MinVersion: cl.VersionOf(3, 0, 0)}); !reflect.DeepEqual(got, []string{"small gpu"}) {
+		t.Errorf("unexpected selection by extension and version: %v", got)
+	}
+	if got := names(cl.DeviceCriteria{Type: cl.DeviceTypeCPU}); !reflect.DeepEqual(got, []string{"cpu"}) {
+		t.Errorf("unexpected selection by type: %v", got)
+	}
+	if got := names(cl.DeviceCriteria{ImageSupport: true}); len(got) != 0 {
+		t.Errorf("unexpected selection with image support: %v", got)
+	}
+}
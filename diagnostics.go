@@ -0,0 +1,95 @@
+package cl30
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiagnosticSeverity describes the severity of a Diagnostic.
+type DiagnosticSeverity string
+
+// These constants are the severities that ParseBuildLog() recognizes.
+const (
+	DiagnosticError   DiagnosticSeverity = "error"
+	DiagnosticWarning DiagnosticSeverity = "warning"
+	DiagnosticNote    DiagnosticSeverity = "note"
+	DiagnosticRemark  DiagnosticSeverity = "remark"
+)
+
+// Diagnostic is a single structured message of a build log.
+type Diagnostic struct {
+	// File is the name of the source as reported by the compiler. Depending on the implementation, this is
+	// a temporary file name or a placeholder such as "<kernel>". It may be empty.
+	File string
+	// Line is the 1-based line number within the source. It is zero if the message has no location.
+	Line int
+	// Column is the 1-based column within the line. It is zero if the compiler did not report it.
+	Column int
+	// Severity of the message.
+	Severity DiagnosticSeverity
+	// Message is the text of the diagnostic, without location and severity.
+	Message string
+}
+
+var (
+	// clangDiagnosticPattern matches the format of Clang/LLVM based compilers, used by most implementations:
+	// "<kernel>:3:5: error: use of undeclared identifier 'x'".
+	clangDiagnosticPattern = regexp.MustCompile(`^(.*?):(\d+):(?:(\d+):)?\s*(fatal error|error|warning|note|remark)\s*:\s*(.*)$`)
+	// edgDiagnosticPattern matches the format of EDG based compilers, such as older AMD implementations:
+	// "/tmp/OCL123.cl", line 3: error: identifier "x" is undefined
+	edgDiagnosticPattern = regexp.MustCompile(`^"(.*)", line (\d+):\s*(fatal error|error|warning|remark|note)\s*(?:#\d+-D)?\s*:\s*(.*)$`)
+)
+
+// ParseBuildLog extracts structured diagnostics from a build log, as returned for ProgramBuildLogInfo.
+//
+// The common formats of Clang/LLVM based compilers and EDG based compilers are recognized.
+// Lines that do not start a diagnostic, such as source excerpts, are skipped.
+func ParseBuildLog(log string) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, line := range strings.Split(log, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if match := clangDiagnosticPattern.FindStringSubmatch(line); match != nil {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     match[1],
+				Line:     atoiOrZero(match[2]),
+				Column:   atoiOrZero(match[3]),
+				Severity: diagnosticSeverityFrom(match[4]),
+				Message:  strings.TrimSpace(match[5]),
+			})
+		} else if match := edgDiagnosticPattern.FindStringSubmatch(line); match != nil {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     match[1],
+				Line:     atoiOrZero(match[2]),
+				Severity: diagnosticSeverityFrom(match[3]),
+				Message:  strings.TrimSpace(match[4]),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// ProgramBuildDiagnostics is a convenience function that queries the ProgramBuildLogInfo of a program for a
+// device, and parses the log with ParseBuildLog().
+func ProgramBuildDiagnostics(program Program, device DeviceID) ([]Diagnostic, error) {
+	log, err := ProgramBuildInfoString(program, device, ProgramBuildLogInfo)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBuildLog(log), nil
+}
+
+func diagnosticSeverityFrom(text string) DiagnosticSeverity {
+	if text == "fatal error" {
+		return DiagnosticError
+	}
+	return DiagnosticSeverity(text)
+}
+
+func atoiOrZero(text string) int {
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,58 @@
+package cl30_test
+
+import (
+	"reflect"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestParseBuildLog(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		name     string
+		log      string
+		expected []cl.Diagnostic
+	}{
+		{
+			name: "clang",
+			log: "<kernel>:3:5: error: use of undeclared identifier 'x'\n" +
+				"    x = 1;\n" +
+				"    ^\n" +
+				"<kernel>:7:1: warning: no previous prototype for function 'helper'\r\n",
+			expected: []cl.Diagnostic{
+				{File: "<kernel>", Line: 3, Column: 5, Severity: cl.DiagnosticError, Message: "use of undeclared identifier 'x'"},
+				{File: "<kernel>", Line: 7, Column: 1, Severity: cl.DiagnosticWarning, Message: "no previous prototype for function 'helper'"},
+			},
+		},
+		{
+			name: "clang fatal without column",
+			log:  "/tmp/source.cl:12: fatal error: 'missing.h' file not found",
+			expected: []cl.Diagnostic{
+				{File: "/tmp/source.cl", Line: 12, Severity: cl.DiagnosticError, Message: "'missing.h' file not found"},
+			},
+		},
+		{
+			name: "edg",
+			log:  "\"/tmp/OCL123.cl\", line 4: error: identifier \"y\" is undefined\n  y = 2;\n",
+			expected: []cl.Diagnostic{
+				{File: "/tmp/OCL123.cl", Line: 4, Severity: cl.DiagnosticError, Message: "identifier \"y\" is undefined"},
+			},
+		},
+		{
+			name:     "no diagnostics",
+			log:      "Build succeeded.\n",
+			expected: nil,
+		},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			diagnostics := cl.ParseBuildLog(tc.log)
+			if !reflect.DeepEqual(diagnostics, tc.expected) {
+				t.Errorf("unexpected diagnostics: %#v", diagnostics)
+			}
+		})
+	}
+}
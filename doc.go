@@ -6,6 +6,19 @@
 // To build and work with this library, you need an OpenCL SDK installed on your system.
 // Refer to the documentation on opencl-go (https://opencl-go.github.com) on how to do this.
 //
+// Alternatively, build with the tag "cl30_dynamic" to load the OpenCL library at runtime instead.
+// This dynamic loading mode requires only the OpenCL headers at build time, and no library at link time.
+// Applications then have to call Initialize() before any other function of the package.
+// IsAvailable() reports whether the library was loaded.
+//
+// For tests without an OpenCL implementation, build with the tag "cl30_mock". This replaces the OpenCL library with
+// an in-memory mock driver that simulates platforms, devices, buffers, and the completion of events.
+// See SetMockPlatforms() for details.
+//
+// Memory that was allocated outside OpenCL, such as dma-buf file descriptors, can be imported with WithDmaBuf()
+// or ExtensionImportMemoryArm. Exporting memory objects, for example as dma-buf, is not supported: the extensions
+// for dma-buf sharing are import-only, and OpenCL provides no standard way to export a memory object.
+//
 // The API requires knowledge of the OpenCL API. While the wrapper hides some low-level C-API details,
 // there is still heavy use of `unsafe.Pointer` and the potential for memory access-violations if used wrong.
 //
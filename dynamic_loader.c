@@ -0,0 +1,466 @@
+//go:build cl30_dynamic || cl30_mock
+
+#include "dynamic_loader.h"
+
+#ifndef CL30_MOCK_DRIVER
+#ifdef _WIN32
+#include <windows.h>
+#else
+#include <dlfcn.h>
+#endif
+#endif
+
+// This file provides the OpenCL API functions for the dynamic loading mode and the mock driver.
+// Each function forwards to the symbol resolved from the loaded library, or provided by the mock driver.
+// In case no functions are resolved, the functions report CL_PLATFORM_NOT_FOUND_KHR, as if no platform was installed.
+// In case the functions are resolved, yet the requested one is not provided, they report CL_INVALID_OPERATION.
+
+#define CL30_PLATFORM_NOT_FOUND_KHR (-1001)
+
+static void *cl30Library = NULL;
+
+static cl_int cl30MissingStatus(void)
+{
+    return (cl30Library == NULL) ? CL30_PLATFORM_NOT_FOUND_KHR : CL_INVALID_OPERATION;
+}
+
+static void cl30SetMissingStatus(cl_int *errcodeReturn)
+{
+    if (errcodeReturn != NULL)
+    {
+        *errcodeReturn = cl30MissingStatus();
+    }
+}
+
+typedef void (CL_CALLBACK *cl30ContextNotify_fn)(const char *errinfo, const void *privateInfo, size_t cb, void *userData);
+typedef void (CL_CALLBACK *cl30ContextDestructor_fn)(cl_context context, void *userData);
+typedef void (CL_CALLBACK *cl30MemObjectDestructor_fn)(cl_mem memobj, void *userData);
+typedef void (CL_CALLBACK *cl30ProgramNotify_fn)(cl_program program, void *userData);
+typedef void (CL_CALLBACK *cl30EventNotify_fn)(cl_event event, cl_int commandStatus, void *userData);
+typedef void (CL_CALLBACK *cl30NativeKernel_fn)(void *args);
+typedef void (CL_CALLBACK *cl30SvmFreeNotify_fn)(cl_command_queue commandQueue, cl_uint numSvmPointers,
+    void *svmPointers[], void *userData);
+
+#define CL30_WAIT_LIST_PARAMS cl_uint numEventsInWaitList, const cl_event *eventWaitList, cl_event *event
+#define CL30_WAIT_LIST_ARGS numEventsInWaitList, eventWaitList, event
+
+// CL30_STATUS_FUNCTIONS lists all functions that return a status code.
+#define CL30_STATUS_FUNCTIONS(F) \
+    F(clGetPlatformIDs, (cl_uint numEntries, cl_platform_id *platforms, cl_uint *numPlatforms), \
+        (numEntries, platforms, numPlatforms)) \
+    F(clGetPlatformInfo, (cl_platform_id platform, cl_platform_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (platform, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clUnloadPlatformCompiler, (cl_platform_id platform), (platform)) \
+    F(clGetDeviceIDs, (cl_platform_id platform, cl_device_type deviceType, cl_uint numEntries, \
+        cl_device_id *devices, cl_uint *numDevices), \
+        (platform, deviceType, numEntries, devices, numDevices)) \
+    F(clGetDeviceInfo, (cl_device_id device, cl_device_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (device, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clCreateSubDevices, (cl_device_id inDevice, const cl_device_partition_property *properties, \
+        cl_uint numDevices, cl_device_id *outDevices, cl_uint *numDevicesReturn), \
+        (inDevice, properties, numDevices, outDevices, numDevicesReturn)) \
+    F(clRetainDevice, (cl_device_id device), (device)) \
+    F(clReleaseDevice, (cl_device_id device), (device)) \
+    F(clGetDeviceAndHostTimer, (cl_device_id device, cl_ulong *deviceTimestamp, cl_ulong *hostTimestamp), \
+        (device, deviceTimestamp, hostTimestamp)) \
+    F(clGetHostTimer, (cl_device_id device, cl_ulong *hostTimestamp), (device, hostTimestamp)) \
+    F(clRetainContext, (cl_context context), (context)) \
+    F(clReleaseContext, (cl_context context), (context)) \
+    F(clGetContextInfo, (cl_context context, cl_context_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (context, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clSetContextDestructorCallback, (cl_context context, cl30ContextDestructor_fn notify, void *userData), \
+        (context, notify, userData)) \
+    F(clSetDefaultDeviceCommandQueue, (cl_context context, cl_device_id device, cl_command_queue commandQueue), \
+        (context, device, commandQueue)) \
+    F(clRetainCommandQueue, (cl_command_queue commandQueue), (commandQueue)) \
+    F(clReleaseCommandQueue, (cl_command_queue commandQueue), (commandQueue)) \
+    F(clGetCommandQueueInfo, (cl_command_queue commandQueue, cl_command_queue_info paramName, \
+        size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn), \
+        (commandQueue, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clSetCommandQueueProperty, (cl_command_queue commandQueue, cl_command_queue_properties properties, \
+        cl_bool enable, cl_command_queue_properties *oldProperties), \
+        (commandQueue, properties, enable, oldProperties)) \
+    F(clFlush, (cl_command_queue commandQueue), (commandQueue)) \
+    F(clFinish, (cl_command_queue commandQueue), (commandQueue)) \
+    F(clRetainMemObject, (cl_mem memobj), (memobj)) \
+    F(clReleaseMemObject, (cl_mem memobj), (memobj)) \
+    F(clGetSupportedImageFormats, (cl_context context, cl_mem_flags flags, cl_mem_object_type imageType, \
+        cl_uint numEntries, cl_image_format *imageFormats, cl_uint *numImageFormats), \
+        (context, flags, imageType, numEntries, imageFormats, numImageFormats)) \
+    F(clGetMemObjectInfo, (cl_mem memobj, cl_mem_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (memobj, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clGetImageInfo, (cl_mem image, cl_image_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (image, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clGetPipeInfo, (cl_mem pipe, cl_pipe_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (pipe, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clSetMemObjectDestructorCallback, (cl_mem memobj, cl30MemObjectDestructor_fn notify, void *userData), \
+        (memobj, notify, userData)) \
+    F(clRetainSampler, (cl_sampler sampler), (sampler)) \
+    F(clReleaseSampler, (cl_sampler sampler), (sampler)) \
+    F(clGetSamplerInfo, (cl_sampler sampler, cl_sampler_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (sampler, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clRetainProgram, (cl_program program), (program)) \
+    F(clReleaseProgram, (cl_program program), (program)) \
+    F(clBuildProgram, (cl_program program, cl_uint numDevices, const cl_device_id *deviceList, \
+        const char *options, cl30ProgramNotify_fn notify, void *userData), \
+        (program, numDevices, deviceList, options, notify, userData)) \
+    F(clCompileProgram, (cl_program program, cl_uint numDevices, const cl_device_id *deviceList, \
+        const char *options, cl_uint numInputHeaders, const cl_program *inputHeaders, \
+        const char **headerIncludeNames, cl30ProgramNotify_fn notify, void *userData), \
+        (program, numDevices, deviceList, options, numInputHeaders, inputHeaders, headerIncludeNames, \
+        notify, userData)) \
+    F(clSetProgramReleaseCallback, (cl_program program, cl30ProgramNotify_fn notify, void *userData), \
+        (program, notify, userData)) \
+    F(clSetProgramSpecializationConstant, (cl_program program, cl_uint specId, size_t specSize, \
+        const void *specValue), \
+        (program, specId, specSize, specValue)) \
+    F(clGetProgramInfo, (cl_program program, cl_program_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (program, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clGetProgramBuildInfo, (cl_program program, cl_device_id device, cl_program_build_info paramName, \
+        size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn), \
+        (program, device, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clCreateKernelsInProgram, (cl_program program, cl_uint numKernels, cl_kernel *kernels, \
+        cl_uint *numKernelsReturn), \
+        (program, numKernels, kernels, numKernelsReturn)) \
+    F(clRetainKernel, (cl_kernel kernel), (kernel)) \
+    F(clReleaseKernel, (cl_kernel kernel), (kernel)) \
+    F(clSetKernelArg, (cl_kernel kernel, cl_uint argIndex, size_t argSize, const void *argValue), \
+        (kernel, argIndex, argSize, argValue)) \
+    F(clSetKernelArgSVMPointer, (cl_kernel kernel, cl_uint argIndex, const void *argValue), \
+        (kernel, argIndex, argValue)) \
+    F(clSetKernelExecInfo, (cl_kernel kernel, cl_kernel_exec_info paramName, size_t paramValueSize, \
+        const void *paramValue), \
+        (kernel, paramName, paramValueSize, paramValue)) \
+    F(clGetKernelInfo, (cl_kernel kernel, cl_kernel_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (kernel, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clGetKernelArgInfo, (cl_kernel kernel, cl_uint argIndex, cl_kernel_arg_info paramName, \
+        size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn), \
+        (kernel, argIndex, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clGetKernelWorkGroupInfo, (cl_kernel kernel, cl_device_id device, cl_kernel_work_group_info paramName, \
+        size_t paramValueSize, void *paramValue, size_t *paramValueSizeReturn), \
+        (kernel, device, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clGetKernelSubGroupInfo, (cl_kernel kernel, cl_device_id device, cl_kernel_sub_group_info paramName, \
+        size_t inputValueSize, const void *inputValue, size_t paramValueSize, void *paramValue, \
+        size_t *paramValueSizeReturn), \
+        (kernel, device, paramName, inputValueSize, inputValue, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clWaitForEvents, (cl_uint numEvents, const cl_event *eventList), (numEvents, eventList)) \
+    F(clGetEventInfo, (cl_event event, cl_event_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (event, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clRetainEvent, (cl_event event), (event)) \
+    F(clReleaseEvent, (cl_event event), (event)) \
+    F(clSetUserEventStatus, (cl_event event, cl_int executionStatus), (event, executionStatus)) \
+    F(clSetEventCallback, (cl_event event, cl_int commandExecCallbackType, cl30EventNotify_fn notify, \
+        void *userData), \
+        (event, commandExecCallbackType, notify, userData)) \
+    F(clGetEventProfilingInfo, (cl_event event, cl_profiling_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (event, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clEnqueueReadBuffer, (cl_command_queue commandQueue, cl_mem buffer, cl_bool blockingRead, \
+        size_t offset, size_t size, void *ptr, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, buffer, blockingRead, offset, size, ptr, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueReadBufferRect, (cl_command_queue commandQueue, cl_mem buffer, cl_bool blockingRead, \
+        const size_t *bufferOrigin, const size_t *hostOrigin, const size_t *region, \
+        size_t bufferRowPitch, size_t bufferSlicePitch, size_t hostRowPitch, size_t hostSlicePitch, \
+        void *ptr, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, buffer, blockingRead, bufferOrigin, hostOrigin, region, \
+        bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch, ptr, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueWriteBuffer, (cl_command_queue commandQueue, cl_mem buffer, cl_bool blockingWrite, \
+        size_t offset, size_t size, const void *ptr, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, buffer, blockingWrite, offset, size, ptr, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueWriteBufferRect, (cl_command_queue commandQueue, cl_mem buffer, cl_bool blockingWrite, \
+        const size_t *bufferOrigin, const size_t *hostOrigin, const size_t *region, \
+        size_t bufferRowPitch, size_t bufferSlicePitch, size_t hostRowPitch, size_t hostSlicePitch, \
+        const void *ptr, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, buffer, blockingWrite, bufferOrigin, hostOrigin, region, \
+        bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch, ptr, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueFillBuffer, (cl_command_queue commandQueue, cl_mem buffer, const void *pattern, \
+        size_t patternSize, size_t offset, size_t size, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, buffer, pattern, patternSize, offset, size, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueCopyBuffer, (cl_command_queue commandQueue, cl_mem srcBuffer, cl_mem dstBuffer, \
+        size_t srcOffset, size_t dstOffset, size_t size, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, srcBuffer, dstBuffer, srcOffset, dstOffset, size, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueCopyBufferRect, (cl_command_queue commandQueue, cl_mem srcBuffer, cl_mem dstBuffer, \
+        const size_t *srcOrigin, const size_t *dstOrigin, const size_t *region, \
+        size_t srcRowPitch, size_t srcSlicePitch, size_t dstRowPitch, size_t dstSlicePitch, \
+        CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, srcBuffer, dstBuffer, srcOrigin, dstOrigin, region, \
+        srcRowPitch, srcSlicePitch, dstRowPitch, dstSlicePitch, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueReadImage, (cl_command_queue commandQueue, cl_mem image, cl_bool blockingRead, \
+        const size_t *origin, const size_t *region, size_t rowPitch, size_t slicePitch, void *ptr, \
+        CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, image, blockingRead, origin, region, rowPitch, slicePitch, ptr, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueWriteImage, (cl_command_queue commandQueue, cl_mem image, cl_bool blockingWrite, \
+        const size_t *origin, const size_t *region, size_t inputRowPitch, size_t inputSlicePitch, \
+        const void *ptr, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, image, blockingWrite, origin, region, inputRowPitch, inputSlicePitch, ptr, \
+        CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueFillImage, (cl_command_queue commandQueue, cl_mem image, const void *fillColor, \
+        const size_t *origin, const size_t *region, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, image, fillColor, origin, region, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueCopyImage, (cl_command_queue commandQueue, cl_mem srcImage, cl_mem dstImage, \
+        const size_t *srcOrigin, const size_t *dstOrigin, const size_t *region, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, srcImage, dstImage, srcOrigin, dstOrigin, region, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueCopyImageToBuffer, (cl_command_queue commandQueue, cl_mem srcImage, cl_mem dstBuffer, \
+        const size_t *srcOrigin, const size_t *region, size_t dstOffset, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, srcImage, dstBuffer, srcOrigin, region, dstOffset, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueCopyBufferToImage, (cl_command_queue commandQueue, cl_mem srcBuffer, cl_mem dstImage, \
+        size_t srcOffset, const size_t *dstOrigin, const size_t *region, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, srcBuffer, dstImage, srcOffset, dstOrigin, region, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueUnmapMemObject, (cl_command_queue commandQueue, cl_mem memobj, void *mappedPtr, \
+        CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, memobj, mappedPtr, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueMigrateMemObjects, (cl_command_queue commandQueue, cl_uint numMemObjects, \
+        const cl_mem *memObjects, cl_mem_migration_flags flags, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, numMemObjects, memObjects, flags, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueNDRangeKernel, (cl_command_queue commandQueue, cl_kernel kernel, cl_uint workDim, \
+        const size_t *globalWorkOffset, const size_t *globalWorkSize, const size_t *localWorkSize, \
+        CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, kernel, workDim, globalWorkOffset, globalWorkSize, localWorkSize, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueTask, (cl_command_queue commandQueue, cl_kernel kernel, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, kernel, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueNativeKernel, (cl_command_queue commandQueue, cl30NativeKernel_fn userFunc, void *args, \
+        size_t cbArgs, cl_uint numMemObjects, const cl_mem *memList, const void **argsMemLoc, \
+        CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, userFunc, args, cbArgs, numMemObjects, memList, argsMemLoc, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueMarkerWithWaitList, (cl_command_queue commandQueue, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueBarrierWithWaitList, (cl_command_queue commandQueue, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueSVMFree, (cl_command_queue commandQueue, cl_uint numSvmPointers, void *svmPointers[], \
+        cl30SvmFreeNotify_fn notify, void *userData, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, numSvmPointers, svmPointers, notify, userData, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueSVMMemcpy, (cl_command_queue commandQueue, cl_bool blockingCopy, void *dstPtr, \
+        const void *srcPtr, size_t size, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, blockingCopy, dstPtr, srcPtr, size, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueSVMMemFill, (cl_command_queue commandQueue, void *svmPtr, const void *pattern, \
+        size_t patternSize, size_t size, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, svmPtr, pattern, patternSize, size, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueSVMMap, (cl_command_queue commandQueue, cl_bool blockingMap, cl_map_flags flags, \
+        void *svmPtr, size_t size, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, blockingMap, flags, svmPtr, size, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueSVMUnmap, (cl_command_queue commandQueue, void *svmPtr, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, svmPtr, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueSVMMigrateMem, (cl_command_queue commandQueue, cl_uint numSvmPointers, \
+        const void **svmPointers, const size_t *sizes, cl_mem_migration_flags flags, CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, numSvmPointers, svmPointers, sizes, flags, CL30_WAIT_LIST_ARGS)) \
+    F(clGetGLObjectInfo, (cl_mem memobj, cl_gl_object_type *glObjectType, cl_GLuint *glObjectName), \
+        (memobj, glObjectType, glObjectName)) \
+    F(clGetGLTextureInfo, (cl_mem memobj, cl_gl_texture_info paramName, size_t paramValueSize, \
+        void *paramValue, size_t *paramValueSizeReturn), \
+        (memobj, paramName, paramValueSize, paramValue, paramValueSizeReturn)) \
+    F(clEnqueueAcquireGLObjects, (cl_command_queue commandQueue, cl_uint numObjects, const cl_mem *memObjects, \
+        CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, numObjects, memObjects, CL30_WAIT_LIST_ARGS)) \
+    F(clEnqueueReleaseGLObjects, (cl_command_queue commandQueue, cl_uint numObjects, const cl_mem *memObjects, \
+        CL30_WAIT_LIST_PARAMS), \
+        (commandQueue, numObjects, memObjects, CL30_WAIT_LIST_ARGS))
+
+// CL30_ERRCODE_FUNCTIONS lists all functions that return a value and report their status via errcodeReturn.
+#define CL30_ERRCODE_FUNCTIONS(F) \
+    F(cl_context, clCreateContext, (const cl_context_properties *properties, cl_uint numDevices, \
+        const cl_device_id *devices, cl30ContextNotify_fn notify, void *userData, cl_int *errcodeReturn), \
+        (properties, numDevices, devices, notify, userData, errcodeReturn)) \
+    F(cl_context, clCreateContextFromType, (const cl_context_properties *properties, cl_device_type deviceType, \
+        cl30ContextNotify_fn notify, void *userData, cl_int *errcodeReturn), \
+        (properties, deviceType, notify, userData, errcodeReturn)) \
+    F(cl_command_queue, clCreateCommandQueue, (cl_context context, cl_device_id device, \
+        cl_command_queue_properties properties, cl_int *errcodeReturn), \
+        (context, device, properties, errcodeReturn)) \
+    F(cl_command_queue, clCreateCommandQueueWithProperties, (cl_context context, cl_device_id device, \
+        const cl_queue_properties *properties, cl_int *errcodeReturn), \
+        (context, device, properties, errcodeReturn)) \
+    F(cl_mem, clCreateBuffer, (cl_context context, cl_mem_flags flags, size_t size, void *hostPtr, \
+        cl_int *errcodeReturn), \
+        (context, flags, size, hostPtr, errcodeReturn)) \
+    F(cl_mem, clCreateBufferWithProperties, (cl_context context, const cl_mem_properties *properties, \
+        cl_mem_flags flags, size_t size, void *hostPtr, cl_int *errcodeReturn), \
+        (context, properties, flags, size, hostPtr, errcodeReturn)) \
+    F(cl_mem, clCreateSubBuffer, (cl_mem buffer, cl_mem_flags flags, cl_buffer_create_type bufferCreateType, \
+        const void *bufferCreateInfo, cl_int *errcodeReturn), \
+        (buffer, flags, bufferCreateType, bufferCreateInfo, errcodeReturn)) \
+    F(cl_mem, clCreateImage, (cl_context context, cl_mem_flags flags, const cl_image_format *imageFormat, \
+        const cl_image_desc *imageDesc, void *hostPtr, cl_int *errcodeReturn), \
+        (context, flags, imageFormat, imageDesc, hostPtr, errcodeReturn)) \
+    F(cl_mem, clCreateImageWithProperties, (cl_context context, const cl_mem_properties *properties, \
+        cl_mem_flags flags, const cl_image_format *imageFormat, const cl_image_desc *imageDesc, void *hostPtr, \
+        cl_int *errcodeReturn), \
+        (context, properties, flags, imageFormat, imageDesc, hostPtr, errcodeReturn)) \
+    F(cl_mem, clCreatePipe, (cl_context context, cl_mem_flags flags, cl_uint pipePacketSize, \
+        cl_uint pipeMaxPackets, const cl_pipe_properties *properties, cl_int *errcodeReturn), \
+        (context, flags, pipePacketSize, pipeMaxPackets, properties, errcodeReturn)) \
+    F(cl_mem, clCreateFromGLBuffer, (cl_context context, cl_mem_flags flags, cl_GLuint bufobj, \
+        cl_int *errcodeReturn), \
+        (context, flags, bufobj, errcodeReturn)) \
+    F(cl_mem, clCreateFromGLTexture, (cl_context context, cl_mem_flags flags, cl_GLenum target, \
+        cl_GLint miplevel, cl_GLuint texture, cl_int *errcodeReturn), \
+        (context, flags, target, miplevel, texture, errcodeReturn)) \
+    F(cl_mem, clCreateFromGLRenderbuffer, (cl_context context, cl_mem_flags flags, cl_GLuint renderbuffer, \
+        cl_int *errcodeReturn), \
+        (context, flags, renderbuffer, errcodeReturn)) \
+    F(cl_sampler, clCreateSampler, (cl_context context, cl_bool normalizedCoords, \
+        cl_addressing_mode addressingMode, cl_filter_mode filterMode, cl_int *errcodeReturn), \
+        (context, normalizedCoords, addressingMode, filterMode, errcodeReturn)) \
+    F(cl_sampler, clCreateSamplerWithProperties, (cl_context context, \
+        const cl_sampler_properties *samplerProperties, cl_int *errcodeReturn), \
+        (context, samplerProperties, errcodeReturn)) \
+    F(cl_program, clCreateProgramWithSource, (cl_context context, cl_uint count, const char **strings, \
+        const size_t *lengths, cl_int *errcodeReturn), \
+        (context, count, strings, lengths, errcodeReturn)) \
+    F(cl_program, clCreateProgramWithBinary, (cl_context context, cl_uint numDevices, \
+        const cl_device_id *deviceList, const size_t *lengths, const unsigned char **binaries, \
+        cl_int *binaryStatus, cl_int *errcodeReturn), \
+        (context, numDevices, deviceList, lengths, binaries, binaryStatus, errcodeReturn)) \
+    F(cl_program, clCreateProgramWithBuiltInKernels, (cl_context context, cl_uint numDevices, \
+        const cl_device_id *deviceList, const char *kernelNames, cl_int *errcodeReturn), \
+        (context, numDevices, deviceList, kernelNames, errcodeReturn)) \
+    F(cl_program, clCreateProgramWithIL, (cl_context context, const void *il, size_t length, \
+        cl_int *errcodeReturn), \
+        (context, il, length, errcodeReturn)) \
+    F(cl_program, clLinkProgram, (cl_context context, cl_uint numDevices, const cl_device_id *deviceList, \
+        const char *options, cl_uint numInputPrograms, const cl_program *inputPrograms, \
+        cl30ProgramNotify_fn notify, void *userData, cl_int *errcodeReturn), \
+        (context, numDevices, deviceList, options, numInputPrograms, inputPrograms, notify, userData, \
+        errcodeReturn)) \
+    F(cl_kernel, clCreateKernel, (cl_program program, const char *kernelName, cl_int *errcodeReturn), \
+        (program, kernelName, errcodeReturn)) \
+    F(cl_kernel, clCloneKernel, (cl_kernel sourceKernel, cl_int *errcodeReturn), \
+        (sourceKernel, errcodeReturn)) \
+    F(cl_event, clCreateUserEvent, (cl_context context, cl_int *errcodeReturn), \
+        (context, errcodeReturn)) \
+    F(void *, clEnqueueMapBuffer, (cl_command_queue commandQueue, cl_mem buffer, cl_bool blockingMap, \
+        cl_map_flags mapFlags, size_t offset, size_t size, CL30_WAIT_LIST_PARAMS, cl_int *errcodeReturn), \
+        (commandQueue, buffer, blockingMap, mapFlags, offset, size, CL30_WAIT_LIST_ARGS, errcodeReturn)) \
+    F(void *, clEnqueueMapImage, (cl_command_queue commandQueue, cl_mem image, cl_bool blockingMap, \
+        cl_map_flags mapFlags, const size_t *origin, const size_t *region, size_t *imageRowPitch, \
+        size_t *imageSlicePitch, CL30_WAIT_LIST_PARAMS, cl_int *errcodeReturn), \
+        (commandQueue, image, blockingMap, mapFlags, origin, region, imageRowPitch, imageSlicePitch, \
+        CL30_WAIT_LIST_ARGS, errcodeReturn))
+
+// CL30_POINTER_FUNCTIONS lists all functions that return a pointer, without reporting a status.
+#define CL30_POINTER_FUNCTIONS(F) \
+    F(clSVMAlloc, (cl_context context, cl_svm_mem_flags flags, size_t size, cl_uint alignment), \
+        (context, flags, size, alignment)) \
+    F(clGetExtensionFunctionAddressForPlatform, (cl_platform_id platform, const char *funcName), \
+        (platform, funcName))
+
+#define CL30_DEFINE_STATUS_FUNCTION(name, params, args) \
+    static cl_int (CL_API_CALL *name##_ptr) params = NULL; \
+    CL_API_ENTRY cl_int CL_API_CALL name params \
+    { \
+        if (name##_ptr == NULL) \
+        { \
+            return cl30MissingStatus(); \
+        } \
+        return name##_ptr args; \
+    }
+
+#define CL30_DEFINE_ERRCODE_FUNCTION(type, name, params, args) \
+    static type (CL_API_CALL *name##_ptr) params = NULL; \
+    CL_API_ENTRY type CL_API_CALL name params \
+    { \
+        if (name##_ptr == NULL) \
+        { \
+            cl30SetMissingStatus(errcodeReturn); \
+            return NULL; \
+        } \
+        return name##_ptr args; \
+    }
+
+#define CL30_DEFINE_POINTER_FUNCTION(name, params, args) \
+    static void *(CL_API_CALL *name##_ptr) params = NULL; \
+    CL_API_ENTRY void *CL_API_CALL name params \
+    { \
+        if (name##_ptr == NULL) \
+        { \
+            return NULL; \
+        } \
+        return name##_ptr args; \
+    }
+
+CL30_STATUS_FUNCTIONS(CL30_DEFINE_STATUS_FUNCTION)
+CL30_ERRCODE_FUNCTIONS(CL30_DEFINE_ERRCODE_FUNCTION)
+CL30_POINTER_FUNCTIONS(CL30_DEFINE_POINTER_FUNCTION)
+
+static void (CL_API_CALL *clSVMFree_ptr)(cl_context context, void *svmPointer) = NULL;
+CL_API_ENTRY void CL_API_CALL clSVMFree(cl_context context, void *svmPointer)
+{
+    if (clSVMFree_ptr != NULL)
+    {
+        clSVMFree_ptr(context, svmPointer);
+    }
+}
+
+#define CL30_RESOLVE_STATUS_FUNCTION(name, params, args) \
+    *((void **)&name##_ptr) = symbol(library, #name);
+#define CL30_RESOLVE_ERRCODE_FUNCTION(type, name, params, args) \
+    *((void **)&name##_ptr) = symbol(library, #name);
+#define CL30_RESOLVE_POINTER_FUNCTION(name, params, args) \
+    *((void **)&name##_ptr) = symbol(library, #name);
+
+void cl30ResolveFunctions(void *library, cl30Symbol_fn symbol)
+{
+    CL30_STATUS_FUNCTIONS(CL30_RESOLVE_STATUS_FUNCTION)
+    CL30_ERRCODE_FUNCTIONS(CL30_RESOLVE_ERRCODE_FUNCTION)
+    CL30_POINTER_FUNCTIONS(CL30_RESOLVE_POINTER_FUNCTION)
+    *((void **)&clSVMFree_ptr) = symbol(library, "clSVMFree");
+
+    cl30Library = library;
+}
+
+int cl30LibraryLoaded(void)
+{
+    return (cl30Library != NULL) ? 1 : 0;
+}
+
+#ifndef CL30_MOCK_DRIVER
+
+static void *cl30LibrarySymbol(void *library, char const *name)
+{
+#ifdef _WIN32
+    return (void *)GetProcAddress((HMODULE)library, name);
+#else
+    return dlsym(library, name);
+#endif
+}
+
+int cl30LoadLibrary(char const *name)
+{
+    void *library;
+
+    if (cl30Library != NULL)
+    {
+        return 1;
+    }
+#ifdef _WIN32
+    library = (void *)LoadLibraryA(name);
+#else
+    library = dlopen(name, RTLD_NOW | RTLD_LOCAL);
+#endif
+    if (library == NULL)
+    {
+        return 0;
+    }
+    if (cl30LibrarySymbol(library, "clGetPlatformIDs") == NULL)
+    {
+#ifdef _WIN32
+        FreeLibrary((HMODULE)library);
+#else
+        dlclose(library);
+#endif
+        return 0;
+    }
+    cl30ResolveFunctions(library, cl30LibrarySymbol);
+    return 1;
+}
+
+#endif
@@ -0,0 +1,16 @@
+#pragma once
+
+#include "api.h"
+#ifdef __APPLE__
+#include <OpenCL/cl_gl.h>
+#else
+#include <CL/cl_gl.h>
+#endif
+
+// cl30Symbol_fn resolves the named OpenCL function from the given library.
+typedef void *(*cl30Symbol_fn)(void *library, char const *name);
+
+// cl30ResolveFunctions resolves all OpenCL functions with the given symbol function.
+// Functions that can not be resolved report CL_INVALID_OPERATION when called.
+extern void cl30ResolveFunctions(void *library, cl30Symbol_fn symbol);
+extern int cl30LibraryLoaded(void);
@@ -0,0 +1,410 @@
+package cl30
+
+import (
+	"fmt"
+	"strings"
+)
+
+// This file contains the String() presentations of enumerations and bitfields. They use the names of the
+// constants, which helps to read logs and diagnostic output.
+
+// flagName associates a bit, or a combination of bits, with the name of its constant.
+type flagName[T ~uint32 | ~uint64] struct {
+	value T
+	name  string
+}
+
+// flagsString presents the value as the names of its bits, separated by "|", such as "DeviceTypeCPU|DeviceTypeGpu".
+// A value that equals an entry, such as a combination of bits, is presented with the name of that entry only.
+// Unknown bits are presented in hexadecimal.
+func flagsString[T ~uint32 | ~uint64](value T, names []flagName[T]) string {
+	for _, entry := range names {
+		if entry.value == value {
+			return entry.name
+		}
+	}
+	var parts []string
+	remaining := value
+	for _, entry := range names {
+		if (entry.value != 0) && ((remaining & entry.value) == entry.value) {
+			parts = append(parts, entry.name)
+			remaining &^= entry.value
+		}
+	}
+	if remaining != 0 {
+		parts = append(parts, fmt.Sprintf("0x%X", uint64(remaining)))
+	}
+	if len(parts) == 0 {
+		return "0"
+	}
+	return strings.Join(parts, "|")
+}
+
+// enumString presents the value with the name of its constant. Unknown values, such as those of extensions,
+// are presented with the type name and their numerical value.
+func enumString[T ~int32 | ~uint32](typeName string, value T, names map[T]string) string {
+	if name, known := names[value]; known {
+		return name
+	}
+	if value < 0 {
+		return fmt.Sprintf("%s(%d)", typeName, int64(value))
+	}
+	return fmt.Sprintf("%s(0x%04X)", typeName, int64(value))
+}
+
+var deviceTypeFlagsNames = []flagName[DeviceTypeFlags]{
+	{DeviceTypeCPU, "DeviceTypeCPU"},
+	{DeviceTypeDefault, "DeviceTypeDefault"},
+	{DeviceTypeGpu, "DeviceTypeGpu"},
+	{DeviceTypeAccelerator, "DeviceTypeAccelerator"},
+	{DeviceTypeCustom, "DeviceTypeCustom"},
+	{DeviceTypeAll, "DeviceTypeAll"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags DeviceTypeFlags) String() string {
+	return flagsString(flags, deviceTypeFlagsNames)
+}
+
+var deviceFpConfigFlagsNames = []flagName[DeviceFpConfigFlags]{
+	{FpDenorm, "FpDenorm"},
+	{FpInfNan, "FpInfNan"},
+	{FpRoundToNearest, "FpRoundToNearest"},
+	{FpRoundToZero, "FpRoundToZero"},
+	{FpRoundToInf, "FpRoundToInf"},
+	{FpFma, "FpFma"},
+	{FpSoftFloat, "FpSoftFloat"},
+	{FpCorrectlyRoundedDivideSqrt, "FpCorrectlyRoundedDivideSqrt"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags DeviceFpConfigFlags) String() string {
+	return flagsString(flags, deviceFpConfigFlagsNames)
+}
+
+var commandQueuePropertiesFlagsNames = []flagName[CommandQueuePropertiesFlags]{
+	{QueueOutOfOrderExecModeEnable, "QueueOutOfOrderExecModeEnable"},
+	{QueueProfilingEnable, "QueueProfilingEnable"},
+	{QueueOnDevice, "QueueOnDevice"},
+	{QueueOnDeviceDefault, "QueueOnDeviceDefault"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags CommandQueuePropertiesFlags) String() string {
+	return flagsString(flags, commandQueuePropertiesFlagsNames)
+}
+
+var memFlagsNames = []flagName[MemFlags]{
+	{MemReadWriteFlag, "MemReadWriteFlag"},
+	{MemWriteOnlyFlag, "MemWriteOnlyFlag"},
+	{MemReadOnlyFlag, "MemReadOnlyFlag"},
+	{MemUseHostPtrFlag, "MemUseHostPtrFlag"},
+	{MemAllocHostPtrFlag, "MemAllocHostPtrFlag"},
+	{MemCopyHostPtrFlag, "MemCopyHostPtrFlag"},
+	{MemHostWriteOnlyFlag, "MemHostWriteOnlyFlag"},
+	{MemHostReadOnlyFlag, "MemHostReadOnlyFlag"},
+	{MemHostNoAccessFlag, "MemHostNoAccessFlag"},
+	{MemSvmFineGrainBufferFlag, "MemSvmFineGrainBufferFlag"},
+	{MemSvmAtomicsFlag, "MemSvmAtomicsFlag"},
+	{MemKernelReadAndWriteFlag, "MemKernelReadAndWriteFlag"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags MemFlags) String() string {
+	return flagsString(flags, memFlagsNames)
+}
+
+var svmMemFlagsNames = []flagName[SvmMemFlags]{
+	{MemReadWriteFlag, "MemReadWriteFlag"},
+	{MemWriteOnlyFlag, "MemWriteOnlyFlag"},
+	{MemReadOnlyFlag, "MemReadOnlyFlag"},
+	{MemSvmFineGrainBufferFlag, "MemSvmFineGrainBufferFlag"},
+	{MemSvmAtomicsFlag, "MemSvmAtomicsFlag"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags SvmMemFlags) String() string {
+	return flagsString(flags, svmMemFlagsNames)
+}
+
+var mapFlagsNames = []flagName[MapFlags]{
+	{MapRead, "MapRead"},
+	{MapWrite, "MapWrite"},
+	{MapWriteInvalidateRegion, "MapWriteInvalidateRegion"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags MapFlags) String() string {
+	return flagsString(flags, mapFlagsNames)
+}
+
+var memMigrationFlagsNames = []flagName[MemMigrationFlags]{
+	{MigrateMemObjectHost, "MigrateMemObjectHost"},
+	{MigrateMemObjectContentUndefined, "MigrateMemObjectContentUndefined"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags MemMigrationFlags) String() string {
+	return flagsString(flags, memMigrationFlagsNames)
+}
+
+var deviceSvmCapabilitiesFlagsNames = []flagName[DeviceSvmCapabilitiesFlags]{
+	{DeviceSvmCoarseGrainBuffer, "DeviceSvmCoarseGrainBuffer"},
+	{DeviceSvmFineGrainBuffer, "DeviceSvmFineGrainBuffer"},
+	{DeviceSvmFineGrainSystem, "DeviceSvmFineGrainSystem"},
+	{DeviceSvmAtomics, "DeviceSvmAtomics"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags DeviceSvmCapabilitiesFlags) String() string {
+	return flagsString(flags, deviceSvmCapabilitiesFlagsNames)
+}
+
+var deviceAtomicCapabilitiesFlagsNames = []flagName[DeviceAtomicCapabilitiesFlags]{
+	{DeviceAtomicOrderRelaxed, "DeviceAtomicOrderRelaxed"},
+	{DeviceAtomicOrderAcqRel, "DeviceAtomicOrderAcqRel"},
+	{DeviceAtomicOrderSeqCst, "DeviceAtomicOrderSeqCst"},
+	{DeviceAtomicScopeWorkItem, "DeviceAtomicScopeWorkItem"},
+	{DeviceAtomicScopeWorkGroup, "DeviceAtomicScopeWorkGroup"},
+	{DeviceAtomicScopeDevice, "DeviceAtomicScopeDevice"},
+	{DeviceAtomicScopeAllDevices, "DeviceAtomicScopeAllDevices"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags DeviceAtomicCapabilitiesFlags) String() string {
+	return flagsString(flags, deviceAtomicCapabilitiesFlagsNames)
+}
+
+var deviceDeviceEnqueueCapabilitiesFlagsNames = []flagName[DeviceDeviceEnqueueCapabilitiesFlags]{
+	{DeviceQueueSupported, "DeviceQueueSupported"},
+	{DeviceQueueReplaceableDefault, "DeviceQueueReplaceableDefault"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags DeviceDeviceEnqueueCapabilitiesFlags) String() string {
+	return flagsString(flags, deviceDeviceEnqueueCapabilitiesFlagsNames)
+}
+
+var deviceExecCapabilitiesFlagsNames = []flagName[DeviceExecCapabilitiesFlags]{
+	{ExecKernel, "ExecKernel"},
+	{ExecNativeKernel, "ExecNativeKernel"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags DeviceExecCapabilitiesFlags) String() string {
+	return flagsString(flags, deviceExecCapabilitiesFlagsNames)
+}
+
+var deviceAffinityDomainFlagsNames = []flagName[DeviceAffinityDomainFlags]{
+	{DeviceAffinityDomainNuma, "DeviceAffinityDomainNuma"},
+	{DeviceAffinityDomainL4Cache, "DeviceAffinityDomainL4Cache"},
+	{DeviceAffinityDomainL3Cache, "DeviceAffinityDomainL3Cache"},
+	{DeviceAffinityDomainL2Cache, "DeviceAffinityDomainL2Cache"},
+	{DeviceAffinityDomainL1Cache, "DeviceAffinityDomainL1Cache"},
+	{DeviceAffinityDomainNextPartitionable, "DeviceAffinityDomainNextPartitionable"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (flags DeviceAffinityDomainFlags) String() string {
+	return flagsString(flags, deviceAffinityDomainFlagsNames)
+}
+
+var kernelArgTypeQualifierNames = []flagName[KernelArgTypeQualifier]{
+	{KernelArgTypeNone, "KernelArgTypeNone"},
+	{KernelArgTypeConst, "KernelArgTypeConst"},
+	{KernelArgTypeRestrict, "KernelArgTypeRestrict"},
+	{KernelArgTypeVolatile, "KernelArgTypeVolatile"},
+	{KernelArgTypePipe, "KernelArgTypePipe"},
+}
+
+// String returns the names of the set flags, separated by "|".
+func (qualifier KernelArgTypeQualifier) String() string {
+	return flagsString(qualifier, kernelArgTypeQualifierNames)
+}
+
+var channelOrderNames = map[ChannelOrder]string{
+	ChannelOrderR:         "ChannelOrderR",
+	ChannelOrderA:         "ChannelOrderA",
+	ChannelOrderRg:        "ChannelOrderRg",
+	ChannelOrderRa:        "ChannelOrderRa",
+	ChannelOrderRgb:       "ChannelOrderRgb",
+	ChannelOrderRgba:      "ChannelOrderRgba",
+	ChannelOrderBgra:      "ChannelOrderBgra",
+	ChannelOrderArgb:      "ChannelOrderArgb",
+	ChannelOrderIntensity: "ChannelOrderIntensity",
+	ChannelOrderLuminance: "ChannelOrderLuminance",
+	ChannelOrderRx:        "ChannelOrderRx",
+	ChannelOrderRgx:       "ChannelOrderRgx",
+	ChannelOrderRgbx:      "ChannelOrderRgbx",
+	ChannelOrderDepth:     "ChannelOrderDepth",
+	ChannelOrderStencil:   "ChannelOrderStencil",
+	ChannelOrderSrgb:      "ChannelOrderSrgb",
+	ChannelOrderSrgbx:     "ChannelOrderSrgbx",
+	ChannelOrderSrgba:     "ChannelOrderSrgba",
+	ChannelOrderSbgra:     "ChannelOrderSbgra",
+	ChannelOrderAbgr:      "ChannelOrderAbgr",
+}
+
+// String returns the name of the constant, such as "ChannelOrderR".
+func (order ChannelOrder) String() string {
+	return enumString("ChannelOrder", order, channelOrderNames)
+}
+
+var channelTypeNames = map[ChannelType]string{
+	ChannelTypeSnormInt8:       "ChannelTypeSnormInt8",
+	ChannelTypeSnormInt16:      "ChannelTypeSnormInt16",
+	ChannelTypeUnormInt8:       "ChannelTypeUnormInt8",
+	ChannelTypeUnormInt16:      "ChannelTypeUnormInt16",
+	ChannelTypeUnormShort565:   "ChannelTypeUnormShort565",
+	ChannelTypeUnormShort555:   "ChannelTypeUnormShort555",
+	ChannelTypeUnormInt101010:  "ChannelTypeUnormInt101010",
+	ChannelTypeSignedInt8:      "ChannelTypeSignedInt8",
+	ChannelTypeSignedInt16:     "ChannelTypeSignedInt16",
+	ChannelTypeSignedInt32:     "ChannelTypeSignedInt32",
+	ChannelTypeUnsignedInt8:    "ChannelTypeUnsignedInt8",
+	ChannelTypeUnsignedInt16:   "ChannelTypeUnsignedInt16",
+	ChannelTypeUnsignedInt32:   "ChannelTypeUnsignedInt32",
+	ChannelTypeHalfFloat:       "ChannelTypeHalfFloat",
+	ChannelTypeFloat:           "ChannelTypeFloat",
+	ChannelTypeUnormInt24:      "ChannelTypeUnormInt24",
+	ChannelTypeUnormInt1010102: "ChannelTypeUnormInt1010102",
+}
+
+// String returns the name of the constant, such as "ChannelTypeSnormInt8".
+func (channelType ChannelType) String() string {
+	return enumString("ChannelType", channelType, channelTypeNames)
+}
+
+var memObjectTypeNames = map[MemObjectType]string{
+	MemObjectBufferType:        "MemObjectBufferType",
+	MemObjectImage2DType:       "MemObjectImage2DType",
+	MemObjectImage3DType:       "MemObjectImage3DType",
+	MemObjectImage2DArrayType:  "MemObjectImage2DArrayType",
+	MemObjectImage1DType:       "MemObjectImage1DType",
+	MemObjectImage1DArrayType:  "MemObjectImage1DArrayType",
+	MemObjectImage1DBufferType: "MemObjectImage1DBufferType",
+	MemObjectPipeType:          "MemObjectPipeType",
+}
+
+// String returns the name of the constant, such as "MemObjectBufferType".
+func (objectType MemObjectType) String() string {
+	return enumString("MemObjectType", objectType, memObjectTypeNames)
+}
+
+var bufferCreateTypeNames = map[BufferCreateType]string{
+	BufferCreateTypeRegion: "BufferCreateTypeRegion",
+}
+
+// String returns the name of the constant, such as "BufferCreateTypeRegion".
+func (createType BufferCreateType) String() string {
+	return enumString("BufferCreateType", createType, bufferCreateTypeNames)
+}
+
+var deviceMemCacheTypeEnumNames = map[DeviceMemCacheTypeEnum]string{
+	DeviceMemCacheNone:      "DeviceMemCacheNone",
+	DeviceMemCacheReadOnly:  "DeviceMemCacheReadOnly",
+	DeviceMemCacheReadWrite: "DeviceMemCacheReadWrite",
+}
+
+// String returns the name of the constant, such as "DeviceMemCacheNone".
+func (cacheType DeviceMemCacheTypeEnum) String() string {
+	return enumString("DeviceMemCacheTypeEnum", cacheType, deviceMemCacheTypeEnumNames)
+}
+
+var deviceLocalMemTypeEnumNames = map[DeviceLocalMemTypeEnum]string{
+	DeviceLocalMemTypeNone:   "DeviceLocalMemTypeNone",
+	DeviceLocalMemTypeLocal:  "DeviceLocalMemTypeLocal",
+	DeviceLocalMemTypeGlobal: "DeviceLocalMemTypeGlobal",
+}
+
+// String returns the name of the constant, such as "DeviceLocalMemTypeNone".
+func (memType DeviceLocalMemTypeEnum) String() string {
+	return enumString("DeviceLocalMemTypeEnum", memType, deviceLocalMemTypeEnumNames)
+}
+
+var buildStatusNames = map[BuildStatus]string{
+	BuildNoneStatus:       "BuildNoneStatus",
+	BuildSuccessStatus:    "BuildSuccessStatus",
+	BuildErrorStatus:      "BuildErrorStatus",
+	BuildInProgressStatus: "BuildInProgressStatus",
+}
+
+// String returns the name of the constant, such as "BuildNoneStatus".
+func (status BuildStatus) String() string {
+	return enumString("BuildStatus", status, buildStatusNames)
+}
+
+var programBinaryTypeNames = map[ProgramBinaryType]string{
+	ProgramBinaryTypeNone:           "ProgramBinaryTypeNone",
+	ProgramBinaryTypeCompiledObject: "ProgramBinaryTypeCompiledObject",
+	ProgramBinaryTypeLibrary:        "ProgramBinaryTypeLibrary",
+	ProgramBinaryTypeExecutable:     "ProgramBinaryTypeExecutable",
+}
+
+// String returns the name of the constant, such as "ProgramBinaryTypeNone".
+func (binaryType ProgramBinaryType) String() string {
+	return enumString("ProgramBinaryType", binaryType, programBinaryTypeNames)
+}
+
+var samplerAddressingModeNames = map[SamplerAddressingMode]string{
+	AddressNoneMode:           "AddressNoneMode",
+	AddressClampToEdgeMode:    "AddressClampToEdgeMode",
+	AddressClampMode:          "AddressClampMode",
+	AddressRepeatMode:         "AddressRepeatMode",
+	AddressMirroredRepeatMode: "AddressMirroredRepeatMode",
+}
+
+// String returns the name of the constant, such as "AddressNoneMode".
+func (mode SamplerAddressingMode) String() string {
+	return enumString("SamplerAddressingMode", mode, samplerAddressingModeNames)
+}
+
+var samplerFilterModeNames = map[SamplerFilterMode]string{
+	FilterNearestMode: "FilterNearestMode",
+	FilterLinearMode:  "FilterLinearMode",
+}
+
+// String returns the name of the constant, such as "FilterNearestMode".
+func (mode SamplerFilterMode) String() string {
+	return enumString("SamplerFilterMode", mode, samplerFilterModeNames)
+}
+
+var kernelArgAddressQualifierNames = map[KernelArgAddressQualifier]string{
+	KernelArgAddressGlobal:   "KernelArgAddressGlobal",
+	KernelArgAddressLocal:    "KernelArgAddressLocal",
+	KernelArgAddressConstant: "KernelArgAddressConstant",
+	KernelArgAddressPrivate:  "KernelArgAddressPrivate",
+}
+
+// String returns the name of the constant, such as "KernelArgAddressGlobal".
+func (qualifier KernelArgAddressQualifier) String() string {
+	return enumString("KernelArgAddressQualifier", qualifier, kernelArgAddressQualifierNames)
+}
+
+var kernelArgAccessQualifierNames = map[KernelArgAccessQualifier]string{
+	KernelArgAccessReadOnly:  "KernelArgAccessReadOnly",
+	KernelArgAccessWriteOnly: "KernelArgAccessWriteOnly",
+	KernelArgAccessReadWrite: "KernelArgAccessReadWrite",
+	KernelArgAccessNone:      "KernelArgAccessNone",
+}
+
+// String returns the name of the constant, such as "KernelArgAccessReadOnly".
+func (qualifier KernelArgAccessQualifier) String() string {
+	return enumString("KernelArgAccessQualifier", qualifier, kernelArgAccessQualifierNames)
+}
+
+var eventCommandExecutionStatusNames = map[EventCommandExecutionStatus]string{
+	EventCommandQueuedStatus:    "EventCommandQueuedStatus",
+	EventCommandSubmittedStatus: "EventCommandSubmittedStatus",
+	EventCommandRunningStatus:   "EventCommandRunningStatus",
+	EventCommandCompleteStatus:  "EventCommandCompleteStatus",
+}
+
+// String returns the name of the constant, such as "EventCommandCompleteStatus". Negative values, which indicate
+// that the command was terminated abnormally, are presented as the respective error.
+func (status EventCommandExecutionStatus) String() string {
+	if status < 0 {
+		return StatusError(status).Error()
+	}
+	return enumString("EventCommandExecutionStatus", status, eventCommandExecutionStatusNames)
+}
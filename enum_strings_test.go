@@ -0,0 +1,37 @@
+package cl30_test
+
+import (
+	"fmt"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestEnumStrings(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		value fmt.Stringer
+		want  string
+	}{
+		{value: cl.DeviceTypeGpu, want: "DeviceTypeGpu"},
+		{value: cl.DeviceTypeCPU | cl.DeviceTypeGpu, want: "DeviceTypeCPU|DeviceTypeGpu"},
+		{value: cl.DeviceTypeAll, want: "DeviceTypeAll"},
+		{value: cl.DeviceTypeFlags(0), want: "0"},
+		{value: cl.MemFlags(cl.MemReadOnlyFlag | cl.MemCopyHostPtrFlag), want: "MemReadOnlyFlag|MemCopyHostPtrFlag"},
+		{value: cl.CommandQueuePropertiesFlags(cl.QueueProfilingEnable | 0x100), want: "QueueProfilingEnable|0x100"},
+		{value: cl.BuildErrorStatus, want: "BuildErrorStatus"},
+		{value: cl.EventCommandCompleteStatus, want: "EventCommandCompleteStatus"},
+		{value: cl.EventCommandExecutionStatus(cl.ErrOutOfResources), want: cl.ErrOutOfResources.Error()},
+		{value: cl.ChannelOrderRgba, want: "ChannelOrderRgba"},
+		{value: cl.ChannelOrder(0x7FFF), want: "ChannelOrder(0x7FFF)"},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.want, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.value.String(); got != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
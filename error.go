@@ -7,11 +7,23 @@ import "fmt"
 // StatusError represents an error based on a status value from an OpenCL call.
 type StatusError C.cl_int
 
-// Error returns the string presentation of the numeric value.
-// A name lookup is not performed as errors can be extended through extensions, making a consistent presentation
-// difficult.
+// Error returns the symbolic name of the status, followed by its numeric value; for example
+// "CL_INVALID_KERNEL_ARGS (-52)". For values without a known name, only the numeric value is returned.
+// Errors can be extended through extensions, so an unknown value is not necessarily invalid.
+//
+// The error constants of this package, such as ErrInvalidValue, can be used with errors.Is() to identify a status.
 func (err StatusError) Error() string {
-	return fmt.Sprintf("%d", int(err))
+	name := err.Name()
+	if len(name) == 0 {
+		return fmt.Sprintf("%d", int(err))
+	}
+	return fmt.Sprintf("%s (%d)", name, int(err))
+}
+
+// Name returns the symbolic name of the status, as it is defined in the API, or an empty string if the value is
+// not known.
+func (err StatusError) Name() string {
+	return statusErrorNames[err]
 }
 
 // This block contains common error constants.
@@ -80,6 +92,78 @@ const (
 	ErrMaxSizeRestrictionExceeded         StatusError = C.CL_MAX_SIZE_RESTRICTION_EXCEEDED
 )
 
+// statusErrorNames maps the known status values to their symbolic names, including those of extensions.
+var statusErrorNames = map[StatusError]string{
+	ErrDeviceNotFound:                     "CL_DEVICE_NOT_FOUND",
+	ErrDeviceNotAvailable:                 "CL_DEVICE_NOT_AVAILABLE",
+	ErrCompilerNotAvailable:               "CL_COMPILER_NOT_AVAILABLE",
+	ErrMemObjectAllocationFailure:         "CL_MEM_OBJECT_ALLOCATION_FAILURE",
+	ErrOutOfResources:                     "CL_OUT_OF_RESOURCES",
+	ErrOutOfHostMemory:                    "CL_OUT_OF_HOST_MEMORY",
+	ErrProfilingInfoNotAvailable:          "CL_PROFILING_INFO_NOT_AVAILABLE",
+	ErrMemCopyOverlap:                     "CL_MEM_COPY_OVERLAP",
+	ErrImageFormatMismatch:                "CL_IMAGE_FORMAT_MISMATCH",
+	ErrImageFormatNotSupported:            "CL_IMAGE_FORMAT_NOT_SUPPORTED",
+	ErrBuildProgramFailure:                "CL_BUILD_PROGRAM_FAILURE",
+	ErrMapFailure:                         "CL_MAP_FAILURE",
+	ErrMisalignedSubBufferOffset:          "CL_MISALIGNED_SUB_BUFFER_OFFSET",
+	ErrExecStatusErrorForEventsInWaitList: "CL_EXEC_STATUS_ERROR_FOR_EVENTS_IN_WAIT_LIST",
+	ErrCompileProgramFailure:              "CL_COMPILE_PROGRAM_FAILURE",
+	ErrLinkerNotAvailable:                 "CL_LINKER_NOT_AVAILABLE",
+	ErrLinkProgramFailure:                 "CL_LINK_PROGRAM_FAILURE",
+	ErrDevicePartitionFailed:              "CL_DEVICE_PARTITION_FAILED",
+	ErrKernelArgInfoNotAvailable:          "CL_KERNEL_ARG_INFO_NOT_AVAILABLE",
+	ErrInvalidValue:                       "CL_INVALID_VALUE",
+	ErrInvalidDeviceType:                  "CL_INVALID_DEVICE_TYPE",
+	ErrInvalidPlatform:                    "CL_INVALID_PLATFORM",
+	ErrInvalidDevice:                      "CL_INVALID_DEVICE",
+	ErrInvalidContext:                     "CL_INVALID_CONTEXT",
+	ErrInvalidQueueProperties:             "CL_INVALID_QUEUE_PROPERTIES",
+	ErrInvalidCommandQueue:                "CL_INVALID_COMMAND_QUEUE",
+	ErrInvalidHostPtr:                     "CL_INVALID_HOST_PTR",
+	ErrInvalidMemObject:                   "CL_INVALID_MEM_OBJECT",
+	ErrINvalidImageFormatDescriptor:       "CL_INVALID_IMAGE_FORMAT_DESCRIPTOR",
+	ErrInvalidImageSize:                   "CL_INVALID_IMAGE_SIZE",
+	ErrInvalidSampler:                     "CL_INVALID_SAMPLER",
+	ErrInvalidBinary:                      "CL_INVALID_BINARY",
+	ErrInvalidBuildOptions:                "CL_INVALID_BUILD_OPTIONS",
+	ErrInvalidProgram:                     "CL_INVALID_PROGRAM",
+	ErrInvalidProgramExecutable:           "CL_INVALID_PROGRAM_EXECUTABLE",
+	ErrInvalidKernelName:                  "CL_INVALID_KERNEL_NAME",
+	ErrInvalidKernelDefinition:            "CL_INVALID_KERNEL_DEFINITION",
+	ErrInvalidKernel:                      "CL_INVALID_KERNEL",
+	ErrInvalidArgIndex:                    "CL_INVALID_ARG_INDEX",
+	ErrInvalidArgValue:                    "CL_INVALID_ARG_VALUE",
+	ErrInvalidArgSize:                     "CL_INVALID_ARG_SIZE",
+	ErrInvalidKernelArgs:                  "CL_INVALID_KERNEL_ARGS",
+	ErrInvalidWorkDimension:               "CL_INVALID_WORK_DIMENSION",
+	ErrInvalidWorkGroupSize:               "CL_INVALID_WORK_GROUP_SIZE",
+	ErrInvalidWorkItemSize:                "CL_INVALID_WORK_ITEM_SIZE",
+	ErrInvalidGlobalOffset:                "CL_INVALID_GLOBAL_OFFSET",
+	ErrInvalidEventWaitList:               "CL_INVALID_EVENT_WAIT_LIST",
+	ErrInvalidEvent:                       "CL_INVALID_EVENT",
+	ErrInvalidOperation:                   "CL_INVALID_OPERATION",
+	ErrInvalidGlObject:                    "CL_INVALID_GL_OBJECT",
+	ErrInvalidBufferSize:                  "CL_INVALID_BUFFER_SIZE",
+	ErrInvalidMipLevel:                    "CL_INVALID_MIP_LEVEL",
+	ErrInvalidGlobalWorkSize:              "CL_INVALID_GLOBAL_WORK_SIZE",
+	ErrInvalidProperty:                    "CL_INVALID_PROPERTY",
+	ErrInvalidImageDescriptor:             "CL_INVALID_IMAGE_DESCRIPTOR",
+	ErrInvalidCompilerOptions:             "CL_INVALID_COMPILER_OPTIONS",
+	ErrInvalidLinkerOptions:               "CL_INVALID_LINKER_OPTIONS",
+	ErrInvalidDevicePartitionCount:        "CL_INVALID_DEVICE_PARTITION_COUNT",
+	ErrInvalidPipeSize:                    "CL_INVALID_PIPE_SIZE",
+	ErrInvalidDeviceQueue:                 "CL_INVALID_DEVICE_QUEUE",
+	ErrInvalidSpecID:                      "CL_INVALID_SPEC_ID",
+	ErrMaxSizeRestrictionExceeded:         "CL_MAX_SIZE_RESTRICTION_EXCEEDED",
+	ErrInvalidCommandBufferKhr:            "CL_INVALID_COMMAND_BUFFER_KHR",
+	ErrInvalidSyncPointWaitListKhr:        "CL_INVALID_SYNC_POINT_WAIT_LIST_KHR",
+	ErrIncompatibleCommandQueueKhr:        "CL_INCOMPATIBLE_COMMAND_QUEUE_KHR",
+	ErrInvalidSemaphoreKhr:                "CL_INVALID_SEMAPHORE_KHR",
+	ErrContextTerminatedKhr:               "CL_CONTEXT_TERMINATED_KHR",
+	ErrPlatformNotFoundKhr:                "CL_PLATFORM_NOT_FOUND_KHR",
+}
+
 // WrapperError represents a basic error that occurs within the wrapper.
 type WrapperError string
 
@@ -98,4 +182,47 @@ const (
 	ErrDataSizeLimitExceeded WrapperError = "data size limit exceeded"
 	// ErrOutOfMemory is returned by wrapper functions that need to allocate memory.
 	ErrOutOfMemory WrapperError = "out of memory"
+	// ErrUnsupportedHandleType is returned by functions that accept generic handles, in case the provided
+	// value is not of a supported handle type.
+	ErrUnsupportedHandleType WrapperError = "unsupported handle type"
+	// ErrProgramIlMismatch is returned by VerifyProgramIl() in case the intermediate language of a program
+	// differs from the expected one.
+	ErrProgramIlMismatch WrapperError = "program IL mismatch"
+	// ErrBlockingCallInCallback is returned by blocking functions if they are called from within a callback of
+	// the OpenCL implementation. Such calls could otherwise deadlock the implementation.
+	ErrBlockingCallInCallback WrapperError = "blocking call in callback"
+	// ErrArenaBoundsExceeded is returned in case a region is not within the bounds of an Arena.
+	ErrArenaBoundsExceeded WrapperError = "arena bounds exceeded"
+	// ErrSchedulerClosed is returned for work items that are submitted to a Scheduler after it was closed.
+	ErrSchedulerClosed WrapperError = "scheduler closed"
+	// ErrSvmMapNotRequired is returned by SvmMapSlice() in case the SVM buffer is fine-grained,
+	// for which mapping is not necessary.
+	ErrSvmMapNotRequired WrapperError = "SVM map not required"
+	// ErrKernelSetClosed is returned for launches of a KernelSet after it was closed.
+	ErrKernelSetClosed WrapperError = "kernel set closed"
+	// ErrDeprecatedUse is returned by functions that use deprecated functionality, in case DeprecationFail is
+	// configured for the severity of the use. See SetDeprecationActions().
+	ErrDeprecatedUse WrapperError = "deprecated use"
+	// ErrUnsupportedKernelArgType is returned by functions that set kernel arguments from generic values, in case
+	// a value is not of a supported type.
+	ErrUnsupportedKernelArgType WrapperError = "unsupported kernel argument type"
+	// ErrLegacyCallNotAllowed is returned by SetCommandQueueProperty() in case the device is not an OpenCL 1.0
+	// device, and QuirkLegacyQueueProperties is not enabled.
+	ErrLegacyCallNotAllowed WrapperError = "legacy call not allowed"
+	// ErrBufferSizeNotMultiple is returned by EnqueueKernel() with GlobalFromBuffer(), in case the size of the buffer
+	// is not a multiple of the element size.
+	ErrBufferSizeNotMultiple WrapperError = "buffer size not a multiple of element size"
+	// ErrSvmRangeInvalid is returned by PrefetchSvm() in case a range is not within an allocation of SvmAlloc().
+	ErrSvmRangeInvalid WrapperError = "SVM range invalid"
+	// ErrLibraryNotAvailable is returned by Initialize() in case the OpenCL library could not be loaded in the
+	// dynamic loading mode.
+	ErrLibraryNotAvailable WrapperError = "OpenCL library not available"
+	// ErrKernelArgsNotRecorded is returned by ArgsSnapshot() in case the recording of kernel arguments is not
+	// enabled. See EnableKernelArgRecording().
+	ErrKernelArgsNotRecorded WrapperError = "kernel arguments not recorded"
+	// ErrSizeOutOfRange is returned in case a size or offset value is negative, or can not be represented
+	// as size_t on the current platform.
+	ErrSizeOutOfRange WrapperError = "size out of range"
+	// ErrInvalidDeviceSelector is returned by FilterDevicesBySelector() in case the selector is malformed.
+	ErrInvalidDeviceSelector WrapperError = "invalid device selector"
 )
@@ -0,0 +1,37 @@
+package cl30_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestStatusErrorText(t *testing.T) {
+	t.Parallel()
+	expected := fmt.Sprintf("CL_INVALID_KERNEL_ARGS (%d)", int(cl.ErrInvalidKernelArgs))
+	if text := cl.ErrInvalidKernelArgs.Error(); text != expected {
+		t.Errorf("unexpected text: %q", text)
+	}
+	if text := cl.StatusError(-9999).Error(); text != "-9999" {
+		t.Errorf("unexpected text for unknown value: %q", text)
+	}
+	wrapped := fmt.Errorf("enqueue: %w", cl.StatusError(cl.ErrOutOfResources))
+	var statusErr cl.StatusError
+	if !errors.Is(wrapped, cl.ErrOutOfResources) || !errors.As(wrapped, &statusErr) || (statusErr != cl.ErrOutOfResources) {
+		t.Errorf("wrapped error not identified")
+	}
+}
+
+func TestOperationErrorText(t *testing.T) {
+	t.Parallel()
+	err := &cl.OperationError{Operation: "clEnqueueNDRangeKernel", Parameters: "dims=2", Err: cl.ErrInvalidWorkGroupSize}
+	expected := "clEnqueueNDRangeKernel: dims=2: " + cl.ErrInvalidWorkGroupSize.Error()
+	if err.Error() != expected {
+		t.Errorf("unexpected text: %q", err.Error())
+	}
+	if !errors.Is(err, cl.ErrInvalidWorkGroupSize) {
+		t.Errorf("wrapped error not identified")
+	}
+}
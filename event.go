@@ -11,6 +11,11 @@ import (
 // Event objects are used as synchronization points between different commands within a context.
 // Enqueue* functions offer the option to return a new event object, and a manually controlled event object can
 // be created with CreateUserEvent().
+//
+// The event output parameter of the Enqueue* functions may be nil, in case the caller does not need an event for
+// the command. The OpenCL implementation is then not asked to create one, which avoids the cost of creating,
+// and later releasing, the event. Non-blocking transfers between host memory and device memory are an exception:
+// to keep the host memory pinned until the transfer completed, the wrapper requests an event internally.
 type Event uintptr
 
 func (event Event) handle() C.cl_event {
@@ -18,9 +23,49 @@ func (event Event) handle() C.cl_event {
 }
 
 // String provides a readable presentation of the event identifier.
-// It is based on the numerical value of the underlying pointer.
+// It is based on the numerical value of the underlying pointer, followed by the name set with SetDebugName(), if any.
 func (event Event) String() string {
-	return fmt.Sprintf("0x%X", uintptr(event))
+	return handleString(event, uintptr(event))
+}
+
+// Done returns a channel that receives the result of the command associated with the event, once the command is
+// complete. This allows the completion of commands to participate in select statements:
+//
+//	select {
+//	case err := <-event.Done():
+//		...
+//	case <-ctx.Done():
+//		...
+//	}
+//
+// The channel receives exactly one value: nil if the command completed successfully, or the error with which the
+// command was terminated. The error of registering the callback is delivered the same way. The channel is closed
+// after the value was delivered.
+//
+// Each call registers a new callback with SetEventCallback(). The event needs to remain valid until the callback
+// was registered.
+func (event Event) Done() <-chan error {
+	done := make(chan error, 1)
+	err := SetEventCallback(event, EventCommandCompleteStatus, func(err error) {
+		done <- err
+		close(done)
+	})
+	if err != nil {
+		done <- err
+		close(done)
+	}
+	return done
+}
+
+// EventIf returns the given event output parameter if wanted is true, and nil otherwise.
+// Use it for code paths that need an event only under certain conditions, such as when profiling is enabled:
+//
+//	err := cl.EnqueueCopyBuffer(queue, src, dst, 0, 0, size, nil, cl.EventIf(profiling, &event))
+func EventIf(wanted bool, event *Event) *Event {
+	if !wanted {
+		return nil
+	}
+	return event
 }
 
 // CreateUserEvent creates a user event object.
@@ -29,12 +74,13 @@ func (event Event) String() string {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateUserEvent.html
 func CreateUserEvent(context Context) (Event, error) {
+	defer observeCall("clCreateUserEvent")()
 	var status C.cl_int
 	event := C.clCreateUserEvent(context.handle(), &status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateUserEvent", status, "context", context)
 	}
-	return Event(*((*uintptr)(unsafe.Pointer(&event)))), nil
+	return trackCreated(Event(*((*uintptr)(unsafe.Pointer(&event))))), nil
 }
 
 // SetUserEventStatus sets the execution status of a user event object.
@@ -47,24 +93,36 @@ func CreateUserEvent(context Context) (Event, error) {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSetUserEventStatus.html
 func SetUserEventStatus(event Event, executionStatus int) error {
+	defer observeCall("clSetUserEventStatus")()
 	status := C.clSetUserEventStatus(event.handle(), C.cl_int(executionStatus))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clSetUserEventStatus", status, "event", event)
 	}
 	return nil
 }
 
 // WaitForEvents waits on the host thread for commands identified by event objects to complete.
 //
+// ErrBlockingCallInCallback is returned if WaitForEvents() is called from within a callback of the
+// OpenCL implementation.
+//
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clWaitForEvents.html
 func WaitForEvents(events []Event) error {
-	var rawEvents unsafe.Pointer
-	if len(events) > 0 {
-		rawEvents = unsafe.Pointer(&events[0])
+	defer observeCall("clWaitForEvents")()
+	err := checkBlockingAllowed()
+	if err != nil {
+		return err
 	}
-	status := C.clWaitForEvents(C.cl_uint(len(events)), (*C.cl_event)(rawEvents))
+	injectedEventDelay()
+	status := runBlocking(true, events, func(events []Event) C.cl_int {
+		var rawEvents unsafe.Pointer
+		if len(events) > 0 {
+			rawEvents = unsafe.Pointer(&events[0])
+		}
+		return C.clWaitForEvents(C.cl_uint(len(events)), (*C.cl_event)(rawEvents))
+	})
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clWaitForEvents", status)
 	}
 	return nil
 }
@@ -197,6 +255,48 @@ const (
 	CommandSvmMigrateMem EventCommandType = C.CL_COMMAND_SVM_MIGRATE_MEM
 )
 
+var eventCommandTypeNames = map[EventCommandType]string{
+	CommandNdRangeKernel:     "CommandNdRangeKernel",
+	CommandTask:              "CommandTask",
+	CommandNativeKernel:      "CommandNativeKernel",
+	CommandReadBuffer:        "CommandReadBuffer",
+	CommandWriteBuffer:       "CommandWriteBuffer",
+	CommandCopyBuffer:        "CommandCopyBuffer",
+	CommandReadImage:         "CommandReadImage",
+	CommandWriteImage:        "CommandWriteImage",
+	CommandCopyImage:         "CommandCopyImage",
+	CommandCopyImageToBuffer: "CommandCopyImageToBuffer",
+	CommandCopyBufferToImage: "CommandCopyBufferToImage",
+	CommandMapBuffer:         "CommandMapBuffer",
+	CommandMapImage:          "CommandMapImage",
+	CommandUnmapMemObject:    "CommandUnmapMemObject",
+	CommandMarker:            "CommandMarker",
+	CommandReadBufferRect:    "CommandReadBufferRect",
+	CommandWriteBufferRect:   "CommandWriteBufferRect",
+	CommandCopyBufferRect:    "CommandCopyBufferRect",
+	CommandUser:              "CommandUser",
+	CommandBarrier:           "CommandBarrier",
+	CommandMigrateMemObjects: "CommandMigrateMemObjects",
+	CommandFillBuffer:        "CommandFillBuffer",
+	CommandFillImage:         "CommandFillImage",
+	CommandSvmFree:           "CommandSvmFree",
+	CommandSvmMemcpy:         "CommandSvmMemcpy",
+	CommandSvmMemFill:        "CommandSvmMemFill",
+	CommandSvmMap:            "CommandSvmMap",
+	CommandSvmUnmap:          "CommandSvmUnmap",
+	CommandSvmMigrateMem:     "CommandSvmMigrateMem",
+}
+
+// String returns the name of the constant that identifies the command type, such as "CommandReadBuffer".
+// Unknown command types, such as those of extensions, are presented with their numerical value.
+func (commandType EventCommandType) String() string {
+	name, known := eventCommandTypeNames[commandType]
+	if !known {
+		return fmt.Sprintf("EventCommandType(0x%04X)", uint32(commandType))
+	}
+	return name
+}
+
 // EventCommandExecutionStatus describes the execution status of an event.
 // Negative values are error status values.
 type EventCommandExecutionStatus C.cl_int
@@ -225,6 +325,7 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetEventInfo.html
 func EventInfo(event Event, paramName EventInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetEventInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetEventInfo(
 		event.handle(),
@@ -233,20 +334,55 @@ func EventInfo(event Event, paramName EventInfoName, paramSize uintptr, paramVal
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetEventInfo", status, "event", event, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// EventQueue returns the command-queue associated with the event.
+// For user events, a zero value is returned.
+func EventQueue(event Event) (CommandQueue, error) {
+	return queryValue[CommandQueue](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return EventInfo(event, EventCommandQueueInfo, paramSize, paramValue)
+	})
+}
+
+// EventContext returns the context associated with the event.
+func EventContext(event Event) (Context, error) {
+	return queryValue[Context](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return EventInfo(event, EventContextInfo, paramSize, paramValue)
+	})
+}
+
+// EventCommandTypeOf returns the type of the command associated with the event.
+// The function is not called EventCommandType, as that name is taken by the returned type.
+func EventCommandTypeOf(event Event) (EventCommandType, error) {
+	return queryValue[EventCommandType](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return EventInfo(event, EventCommandTypeInfo, paramSize, paramValue)
+	})
+}
+
+// EventExecutionStatus returns the execution status of the command associated with the event.
+// A negative value means that the command was abnormally terminated; use its value as a StatusError in this case.
+func EventExecutionStatus(event Event) (EventCommandExecutionStatus, error) {
+	return queryValue[EventCommandExecutionStatus](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return EventInfo(event, EventCommandExecutionStatusInfo, paramSize, paramValue)
+	})
+}
+
 // RetainEvent increments the event reference count.
 // The OpenCL commands that return an event perform an implicit retain.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clRetainEvent.html
 func RetainEvent(event Event) error {
+	defer observeCall("clRetainEvent")()
 	status := C.clRetainEvent(event.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clRetainEvent", status, "event", event)
 	}
+	trackRetained(event)
+	retainOwned(event)
+	retainNamed(event)
 	return nil
 }
 
@@ -254,10 +390,14 @@ func RetainEvent(event Event) error {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clReleaseEvent.html
 func ReleaseEvent(event Event) error {
+	defer observeCall("clReleaseEvent")()
 	status := C.clReleaseEvent(event.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clReleaseEvent", status, "event", event)
 	}
+	trackReleased(event)
+	disownReleased(event)
+	unnameReleased(event)
 	return nil
 }
 
@@ -305,6 +445,7 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetEventProfilingInfo.html
 func EventProfilingInfo(event Event, paramName EventProfilingInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetEventProfilingInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetEventProfilingInfo(
 		event.handle(),
@@ -313,7 +454,7 @@ func EventProfilingInfo(event Event, paramName EventProfilingInfoName, paramSize
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetEventProfilingInfo", status, "event", event, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
@@ -328,6 +469,7 @@ func EventProfilingInfo(event Event, paramName EventProfilingInfoName, paramSize
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSetEventCallback.html
 func SetEventCallback(event Event, callbackType EventCommandExecutionStatus, callback func(error)) error {
+	defer observeCall("clSetEventCallback")()
 	callbackUserData, err := userDataFor(callback)
 	if err != nil {
 		return err
@@ -335,7 +477,7 @@ func SetEventCallback(event Event, callbackType EventCommandExecutionStatus, cal
 	status := C.cl30SetEventCallback(event.handle(), C.cl_int(callbackType), callbackUserData.ptr)
 	if status != C.CL_SUCCESS {
 		callbackUserData.Delete()
-		return StatusError(status)
+		return operationError("clSetEventCallback", status, "event", event)
 	}
 	return nil
 }
@@ -349,7 +491,7 @@ func cl30GoEventCallback(_ Event, commandStatus C.cl_int, userData *C.uintptr_t)
 	if commandStatus < 0 {
 		err = StatusError(commandStatus)
 	}
-	callback(err)
+	dispatchCallback(func() { callback(err) })
 }
 
 // EnqueueMarkerWithWaitList enqueues a marker command which waits for either a list of events to complete,
@@ -358,21 +500,59 @@ func cl30GoEventCallback(_ Event, commandStatus C.cl_int, userData *C.uintptr_t)
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueMarkerWithWaitList.html
 func EnqueueMarkerWithWaitList(commandQueue CommandQueue, waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueMarkerWithWaitList")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
 	}
+	defer releaseWaitList()
 	status := C.clEnqueueMarkerWithWaitList(
 		commandQueue.handle(),
 		C.cl_uint(len(waitList)),
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueMarkerWithWaitList", status, "commandQueue", commandQueue)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
+// BridgeEvent makes the completion of an event available as an event of another command queue.
+//
+// It enqueues a marker on dstQueue that waits for srcEvent, and returns the event of that marker.
+// This is the common pattern to express dependencies across command queues. Both the event and the command queue
+// must belong to the same context, otherwise ErrInvalidContext is returned.
+//
+// The returned event must be released with ReleaseEvent().
+//
+// Since: 1.2
+func BridgeEvent(srcEvent Event, dstQueue CommandQueue) (Event, error) {
+	var eventContext Context
+	_, err := EventInfo(srcEvent, EventContextInfo, unsafe.Sizeof(eventContext), unsafe.Pointer(&eventContext))
+	if err != nil {
+		return 0, err
+	}
+	var queueContext Context
+	_, err = CommandQueueInfo(dstQueue, QueueContextInfo, unsafe.Sizeof(queueContext), unsafe.Pointer(&queueContext))
+	if err != nil {
+		return 0, err
+	}
+	if eventContext != queueContext {
+		return 0, ErrInvalidContext
+	}
+	var bridged Event
+	err = EnqueueMarkerWithWaitList(dstQueue, []Event{srcEvent}, &bridged)
+	if err != nil {
+		return 0, err
+	}
+	return bridged, nil
+}
+
 // EnqueueBarrierWithWaitList is a synchronization point that enqueues a barrier operation.
 //
 // The barrier command either waits for a list of events to complete, or if the list is empty it waits for all
@@ -382,17 +562,24 @@ func EnqueueMarkerWithWaitList(commandQueue CommandQueue, waitList []Event, even
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueBarrierWithWaitList.html
 func EnqueueBarrierWithWaitList(commandQueue CommandQueue, waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueBarrierWithWaitList")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
 	}
+	defer releaseWaitList()
 	status := C.clEnqueueBarrierWithWaitList(
 		commandQueue.handle(),
 		C.cl_uint(len(waitList)),
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueBarrierWithWaitList", status, "commandQueue", commandQueue)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
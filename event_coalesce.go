@@ -0,0 +1,111 @@
+package cl30
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Coalesce returns a single event that completes once all the given events completed.
+//
+// It enqueues a marker on the command queue that waits for the events. For an empty list, the returned event is
+// an already completed user event, rather than a marker that would wait for all previously enqueued commands.
+// The events and the command queue must belong to the same context.
+//
+// The returned event must be released with ReleaseEvent().
+//
+// Since: 1.2
+func Coalesce(commandQueue CommandQueue, events []Event) (Event, error) {
+	if len(events) == 0 {
+		context, err := queryValue[Context](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return CommandQueueInfo(commandQueue, QueueContextInfo, paramSize, paramValue)
+		})
+		if err != nil {
+			return 0, err
+		}
+		return completedUserEvent(context)
+	}
+	var marker Event
+	if err := EnqueueMarkerWithWaitList(commandQueue, events, &marker); err != nil {
+		return 0, err
+	}
+	return marker, nil
+}
+
+// AfterAll returns a user event that completes once all the given events completed. If any of the events
+// terminates with an error, the returned event terminates with the status of the first reported error.
+//
+// Unlike Coalesce(), no command queue is involved; the events may belong to different command queues, or be user
+// events, as long as they belong to the same context. ErrInvalidEventWaitList is returned for an empty list.
+//
+// The returned event must be released with ReleaseEvent().
+func AfterAll(events ...Event) (Event, error) {
+	if len(events) == 0 {
+		return 0, ErrInvalidEventWaitList
+	}
+	context, err := EventContext(events[0])
+	if err != nil {
+		return 0, err
+	}
+	aggregate, err := CreateUserEvent(context)
+	if err != nil {
+		return 0, err
+	}
+	// The callbacks keep their own reference, so that the caller may release the event at any time.
+	if err = RetainEvent(aggregate); err != nil {
+		_ = ReleaseEvent(aggregate)
+		return 0, err
+	}
+	remaining := int32(len(events))
+	var failure int32
+	var once sync.Once
+	complete := func(executionStatus int) {
+		once.Do(func() {
+			_ = SetUserEventStatus(aggregate, executionStatus)
+			_ = ReleaseEvent(aggregate)
+		})
+	}
+	for _, event := range events {
+		err = SetEventCallback(event, EventCommandCompleteStatus, func(err error) {
+			if err != nil {
+				atomic.CompareAndSwapInt32(&failure, 0, int32(aggregateFailureStatus(err)))
+			}
+			if atomic.AddInt32(&remaining, -1) == 0 {
+				executionStatus := int(EventCommandCompleteStatus)
+				if status := atomic.LoadInt32(&failure); status != 0 {
+					executionStatus = int(status)
+				}
+				complete(executionStatus)
+			}
+		})
+		if err != nil {
+			// Already registered callbacks find the event completed and leave it alone.
+			complete(int(ErrExecStatusErrorForEventsInWaitList))
+			_ = ReleaseEvent(aggregate)
+			return 0, err
+		}
+	}
+	return aggregate, nil
+}
+
+func completedUserEvent(context Context) (Event, error) {
+	event, err := CreateUserEvent(context)
+	if err != nil {
+		return 0, err
+	}
+	if err = SetUserEventStatus(event, int(EventCommandCompleteStatus)); err != nil {
+		_ = ReleaseEvent(event)
+		return 0, err
+	}
+	return event, nil
+}
+
+// aggregateFailureStatus returns the negative execution status that represents the given error.
+func aggregateFailureStatus(err error) StatusError {
+	var status StatusError
+	if errors.As(err, &status) && (status < 0) {
+		return status
+	}
+	return ErrExecStatusErrorForEventsInWaitList
+}
@@ -0,0 +1,56 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockCoalesceAndAfterAll(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	empty, err := cl.Coalesce(queue, nil)
+	if err != nil {
+		t.Fatalf("Coalesce failed: %v", err)
+	}
+	if status, err := cl.EventExecutionStatus(empty); (err != nil) || (status != cl.EventCommandCompleteStatus) {
+		t.Errorf("unexpected status of empty coalesced event: %v, %v", status, err)
+	}
+	_ = cl.ReleaseEvent(empty)
+
+	first, _ := cl.CreateUserEvent(context)
+	second, _ := cl.CreateUserEvent(context)
+	defer func() { _ = cl.ReleaseEvent(first) }()
+	defer func() { _ = cl.ReleaseEvent(second) }()
+	coalesced, err := cl.Coalesce(queue, []cl.Event{first, second})
+	if err != nil {
+		t.Fatalf("Coalesce failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(coalesced) }()
+	aggregate, err := cl.AfterAll(first, second)
+	if err != nil {
+		t.Fatalf("AfterAll failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(aggregate) }()
+	if _, err = cl.AfterAll(); !errors.Is(err, cl.ErrInvalidEventWaitList) {
+		t.Errorf("unexpected error for empty list: %v", err)
+	}
+
+	_ = cl.SetUserEventStatus(first, int(cl.EventCommandCompleteStatus))
+	if status, _ := cl.EventExecutionStatus(aggregate); status == cl.EventCommandCompleteStatus {
+		t.Errorf("aggregate completed before all events")
+	}
+	_ = cl.SetUserEventStatus(second, int(cl.ErrOutOfResources))
+	deadline := time.Now().Add(time.Second)
+	status, _ := cl.EventExecutionStatus(aggregate)
+	for (status >= 0) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		status, _ = cl.EventExecutionStatus(aggregate)
+	}
+	if status != cl.EventCommandExecutionStatus(cl.ErrOutOfResources) {
+		t.Errorf("unexpected status of aggregate: %v", status)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockEventDone(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	gate, err := cl.CreateUserEvent(context)
+	if err != nil {
+		t.Fatalf("CreateUserEvent failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(gate) }()
+	done := gate.Done()
+	select {
+	case err = <-done:
+		t.Fatalf("event reported done before completion: %v", err)
+	default:
+	}
+	if err = cl.SetUserEventStatus(gate, int(cl.ErrOutOfResources)); err != nil {
+		t.Fatalf("SetUserEventStatus failed: %v", err)
+	}
+	if err = <-done; !errors.Is(err, cl.ErrOutOfResources) {
+		t.Errorf("unexpected result: %v", err)
+	}
+	if _, open := <-done; open {
+		t.Errorf("channel not closed after delivery")
+	}
+}
@@ -0,0 +1,28 @@
+package cl30_test
+
+import (
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestEventCommandTypeString(t *testing.T) {
+	t.Parallel()
+	if got := cl.CommandReadBuffer.String(); got != "CommandReadBuffer" {
+		t.Errorf("unexpected name: %q", got)
+	}
+	if got := cl.EventCommandType(0x7FFF).String(); got != "EventCommandType(0x7FFF)" {
+		t.Errorf("unexpected name for unknown type: %q", got)
+	}
+}
+
+func TestEventIf(t *testing.T) {
+	t.Parallel()
+	var event cl.Event
+	if got := cl.EventIf(true, &event); got != &event {
+		t.Errorf("wanted event not returned")
+	}
+	if got := cl.EventIf(false, &event); got != nil {
+		t.Errorf("unwanted event not suppressed: %v", got)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build cl30_mock
+
+package cl30
+
+// MockRawKernelLaunch exposes mockRawKernelLaunch() to the tests.
+var MockRawKernelLaunch = mockRawKernelLaunch
+
+// MockKernelExecInfo exposes mockKernelExecInfo() to the tests.
+var MockKernelExecInfo = mockKernelExecInfo
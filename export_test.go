@@ -0,0 +1,14 @@
+package cl30
+
+// RunBlocking exposes runBlocking() to the tests.
+func RunBlocking(blocking bool, call func()) {
+	runBlocking(blocking, call, func(call func()) struct{} {
+		call()
+		return struct{}{}
+	})
+}
+
+// RunBlockingIncrement calls runBlocking() the way the wrapper functions do, with a call that captures no variables.
+func RunBlockingIncrement(blocking bool, value int) int {
+	return runBlocking(blocking, value, func(value int) int { return value + 1 })
+}
@@ -0,0 +1,110 @@
+//go:build cl30_mock
+
+package cl30
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FaultInjection describes faults that the wrapper injects into calls of the OpenCL API.
+//
+// Injected faults are reported before the OpenCL implementation is called, exactly as if the implementation had
+// returned the configured status. This allows retry logic and watchdogs of applications to be tested
+// deterministically, without the need for a misbehaving implementation.
+//
+// Fault injection is only available with the build tag "cl30_mock". Other builds do not check for faults at all.
+type FaultInjection struct {
+	// EnqueueFailureInterval makes every n-th enqueue call fail. A value of zero disables enqueue failures.
+	EnqueueFailureInterval int
+	// EnqueueFailureStatus is the error that failing enqueue calls return. It defaults to ErrOutOfResources.
+	EnqueueFailureStatus StatusError
+	// BuildFailureProbability is the probability, in the range [0.0, 1.0], with which BuildProgram(),
+	// CompileProgram(), and LinkProgram() fail. They return ErrBuildProgramFailure, ErrCompileProgramFailure, or
+	// ErrLinkProgramFailure respectively.
+	BuildFailureProbability float64
+	// EventDelay is added to each call of WaitForEvents() and Finish(), to simulate slow completion of commands.
+	EventDelay time.Duration
+	// Seed initializes the random source for build failures. The same seed produces the same sequence of failures.
+	Seed int64
+}
+
+var faultInjection = struct {
+	// enabled is set while a config exists. It lets calls skip the mutex while fault injection is disabled.
+	enabled      int32
+	mutex        sync.Mutex
+	config       *FaultInjection
+	enqueueCount int
+	random       *rand.Rand
+}{}
+
+// SetFaultInjection enables the injection of the given faults, and resets all counters and the random source.
+// A nil config disables fault injection, which is the default.
+//
+// Fault injection is meant for tests only.
+func SetFaultInjection(config *FaultInjection) {
+	faultInjection.mutex.Lock()
+	defer faultInjection.mutex.Unlock()
+	faultInjection.enqueueCount = 0
+	faultInjection.random = nil
+	faultInjection.config = nil
+	atomic.StoreInt32(&faultInjection.enabled, 0)
+	if config == nil {
+		return
+	}
+	configCopy := *config
+	if configCopy.EnqueueFailureStatus == 0 {
+		configCopy.EnqueueFailureStatus = ErrOutOfResources
+	}
+	faultInjection.config = &configCopy
+	faultInjection.random = rand.New(rand.NewSource(configCopy.Seed))
+	atomic.StoreInt32(&faultInjection.enabled, 1)
+}
+
+func injectedEnqueueFault() error {
+	if atomic.LoadInt32(&faultInjection.enabled) == 0 {
+		return nil
+	}
+	faultInjection.mutex.Lock()
+	defer faultInjection.mutex.Unlock()
+	config := faultInjection.config
+	if (config == nil) || (config.EnqueueFailureInterval <= 0) {
+		return nil
+	}
+	faultInjection.enqueueCount++
+	if (faultInjection.enqueueCount % config.EnqueueFailureInterval) != 0 {
+		return nil
+	}
+	return config.EnqueueFailureStatus
+}
+
+func injectedBuildFault(status StatusError) error {
+	if atomic.LoadInt32(&faultInjection.enabled) == 0 {
+		return nil
+	}
+	faultInjection.mutex.Lock()
+	defer faultInjection.mutex.Unlock()
+	config := faultInjection.config
+	if (config == nil) || (config.BuildFailureProbability <= 0) {
+		return nil
+	}
+	if faultInjection.random.Float64() >= config.BuildFailureProbability {
+		return nil
+	}
+	return status
+}
+
+func injectedEventDelay() {
+	if atomic.LoadInt32(&faultInjection.enabled) == 0 {
+		return
+	}
+	faultInjection.mutex.Lock()
+	config := faultInjection.config
+	faultInjection.mutex.Unlock()
+	if (config == nil) || (config.EventDelay <= 0) {
+		return
+	}
+	time.Sleep(config.EventDelay)
+}
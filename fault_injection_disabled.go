@@ -0,0 +1,13 @@
+//go:build !cl30_mock
+
+package cl30
+
+func injectedEnqueueFault() error {
+	return nil
+}
+
+func injectedBuildFault(StatusError) error {
+	return nil
+}
+
+func injectedEventDelay() {}
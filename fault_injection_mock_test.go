@@ -0,0 +1,30 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestFaultInjectionFailsEnqueueCalls(t *testing.T) {
+	cl.SetFaultInjection(&cl.FaultInjection{EnqueueFailureInterval: 1})
+	defer cl.SetFaultInjection(nil)
+
+	err := cl.EnqueueMarkerWithWaitList(0, nil, nil)
+	if !errors.Is(err, cl.ErrOutOfResources) {
+		t.Errorf("expected ErrOutOfResources, got %v", err)
+	}
+}
+
+func TestFaultInjectionFailsBuilds(t *testing.T) {
+	cl.SetFaultInjection(&cl.FaultInjection{BuildFailureProbability: 1.0})
+	defer cl.SetFaultInjection(nil)
+
+	err := cl.BuildProgram(0, nil, "", nil)
+	if !errors.Is(err, cl.ErrBuildProgramFailure) {
+		t.Errorf("expected ErrBuildProgramFailure, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package cl30
+
+import "sync/atomic"
+
+var forceBlocking int32
+
+// SetForceBlocking enables or disables the forced synchronous execution of enqueued commands.
+//
+// When enabled, every enqueue function waits with Finish() for the command-queue to complete, before it returns.
+// Non-blocking variants therefore behave as if they were blocking, and each command is completed before the next
+// one is enqueued. This is intended for debugging: it helps to bisect corruptions caused by missing
+// synchronization, without changes to the application code.
+//
+// Commands that wait for user events block until the user events are completed by another goroutine.
+// Errors of the implicit Finish() are not reported by the enqueue function; they surface with the next call
+// that waits for the command-queue. Enqueue functions that are called from within callbacks do not wait,
+// as blocking calls are not allowed there.
+func SetForceBlocking(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&forceBlocking, value)
+}
+
+// ForceBlockingEnabled returns true if enqueued commands are forced to complete synchronously.
+// See SetForceBlocking().
+func ForceBlockingEnabled() bool {
+	return atomic.LoadInt32(&forceBlocking) != 0
+}
+
+// finishIfForcedBlocking waits for the command-queue to complete, if SetForceBlocking() is enabled.
+func finishIfForcedBlocking(commandQueue CommandQueue) {
+	if !ForceBlockingEnabled() || (checkBlockingAllowed() != nil) {
+		return
+	}
+	_ = Finish(commandQueue)
+}
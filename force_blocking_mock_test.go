@@ -0,0 +1,33 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockForceBlocking(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	gate, err := cl.CreateUserEvent(context)
+	if err != nil {
+		t.Fatalf("CreateUserEvent failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(gate) }()
+	cl.SetForceBlocking(true)
+	defer cl.SetForceBlocking(false)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = cl.SetUserEventStatus(gate, int(cl.EventCommandCompleteStatus))
+	}()
+	var marker cl.Event
+	if err = cl.EnqueueMarkerWithWaitList(queue, []cl.Event{gate}, &marker); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(marker) }()
+	if status, err := cl.EventExecutionStatus(marker); (err != nil) || (status != cl.EventCommandCompleteStatus) {
+		t.Errorf("command not completed with forced blocking: %v, %v", status, err)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/opencl-go/cl30
 
-go 1.18
+go 1.21
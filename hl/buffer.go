@@ -0,0 +1,34 @@
+package hl
+
+import (
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+// Buffer represents an OpenCL buffer object.
+type Buffer struct {
+	handle cl.MemObject
+}
+
+// Handle returns the raw handle of the buffer.
+func (buffer *Buffer) Handle() cl.MemObject {
+	return buffer.handle
+}
+
+// Release gives up the reference of the buffer.
+func (buffer *Buffer) Release() error {
+	if buffer.handle == 0 {
+		return nil
+	}
+	err := cl.ReleaseMemObject(buffer.handle)
+	buffer.handle = 0
+	return err
+}
+
+// Size returns the size of the buffer, in bytes.
+func (buffer *Buffer) Size() (uintptr, error) {
+	var size uintptr
+	_, err := cl.MemObjectInfo(buffer.handle, cl.MemSizeInfo, unsafe.Sizeof(size), unsafe.Pointer(&size))
+	return size, err
+}
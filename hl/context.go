@@ -0,0 +1,70 @@
+package hl
+
+import (
+	cl "github.com/opencl-go/cl30"
+)
+
+// Context represents an OpenCL context.
+type Context struct {
+	handle cl.Context
+}
+
+// NewContext creates a context for the given devices.
+func NewContext(devices ...Device) (*Context, error) {
+	handle, err := cl.CreateContext(deviceIDs(devices), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Context{handle: handle}, nil
+}
+
+// Handle returns the raw handle of the context.
+func (ctx *Context) Handle() cl.Context {
+	return ctx.handle
+}
+
+// Release gives up the reference of the context.
+func (ctx *Context) Release() error {
+	if ctx.handle == 0 {
+		return nil
+	}
+	err := cl.ReleaseContext(ctx.handle)
+	ctx.handle = 0
+	return err
+}
+
+// CreateBuffer creates a buffer of the given size, in bytes.
+func (ctx *Context) CreateBuffer(flags cl.MemFlags, size int) (*Buffer, error) {
+	handle, err := cl.CreateBuffer(ctx.handle, flags, size, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Buffer{handle: handle}, nil
+}
+
+// CreateBufferFrom creates a buffer that is initialized with a copy of the given host memory.
+func (ctx *Context) CreateBufferFrom(flags cl.MemFlags, data cl.HostMemory) (*Buffer, error) {
+	handle, err := cl.CreateBuffer(ctx.handle, flags|cl.MemCopyHostPtrFlag, int(data.Size()), data.Pointer())
+	if err != nil {
+		return nil, err
+	}
+	return &Buffer{handle: handle}, nil
+}
+
+// CreateProgram creates a program from OpenCL C source code. The program still needs to be built.
+func (ctx *Context) CreateProgram(sources ...string) (*Program, error) {
+	handle, err := cl.CreateProgramWithSource(ctx.handle, sources)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{handle: handle}, nil
+}
+
+// NewQueue creates a command-queue for the given device.
+func (ctx *Context) NewQueue(device Device, properties ...cl.CommandQueueProperty) (*Queue, error) {
+	handle, err := cl.CreateCommandQueueWithProperties(ctx.handle, device.id, properties...)
+	if err != nil {
+		return nil, err
+	}
+	return &Queue{handle: handle}, nil
+}
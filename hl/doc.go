@@ -0,0 +1,18 @@
+// Package hl provides a high-level, method-based layer on top of the raw handles of package cl30.
+//
+// The types of this package wrap the handles of the OpenCL objects, and expose the functions that operate on them
+// as methods. For example:
+//
+//	platforms, err := hl.Platforms()
+//	devices, err := platforms[0].Devices(cl.DeviceTypeAll)
+//	ctx, err := hl.NewContext(devices...)
+//	defer ctx.Release()
+//	buffer, err := ctx.CreateBuffer(cl.MemReadWriteFlag, 1024)
+//	defer buffer.Release()
+//
+// Each wrapper of a reference-counted object owns exactly one reference, which it gives up with Release().
+// Calling Release() more than once is safe. Objects that are returned by methods are owned by the caller.
+//
+// The raw handle of each wrapper is available with Handle(), which allows combining this package with
+// the functions of package cl30 for functionality that is not wrapped.
+package hl
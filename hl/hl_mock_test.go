@@ -0,0 +1,99 @@
+//go:build cl30_mock
+
+package hl_test
+
+import (
+	"bytes"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+	"github.com/opencl-go/cl30/hl"
+)
+
+const mockSource = "kernel void add(global const uchar *in, global uchar *out, uchar value) {}"
+
+func TestMockBuildLaunchAndRead(t *testing.T) {
+	cl.SetMockPlatforms(nil)
+	cl.SetMockKernel("add", func(call cl.MockKernelCall) error {
+		for i, value := range call.Args[0].Buffer {
+			call.Args[1].Buffer[i] = value + call.Args[2].Value[0]
+		}
+		return nil
+	})
+	defer cl.SetMockKernel("add", nil)
+
+	platforms, err := hl.Platforms()
+	if err != nil {
+		t.Fatalf("Platforms failed: %v", err)
+	}
+	devices, err := platforms[0].Devices(cl.DeviceTypeAll)
+	if (err != nil) || (len(devices) == 0) {
+		t.Fatalf("Devices failed: %v, %v", devices, err)
+	}
+	ctx, err := hl.NewContext(devices[0])
+	if err != nil {
+		t.Fatalf("NewContext failed: %v", err)
+	}
+	defer func() { _ = ctx.Release() }()
+	queue, err := ctx.NewQueue(devices[0])
+	if err != nil {
+		t.Fatalf("NewQueue failed: %v", err)
+	}
+	defer func() { _ = queue.Release() }()
+
+	program, err := ctx.CreateProgram(mockSource)
+	if err != nil {
+		t.Fatalf("CreateProgram failed: %v", err)
+	}
+	defer func() { _ = program.Release() }()
+	if err = program.Build(""); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	kernel, err := program.Kernel("add")
+	if err != nil {
+		t.Fatalf("Kernel failed: %v", err)
+	}
+	defer func() { _ = kernel.Release() }()
+
+	input := []byte{1, 2, 3, 4}
+	in, err := ctx.CreateBufferFrom(cl.MemReadOnlyFlag, cl.Slice(input))
+	if err != nil {
+		t.Fatalf("CreateBufferFrom failed: %v", err)
+	}
+	defer func() { _ = in.Release() }()
+	out, err := ctx.CreateBuffer(cl.MemReadWriteFlag, len(input))
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = out.Release() }()
+	if size, err := out.Size(); (err != nil) || (size != uintptr(len(input))) {
+		t.Errorf("unexpected buffer size: %v, %v", size, err)
+	}
+	if err = queue.WriteBuffer(out, 0, cl.Slice([]byte{0xFF, 0xFF, 0xFF, 0xFF})); err != nil {
+		t.Fatalf("WriteBuffer failed: %v", err)
+	}
+
+	value := uint8(10)
+	if err = kernel.SetArgBuffer(0, in); err != nil {
+		t.Fatalf("SetArgBuffer failed: %v", err)
+	}
+	if err = kernel.SetArgBuffer(1, out); err != nil {
+		t.Fatalf("SetArgBuffer failed: %v", err)
+	}
+	if err = kernel.SetArg(2, cl.Value(&value)); err != nil {
+		t.Fatalf("SetArg failed: %v", err)
+	}
+	if err = queue.EnqueueKernel(kernel, []uintptr{uintptr(len(input))}); err != nil {
+		t.Fatalf("EnqueueKernel failed: %v", err)
+	}
+	output := make([]byte, len(input))
+	if err = queue.ReadBuffer(out, 0, cl.Slice(output)); err != nil {
+		t.Fatalf("ReadBuffer failed: %v", err)
+	}
+	if !bytes.Equal(output, []byte{11, 12, 13, 14}) {
+		t.Errorf("unexpected kernel result: %v", output)
+	}
+	if err = queue.Finish(); err != nil {
+		t.Errorf("Finish failed: %v", err)
+	}
+}
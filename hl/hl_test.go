@@ -0,0 +1,19 @@
+package hl_test
+
+import (
+	"testing"
+
+	"github.com/opencl-go/cl30/hl"
+)
+
+func TestReleaseOfEmptyWrappers(t *testing.T) {
+	t.Parallel()
+	releasers := []interface{ Release() error }{
+		&hl.Context{}, &hl.Buffer{}, &hl.Program{}, &hl.Kernel{}, &hl.Queue{},
+	}
+	for _, releaser := range releasers {
+		if err := releaser.Release(); err != nil {
+			t.Errorf("unexpected error for %T: %v", releaser, err)
+		}
+	}
+}
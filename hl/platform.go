@@ -0,0 +1,84 @@
+package hl
+
+import (
+	"errors"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+// Platform represents an OpenCL platform. Platforms are not reference-counted, and need not be released.
+type Platform struct {
+	id cl.PlatformID
+}
+
+// Platforms returns all available platforms.
+func Platforms() ([]Platform, error) {
+	ids, err := cl.PlatformIDs()
+	if err != nil {
+		return nil, err
+	}
+	platforms := make([]Platform, len(ids))
+	for i, id := range ids {
+		platforms[i] = Platform{id: id}
+	}
+	return platforms, nil
+}
+
+// Handle returns the raw identifier of the platform.
+func (platform Platform) Handle() cl.PlatformID {
+	return platform.id
+}
+
+// Name returns the human-readable name of the platform.
+func (platform Platform) Name() (string, error) {
+	return cl.PlatformInfoString(platform.id, cl.PlatformNameInfo)
+}
+
+// Devices returns the devices of the platform that match the given type.
+// An empty list is returned if no device matches.
+func (platform Platform) Devices(deviceType cl.DeviceTypeFlags) ([]Device, error) {
+	ids, err := cl.DeviceIDs(platform.id, deviceType)
+	if errors.Is(err, cl.ErrDeviceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]Device, len(ids))
+	for i, id := range ids {
+		devices[i] = Device{id: id}
+	}
+	return devices, nil
+}
+
+// Device represents a root-level OpenCL device. Root-level devices are not reference-counted,
+// and need not be released.
+type Device struct {
+	id cl.DeviceID
+}
+
+// Handle returns the raw identifier of the device.
+func (device Device) Handle() cl.DeviceID {
+	return device.id
+}
+
+// Name returns the human-readable name of the device.
+func (device Device) Name() (string, error) {
+	return cl.DeviceInfoString(device.id, cl.DeviceNameInfo)
+}
+
+// Type returns the type of the device.
+func (device Device) Type() (cl.DeviceTypeFlags, error) {
+	var deviceType cl.DeviceTypeFlags
+	_, err := cl.DeviceInfo(device.id, cl.DeviceTypeInfo, unsafe.Sizeof(deviceType), unsafe.Pointer(&deviceType))
+	return deviceType, err
+}
+
+func deviceIDs(devices []Device) []cl.DeviceID {
+	ids := make([]cl.DeviceID, len(devices))
+	for i, device := range devices {
+		ids[i] = device.id
+	}
+	return ids
+}
@@ -0,0 +1,80 @@
+package hl
+
+import (
+	cl "github.com/opencl-go/cl30"
+)
+
+// Program represents an OpenCL program object.
+type Program struct {
+	handle cl.Program
+}
+
+// Handle returns the raw handle of the program.
+func (program *Program) Handle() cl.Program {
+	return program.handle
+}
+
+// Release gives up the reference of the program.
+func (program *Program) Release() error {
+	if program.handle == 0 {
+		return nil
+	}
+	err := cl.ReleaseProgram(program.handle)
+	program.handle = 0
+	return err
+}
+
+// Build builds the program for the given devices, or for all devices of the context if none are given.
+// The call blocks until the build is complete.
+func (program *Program) Build(options string, devices ...Device) error {
+	return cl.BuildProgram(program.handle, deviceIDs(devices), options, nil)
+}
+
+// BuildLog returns the log of the latest build for the given device.
+func (program *Program) BuildLog(device Device) (string, error) {
+	return cl.ProgramBuildInfoString(program.handle, device.id, cl.ProgramBuildLogInfo)
+}
+
+// Kernel creates the kernel with the given name from the built program.
+func (program *Program) Kernel(name string) (*Kernel, error) {
+	handle, err := cl.CreateKernel(program.handle, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Kernel{handle: handle}, nil
+}
+
+// Kernel represents an OpenCL kernel object.
+type Kernel struct {
+	handle cl.Kernel
+}
+
+// Handle returns the raw handle of the kernel.
+func (kernel *Kernel) Handle() cl.Kernel {
+	return kernel.handle
+}
+
+// Release gives up the reference of the kernel.
+func (kernel *Kernel) Release() error {
+	if kernel.handle == 0 {
+		return nil
+	}
+	err := cl.ReleaseKernel(kernel.handle)
+	kernel.handle = 0
+	return err
+}
+
+// SetArg sets the argument at the given index to the value in host memory, such as cl.Value(&count).
+func (kernel *Kernel) SetArg(index uint32, value cl.HostMemory) error {
+	return cl.SetKernelArg(kernel.handle, index, value.Size(), value.Pointer())
+}
+
+// SetArgBuffer sets the argument at the given index to the buffer.
+func (kernel *Kernel) SetArgBuffer(index uint32, buffer *Buffer) error {
+	return kernel.SetArg(index, cl.Value(&buffer.handle))
+}
+
+// SetArgLocal sets the argument at the given index to local memory of the given size, in bytes.
+func (kernel *Kernel) SetArgLocal(index uint32, size uintptr) error {
+	return cl.SetKernelArg(kernel.handle, index, size, nil)
+}
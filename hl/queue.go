@@ -0,0 +1,72 @@
+package hl
+
+import (
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+// Queue represents an OpenCL command-queue.
+type Queue struct {
+	handle cl.CommandQueue
+}
+
+// Handle returns the raw handle of the command-queue.
+func (queue *Queue) Handle() cl.CommandQueue {
+	return queue.handle
+}
+
+// Release gives up the reference of the command-queue.
+// Any automatic flush policy of the command-queue is removed first, see cl.ClearAutoFlush().
+func (queue *Queue) Release() error {
+	if queue.handle == 0 {
+		return nil
+	}
+	cl.ClearAutoFlush(queue.handle)
+	err := cl.ReleaseCommandQueue(queue.handle)
+	queue.handle = 0
+	return err
+}
+
+// EnqueueKernel enqueues the kernel with the given global work size. See cl.EnqueueKernel() for the options.
+func (queue *Queue) EnqueueKernel(kernel *Kernel, globalWorkSize []uintptr, opts ...cl.KernelEnqueueOption) error {
+	return cl.EnqueueKernel(queue.handle, kernel.handle, globalWorkSize, opts...)
+}
+
+// ReadBuffer reads data.Size() bytes, starting at the given offset of the buffer, into data.
+// The call blocks until the data is available.
+func (queue *Queue) ReadBuffer(buffer *Buffer, offset uintptr, data cl.HostMemory) error {
+	return cl.EnqueueReadBufferInto(queue.handle, buffer.handle, true, offset, data, nil, nil)
+}
+
+// WriteBuffer writes data.Size() bytes from data into the buffer, starting at the given offset.
+// The call blocks until data may be reused.
+func (queue *Queue) WriteBuffer(buffer *Buffer, offset uintptr, data cl.HostMemory) error {
+	return cl.EnqueueWriteBufferFrom(queue.handle, buffer.handle, true, offset, data, nil, nil)
+}
+
+// Flush issues all previously queued commands to the device.
+func (queue *Queue) Flush() error {
+	return cl.Flush(queue.handle)
+}
+
+// Finish blocks until all previously queued commands have completed.
+func (queue *Queue) Finish() error {
+	return cl.Finish(queue.handle)
+}
+
+// SetAutoFlush flushes the command-queue automatically after every n-th enqueue call. See cl.SetAutoFlush().
+func (queue *Queue) SetAutoFlush(every int) {
+	cl.SetAutoFlush(queue.handle, every)
+}
+
+// SetAutoFlushInterval flushes the command-queue periodically. See cl.SetAutoFlushInterval().
+// The periodic flush is stopped when the queue is released.
+func (queue *Queue) SetAutoFlushInterval(interval time.Duration) {
+	cl.SetAutoFlushInterval(queue.handle, interval)
+}
+
+// Stats returns the counters of the automatic flush policy of the command-queue.
+func (queue *Queue) Stats() cl.AutoFlushStat {
+	return cl.AutoFlushStats(queue.handle)
+}
@@ -0,0 +1,101 @@
+package cl30
+
+import "unsafe"
+
+// HostMemory describes a contiguous region of memory in the host address space.
+//
+// Functions that operate on host memory, such as the info queries and the enqueue functions for reading
+// and writing, take a pointer and a size. HostMemory provides both of these values based on Go data,
+// and holds a reference to that data. For example:
+//
+//	var count uint32
+//	err := cl.QueryInfo(cl.Value(&count), func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+//		return cl.DeviceInfo(id, cl.DeviceMaxComputeUnitsInfo, paramSize, paramValue)
+//	})
+//
+// Non-blocking transfers, such as EnqueueReadBufferInto() with blocking set to false, pin the memory until the
+// command has completed. The Go data must not be accessed in the meantime.
+type HostMemory interface {
+	// Pointer returns the address of the first byte of the memory region. It returns nil if the region is empty.
+	Pointer() unsafe.Pointer
+	// Size returns the number of bytes of the memory region.
+	Size() uintptr
+}
+
+// HostData is the set of types that Value() and Slice() accept. These types contain no Go pointers, which must not
+// be passed to the OpenCL implementation. Handles, such as MemObject, are covered by ~uintptr.
+type HostData interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~int |
+		~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uint | ~uintptr |
+		~float32 | ~float64
+}
+
+// Value returns a HostMemory that represents the memory of the value that v points to.
+func Value[T HostData](v *T) HostMemory {
+	return valueMemory[T]{value: v}
+}
+
+// Slice returns a HostMemory that represents the memory of the elements of s.
+//
+// The returned region covers the length of the slice, not its capacity.
+func Slice[T HostData](s []T) HostMemory {
+	return sliceMemory[T]{slice: s}
+}
+
+// QueryInfo calls the query, typically a closure around one of the info functions such as DeviceInfo(), with the
+// region of data. ErrInvalidValue is returned if the size of the queried value differs from the size of data.
+func QueryInfo(data HostMemory, query func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)) error {
+	size, err := query(data.Size(), data.Pointer())
+	if err != nil {
+		return err
+	}
+	if size != data.Size() {
+		return ErrInvalidValue
+	}
+	return nil
+}
+
+// EnqueueReadBufferInto enqueues a command to read data.Size() bytes from a buffer object, starting at offset,
+// into data. See EnqueueReadBuffer().
+func EnqueueReadBufferInto(commandQueue CommandQueue, mem MemObject, blocking bool, offset uintptr, data HostMemory,
+	waitList []Event, event *Event) error {
+	return EnqueueReadBuffer(commandQueue, mem, blocking, offset, data.Size(), data.Pointer(), waitList, event)
+}
+
+// EnqueueWriteBufferFrom enqueues a command to write the data.Size() bytes of data into a buffer object,
+// starting at offset. See EnqueueWriteBuffer().
+func EnqueueWriteBufferFrom(commandQueue CommandQueue, mem MemObject, blocking bool, offset uintptr, data HostMemory,
+	waitList []Event, event *Event) error {
+	return EnqueueWriteBuffer(commandQueue, mem, blocking, offset, data.Size(), data.Pointer(), waitList, event)
+}
+
+type valueMemory[T HostData] struct {
+	value *T
+}
+
+func (mem valueMemory[T]) Pointer() unsafe.Pointer {
+	return unsafe.Pointer(mem.value)
+}
+
+func (mem valueMemory[T]) Size() uintptr {
+	if mem.value == nil {
+		return 0
+	}
+	return unsafe.Sizeof(*mem.value)
+}
+
+type sliceMemory[T HostData] struct {
+	slice []T
+}
+
+func (mem sliceMemory[T]) Pointer() unsafe.Pointer {
+	if len(mem.slice) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&mem.slice[0])
+}
+
+func (mem sliceMemory[T]) Size() uintptr {
+	var zero T
+	return unsafe.Sizeof(zero) * uintptr(len(mem.slice))
+}
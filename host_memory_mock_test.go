@@ -0,0 +1,35 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"reflect"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockHostMemoryTransfers(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 16, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+	input := []uint32{1, 2, 3}
+	if err = cl.EnqueueWriteBufferFrom(queue, buffer, false, 4, cl.Slice(input), nil, nil); err != nil {
+		t.Fatalf("EnqueueWriteBufferFrom failed: %v", err)
+	}
+	output := make([]uint32, 3)
+	var event cl.Event
+	if err = cl.EnqueueReadBufferInto(queue, buffer, false, 4, cl.Slice(output), nil, &event); err != nil {
+		t.Fatalf("EnqueueReadBufferInto failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(event) }()
+	if err = cl.WaitForEvents([]cl.Event{event}); err != nil {
+		t.Fatalf("WaitForEvents failed: %v", err)
+	}
+	if !reflect.DeepEqual(output, input) {
+		t.Errorf("unexpected output: %v", output)
+	}
+}
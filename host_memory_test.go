@@ -0,0 +1,55 @@
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestValueHostMemory(t *testing.T) {
+	t.Parallel()
+	var value uint64
+	mem := cl.Value(&value)
+	if mem.Pointer() != unsafe.Pointer(&value) {
+		t.Errorf("pointer mismatch")
+	}
+	if mem.Size() != 8 {
+		t.Errorf("unexpected size: %v", mem.Size())
+	}
+}
+
+func TestSliceHostMemory(t *testing.T) {
+	t.Parallel()
+	values := make([]uint32, 3, 10)
+	mem := cl.Slice(values)
+	if mem.Pointer() != unsafe.Pointer(&values[0]) {
+		t.Errorf("pointer mismatch")
+	}
+	if mem.Size() != 12 {
+		t.Errorf("unexpected size: %v", mem.Size())
+	}
+	empty := cl.Slice([]uint32{})
+	if (empty.Pointer() != nil) || (empty.Size() != 0) {
+		t.Errorf("empty slice must have no memory")
+	}
+}
+
+func TestQueryInfo(t *testing.T) {
+	t.Parallel()
+	var value uint32
+	err := cl.QueryInfo(cl.Value(&value), func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		*(*uint32)(paramValue) = 42
+		return paramSize, nil
+	})
+	if (err != nil) || (value != 42) {
+		t.Errorf("unexpected result: %v, %v", value, err)
+	}
+	err = cl.QueryInfo(cl.Value(&value), func(uintptr, unsafe.Pointer) (uintptr, error) {
+		return 8, nil
+	})
+	if !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("unexpected error for size mismatch: %v", err)
+	}
+}
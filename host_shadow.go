@@ -0,0 +1,180 @@
+package cl30
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// HostShadow is a copy of the content of a buffer in host memory.
+//
+// A shadow keeps the data of a critical buffer available should the device be lost. It is updated with every
+// successful EnqueueWriteBuffer() to its buffer, and on demand with Sync() for all other modifications, such as
+// kernels or copy commands. HostShadow implements HostMemory, which allows it to be used as the shadow of
+// a BufferSpec, so that Recreate() re-uploads the data.
+type HostShadow struct {
+	mem   MemObject
+	mutex sync.Mutex
+	data  []byte
+}
+
+var hostShadows = struct {
+	mutex   sync.Mutex
+	count   int32
+	bytes   uint64
+	shadows map[MemObject]*HostShadow
+}{
+	shadows: make(map[MemObject]*HostShadow),
+}
+
+// WithHostShadow registers a host shadow for the given buffer, or returns the already registered one.
+//
+// The shadow is created with zeroed content; call Sync() to capture the current content of the buffer.
+// The shadow is removed when the buffer is destroyed, or when Release() is called.
+// The memory of all shadows is accounted for with HostShadowBytes(), and is not limited by the package.
+//
+// Since: 1.1
+func WithHostShadow(mem MemObject) (*HostShadow, error) {
+	if shadow := HostShadowOf(mem); shadow != nil {
+		return shadow, nil
+	}
+	size, err := queryValue[uintptr](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return MemObjectInfo(mem, MemSizeInfo, paramSize, paramValue)
+	})
+	if err != nil {
+		return nil, err
+	}
+	shadow := &HostShadow{mem: mem, data: make([]byte, size)}
+	hostShadows.mutex.Lock()
+	if existing, known := hostShadows.shadows[mem]; known {
+		hostShadows.mutex.Unlock()
+		return existing, nil
+	}
+	hostShadows.shadows[mem] = shadow
+	hostShadows.bytes += uint64(size)
+	atomic.AddInt32(&hostShadows.count, 1)
+	hostShadows.mutex.Unlock()
+	err = SetMemObjectDestructorCallback(mem, func() { shadow.unregister(mem) })
+	if err != nil {
+		shadow.unregister(mem)
+		return nil, err
+	}
+	return shadow, nil
+}
+
+// HostShadowOf returns the host shadow that is registered for the given buffer, or nil if there is none.
+func HostShadowOf(mem MemObject) *HostShadow {
+	hostShadows.mutex.Lock()
+	defer hostShadows.mutex.Unlock()
+	return hostShadows.shadows[mem]
+}
+
+// HostShadowBytes returns the amount of host memory, in bytes, that all registered shadows occupy.
+// This is the overhead to consider for a memory budget of the host.
+//
+// The package has no memory budget tracker of its own. The shadows are not charged against any limit, and their
+// memory is reported only by this function; applications that enforce a budget need to add it themselves.
+func HostShadowBytes() uint64 {
+	hostShadows.mutex.Lock()
+	defer hostShadows.mutex.Unlock()
+	return hostShadows.bytes
+}
+
+// Release removes the registration of the shadow. The shadow is no longer updated, and its memory no longer
+// accounted for. The data of the shadow remains accessible.
+func (shadow *HostShadow) Release() {
+	shadow.unregister(shadow.MemObject())
+}
+
+// unregister removes the registration of the shadow for the given buffer, if it is still registered for it.
+func (shadow *HostShadow) unregister(mem MemObject) {
+	hostShadows.mutex.Lock()
+	defer hostShadows.mutex.Unlock()
+	if hostShadows.shadows[mem] != shadow {
+		return
+	}
+	delete(hostShadows.shadows, mem)
+	hostShadows.bytes -= uint64(len(shadow.data))
+	atomic.AddInt32(&hostShadows.count, -1)
+}
+
+// prepareRebind registers the destructor callback of a buffer that the shadow is later bound to with rebind().
+// Until then, the callback has no effect. This is the part of rebinding that can fail.
+func (shadow *HostShadow) prepareRebind(mem MemObject) error {
+	return SetMemObjectDestructorCallback(mem, func() { shadow.unregister(mem) })
+}
+
+// rebind makes the given buffer the buffer of the shadow, which Recreate() uses for a recreated buffer.
+// The registration moves from the previous buffer, whose destructor then leaves the shadow alone, to the
+// given one. A shadow whose registration was already removed is registered again.
+// prepareRebind() must have been called for the buffer before.
+func (shadow *HostShadow) rebind(mem MemObject) {
+	hostShadows.mutex.Lock()
+	shadow.mutex.Lock()
+	previous := shadow.mem
+	shadow.mem = mem
+	shadow.mutex.Unlock()
+	if hostShadows.shadows[previous] == shadow {
+		delete(hostShadows.shadows, previous)
+	} else {
+		hostShadows.bytes += uint64(len(shadow.data))
+		atomic.AddInt32(&hostShadows.count, 1)
+	}
+	hostShadows.shadows[mem] = shadow
+	hostShadows.mutex.Unlock()
+}
+
+// MemObject returns the buffer of the shadow.
+func (shadow *HostShadow) MemObject() MemObject {
+	shadow.mutex.Lock()
+	defer shadow.mutex.Unlock()
+	return shadow.mem
+}
+
+// Pointer returns the address of the shadow data.
+func (shadow *HostShadow) Pointer() unsafe.Pointer {
+	if len(shadow.data) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&shadow.data[0])
+}
+
+// Size returns the size of the shadow data, which is the size of the buffer.
+func (shadow *HostShadow) Size() uintptr {
+	return uintptr(len(shadow.data))
+}
+
+// Bytes returns a copy of the shadow data.
+func (shadow *HostShadow) Bytes() []byte {
+	shadow.mutex.Lock()
+	defer shadow.mutex.Unlock()
+	return append([]byte(nil), shadow.data...)
+}
+
+// Sync updates the shadow with the current content of the buffer, with a blocking read on the given queue.
+func (shadow *HostShadow) Sync(commandQueue CommandQueue, waitList []Event) error {
+	shadow.mutex.Lock()
+	defer shadow.mutex.Unlock()
+	if len(shadow.data) == 0 {
+		return nil
+	}
+	return EnqueueReadBuffer(commandQueue, shadow.mem, true, 0, uintptr(len(shadow.data)),
+		unsafe.Pointer(&shadow.data[0]), waitList, nil)
+}
+
+// updateHostShadow copies the written data into the shadow of the buffer, if there is one.
+func updateHostShadow(mem MemObject, offset, size uintptr, data unsafe.Pointer) {
+	if (atomic.LoadInt32(&hostShadows.count) == 0) || (data == nil) {
+		return
+	}
+	shadow := HostShadowOf(mem)
+	if shadow == nil {
+		return
+	}
+	shadow.mutex.Lock()
+	defer shadow.mutex.Unlock()
+	if (offset > uintptr(len(shadow.data))) || (size > uintptr(len(shadow.data))-offset) {
+		return
+	}
+	copy(shadow.data[offset:offset+size], unsafe.Slice((*byte)(data), size))
+}
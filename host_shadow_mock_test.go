@@ -0,0 +1,56 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"testing"
+	"time"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockHostShadow(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 8, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	before := cl.HostShadowBytes()
+	shadow, err := cl.WithHostShadow(buffer)
+	if err != nil {
+		t.Fatalf("WithHostShadow failed: %v", err)
+	}
+	if overhead := cl.HostShadowBytes() - before; overhead != 8 {
+		t.Errorf("unexpected shadow overhead: %v", overhead)
+	}
+	input := []byte("data")
+	err = cl.EnqueueWriteBuffer(queue, buffer, true, 2, 4, unsafe.Pointer(&input[0]), nil, nil)
+	if err != nil {
+		t.Fatalf("EnqueueWriteBuffer failed: %v", err)
+	}
+	if content := shadow.Bytes(); string(content[2:6]) != "data" {
+		t.Errorf("shadow not updated on write: %q", content)
+	}
+	pattern := byte('x')
+	err = cl.EnqueueFillBuffer(queue, buffer, unsafe.Pointer(&pattern), 1, 0, 2, nil, nil)
+	if err != nil {
+		t.Fatalf("EnqueueFillBuffer failed: %v", err)
+	}
+	if err = shadow.Sync(queue, nil); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if content := shadow.Bytes(); string(content[:6]) != "xxdata" {
+		t.Errorf("shadow not updated on sync: %q", content)
+	}
+	if err = cl.ReleaseMemObject(buffer); err != nil {
+		t.Fatalf("ReleaseMemObject failed: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for (cl.HostShadowOf(buffer) != nil) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if cl.HostShadowBytes() != before {
+		t.Errorf("shadow not removed with buffer")
+	}
+}
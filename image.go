@@ -91,6 +91,7 @@ type ImageDesc struct {
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateImage.html
 func CreateImage(context Context, flags MemFlags, format ImageFormat, desc ImageDesc, hostPtr unsafe.Pointer) (MemObject, error) {
+	defer observeCall("clCreateImage")()
 	var status C.cl_int
 	mem := C.clCreateImage(
 		context.handle(),
@@ -100,9 +101,9 @@ func CreateImage(context Context, flags MemFlags, format ImageFormat, desc Image
 		hostPtr,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateImage", status, "context", context, "flags", flags)
 	}
-	return MemObject(*((*uintptr)(unsafe.Pointer(&mem)))), nil
+	return trackCreated(MemObject(*((*uintptr)(unsafe.Pointer(&mem))))), nil
 }
 
 // CreateImageWithProperties creates a 1D image, 1D image buffer, 1D image array, 2D image, 2D image array,
@@ -112,6 +113,7 @@ func CreateImage(context Context, flags MemFlags, format ImageFormat, desc Image
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateImageWithProperties.html
 func CreateImageWithProperties(context Context, flags MemFlags, format ImageFormat, desc ImageDesc, hostPtr unsafe.Pointer,
 	properties ...MemProperty) (MemObject, error) {
+	defer observeCall("clCreateImageWithProperties")()
 	var rawPropertyList []uint64
 	for _, property := range properties {
 		rawPropertyList = append(rawPropertyList, property...)
@@ -131,15 +133,16 @@ func CreateImageWithProperties(context Context, flags MemFlags, format ImageForm
 		hostPtr,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateImageWithProperties", status, "context", context, "flags", flags)
 	}
-	return MemObject(*((*uintptr)(unsafe.Pointer(&mem)))), nil
+	return trackCreated(MemObject(*((*uintptr)(unsafe.Pointer(&mem))))), nil
 }
 
 // SupportedImageFormats returns the list of image formats supported by an OpenCL implementation.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetSupportedImageFormats.html
 func SupportedImageFormats(context Context, flags MemFlags, imageType MemObjectType) ([]ImageFormat, error) {
+	defer observeCall("clGetSupportedImageFormats")()
 	requiredCount := C.cl_uint(0)
 	status := C.clGetSupportedImageFormats(
 		context.handle(),
@@ -149,7 +152,7 @@ func SupportedImageFormats(context Context, flags MemFlags, imageType MemObjectT
 		nil,
 		&requiredCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, operationError("clGetSupportedImageFormats", status, "context", context, "flags", flags)
 	}
 	if requiredCount == 0 {
 		return nil, nil
@@ -164,11 +167,64 @@ func SupportedImageFormats(context Context, flags MemFlags, imageType MemObjectT
 		(*C.cl_image_format)(unsafe.Pointer(&formats[0])),
 		&returnedCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, operationError("clGetSupportedImageFormats", status, "context", context, "flags", flags)
 	}
 	return formats[:returnedCount], nil
 }
 
+// SupportsReadWriteImages returns true if kernels on the device can access images of given format and type with
+// the read_write qualifier.
+//
+// The device must support image arguments with the read_write qualifier, see DeviceMaxReadWriteImageArgsInfo, and
+// the format must be supported for MemKernelReadAndWriteFlag in the context, which must contain the device.
+// The supported formats are queried once per context and image type, and kept in the ContextServices() of the
+// context. If the registry is not available, the formats are queried with every call.
+//
+// Since: 2.0
+func SupportsReadWriteImages(context Context, device DeviceID, format ImageFormat, imageType MemObjectType) (bool, error) {
+	var maxArgs uint32
+	_, err := DeviceInfo(device, DeviceMaxReadWriteImageArgsInfo, unsafe.Sizeof(maxArgs), unsafe.Pointer(&maxArgs))
+	if err != nil {
+		return false, err
+	}
+	if maxArgs == 0 {
+		return false, nil
+	}
+	formats, err := readWriteImageFormats(context, imageType)
+	if err != nil {
+		return false, err
+	}
+	for _, supported := range formats {
+		if supported == format {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// readWriteImageFormatsKey identifies the cached formats of an image type in the registry of a context.
+type readWriteImageFormatsKey struct {
+	imageType MemObjectType
+}
+
+func readWriteImageFormats(context Context, imageType MemObjectType) ([]ImageFormat, error) {
+	services, servicesErr := ContextServices(context)
+	key := readWriteImageFormatsKey{imageType: imageType}
+	if servicesErr == nil {
+		if cached, known := services.Load(key); known {
+			return cached.([]ImageFormat), nil
+		}
+	}
+	formats, err := SupportedImageFormats(context, MemKernelReadAndWriteFlag, imageType)
+	if err != nil {
+		return nil, err
+	}
+	if servicesErr == nil {
+		services.Store(key, formats)
+	}
+	return formats, nil
+}
+
 // MappedImage describes an image as it was mapped into host memory.
 type MappedImage struct {
 	Ptr        unsafe.Pointer
@@ -176,6 +232,24 @@ type MappedImage struct {
 	SlicePitch uintptr
 }
 
+// mapImageArgs are the arguments of clEnqueueMapImage() for runBlocking().
+type mapImageArgs struct {
+	commandQueue C.cl_command_queue
+	image        C.cl_mem
+	blocking     C.cl_bool
+	flags        C.cl_map_flags
+	origin       [3]uintptr
+	region       [3]uintptr
+	numEvents    C.cl_uint
+	waitList     *C.cl_event
+	event        *C.cl_event
+}
+
+type mapImageResult struct {
+	mapped MappedImage
+	status C.cl_int
+}
+
 // EnqueueMapImage enqueues a command to map a region of an image object into the host address space and
 // returns a description of this mapped region.
 //
@@ -183,28 +257,46 @@ type MappedImage struct {
 func EnqueueMapImage(commandQueue CommandQueue,
 	image MemObject, blocking bool, flags MapFlags, origin, region [3]uintptr,
 	waitList []Event, event *Event) (MappedImage, error) {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueMapImage")()
+	if err := injectedEnqueueFault(); err != nil {
+		return MappedImage{}, err
 	}
-	var mapped MappedImage
-	var status C.cl_int
-	mapped.Ptr = C.clEnqueueMapImage(
-		commandQueue.handle(),
-		image.handle(),
-		C.cl_bool(BoolFrom(blocking)),
-		C.cl_map_flags(flags),
-		(*C.size_t)(unsafe.Pointer(&origin[0])),
-		(*C.size_t)(unsafe.Pointer(&region[0])),
-		(*C.size_t)(unsafe.Pointer(&mapped.RowPitch)),
-		(*C.size_t)(unsafe.Pointer(&mapped.SlicePitch)),
-		C.cl_uint(len(waitList)),
-		(*C.cl_event)(rawWaitList),
-		(*C.cl_event)(unsafe.Pointer(event)),
-		&status)
+	if blocking {
+		err := checkBlockingAllowed()
+		if err != nil {
+			return MappedImage{}, err
+		}
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return MappedImage{}, err
+	}
+	defer releaseWaitList()
+	result := runBlocking(blocking, mapImageArgs{
+		commandQueue: commandQueue.handle(),
+		image:        image.handle(),
+		blocking:     C.cl_bool(BoolFrom(blocking)),
+		flags:        C.cl_map_flags(flags),
+		origin:       origin,
+		region:       region,
+		numEvents:    C.cl_uint(len(waitList)),
+		waitList:     (*C.cl_event)(rawWaitList),
+		event:        (*C.cl_event)(unsafe.Pointer(event)),
+	}, func(args mapImageArgs) (mapping mapImageResult) {
+		mapping.mapped.Ptr = C.clEnqueueMapImage(args.commandQueue, args.image, args.blocking, args.flags,
+			(*C.size_t)(unsafe.Pointer(&args.origin[0])),
+			(*C.size_t)(unsafe.Pointer(&args.region[0])),
+			(*C.size_t)(unsafe.Pointer(&mapping.mapped.RowPitch)),
+			(*C.size_t)(unsafe.Pointer(&mapping.mapped.SlicePitch)),
+			args.numEvents, args.waitList, args.event, &mapping.status)
+		return mapping
+	})
+	mapped, status := result.mapped, result.status
 	if status != C.CL_SUCCESS {
-		return MappedImage{}, StatusError(status)
+		return MappedImage{}, operationError("clEnqueueMapImage", status, "commandQueue", commandQueue, "image", image, "flags", flags)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return mapped, nil
 }
 
@@ -279,6 +371,18 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetImageInfo.html
 func ImageInfo(image MemObject, paramName ImageInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	if item, deprecated := deprecatedImageInfoNames[paramName]; deprecated {
+		err := checkDeprecatedUse(item, 0)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return imageInfo(image, paramName, paramSize, paramValue)
+}
+
+// imageInfo is ImageInfo() without the check for deprecated queries, for queries of the library.
+func imageInfo(image MemObject, paramName ImageInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetImageInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetImageInfo(
 		image.handle(),
@@ -287,64 +391,125 @@ func ImageInfo(image MemObject, paramName ImageInfoName, paramSize uintptr, para
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetImageInfo", status, "image", image, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// imageTransferArgs are the arguments of clEnqueueReadImage() and clEnqueueWriteImage() for runBlocking().
+type imageTransferArgs struct {
+	commandQueue C.cl_command_queue
+	image        C.cl_mem
+	blocking     C.cl_bool
+	origin       [3]uintptr
+	region       [3]uintptr
+	rowPitch     C.size_t
+	slicePitch   C.size_t
+	ptr          unsafe.Pointer
+	numEvents    C.cl_uint
+	waitList     *C.cl_event
+	event        *C.cl_event
+}
+
 // EnqueueReadImage enqueues a command to read from an image or image array object to host memory.
 //
+// For non-blocking calls, the host memory is pinned until the command has completed.
+//
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueReadImage.html
 func EnqueueReadImage(commandQueue CommandQueue, image MemObject, blocking bool, origin, region [3]uintptr,
 	rowPitch, slicePitch uintptr, ptr unsafe.Pointer,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueReadImage")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
-	status := C.clEnqueueReadImage(
-		commandQueue.handle(),
-		image.handle(),
-		C.cl_bool(BoolFrom(blocking)),
-		(*C.size_t)(unsafe.Pointer(&origin[0])),
-		(*C.size_t)(unsafe.Pointer(&region[0])),
-		C.size_t(rowPitch),
-		C.size_t(slicePitch),
-		ptr,
-		C.cl_uint(len(waitList)),
-		(*C.cl_event)(rawWaitList),
-		(*C.cl_event)(unsafe.Pointer(event)))
+	if blocking {
+		err := checkBlockingAllowed()
+		if err != nil {
+			return err
+		}
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	pin, eventOut := pinTransfer(blocking, ptr, event)
+	status := runBlocking(blocking, imageTransferArgs{
+		commandQueue: commandQueue.handle(),
+		image:        image.handle(),
+		blocking:     C.cl_bool(BoolFrom(blocking)),
+		origin:       origin,
+		region:       region,
+		rowPitch:     C.size_t(rowPitch),
+		slicePitch:   C.size_t(slicePitch),
+		ptr:          ptr,
+		numEvents:    C.cl_uint(len(waitList)),
+		waitList:     (*C.cl_event)(rawWaitList),
+		event:        (*C.cl_event)(unsafe.Pointer(eventOut)),
+	}, func(args imageTransferArgs) C.cl_int {
+		return C.clEnqueueReadImage(args.commandQueue, args.image, args.blocking,
+			(*C.size_t)(unsafe.Pointer(&args.origin[0])),
+			(*C.size_t)(unsafe.Pointer(&args.region[0])),
+			args.rowPitch, args.slicePitch, args.ptr, args.numEvents, args.waitList, args.event)
+	})
+	pin.enqueued(status == C.CL_SUCCESS, eventOut)
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueReadImage", status, "commandQueue", commandQueue, "image", image)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
 // EnqueueWriteImage enqueues a command to write to an image or image array object from host memory.
 //
+// For non-blocking calls, the host memory is pinned until the command has completed.
+//
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueWriteImage.html
 func EnqueueWriteImage(commandQueue CommandQueue, image MemObject, blocking bool, origin, region [3]uintptr,
 	rowPitch, slicePitch uintptr, ptr unsafe.Pointer,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueWriteImage")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
-	status := C.clEnqueueWriteImage(
-		commandQueue.handle(),
-		image.handle(),
-		C.cl_bool(BoolFrom(blocking)),
-		(*C.size_t)(unsafe.Pointer(&origin[0])),
-		(*C.size_t)(unsafe.Pointer(&region[0])),
-		C.size_t(rowPitch),
-		C.size_t(slicePitch),
-		ptr,
-		C.cl_uint(len(waitList)),
-		(*C.cl_event)(rawWaitList),
-		(*C.cl_event)(unsafe.Pointer(event)))
+	if blocking {
+		err := checkBlockingAllowed()
+		if err != nil {
+			return err
+		}
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	pin, eventOut := pinTransfer(blocking, ptr, event)
+	status := runBlocking(blocking, imageTransferArgs{
+		commandQueue: commandQueue.handle(),
+		image:        image.handle(),
+		blocking:     C.cl_bool(BoolFrom(blocking)),
+		origin:       origin,
+		region:       region,
+		rowPitch:     C.size_t(rowPitch),
+		slicePitch:   C.size_t(slicePitch),
+		ptr:          ptr,
+		numEvents:    C.cl_uint(len(waitList)),
+		waitList:     (*C.cl_event)(rawWaitList),
+		event:        (*C.cl_event)(unsafe.Pointer(eventOut)),
+	}, func(args imageTransferArgs) C.cl_int {
+		return C.clEnqueueWriteImage(args.commandQueue, args.image, args.blocking,
+			(*C.size_t)(unsafe.Pointer(&args.origin[0])),
+			(*C.size_t)(unsafe.Pointer(&args.region[0])),
+			args.rowPitch, args.slicePitch, args.ptr, args.numEvents, args.waitList, args.event)
+	})
+	pin.enqueued(status == C.CL_SUCCESS, eventOut)
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueWriteImage", status, "commandQueue", commandQueue, "image", image)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -357,13 +522,23 @@ func EnqueueWriteImage(commandQueue CommandQueue, image MemObject, blocking bool
 // channel data type is an unnormalized unsigned integer type.
 // The fill color will be converted to the appropriate image channel format and order associated with image.
 //
+// If QuirkEmulateFillImage is enabled and the implementation does not support filling images, the fill is
+// emulated by mapping the region and writing the color on the host. The implementation is considered to not
+// support filling if it fails with ErrInvalidOperation although the device supports images, the image format is
+// supported in the context, and the image is accessible from the host.
+//
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueFillImage.html
 func EnqueueFillImage(commandQueue CommandQueue, image MemObject, fillColor unsafe.Pointer, origin, region [3]uintptr,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueFillImage")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
 	status := C.clEnqueueFillImage(
 		commandQueue.handle(),
 		image.handle(),
@@ -373,9 +548,16 @@ func EnqueueFillImage(commandQueue CommandQueue, image MemObject, fillColor unsa
 		C.cl_uint(len(waitList)),
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
+	if (status == C.CL_INVALID_OPERATION) && QuirksEnabled(QuirkEmulateFillImage) {
+		if emulated, err := emulateFillImage(commandQueue, image, fillColor, origin, region, waitList, event); emulated {
+			return err
+		}
+	}
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueFillImage", status, "commandQueue", commandQueue, "image", image)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -384,10 +566,15 @@ func EnqueueFillImage(commandQueue CommandQueue, image MemObject, fillColor unsa
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueCopyImage.html
 func EnqueueCopyImage(commandQueue CommandQueue, srcImage, dstImage MemObject, srcOrigin, dstOrigin, region [3]uintptr,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueCopyImage")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
 	status := C.clEnqueueCopyImage(
 		commandQueue.handle(),
 		srcImage.handle(),
@@ -399,8 +586,10 @@ func EnqueueCopyImage(commandQueue CommandQueue, srcImage, dstImage MemObject, s
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueCopyImage", status, "commandQueue", commandQueue, "srcImage", srcImage, "dstImage", dstImage)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -409,10 +598,15 @@ func EnqueueCopyImage(commandQueue CommandQueue, srcImage, dstImage MemObject, s
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueCopyImageToBuffer.html
 func EnqueueCopyImageToBuffer(commandQueue CommandQueue, srcImage, dstBuffer MemObject, srcOrigin, region [3]uintptr, dstOffset uintptr,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueCopyImageToBuffer")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
 	}
+	defer releaseWaitList()
 	status := C.clEnqueueCopyImageToBuffer(
 		commandQueue.handle(),
 		srcImage.handle(),
@@ -424,8 +618,10 @@ func EnqueueCopyImageToBuffer(commandQueue CommandQueue, srcImage, dstBuffer Mem
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueCopyImageToBuffer", status, "commandQueue", commandQueue, "srcImage", srcImage, "dstBuffer", dstBuffer)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -434,10 +630,15 @@ func EnqueueCopyImageToBuffer(commandQueue CommandQueue, srcImage, dstBuffer Mem
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueCopyBufferToImage.html
 func EnqueueCopyBufferToImage(commandQueue CommandQueue, srcBuffer, dstImage MemObject, srcOffset uintptr, srcOrigin, region [3]uintptr,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueCopyBufferToImage")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
 	}
+	defer releaseWaitList()
 	status := C.clEnqueueCopyBufferToImage(
 		commandQueue.handle(),
 		srcBuffer.handle(),
@@ -449,7 +650,9 @@ func EnqueueCopyBufferToImage(commandQueue CommandQueue, srcBuffer, dstImage Mem
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueCopyBufferToImage", status, "commandQueue", commandQueue, "srcBuffer", srcBuffer, "dstImage", dstImage)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
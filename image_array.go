@@ -0,0 +1,94 @@
+package cl30
+
+import "unsafe"
+
+// ImageArraySliceRegion returns the origin and region that describe one slice of a 1D or 2D image array.
+//
+// The origin and region can be used with functions such as EnqueueReadImage() to process one layer of the array.
+// ErrInvalidMemObject is returned if the memory object is not an image array, and ErrInvalidValue is returned if the
+// index is out of range.
+//
+// Since: 1.2
+func ImageArraySliceRegion(imageArray MemObject, index uintptr) (origin, region [3]uintptr, err error) {
+	var memType MemObjectType
+	_, err = MemObjectInfo(imageArray, MemTypeInfo, unsafe.Sizeof(memType), unsafe.Pointer(&memType))
+	if err != nil {
+		return
+	}
+	if (memType != MemObjectImage1DArrayType) && (memType != MemObjectImage2DArrayType) {
+		err = ErrInvalidMemObject
+		return
+	}
+	var arraySize uintptr
+	_, err = ImageInfo(imageArray, ImageArraySizeInfo, unsafe.Sizeof(arraySize), unsafe.Pointer(&arraySize))
+	if err != nil {
+		return
+	}
+	if index >= arraySize {
+		err = ErrInvalidValue
+		return
+	}
+	var width uintptr
+	_, err = ImageInfo(imageArray, ImageWidthInfo, unsafe.Sizeof(width), unsafe.Pointer(&width))
+	if err != nil {
+		return
+	}
+	if memType == MemObjectImage1DArrayType {
+		return [3]uintptr{0, index, 0}, [3]uintptr{width, 1, 1}, nil
+	}
+	var height uintptr
+	_, err = ImageInfo(imageArray, ImageHeightInfo, unsafe.Sizeof(height), unsafe.Pointer(&height))
+	if err != nil {
+		return
+	}
+	return [3]uintptr{0, 0, index}, [3]uintptr{width, height, 1}, nil
+}
+
+// EnqueueReadImageArraySlice enqueues a command to read one slice of a 1D or 2D image array to host memory.
+// The rowPitch describes the host memory; it can be zero for tightly packed rows.
+//
+// See ImageArraySliceRegion() and EnqueueReadImage() for details.
+//
+// Since: 1.2
+func EnqueueReadImageArraySlice(commandQueue CommandQueue, imageArray MemObject, index uintptr, blocking bool,
+	rowPitch uintptr, ptr unsafe.Pointer, waitList []Event, event *Event) error {
+	origin, region, err := ImageArraySliceRegion(imageArray, index)
+	if err != nil {
+		return err
+	}
+	return EnqueueReadImage(commandQueue, imageArray, blocking, origin, region, rowPitch, 0, ptr, waitList, event)
+}
+
+// EnqueueWriteImageArraySlice enqueues a command to write one slice of a 1D or 2D image array from host memory.
+// The rowPitch describes the host memory; it can be zero for tightly packed rows.
+//
+// See ImageArraySliceRegion() and EnqueueWriteImage() for details.
+//
+// Since: 1.2
+func EnqueueWriteImageArraySlice(commandQueue CommandQueue, imageArray MemObject, index uintptr, blocking bool,
+	rowPitch uintptr, ptr unsafe.Pointer, waitList []Event, event *Event) error {
+	origin, region, err := ImageArraySliceRegion(imageArray, index)
+	if err != nil {
+		return err
+	}
+	return EnqueueWriteImage(commandQueue, imageArray, blocking, origin, region, rowPitch, 0, ptr, waitList, event)
+}
+
+// EnqueueCopyImageArraySlice enqueues a command to copy one slice of an image array into a slice of another
+// image array of the same type. The size of the slice is taken from the source.
+//
+// See ImageArraySliceRegion() and EnqueueCopyImage() for details.
+//
+// Since: 1.2
+func EnqueueCopyImageArraySlice(commandQueue CommandQueue, srcImageArray MemObject, srcIndex uintptr,
+	dstImageArray MemObject, dstIndex uintptr, waitList []Event, event *Event) error {
+	srcOrigin, region, err := ImageArraySliceRegion(srcImageArray, srcIndex)
+	if err != nil {
+		return err
+	}
+	dstOrigin, _, err := ImageArraySliceRegion(dstImageArray, dstIndex)
+	if err != nil {
+		return err
+	}
+	return EnqueueCopyImage(commandQueue, srcImageArray, dstImageArray, srcOrigin, dstOrigin, region, waitList, event)
+}
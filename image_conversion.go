@@ -0,0 +1,128 @@
+package cl30
+
+import "math"
+
+// EncodeUnorm8 converts floating-point values to normalized unsigned 8-bit values, as used by images with
+// the channel type ChannelTypeUnormInt8. Values are clamped to the range [0.0, 1.0], and NaN is converted to zero.
+func EncodeUnorm8(values []float32) []uint8 {
+	data := make([]uint8, len(values))
+	for i, value := range values {
+		data[i] = unorm8From(value)
+	}
+	return data
+}
+
+// DecodeUnorm8 converts normalized unsigned 8-bit values to floating-point values in the range [0.0, 1.0].
+func DecodeUnorm8(data []uint8) []float32 {
+	values := make([]float32, len(data))
+	for i, raw := range data {
+		values[i] = float32(raw) / math.MaxUint8
+	}
+	return values
+}
+
+// EncodeSnorm8 converts floating-point values to normalized signed 8-bit values, as used by images with
+// the channel type ChannelTypeSnormInt8. Values are clamped to the range [-1.0, 1.0], and NaN is converted to zero.
+func EncodeSnorm8(values []float32) []int8 {
+	data := make([]int8, len(values))
+	for i, value := range values {
+		data[i] = snorm8From(value)
+	}
+	return data
+}
+
+// DecodeSnorm8 converts normalized signed 8-bit values to floating-point values in the range [-1.0, 1.0].
+// As specified by OpenCL, both -128 and -127 are converted to -1.0.
+func DecodeSnorm8(data []int8) []float32 {
+	values := make([]float32, len(data))
+	for i, raw := range data {
+		values[i] = float32(math.Max(-1.0, float64(raw)/math.MaxInt8))
+	}
+	return values
+}
+
+// EncodeUnorm16 converts floating-point values to normalized unsigned 16-bit values, as used by images with
+// the channel type ChannelTypeUnormInt16. Values are clamped to the range [0.0, 1.0], and NaN is converted to zero.
+func EncodeUnorm16(values []float32) []uint16 {
+	data := make([]uint16, len(values))
+	for i, value := range values {
+		data[i] = unorm16From(value)
+	}
+	return data
+}
+
+// DecodeUnorm16 converts normalized unsigned 16-bit values to floating-point values in the range [0.0, 1.0].
+func DecodeUnorm16(data []uint16) []float32 {
+	values := make([]float32, len(data))
+	for i, raw := range data {
+		values[i] = float32(raw) / math.MaxUint16
+	}
+	return values
+}
+
+// EncodeSrgb8 converts linear floating-point values to normalized unsigned 8-bit values with the sRGB transfer
+// function applied, as used by images with the channel orders ChannelOrderSrgb, ChannelOrderSrgba, and similar.
+//
+// The channels parameter is the number of channels per pixel. For four channels, the last channel of each pixel is
+// the alpha channel, which is encoded linearly.
+func EncodeSrgb8(values []float32, channels int) []uint8 {
+	data := make([]uint8, len(values))
+	for i, value := range values {
+		if isAlphaChannel(i, channels) {
+			data[i] = unorm8From(value)
+		} else {
+			data[i] = unorm8From(srgbFromLinear(normalized(value, 0, 1)))
+		}
+	}
+	return data
+}
+
+// DecodeSrgb8 converts normalized unsigned 8-bit values in sRGB encoding to linear floating-point values.
+//
+// The channels parameter is the number of channels per pixel. For four channels, the last channel of each pixel is
+// the alpha channel, which is decoded linearly.
+func DecodeSrgb8(data []uint8, channels int) []float32 {
+	values := make([]float32, len(data))
+	for i, raw := range data {
+		value := float32(raw) / math.MaxUint8
+		if !isAlphaChannel(i, channels) {
+			value = linearFromSrgb(value)
+		}
+		values[i] = value
+	}
+	return values
+}
+
+func isAlphaChannel(index, channels int) bool {
+	return (channels == 4) && ((index % channels) == 3)
+}
+
+func unorm8From(value float32) uint8 {
+	return uint8(normalized(value, 0, 1)*math.MaxUint8 + 0.5)
+}
+
+func unorm16From(value float32) uint16 {
+	return uint16(normalized(value, 0, 1)*math.MaxUint16 + 0.5)
+}
+
+func snorm8From(value float32) int8 {
+	return int8(math.Round(float64(normalized(value, -1, 1) * math.MaxInt8)))
+}
+
+func snorm16From(value float32) int16 {
+	return int16(math.Round(float64(normalized(value, -1, 1) * math.MaxInt16)))
+}
+
+func srgbFromLinear(value float32) float32 {
+	if value <= 0.0031308 {
+		return value * 12.92
+	}
+	return float32(1.055*math.Pow(float64(value), 1.0/2.4) - 0.055)
+}
+
+func linearFromSrgb(value float32) float32 {
+	if value <= 0.04045 {
+		return value / 12.92
+	}
+	return float32(math.Pow((float64(value)+0.055)/1.055, 2.4))
+}
@@ -0,0 +1,53 @@
+package cl30_test
+
+import (
+	"math"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestNormalizedConversions(t *testing.T) {
+	t.Parallel()
+	unorm8 := cl.EncodeUnorm8([]float32{-0.5, 0.0, 0.5, 1.0, 2.0})
+	if expected := []uint8{0, 0, 128, 255, 255}; !equalSlices(unorm8, expected) {
+		t.Errorf("unexpected UNORM8 encoding: %v, expected %v", unorm8, expected)
+	}
+	snorm8 := cl.EncodeSnorm8([]float32{-2.0, -1.0, 0.0, 1.0})
+	if expected := []int8{-127, -127, 0, 127}; !equalSlices(snorm8, expected) {
+		t.Errorf("unexpected SNORM8 encoding: %v, expected %v", snorm8, expected)
+	}
+	if decoded := cl.DecodeSnorm8([]int8{-128}); decoded[0] != -1.0 {
+		t.Errorf("unexpected SNORM8 decoding of -128: %v", decoded[0])
+	}
+	unorm16 := cl.EncodeUnorm16([]float32{1.0})
+	if decoded := cl.DecodeUnorm16(unorm16); decoded[0] != 1.0 {
+		t.Errorf("unexpected UNORM16 round trip: %v", decoded[0])
+	}
+}
+
+func TestSrgbConversionKeepsAlphaLinear(t *testing.T) {
+	t.Parallel()
+	encoded := cl.EncodeSrgb8([]float32{0.5, 0.5, 0.5, 0.5}, 4)
+	if (encoded[0] != 188) || (encoded[3] != 128) {
+		t.Errorf("unexpected sRGB encoding: %v", encoded)
+	}
+	decoded := cl.DecodeSrgb8(encoded, 4)
+	for i, value := range decoded {
+		if math.Abs(float64(value)-0.5) > 0.01 {
+			t.Errorf("unexpected round trip value at %d: %v", i, value)
+		}
+	}
+}
+
+func equalSlices[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,184 @@
+package cl30
+
+import (
+	"encoding/binary"
+	"math"
+	"unsafe"
+)
+
+// emulateFillImage fills the region of an image by mapping it into host memory and writing the encoded color.
+// It returns false if the fill is not emulated, because the failure of the native call has a different cause, or
+// because the format of the image is not supported by the emulation.
+func emulateFillImage(commandQueue CommandQueue, image MemObject, fillColor unsafe.Pointer, origin, region [3]uintptr,
+	waitList []Event, event *Event) (bool, error) {
+	var format ImageFormat
+	_, err := imageInfo(image, ImageFormatInfo, unsafe.Sizeof(format), unsafe.Pointer(&format))
+	if err != nil {
+		return false, err
+	}
+	var memType MemObjectType
+	_, err = MemObjectInfo(image, MemTypeInfo, unsafe.Sizeof(memType), unsafe.Pointer(&memType))
+	if err != nil {
+		return false, err
+	}
+	if !fillImageUnsupported(commandQueue, image, format, memType) {
+		return false, nil
+	}
+	pixel, supported := encodeFillColor(format, fillColor)
+	if !supported {
+		return false, nil
+	}
+	mapped, err := EnqueueMapImage(commandQueue, image, true, MapWrite, origin, region, waitList, nil)
+	if err != nil {
+		return true, err
+	}
+	rowPitch := mapped.RowPitch
+	if memType == MemObjectImage1DArrayType {
+		// The rows of a 1D image array are its images.
+		rowPitch = mapped.SlicePitch
+	}
+	pixelSize := uintptr(len(pixel))
+	for z := uintptr(0); z < region[2]; z++ {
+		for y := uintptr(0); y < region[1]; y++ {
+			row := unsafe.Add(mapped.Ptr, z*mapped.SlicePitch+y*rowPitch)
+			rowBytes := unsafe.Slice((*byte)(row), region[0]*pixelSize)
+			for x := uintptr(0); x < region[0]; x++ {
+				copy(rowBytes[x*pixelSize:], pixel)
+			}
+		}
+	}
+	return true, EnqueueUnmapMemObject(commandQueue, image, mapped.Ptr, nil, event)
+}
+
+// fillImageUnsupported returns true if a failure of clEnqueueFillImage() with CL_INVALID_OPERATION can only be
+// caused by missing support for filling images. This is the case if the device of the command-queue supports
+// images, the format of the image is supported in its context, and the image allows writes from the host, which
+// the emulation requires.
+func fillImageUnsupported(commandQueue CommandQueue, image MemObject, format ImageFormat, memType MemObjectType) bool {
+	var device DeviceID
+	_, err := CommandQueueInfo(commandQueue, QueueDeviceInfo, unsafe.Sizeof(device), unsafe.Pointer(&device))
+	if err != nil {
+		return false
+	}
+	imageSupport, err := queryValue[Bool](internalDeviceInfoLoader(device, DeviceImageSupportInfo))
+	if (err != nil) || !imageSupport.ToGoBool() {
+		return false
+	}
+	var flags MemFlags
+	_, err = MemObjectInfo(image, MemFlagsInfo, unsafe.Sizeof(flags), unsafe.Pointer(&flags))
+	if (err != nil) || ((flags & (MemHostReadOnlyFlag | MemHostNoAccessFlag)) != 0) {
+		return false
+	}
+	var context Context
+	_, err = MemObjectInfo(image, MemContextInfo, unsafe.Sizeof(context), unsafe.Pointer(&context))
+	if err != nil {
+		return false
+	}
+	accessFlags := flags & (MemReadWriteFlag | MemWriteOnlyFlag | MemReadOnlyFlag | MemKernelReadAndWriteFlag)
+	formats, err := SupportedImageFormats(context, accessFlags, memType)
+	if err != nil {
+		return false
+	}
+	for _, supported := range formats {
+		if supported == format {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeFillColor converts the fill color, as specified for EnqueueFillImage(), into the bytes of one pixel.
+func encodeFillColor(format ImageFormat, fillColor unsafe.Pointer) ([]byte, bool) {
+	components, supported := fillColorComponents(format.ChannelOrder)
+	if !supported {
+		return nil, false
+	}
+	floats := unsafe.Slice((*float32)(fillColor), 4)
+	ints := unsafe.Slice((*int32)(fillColor), 4)
+	uints := unsafe.Slice((*uint32)(fillColor), 4)
+	var pixel []byte
+	for _, component := range components {
+		switch format.ChannelType {
+		case ChannelTypeUnormInt8:
+			pixel = append(pixel, unorm8From(floats[component]))
+		case ChannelTypeUnormInt16:
+			pixel = binary.LittleEndian.AppendUint16(pixel, unorm16From(floats[component]))
+		case ChannelTypeSnormInt8:
+			pixel = append(pixel, uint8(snorm8From(floats[component])))
+		case ChannelTypeSnormInt16:
+			pixel = binary.LittleEndian.AppendUint16(pixel, uint16(snorm16From(floats[component])))
+		case ChannelTypeSignedInt8:
+			pixel = append(pixel, uint8(int8(ints[component])))
+		case ChannelTypeSignedInt16:
+			pixel = binary.LittleEndian.AppendUint16(pixel, uint16(int16(ints[component])))
+		case ChannelTypeSignedInt32:
+			pixel = binary.LittleEndian.AppendUint32(pixel, uint32(ints[component]))
+		case ChannelTypeUnsignedInt8:
+			pixel = append(pixel, uint8(uints[component]))
+		case ChannelTypeUnsignedInt16:
+			pixel = binary.LittleEndian.AppendUint16(pixel, uint16(uints[component]))
+		case ChannelTypeUnsignedInt32:
+			pixel = binary.LittleEndian.AppendUint32(pixel, uints[component])
+		case ChannelTypeHalfFloat:
+			pixel = binary.LittleEndian.AppendUint16(pixel, halfFloatFrom(floats[component]))
+		case ChannelTypeFloat:
+			pixel = binary.LittleEndian.AppendUint32(pixel, math.Float32bits(floats[component]))
+		default:
+			return nil, false
+		}
+	}
+	return pixel, true
+}
+
+// fillColorComponents returns the indices into the RGBA fill color, in the order of the channels in memory.
+func fillColorComponents(order ChannelOrder) ([]int, bool) {
+	switch order {
+	case ChannelOrderR, ChannelOrderIntensity, ChannelOrderLuminance, ChannelOrderDepth:
+		return []int{0}, true
+	case ChannelOrderA:
+		return []int{3}, true
+	case ChannelOrderRg:
+		return []int{0, 1}, true
+	case ChannelOrderRa:
+		return []int{0, 3}, true
+	case ChannelOrderRgba:
+		return []int{0, 1, 2, 3}, true
+	case ChannelOrderBgra:
+		return []int{2, 1, 0, 3}, true
+	case ChannelOrderArgb:
+		return []int{3, 0, 1, 2}, true
+	case ChannelOrderAbgr:
+		return []int{3, 2, 1, 0}, true
+	default:
+		return nil, false
+	}
+}
+
+func normalized(value, lower, upper float32) float32 {
+	if math.IsNaN(float64(value)) {
+		return 0
+	}
+	return float32(math.Max(float64(lower), math.Min(float64(upper), float64(value))))
+}
+
+// halfFloatFrom converts a 32-bit floating point value to a 16-bit one, rounding towards zero.
+func halfFloatFrom(value float32) uint16 {
+	bits := math.Float32bits(value)
+	sign := uint16((bits >> 16) & 0x8000)
+	exponent := int32((bits>>23)&0xFF) - 127 + 15
+	mantissa := bits & 0x7FFFFF
+	switch {
+	case math.IsNaN(float64(value)):
+		return sign | 0x7E00
+	case exponent >= 0x1F:
+		return sign | 0x7C00
+	case exponent <= 0:
+		if exponent < -10 {
+			return sign
+		}
+		mantissa |= 0x800000
+		return sign | uint16(mantissa>>uint32(14-exponent))
+	default:
+		return sign | uint16(exponent)<<10 | uint16(mantissa>>13)
+	}
+}
@@ -0,0 +1,62 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockSupportsReadWriteImages(t *testing.T) {
+	rgba := cl.ImageFormat{ChannelOrder: cl.ChannelOrderRgba, ChannelType: cl.ChannelTypeUnormInt8}
+	r := cl.ImageFormat{ChannelOrder: cl.ChannelOrderR, ChannelType: cl.ChannelTypeUnormInt8}
+	cl.SetMockPlatforms([]cl.MockPlatform{{Devices: []cl.MockDevice{
+		{MaxReadWriteImageArgs: 8, ImageFormats: []cl.ImageFormat{rgba}},
+		{ImageFormats: []cl.ImageFormat{rgba, r}},
+	}}})
+	t.Cleanup(func() { cl.SetMockPlatforms(nil) })
+	platforms, err := cl.PlatformIDs()
+	if err != nil {
+		t.Fatalf("PlatformIDs failed: %v", err)
+	}
+	devices, err := cl.DeviceIDs(platforms[0], cl.DeviceTypeAll)
+	if err != nil {
+		t.Fatalf("DeviceIDs failed: %v", err)
+	}
+	context, err := cl.CreateContext(devices[:1], nil)
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseContext(context) }()
+
+	cl.ResetCallStats()
+	cl.EnableCallMetrics(true)
+	defer cl.EnableCallMetrics(false)
+	defer cl.ResetCallStats()
+	for i := 0; i < 3; i++ {
+		supported, err := cl.SupportsReadWriteImages(context, devices[0], rgba, cl.MemObjectImage2DType)
+		if err != nil {
+			t.Fatalf("SupportsReadWriteImages failed: %v", err)
+		}
+		if !supported {
+			t.Errorf("format of the device not reported as supported")
+		}
+	}
+	if supported, _ := cl.SupportsReadWriteImages(context, devices[0], r, cl.MemObjectImage2DType); supported {
+		t.Errorf("unknown format reported as supported")
+	}
+	if supported, _ := cl.SupportsReadWriteImages(context, devices[1], rgba, cl.MemObjectImage2DType); supported {
+		t.Errorf("device without read-write image arguments reported as supported")
+	}
+	for _, stat := range cl.CallStats() {
+		switch stat.Name {
+		case "clCreateContext":
+			t.Errorf("temporary context created")
+		case "clGetSupportedImageFormats":
+			if stat.Count != 1 {
+				t.Errorf("formats not cached per context: %d queries", stat.Count)
+			}
+		}
+	}
+}
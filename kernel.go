@@ -8,7 +8,7 @@ package cl30
 //    cl_event *event);
 import "C"
 import (
-	"fmt"
+	"sync"
 	"unsafe"
 )
 
@@ -20,23 +20,26 @@ func (kernel Kernel) handle() C.cl_kernel {
 }
 
 // String provides a readable presentation of the kernel identifier.
-// It is based on the numerical value of the underlying pointer.
+// It is based on the numerical value of the underlying pointer, followed by the name set with SetDebugName(), if any.
 func (kernel Kernel) String() string {
-	return fmt.Sprintf("0x%X", uintptr(kernel))
+	return handleString(kernel, uintptr(kernel))
 }
 
 // CreateKernel creates a kernel object.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateKernel.html
 func CreateKernel(program Program, name string) (Kernel, error) {
+	defer observeCall("clCreateKernel")()
 	rawName := C.CString(name)
 	defer C.free(unsafe.Pointer(rawName))
 	var status C.cl_int
 	kernel := C.clCreateKernel(program.handle(), rawName, &status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateKernel", status, "program", program)
 	}
-	return Kernel(*((*uintptr)(unsafe.Pointer(&kernel)))), nil
+	created := trackCreated(Kernel(*((*uintptr)(unsafe.Pointer(&kernel)))))
+	recordKernelCreated(created)
+	return created, nil
 }
 
 // CreateKernelsInProgram creates kernel objects for all kernel functions in a program object.
@@ -58,10 +61,11 @@ func CreateKernel(program Program, name string) (Kernel, error) {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateKernelsInProgram.html
 func CreateKernelsInProgram(program Program) ([]Kernel, error) {
+	defer observeCall("clCreateKernelsInProgram")()
 	var requiredCount C.cl_uint
 	status := C.clCreateKernelsInProgram(program.handle(), 0, nil, &requiredCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, operationError("clCreateKernelsInProgram", status, "program", program)
 	}
 	if requiredCount == 0 {
 		return nil, nil
@@ -74,9 +78,14 @@ func CreateKernelsInProgram(program Program) ([]Kernel, error) {
 		(*C.cl_kernel)(unsafe.Pointer(&kernels[0])),
 		&returnedCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, operationError("clCreateKernelsInProgram", status, "program", program)
 	}
-	return kernels[:int(returnedCount)], nil
+	kernels = kernels[:int(returnedCount)]
+	for _, kernel := range kernels {
+		trackCreated(kernel)
+		recordKernelCreated(kernel)
+	}
+	return kernels, nil
 }
 
 // CloneKernel makes a shallow copy of the kernel object.
@@ -105,22 +114,30 @@ func CreateKernelsInProgram(program Program) ([]Kernel, error) {
 // Since: 2.1
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCloneKernel.html
 func CloneKernel(kernel Kernel) (Kernel, error) {
+	defer observeCall("clCloneKernel")()
 	var status C.cl_int
 	kernelCopy := C.clCloneKernel(kernel.handle(), &status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCloneKernel", status, "kernel", kernel)
 	}
-	return Kernel(*((*uintptr)(unsafe.Pointer(&kernelCopy)))), nil
+	clone := trackCreated(Kernel(*((*uintptr)(unsafe.Pointer(&kernelCopy)))))
+	recordKernelClone(kernel, clone)
+	return clone, nil
 }
 
 // RetainKernel increments the kernel reference count.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clRetainKernel.html
 func RetainKernel(kernel Kernel) error {
+	defer observeCall("clRetainKernel")()
 	status := C.clRetainKernel(kernel.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clRetainKernel", status, "kernel", kernel)
 	}
+	trackRetained(kernel)
+	retainOwned(kernel)
+	retainNamed(kernel)
+	recordKernelRetained(kernel)
 	return nil
 }
 
@@ -131,10 +148,15 @@ func RetainKernel(kernel Kernel) error {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clReleaseKernel.html
 func ReleaseKernel(kernel Kernel) error {
+	defer observeCall("clReleaseKernel")()
 	status := C.clReleaseKernel(kernel.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clReleaseKernel", status, "kernel", kernel)
 	}
+	recordKernelReleased(kernel)
+	trackReleased(kernel)
+	disownReleased(kernel)
+	unnameReleased(kernel)
 	return nil
 }
 
@@ -142,14 +164,16 @@ func ReleaseKernel(kernel Kernel) error {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSetKernelArg.html
 func SetKernelArg(kernel Kernel, index uint32, size uintptr, value unsafe.Pointer) error {
+	defer observeCall("clSetKernelArg")()
 	status := C.clSetKernelArg(
 		kernel.handle(),
 		C.cl_uint(index),
 		C.size_t(size),
 		value)
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clSetKernelArg", status, "kernel", kernel, "index", index, "size", size)
 	}
+	recordKernelArgValue(kernel, index, size, value)
 	return nil
 }
 
@@ -158,10 +182,12 @@ func SetKernelArg(kernel Kernel, index uint32, size uintptr, value unsafe.Pointe
 // Since: 2.0
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSetKernelArgSVMPointer.html
 func SetKernelArgSvmPointer(kernel Kernel, index uint32, value unsafe.Pointer) error {
+	defer observeCall("clSetKernelArgSVMPointer")()
 	status := C.clSetKernelArgSVMPointer(kernel.handle(), C.cl_uint(index), value)
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clSetKernelArgSVMPointer", status, "kernel", kernel, "index", index)
 	}
+	recordKernelArg(kernel, index, recordedKernelArg{svm: value, isSvm: true})
 	return nil
 }
 
@@ -192,13 +218,120 @@ const (
 // Since: 2.0
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSetKernelExecInfo.html
 func SetKernelExecInfo(kernel Kernel, paramName KernelExecInfoName, paramSize uintptr, paramValue unsafe.Pointer) error {
+	defer observeCall("clSetKernelExecInfo")()
 	status := C.clSetKernelExecInfo(
 		kernel.handle(),
 		C.cl_kernel_exec_info(paramName),
 		C.size_t(paramSize),
 		paramValue)
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clSetKernelExecInfo", status, "kernel", kernel, "paramName", paramName)
+	}
+	return nil
+}
+
+// SetKernelExecInfoSvmPtrs is a convenience function for SetKernelExecInfo() to set KernelExecInfoSvmPtrs.
+//
+// The provided pointers are those SVM allocations that the kernel accesses indirectly, for example through
+// pointers stored within other SVM buffers or when the kernel enqueues child kernels on the device-side default
+// queue that access these buffers. An empty slice clears any previously set list.
+//
+// ErrInvalidOperation is returned without calling the driver if pointers are provided, yet no device of the
+// context of the kernel supports shared virtual memory; see KernelSvmCapabilities().
+//
+// Since: 2.0
+func SetKernelExecInfoSvmPtrs(kernel Kernel, ptrs []unsafe.Pointer) error {
+	var rawPtrs unsafe.Pointer
+	if len(ptrs) > 0 {
+		if err := requireKernelSvmCapabilities(kernel, DeviceSvmCoarseGrainBuffer|DeviceSvmFineGrainBuffer|
+			DeviceSvmFineGrainSystem); err != nil {
+			return err
+		}
+		ptrAddresses := make([]uintptr, len(ptrs))
+		for i, ptr := range ptrs {
+			ptrAddresses[i] = uintptr(ptr)
+		}
+		rawPtrs = unsafe.Pointer(&ptrAddresses[0])
+	}
+	return SetKernelExecInfo(kernel, KernelExecInfoSvmPtrs, uintptr(len(ptrs))*unsafe.Sizeof(uintptr(0)), rawPtrs)
+}
+
+// SetKernelExecInfoSvmFineGrainSystem is a convenience function for SetKernelExecInfo() to set
+// KernelExecInfoSvmFineGrainSystem.
+//
+// ErrInvalidOperation is returned without calling the driver if used is true, yet no device of the context
+// of the kernel supports fine-grain system SVM allocations; see KernelSvmCapabilities().
+//
+// Since: 2.0
+func SetKernelExecInfoSvmFineGrainSystem(kernel Kernel, used bool) error {
+	if used {
+		if err := requireKernelSvmCapabilities(kernel, DeviceSvmFineGrainSystem); err != nil {
+			return err
+		}
+	}
+	value := BoolFrom(used)
+	return SetKernelExecInfo(kernel, KernelExecInfoSvmFineGrainSystem, unsafe.Sizeof(value), unsafe.Pointer(&value))
+}
+
+// KernelSvmCapabilities returns the combined shared virtual memory capabilities of all devices in the context of
+// the kernel. These determine which execution information is accepted by SetKernelExecInfo().
+//
+// The capabilities are queried once per context, and kept in the ContextServices() of the context.
+//
+// Since: 2.0
+func KernelSvmCapabilities(kernel Kernel) (DeviceSvmCapabilitiesFlags, error) {
+	context, err := queryValue[Context](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return KernelInfo(kernel, KernelContextInfo, paramSize, paramValue)
+	})
+	if err != nil {
+		return 0, err
+	}
+	services, servicesErr := ContextServices(context)
+	if servicesErr == nil {
+		if cached, known := services.Load(svmCapabilitiesKey{}); known {
+			return cached.(DeviceSvmCapabilitiesFlags), nil
+		}
+	}
+	combined, err := contextSvmCapabilities(context)
+	if err != nil {
+		return 0, err
+	}
+	if servicesErr == nil {
+		services.Store(svmCapabilitiesKey{}, combined)
+	}
+	return combined, nil
+}
+
+// svmCapabilitiesKey identifies the combined SVM capabilities in the registry of a context.
+type svmCapabilitiesKey struct{}
+
+func contextSvmCapabilities(context Context) (DeviceSvmCapabilitiesFlags, error) {
+	devices, err := querySlice[DeviceID](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return ContextInfo(context, ContextDevicesInfo, paramSize, paramValue)
+	})
+	if err != nil {
+		return 0, err
+	}
+	var combined DeviceSvmCapabilitiesFlags
+	for _, device := range devices {
+		capabilities, err := queryValue[DeviceSvmCapabilitiesFlags](deviceInfoLoader(device, DeviceSvmCapabilitiesInfo))
+		if err != nil {
+			return 0, err
+		}
+		combined |= capabilities
+	}
+	return combined, nil
+}
+
+// requireKernelSvmCapabilities returns ErrInvalidOperation if no device of the kernel provides any of the
+// given capabilities.
+func requireKernelSvmCapabilities(kernel Kernel, anyOf DeviceSvmCapabilitiesFlags) error {
+	capabilities, err := KernelSvmCapabilities(kernel)
+	if err != nil {
+		return err
+	}
+	if (capabilities & anyOf) == 0 {
+		return ErrInvalidOperation
 	}
 	return nil
 }
@@ -250,6 +383,7 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetKernelInfo.html
 func KernelInfo(kernel Kernel, paramName KernelInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetKernelInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetKernelInfo(
 		kernel.handle(),
@@ -258,7 +392,7 @@ func KernelInfo(kernel Kernel, paramName KernelInfoName, paramSize uintptr, para
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetKernelInfo", status, "kernel", kernel, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
@@ -343,6 +477,7 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetKernelWorkGroupInfo.html
 func KernelWorkGroupInfo(kernel Kernel, device DeviceID, paramName KernelWorkGroupInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetKernelWorkGroupInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetKernelWorkGroupInfo(
 		kernel.handle(),
@@ -352,7 +487,7 @@ func KernelWorkGroupInfo(kernel Kernel, device DeviceID, paramName KernelWorkGro
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetKernelWorkGroupInfo", status, "kernel", kernel, "device", device, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
@@ -438,6 +573,7 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetKernelArgInfo.html
 func KernelArgInfo(kernel Kernel, index uint32, paramName KernelArgInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetKernelArgInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetKernelArgInfo(
 		kernel.handle(),
@@ -447,7 +583,7 @@ func KernelArgInfo(kernel Kernel, index uint32, paramName KernelArgInfoName, par
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetKernelArgInfo", status, "kernel", kernel, "index", index, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
@@ -543,6 +679,7 @@ const (
 func KernelSubGroupInfo(kernel Kernel, device DeviceID, paramName KernelSubGroupInfoName,
 	inputSize uintptr, inputValue unsafe.Pointer,
 	paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetKernelSubGroupInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetKernelSubGroupInfo(
 		kernel.handle(),
@@ -554,11 +691,26 @@ func KernelSubGroupInfo(kernel Kernel, device DeviceID, paramName KernelSubGroup
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetKernelSubGroupInfo", status, "kernel", kernel, "device", device, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// pooledWorkDimensions is the number of work dimensions for which kernel launches need no allocations.
+const pooledWorkDimensions = 3
+
+// workSizeBuffers hold the work sizes that are passed to the OpenCL implementation. Memory that is passed to
+// a C function escapes to the heap, which is why the buffers are taken from a pool instead of the stack.
+type workSizeBuffers struct {
+	offsets [pooledWorkDimensions]uintptr
+	globals [pooledWorkDimensions]uintptr
+	locals  [pooledWorkDimensions]uintptr
+}
+
+var workSizeBuffersPool = sync.Pool{
+	New: func() any { return new(workSizeBuffers) },
+}
+
 // WorkDimension describes the parameters within one dimension of a work group.
 type WorkDimension struct {
 	GlobalOffset uintptr
@@ -568,19 +720,29 @@ type WorkDimension struct {
 
 // EnqueueNDRangeKernel enqueues a command to execute a kernel on a device.
 //
+// Launches with up to three dimensions do not allocate memory in this wrapper.
+//
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueNDRangeKernel.html
 func EnqueueNDRangeKernel(commandQueue CommandQueue, kernel Kernel, workDimensions []WorkDimension, waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
-	}
-	globalWorkOffsets := make([]uintptr, len(workDimensions))
-	globalWorkSizes := make([]uintptr, len(workDimensions))
-	localWorkSizes := make([]uintptr, len(workDimensions))
-	for i, dimension := range workDimensions {
-		globalWorkOffsets[i] = dimension.GlobalOffset
-		globalWorkSizes[i] = dimension.GlobalSize
-		localWorkSizes[i] = dimension.LocalSize
+	defer observeCall("clEnqueueNDRangeKernel")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	if len(workDimensions) == 0 {
+		return ErrInvalidWorkDimension
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	buffers := workSizeBuffersPool.Get().(*workSizeBuffers)
+	defer workSizeBuffersPool.Put(buffers)
+	globalWorkOffsets, globalWorkSizes, localWorkSizes := buffers.offsets[:0], buffers.globals[:0], buffers.locals[:0]
+	for _, dimension := range workDimensions {
+		globalWorkOffsets = append(globalWorkOffsets, dimension.GlobalOffset)
+		globalWorkSizes = append(globalWorkSizes, dimension.GlobalSize)
+		localWorkSizes = append(localWorkSizes, dimension.LocalSize)
 	}
 	status := C.clEnqueueNDRangeKernel(
 		commandQueue.handle(),
@@ -593,8 +755,172 @@ func EnqueueNDRangeKernel(commandQueue CommandQueue, kernel Kernel, workDimensio
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueNDRangeKernel", status, "commandQueue", commandQueue, "kernel", kernel, "dims", len(workDimensions))
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
+	return nil
+}
+
+// KernelEnqueueOption is an optional parameter for EnqueueKernel().
+type KernelEnqueueOption func(*kernelEnqueueParameters)
+
+type kernelEnqueueParameters struct {
+	globalOffset     []uintptr
+	localSize        []uintptr
+	waitList         []Event
+	event            *Event
+	globalFromBuffer *globalBufferSource
+	buffers          workSizeBuffers
+}
+
+// kernelEnqueueParametersPool avoids an allocation of the parameters for each call of EnqueueKernel(),
+// as they escape through the option functions.
+var kernelEnqueueParametersPool = sync.Pool{
+	New: func() any { return new(kernelEnqueueParameters) },
+}
+
+func releaseKernelEnqueueParameters(params *kernelEnqueueParameters) {
+	*params = kernelEnqueueParameters{}
+	kernelEnqueueParametersPool.Put(params)
+}
+
+type globalBufferSource struct {
+	buffer      MemObject
+	elementSize uintptr
+}
+
+// WithGlobalOffset specifies the offsets used to calculate the global ID of a work-item.
+// The number of entries must match the number of dimensions of the global work size.
+func WithGlobalOffset(offset []uintptr) KernelEnqueueOption {
+	return func(params *kernelEnqueueParameters) {
+		params.globalOffset = offset
+	}
+}
+
+// WithLocalWorkSize specifies the number of work-items that make up a work-group.
+// The number of entries must match the number of dimensions of the global work size.
+// If not specified, the OpenCL implementation will determine how to break the global work-items
+// into appropriate work-group instances.
+func WithLocalWorkSize(size []uintptr) KernelEnqueueOption {
+	return func(params *kernelEnqueueParameters) {
+		params.localSize = size
+	}
+}
+
+// GlobalFromBuffer derives a one-dimensional global work size from the size of the buffer, with one work-item per
+// element of the given size. This avoids mismatches between the length of the data and the dispatch range of
+// elementwise kernels.
+//
+// The global work size passed to EnqueueKernel() must be empty if this option is used. The size of the buffer is
+// queried with each launch, and ErrBufferSizeNotMultiple is returned if it is not a multiple of the element size.
+func GlobalFromBuffer(buffer MemObject, elementSize uintptr) KernelEnqueueOption {
+	return func(params *kernelEnqueueParameters) {
+		params.globalFromBuffer = &globalBufferSource{buffer: buffer, elementSize: elementSize}
+	}
+}
+
+func (source *globalBufferSource) globalWorkSize() (uintptr, error) {
+	if source.elementSize == 0 {
+		return 0, ErrInvalidValue
+	}
+	size, err := queryValue[uintptr](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return MemObjectInfo(source.buffer, MemSizeInfo, paramSize, paramValue)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if (size % source.elementSize) != 0 {
+		return 0, ErrBufferSizeNotMultiple
+	}
+	return size / source.elementSize, nil
+}
+
+// WithWaitList specifies events that need to complete before the kernel can be executed.
+func WithWaitList(waitList ...Event) KernelEnqueueOption {
+	return func(params *kernelEnqueueParameters) {
+		params.waitList = waitList
+	}
+}
+
+// WithEventOut specifies where to store the event that identifies the kernel execution.
+// Without this option, or with a nil pointer, no event is requested from the OpenCL implementation.
+func WithEventOut(event *Event) KernelEnqueueOption {
+	return func(params *kernelEnqueueParameters) {
+		params.event = event
+	}
+}
+
+// EnqueueKernel enqueues a command to execute a kernel on a device.
+//
+// This is an alternative to EnqueueNDRangeKernel(), which takes the global work size as a slice and accepts
+// the remaining parameters as options. The number of dimensions is determined by the length of globalWorkSize.
+// Unlike EnqueueNDRangeKernel(), the local work size is not specified unless WithLocalWorkSize() is provided.
+//
+// Launches with up to three dimensions do not allocate memory in this wrapper. This does not extend to the options:
+// each call of an option function, such as WithLocalWorkSize(), allocates. Frequent launches should therefore
+// create their options once and reuse them.
+//
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueNDRangeKernel.html
+func EnqueueKernel(commandQueue CommandQueue, kernel Kernel, globalWorkSize []uintptr, opts ...KernelEnqueueOption) error {
+	defer observeCall("clEnqueueNDRangeKernel")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	params := kernelEnqueueParametersPool.Get().(*kernelEnqueueParameters)
+	defer releaseKernelEnqueueParameters(params)
+	for _, opt := range opts {
+		opt(params)
+	}
+	// The global work size is copied so that the slice of the caller does not escape to the heap.
+	globals := append(params.buffers.globals[:0], globalWorkSize...)
+	if params.globalFromBuffer != nil {
+		if len(globals) != 0 {
+			return ErrInvalidGlobalWorkSize
+		}
+		derivedGlobalWorkSize, err := params.globalFromBuffer.globalWorkSize()
+		if err != nil {
+			return err
+		}
+		globals = append(globals, derivedGlobalWorkSize)
+	}
+	if len(globals) == 0 {
+		return ErrInvalidWorkDimension
+	}
+	var rawGlobalOffset unsafe.Pointer
+	if params.globalOffset != nil {
+		if len(params.globalOffset) != len(globals) {
+			return ErrInvalidGlobalOffset
+		}
+		rawGlobalOffset = unsafe.Pointer(&params.globalOffset[0])
+	}
+	var rawLocalSize unsafe.Pointer
+	if params.localSize != nil {
+		if len(params.localSize) != len(globals) {
+			return ErrInvalidWorkGroupSize
+		}
+		rawLocalSize = unsafe.Pointer(&params.localSize[0])
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, params.waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	status := C.clEnqueueNDRangeKernel(
+		commandQueue.handle(),
+		kernel.handle(),
+		C.cl_uint(len(globals)),
+		(*C.size_t)(rawGlobalOffset),
+		(*C.size_t)(unsafe.Pointer(&globals[0])),
+		(*C.size_t)(rawLocalSize),
+		C.cl_uint(len(params.waitList)),
+		(*C.cl_event)(rawWaitList),
+		(*C.cl_event)(unsafe.Pointer(params.event)))
+	if status != C.CL_SUCCESS {
+		return operationError("clEnqueueNDRangeKernel", status, "commandQueue", commandQueue, "kernel", kernel, "dims", len(globals))
+	}
+	trackEnqueuedEvent(params.event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -605,6 +931,10 @@ func EnqueueNDRangeKernel(commandQueue CommandQueue, kernel Kernel, workDimensio
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueNativeKernel.html
 func EnqueueNativeKernel(commandQueue CommandQueue, callback func([]unsafe.Pointer), memObjects []MemObject, waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueNativeKernel")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
 	callbackUserData, err := userDataFor(func(argBasePtr unsafe.Pointer) {
 		argMovePtr := argBasePtr
 		memPtr := make([]unsafe.Pointer, len(memObjects))
@@ -617,10 +947,12 @@ func EnqueueNativeKernel(commandQueue CommandQueue, callback func([]unsafe.Point
 	if err != nil {
 		return err
 	}
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		callbackUserData.Delete()
+		return err
 	}
+	defer releaseWaitList()
 	rawArgs := make([]uintptr, len(memObjects)+1)
 	rawArgs[0] = uintptr(unsafe.Pointer(callbackUserData.ptr))
 	var rawArgsMemLocs []uintptr
@@ -648,8 +980,10 @@ func EnqueueNativeKernel(commandQueue CommandQueue, callback func([]unsafe.Point
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
 		callbackUserData.Delete()
-		return StatusError(status)
+		return operationError("clEnqueueNativeKernel", status, "commandQueue", commandQueue)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -658,5 +992,5 @@ func cl30GoKernelNativeCallback(args unsafe.Pointer) {
 	callbackUserData := userDataFrom(*(**C.uintptr_t)(args))
 	callback := callbackUserData.Value().(func(unsafe.Pointer))
 	callbackUserData.Delete()
-	callback(unsafe.Add(args, unsafe.Sizeof(uintptr(0))))
+	runInlineCallback(func() { callback(unsafe.Add(args, unsafe.Sizeof(uintptr(0)))) })
 }
@@ -0,0 +1,252 @@
+package cl30
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// KernelArgsSnapshot is a captured set of kernel arguments; see ArgsSnapshot().
+type KernelArgsSnapshot struct {
+	args map[uint32]recordedKernelArg
+}
+
+type recordedKernelArg struct {
+	size  uintptr
+	value []byte
+	svm   unsafe.Pointer
+	isSvm bool
+}
+
+func (arg recordedKernelArg) equal(other recordedKernelArg) bool {
+	return (arg.isSvm == other.isSvm) && (arg.svm == other.svm) && (arg.size == other.size) &&
+		((arg.value == nil) == (other.value == nil)) && bytes.Equal(arg.value, other.value)
+}
+
+func (arg recordedKernelArg) apply(kernel Kernel, index uint32) error {
+	if arg.isSvm {
+		return SetKernelArgSvmPointer(kernel, index, arg.svm)
+	}
+	var value unsafe.Pointer
+	if len(arg.value) > 0 {
+		value = unsafe.Pointer(&arg.value[0])
+	}
+	return SetKernelArg(kernel, index, arg.size, value)
+}
+
+// recordedKernel holds the recorded arguments of a kernel, and counts the references of the application,
+// so that the record can be removed with the last release.
+type recordedKernel struct {
+	args       map[uint32]recordedKernelArg
+	references int
+}
+
+var kernelArgRecorder = struct {
+	enabled int32
+	mutex   sync.Mutex
+	kernels map[Kernel]*recordedKernel
+}{
+	kernels: make(map[Kernel]*recordedKernel),
+}
+
+// EnableKernelArgRecording enables or disables the recording of kernel arguments.
+//
+// The OpenCL API provides no means to query the values of kernel arguments. When enabled, the arguments that are
+// set with SetKernelArg() and SetKernelArgSvmPointer() are recorded per kernel, so that they can be captured
+// with ArgsSnapshot() and set again with ApplyArgs(). A clone of CloneKernel() starts with the recorded arguments
+// of its source.
+//
+// The records of a kernel are removed with its last release through ReleaseKernel(). For this, the references
+// of a kernel are counted by CreateKernel(), RetainKernel(), and ReleaseKernel() while recording is enabled.
+// A kernel that was created while recording was disabled is assumed to have a single reference once its first
+// argument is recorded.
+//
+// Recording is disabled by default, as it copies every argument value. Arguments that were set while recording
+// was disabled are not known. Disabling the recording removes all records.
+func EnableKernelArgRecording(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&kernelArgRecorder.enabled, value)
+	if !enabled {
+		kernelArgRecorder.mutex.Lock()
+		kernelArgRecorder.kernels = make(map[Kernel]*recordedKernel)
+		kernelArgRecorder.mutex.Unlock()
+	}
+}
+
+// KernelArgRecordingEnabled returns true if kernel arguments are being recorded.
+func KernelArgRecordingEnabled() bool {
+	return atomic.LoadInt32(&kernelArgRecorder.enabled) != 0
+}
+
+// ArgsSnapshot captures the recorded arguments of the kernel.
+//
+// The snapshot can be applied to clones of the kernel, or to a kernel that was created anew, for example after
+// its program was rebuilt. ErrKernelArgsNotRecorded is returned if recording is not enabled;
+// see EnableKernelArgRecording().
+func ArgsSnapshot(kernel Kernel) (KernelArgsSnapshot, error) {
+	if !KernelArgRecordingEnabled() {
+		return KernelArgsSnapshot{}, ErrKernelArgsNotRecorded
+	}
+	kernelArgRecorder.mutex.Lock()
+	defer kernelArgRecorder.mutex.Unlock()
+	return KernelArgsSnapshot{args: copyRecordedKernelArgs(recordedKernelArgs(kernel))}, nil
+}
+
+// ApplyArgs sets the arguments of the snapshot on the kernel.
+//
+// If recording is enabled, only the arguments that differ from the recorded arguments of the kernel are set.
+// Otherwise, all arguments of the snapshot are set. Arguments that are not part of the snapshot are left unchanged.
+// Shared virtual memory pointers are set with SetKernelArgSvmPointer().
+func ApplyArgs(kernel Kernel, snapshot KernelArgsSnapshot) error {
+	var current KernelArgsSnapshot
+	if KernelArgRecordingEnabled() {
+		kernelArgRecorder.mutex.Lock()
+		current.args = copyRecordedKernelArgs(recordedKernelArgs(kernel))
+		kernelArgRecorder.mutex.Unlock()
+	}
+	for _, index := range snapshot.Diff(current) {
+		arg, set := snapshot.args[index]
+		if !set {
+			continue
+		}
+		if err := arg.apply(kernel, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Len returns the number of arguments in the snapshot.
+func (snapshot KernelArgsSnapshot) Len() int {
+	return len(snapshot.args)
+}
+
+// Indices returns the indices of the arguments in the snapshot, in ascending order.
+func (snapshot KernelArgsSnapshot) Indices() []uint32 {
+	indices := make([]uint32, 0, len(snapshot.args))
+	for index := range snapshot.args {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(a, b int) bool { return indices[a] < indices[b] })
+	return indices
+}
+
+// Diff returns the indices of the arguments that differ between the two snapshots, in ascending order.
+// An argument that is only part of one of the snapshots is considered different.
+func (snapshot KernelArgsSnapshot) Diff(other KernelArgsSnapshot) []uint32 {
+	var indices []uint32
+	for index, arg := range snapshot.args {
+		otherArg, known := other.args[index]
+		if !known || !arg.equal(otherArg) {
+			indices = append(indices, index)
+		}
+	}
+	for index := range other.args {
+		if _, known := snapshot.args[index]; !known {
+			indices = append(indices, index)
+		}
+	}
+	sort.Slice(indices, func(a, b int) bool { return indices[a] < indices[b] })
+	return indices
+}
+
+func copyRecordedKernelArgs(args map[uint32]recordedKernelArg) map[uint32]recordedKernelArg {
+	result := make(map[uint32]recordedKernelArg, len(args))
+	for index, arg := range args {
+		result[index] = arg
+	}
+	return result
+}
+
+// recordedKernelArgs returns the recorded arguments of the kernel. The recorder must be locked.
+func recordedKernelArgs(kernel Kernel) map[uint32]recordedKernelArg {
+	if record, known := kernelArgRecorder.kernels[kernel]; known {
+		return record.args
+	}
+	return nil
+}
+
+// recordKernelArg records a successfully set argument, if recording is enabled.
+func recordKernelArg(kernel Kernel, index uint32, arg recordedKernelArg) {
+	if !KernelArgRecordingEnabled() {
+		return
+	}
+	kernelArgRecorder.mutex.Lock()
+	defer kernelArgRecorder.mutex.Unlock()
+	record := kernelArgRecorder.kernels[kernel]
+	if record == nil {
+		record = &recordedKernel{args: make(map[uint32]recordedKernelArg), references: 1}
+		kernelArgRecorder.kernels[kernel] = record
+	}
+	record.args[index] = arg
+}
+
+// recordKernelArgValue records an argument of SetKernelArg(). The value is copied.
+func recordKernelArgValue(kernel Kernel, index uint32, size uintptr, value unsafe.Pointer) {
+	if !KernelArgRecordingEnabled() {
+		return
+	}
+	arg := recordedKernelArg{size: size}
+	if value != nil {
+		arg.value = append([]byte{}, unsafe.Slice((*byte)(value), size)...)
+	}
+	recordKernelArg(kernel, index, arg)
+}
+
+// recordKernelCreated starts counting the references of a new kernel, if recording is enabled.
+func recordKernelCreated(kernel Kernel) {
+	if !KernelArgRecordingEnabled() {
+		return
+	}
+	kernelArgRecorder.mutex.Lock()
+	defer kernelArgRecorder.mutex.Unlock()
+	kernelArgRecorder.kernels[kernel] = &recordedKernel{args: make(map[uint32]recordedKernelArg), references: 1}
+}
+
+// recordKernelClone lets the clone start with the recorded arguments of the source kernel.
+func recordKernelClone(kernel, clone Kernel) {
+	if !KernelArgRecordingEnabled() {
+		return
+	}
+	kernelArgRecorder.mutex.Lock()
+	defer kernelArgRecorder.mutex.Unlock()
+	args := make(map[uint32]recordedKernelArg)
+	if record, known := kernelArgRecorder.kernels[kernel]; known {
+		args = copyRecordedKernelArgs(record.args)
+	}
+	kernelArgRecorder.kernels[clone] = &recordedKernel{args: args, references: 1}
+}
+
+// recordKernelRetained counts an additional reference of a recorded kernel.
+func recordKernelRetained(kernel Kernel) {
+	if !KernelArgRecordingEnabled() {
+		return
+	}
+	kernelArgRecorder.mutex.Lock()
+	defer kernelArgRecorder.mutex.Unlock()
+	if record, known := kernelArgRecorder.kernels[kernel]; known {
+		record.references++
+	}
+}
+
+// recordKernelReleased counts a released reference of a recorded kernel, and removes the record with the last one.
+func recordKernelReleased(kernel Kernel) {
+	if !KernelArgRecordingEnabled() {
+		return
+	}
+	kernelArgRecorder.mutex.Lock()
+	defer kernelArgRecorder.mutex.Unlock()
+	record, known := kernelArgRecorder.kernels[kernel]
+	if !known {
+		return
+	}
+	record.references--
+	if record.references <= 0 {
+		delete(kernelArgRecorder.kernels, kernel)
+	}
+}
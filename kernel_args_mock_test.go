@@ -0,0 +1,122 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockKernelArgsSnapshot(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	cl.EnableKernelArgRecording(true)
+	defer cl.EnableKernelArgRecording(false)
+	program, err := cl.CreateProgramWithSource(context, []string{"kernel void fill(global uchar *out, uchar value) {}"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	if err = cl.BuildProgram(program, nil, "", nil); err != nil {
+		t.Fatalf("BuildProgram failed: %v", err)
+	}
+	source, err := cl.CreateKernel(program, "fill")
+	if err != nil {
+		t.Fatalf("CreateKernel failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseKernel(source) }()
+	target, err := cl.CreateKernel(program, "fill")
+	if err != nil {
+		t.Fatalf("CreateKernel failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseKernel(target) }()
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 4, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+
+	cl.SetMockKernel("fill", func(call cl.MockKernelCall) error {
+		for i := range call.Args[0].Buffer {
+			call.Args[0].Buffer[i] = call.Args[1].Value[0]
+		}
+		return nil
+	})
+	defer cl.SetMockKernel("fill", nil)
+	value := uint8(5)
+	_ = cl.SetKernelArg(source, 0, unsafe.Sizeof(buffer), unsafe.Pointer(&buffer))
+	_ = cl.SetKernelArg(source, 1, unsafe.Sizeof(value), unsafe.Pointer(&value))
+	snapshot, err := cl.ArgsSnapshot(source)
+	if (err != nil) || (snapshot.Len() != 2) {
+		t.Fatalf("unexpected snapshot: %d, %v", snapshot.Len(), err)
+	}
+	value = 9
+	_ = cl.SetKernelArg(target, 1, unsafe.Sizeof(value), unsafe.Pointer(&value))
+	current, _ := cl.ArgsSnapshot(target)
+	if diff := snapshot.Diff(current); !reflect.DeepEqual(diff, []uint32{0, 1}) {
+		t.Errorf("unexpected diff: %v", diff)
+	}
+	if err = cl.ApplyArgs(target, snapshot); err != nil {
+		t.Fatalf("ApplyArgs failed: %v", err)
+	}
+	if err = cl.EnqueueKernel(queue, target, []uintptr{4}); err != nil {
+		t.Fatalf("EnqueueKernel failed: %v", err)
+	}
+	output := make([]byte, 4)
+	err = cl.EnqueueReadBuffer(queue, buffer, true, 0, 4, unsafe.Pointer(&output[0]), nil, nil)
+	if (err != nil) || (output[0] != 5) || (output[3] != 5) {
+		t.Errorf("unexpected kernel result: %v, %v", output, err)
+	}
+	current, _ = cl.ArgsSnapshot(target)
+	if diff := snapshot.Diff(current); len(diff) != 0 {
+		t.Errorf("arguments differ after ApplyArgs: %v", diff)
+	}
+}
+
+func TestMockKernelArgsRecordsFollowReferences(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	cl.EnableKernelArgRecording(true)
+	defer cl.EnableKernelArgRecording(false)
+	cl.ResetCallStats()
+	cl.EnableCallMetrics(true)
+	defer cl.EnableCallMetrics(false)
+	defer cl.ResetCallStats()
+	program, err := cl.CreateProgramWithSource(context, []string{"kernel void fill(global uchar *out, uchar value) {}"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	if err = cl.BuildProgram(program, nil, "", nil); err != nil {
+		t.Fatalf("BuildProgram failed: %v", err)
+	}
+	kernel, err := cl.CreateKernel(program, "fill")
+	if err != nil {
+		t.Fatalf("CreateKernel failed: %v", err)
+	}
+	value := uint8(3)
+	if err = cl.SetKernelArg(kernel, 1, unsafe.Sizeof(value), unsafe.Pointer(&value)); err != nil {
+		t.Fatalf("SetKernelArg failed: %v", err)
+	}
+	if err = cl.RetainKernel(kernel); err != nil {
+		t.Fatalf("RetainKernel failed: %v", err)
+	}
+	if err = cl.ReleaseKernel(kernel); err != nil {
+		t.Fatalf("ReleaseKernel failed: %v", err)
+	}
+	if snapshot, _ := cl.ArgsSnapshot(kernel); snapshot.Len() != 1 {
+		t.Errorf("records removed while a reference remains")
+	}
+	if err = cl.ReleaseKernel(kernel); err != nil {
+		t.Fatalf("ReleaseKernel failed: %v", err)
+	}
+	if snapshot, _ := cl.ArgsSnapshot(kernel); snapshot.Len() != 0 {
+		t.Errorf("records kept after the last release")
+	}
+	for _, stat := range cl.CallStats() {
+		if stat.Name == "clGetKernelInfo" {
+			t.Errorf("reference count queried from the implementation")
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package cl30
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// KernelAttrs contains the attributes of a kernel function declaration, as they are reported with
+// KernelAttributesInfo.
+type KernelAttrs struct {
+	// ReqdWorkGroupSize is the work-group size that the kernel must be enqueued with,
+	// or all zero if not specified.
+	ReqdWorkGroupSize [3]uintptr
+	// WorkGroupSizeHint is the work-group size that the kernel is most likely to be enqueued with,
+	// or all zero if not specified.
+	WorkGroupSizeHint [3]uintptr
+	// VecTypeHint is the name of the type that the kernel is written for, such as "float4",
+	// or an empty string if not specified.
+	VecTypeHint string
+	// Others contains all further attributes in their source presentation, such as "nosvm".
+	Others []string
+}
+
+// HasReqdWorkGroupSize returns true if the kernel requires a specific work-group size.
+func (attrs KernelAttrs) HasReqdWorkGroupSize() bool {
+	return attrs.ReqdWorkGroupSize != [3]uintptr{}
+}
+
+// KernelAttributes queries the attributes of the kernel with KernelAttributesInfo and parses them.
+//
+// Since: 1.2
+func KernelAttributes(kernel Kernel) (KernelAttrs, error) {
+	text, err := KernelInfoString(kernel, KernelAttributesInfo)
+	if err != nil {
+		return KernelAttrs{}, err
+	}
+	return ParseKernelAttributes(text)
+}
+
+var kernelAttributePattern = regexp.MustCompile(`(\w+)\s*(?:\(([^()]*)\))?`)
+
+// ParseKernelAttributes parses an attribute string, as reported with KernelAttributesInfo.
+//
+// The attributes are separated by white space. An optional __attribute__((...)) wrapping, as used in source,
+// is accepted as well. Work-group sizes with fewer than three dimensions are completed with 1.
+func ParseKernelAttributes(text string) (KernelAttrs, error) {
+	var attrs KernelAttrs
+	for _, match := range kernelAttributePattern.FindAllStringSubmatch(text, -1) {
+		name, arguments := match[1], match[2]
+		var err error
+		switch name {
+		case "__attribute__":
+		case "reqd_work_group_size":
+			attrs.ReqdWorkGroupSize, err = parseKernelAttributeSize(arguments)
+		case "work_group_size_hint":
+			attrs.WorkGroupSizeHint, err = parseKernelAttributeSize(arguments)
+		case "vec_type_hint":
+			attrs.VecTypeHint = strings.TrimSpace(arguments)
+		default:
+			attrs.Others = append(attrs.Others, match[0])
+		}
+		if err != nil {
+			return KernelAttrs{}, fmt.Errorf("attribute %q: %w", match[0], err)
+		}
+	}
+	return attrs, nil
+}
+
+func parseKernelAttributeSize(arguments string) ([3]uintptr, error) {
+	size := [3]uintptr{1, 1, 1}
+	values := strings.Split(arguments, ",")
+	if len(values) > len(size) {
+		return [3]uintptr{}, ErrInvalidValue
+	}
+	for i, value := range values {
+		parsed, err := strconv.ParseUint(strings.TrimSpace(value), 0, 64)
+		if (err != nil) || (parsed == 0) {
+			return [3]uintptr{}, ErrInvalidValue
+		}
+		size[i] = uintptr(parsed)
+	}
+	return size, nil
+}
@@ -0,0 +1,39 @@
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestParseKernelAttributes(t *testing.T) {
+	t.Parallel()
+	attrs, err := cl.ParseKernelAttributes("reqd_work_group_size(8,4,1) work_group_size_hint(64) vec_type_hint(float4) nosvm")
+	if err != nil {
+		t.Fatalf("parsing failed: %v", err)
+	}
+	if !attrs.HasReqdWorkGroupSize() || (attrs.ReqdWorkGroupSize != [3]uintptr{8, 4, 1}) {
+		t.Errorf("unexpected required size: %v", attrs.ReqdWorkGroupSize)
+	}
+	if attrs.WorkGroupSizeHint != [3]uintptr{64, 1, 1} {
+		t.Errorf("unexpected size hint: %v", attrs.WorkGroupSizeHint)
+	}
+	if attrs.VecTypeHint != "float4" {
+		t.Errorf("unexpected vector type hint: %q", attrs.VecTypeHint)
+	}
+	if (len(attrs.Others) != 1) || (attrs.Others[0] != "nosvm") {
+		t.Errorf("unexpected other attributes: %v", attrs.Others)
+	}
+
+	attrs, err = cl.ParseKernelAttributes("__attribute__((reqd_work_group_size(16, 16, 1)))")
+	if (err != nil) || (attrs.ReqdWorkGroupSize != [3]uintptr{16, 16, 1}) || (len(attrs.Others) != 0) {
+		t.Errorf("unexpected result for source syntax: %+v, %v", attrs, err)
+	}
+	if _, err = cl.ParseKernelAttributes("reqd_work_group_size(8,x)"); !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("unexpected error for invalid size: %v", err)
+	}
+	if attrs, err = cl.ParseKernelAttributes(""); (err != nil) || attrs.HasReqdWorkGroupSize() {
+		t.Errorf("unexpected result for empty string: %+v, %v", attrs, err)
+	}
+}
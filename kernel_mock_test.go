@@ -0,0 +1,178 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockEnqueueKernelOptionsDoNotCarryOver(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	kernel := mockKernel(t, context, "kernel void empty() {}", "empty")
+	var event cl.Event
+	if err := cl.EnqueueKernel(queue, kernel, []uintptr{4}, cl.WithEventOut(&event)); err != nil {
+		t.Fatalf("EnqueueKernel failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(event) }()
+	first := event
+	err := cl.EnqueueKernel(queue, kernel, []uintptr{4, 2})
+	if err != nil {
+		t.Fatalf("EnqueueKernel failed: %v", err)
+	}
+	if event != first {
+		t.Errorf("event of previous launch was overwritten")
+	}
+	if err = cl.EnqueueKernel(queue, kernel, nil); !errors.Is(err, cl.ErrInvalidWorkDimension) {
+		t.Errorf("unexpected error for missing work size: %v", err)
+	}
+	if err = cl.EnqueueNDRangeKernel(queue, kernel, nil, nil, nil); !errors.Is(err, cl.ErrInvalidWorkDimension) {
+		t.Errorf("unexpected error for missing dimensions: %v", err)
+	}
+	if err = cl.Finish(queue); err != nil {
+		t.Errorf("Finish failed: %v", err)
+	}
+}
+
+func TestMockKernelExecInfoRequiresSvm(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	kernel := mockKernel(t, context, "kernel void empty() {}", "empty")
+
+	capabilities, err := cl.KernelSvmCapabilities(kernel)
+	if (err != nil) || (capabilities != 0) {
+		t.Errorf("unexpected capabilities: %v, %v", capabilities, err)
+	}
+	var value uint32
+	if err = cl.SetKernelExecInfoSvmPtrs(kernel, []unsafe.Pointer{unsafe.Pointer(&value)}); !errors.Is(err, cl.ErrInvalidOperation) {
+		t.Errorf("unexpected error for SVM pointers: %v", err)
+	}
+	if err = cl.SetKernelExecInfoSvmFineGrainSystem(kernel, true); !errors.Is(err, cl.ErrInvalidOperation) {
+		t.Errorf("unexpected error for fine-grain system: %v", err)
+	}
+}
+
+func TestMockKernelExecInfoSetters(t *testing.T) {
+	context, _, _ := mockQueueOn(t, []cl.MockPlatform{{Devices: []cl.MockDevice{{
+		SvmCapabilities: cl.DeviceSvmCoarseGrainBuffer | cl.DeviceSvmFineGrainSystem,
+	}}}})
+	kernel := mockKernel(t, context, "kernel void empty() {}", "empty")
+
+	var first, second uint64
+	ptrs := []unsafe.Pointer{unsafe.Pointer(&first), unsafe.Pointer(&second)}
+	if err := cl.SetKernelExecInfoSvmPtrs(kernel, ptrs); err != nil {
+		t.Fatalf("SetKernelExecInfoSvmPtrs failed: %v", err)
+	}
+	raw, set := cl.MockKernelExecInfo(kernel, cl.KernelExecInfoSvmPtrs)
+	if !set || (len(raw) != 2*int(unsafe.Sizeof(uintptr(0)))) {
+		t.Fatalf("unexpected raw pointer list: %v", raw)
+	}
+	addresses := unsafe.Slice((*uintptr)(unsafe.Pointer(&raw[0])), 2)
+	if (addresses[0] != uintptr(ptrs[0])) || (addresses[1] != uintptr(ptrs[1])) {
+		t.Errorf("pointers not passed in order: %x", addresses)
+	}
+	if err := cl.SetKernelExecInfoSvmPtrs(kernel, nil); err != nil {
+		t.Fatalf("SetKernelExecInfoSvmPtrs failed for empty list: %v", err)
+	}
+	if raw, _ = cl.MockKernelExecInfo(kernel, cl.KernelExecInfoSvmPtrs); len(raw) != 0 {
+		t.Errorf("pointer list not cleared: %v", raw)
+	}
+
+	for _, used := range []bool{true, false} {
+		if err := cl.SetKernelExecInfoSvmFineGrainSystem(kernel, used); err != nil {
+			t.Fatalf("SetKernelExecInfoSvmFineGrainSystem failed: %v", err)
+		}
+		raw, set = cl.MockKernelExecInfo(kernel, cl.KernelExecInfoSvmFineGrainSystem)
+		if !set || (len(raw) != int(unsafe.Sizeof(cl.Bool(0)))) {
+			t.Fatalf("unexpected raw flag: %v", raw)
+		}
+		if value := *(*cl.Bool)(unsafe.Pointer(&raw[0])); value.ToGoBool() != used {
+			t.Errorf("flag not passed: %v, expected %v", value, used)
+		}
+	}
+}
+
+func TestMockKernelSvmCapabilitiesCachedPerContext(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{{Devices: []cl.MockDevice{
+		{SvmCapabilities: cl.DeviceSvmCoarseGrainBuffer},
+		{SvmCapabilities: cl.DeviceSvmFineGrainSystem},
+	}}})
+	t.Cleanup(func() { cl.SetMockPlatforms(nil) })
+	platforms, err := cl.PlatformIDs()
+	if err != nil {
+		t.Fatalf("PlatformIDs failed: %v", err)
+	}
+	devices, err := cl.DeviceIDs(platforms[0], cl.DeviceTypeAll)
+	if err != nil {
+		t.Fatalf("DeviceIDs failed: %v", err)
+	}
+	context, err := cl.CreateContext(devices, nil)
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseContext(context) }()
+	kernel := mockKernel(t, context, "kernel void empty() {}", "empty")
+
+	defer cl.EnableCallMetrics(false)
+	defer cl.ResetCallStats()
+	for i := 0; i < 3; i++ {
+		capabilities, err := cl.KernelSvmCapabilities(kernel)
+		if err != nil {
+			t.Fatalf("KernelSvmCapabilities failed: %v", err)
+		}
+		if capabilities != cl.DeviceSvmCoarseGrainBuffer|cl.DeviceSvmFineGrainSystem {
+			t.Errorf("unexpected combined capabilities: %v", capabilities)
+		}
+		if i == 0 {
+			// Record the calls after the first query only, which fills the cache.
+			cl.ResetCallStats()
+			cl.EnableCallMetrics(true)
+		}
+	}
+	for _, stat := range cl.CallStats() {
+		if (stat.Name == "clGetContextInfo") || (stat.Name == "clGetDeviceInfo") {
+			t.Errorf("capabilities queried again with %s", stat.Name)
+		}
+	}
+}
+
+func TestMockKernelLaunchesDoNotAllocate(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	kernel := mockKernel(t, context, "kernel void empty() {}", "empty")
+	dimensions := []cl.WorkDimension{{GlobalSize: 16, LocalSize: 4}, {GlobalSize: 8, LocalSize: 2}}
+	// Options allocate when they are created, which EnqueueKernel() documents. Only reused options are free.
+	reusedLocals := cl.WithLocalWorkSize([]uintptr{4, 2})
+	rawLaunch, free := cl.MockRawKernelLaunch(queue, kernel, dimensions)
+	defer free()
+
+	// All allocations of a raw launch are made by the mock driver. The wrappers must not add any.
+	baseline := testing.AllocsPerRun(100, func() { _ = rawLaunch() })
+	tt := []struct {
+		name   string
+		launch func() error
+	}{
+		{name: "EnqueueNDRangeKernel", launch: func() error {
+			return cl.EnqueueNDRangeKernel(queue, kernel, dimensions, nil, nil)
+		}},
+		{name: "EnqueueKernel", launch: func() error {
+			return cl.EnqueueKernel(queue, kernel, []uintptr{16, 8})
+		}},
+		{name: "EnqueueKernel with reused options", launch: func() error {
+			return cl.EnqueueKernel(queue, kernel, []uintptr{16, 8}, reusedLocals)
+		}},
+	}
+	for _, tc := range tt {
+		if err := tc.launch(); err != nil {
+			t.Fatalf("%s failed: %v", tc.name, err)
+		}
+		allocs := testing.AllocsPerRun(100, func() { _ = tc.launch() })
+		if allocs > baseline {
+			t.Errorf("%s allocates %v times per launch, the mock driver alone %v times", tc.name, allocs, baseline)
+		}
+	}
+	if err := cl.Finish(queue); err != nil {
+		t.Errorf("Finish failed: %v", err)
+	}
+}
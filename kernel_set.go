@@ -0,0 +1,109 @@
+package cl30
+
+import "sync"
+
+// KernelSet distributes one kernel across several command-queues.
+//
+// Kernel arguments are state of the kernel object, which is why concurrent SetKernelArg() and enqueue calls on the
+// same kernel race with each other. KernelSet avoids this by holding one clone of the kernel per command-queue,
+// and by serializing the launches per command-queue. Launches for different command-queues can run concurrently.
+type KernelSet struct {
+	queues  []CommandQueue
+	entries []kernelSetEntry
+	closed  bool
+	mutex   sync.RWMutex
+}
+
+type kernelSetEntry struct {
+	kernel Kernel
+	mutex  *sync.Mutex
+}
+
+// NewKernelSet creates a KernelSet that clones the given kernel for each of the command-queues.
+// The clones inherit the arguments that are set on the kernel at this time.
+// The source kernel remains owned by the caller.
+//
+// The returned set must be released with Close().
+//
+// Since: 2.1
+func NewKernelSet(kernel Kernel, queues []CommandQueue) (*KernelSet, error) {
+	set := &KernelSet{queues: append([]CommandQueue{}, queues...)}
+	for range queues {
+		clone, err := CloneKernel(kernel)
+		if err != nil {
+			_ = set.Close()
+			return nil, err
+		}
+		set.entries = append(set.entries, kernelSetEntry{kernel: clone, mutex: &sync.Mutex{}})
+	}
+	return set, nil
+}
+
+// Len returns the number of command-queues of the set. It returns zero once the set is closed.
+func (set *KernelSet) Len() int {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+	return len(set.entries)
+}
+
+// Kernel returns the clone of the kernel that is used for the command-queue with the given index.
+// The returned kernel remains owned by the set. Zero is returned if the index is out of range, or if the set
+// is closed.
+func (set *KernelSet) Kernel(queueIndex int) Kernel {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+	if (queueIndex < 0) || (queueIndex >= len(set.entries)) {
+		return 0
+	}
+	return set.entries[queueIndex].kernel
+}
+
+// Launch sets the arguments on the kernel clone of the command-queue with the given index, and enqueues it
+// on that command-queue. The arguments are set by their position; a nil entry leaves the respective argument
+// unchanged, which allows arguments that were set before, such as local memory sizes, to be kept.
+//
+// ErrInvalidValue is returned if the index is out of range, and ErrKernelSetClosed if the set is closed.
+func (set *KernelSet) Launch(queueIndex int, globalWorkSize []uintptr, args []HostMemory, opts ...KernelEnqueueOption) error {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+	if set.closed {
+		return ErrKernelSetClosed
+	}
+	if (queueIndex < 0) || (queueIndex >= len(set.entries)) {
+		return ErrInvalidValue
+	}
+	entry := set.entries[queueIndex]
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	for i, arg := range args {
+		if arg == nil {
+			continue
+		}
+		err := SetKernelArg(entry.kernel, uint32(i), arg.Size(), arg.Pointer())
+		if err != nil {
+			return err
+		}
+	}
+	return EnqueueKernel(set.queues[queueIndex], entry.kernel, globalWorkSize, opts...)
+}
+
+// Close releases all kernel clones of the set. Launches that are in progress are completed first.
+// The first error of the release calls is returned.
+func (set *KernelSet) Close() error {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+	if set.closed {
+		return nil
+	}
+	set.closed = true
+	var firstErr error
+	for _, entry := range set.entries {
+		err := ReleaseKernel(entry.kernel)
+		if (err != nil) && (firstErr == nil) {
+			firstErr = err
+		}
+	}
+	set.entries = nil
+	set.queues = nil
+	return firstErr
+}
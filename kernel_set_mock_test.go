@@ -0,0 +1,50 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockKernelSet(t *testing.T) {
+	context, device, first := mockQueue(t)
+	second, err := cl.CreateCommandQueueWithProperties(context, device)
+	if err != nil {
+		t.Fatalf("CreateCommandQueueWithProperties failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseCommandQueue(second) }()
+	kernel := mockKernel(t, context, "kernel void fill(global uchar *out, uchar value) {}", "fill")
+	set, err := cl.NewKernelSet(kernel, []cl.CommandQueue{first, second})
+	if err != nil {
+		t.Fatalf("NewKernelSet failed: %v", err)
+	}
+	if set.Len() != 2 {
+		t.Errorf("unexpected length: %v", set.Len())
+	}
+	if (set.Kernel(0) == 0) || (set.Kernel(0) == kernel) || (set.Kernel(0) == set.Kernel(1)) {
+		t.Errorf("kernels are not distinct clones: %v, %v", set.Kernel(0), set.Kernel(1))
+	}
+	for _, index := range []int{-1, 2} {
+		if set.Kernel(index) != 0 {
+			t.Errorf("kernel returned for index %d", index)
+		}
+		if err = set.Launch(index, []uintptr{4}, nil); !errors.Is(err, cl.ErrInvalidValue) {
+			t.Errorf("unexpected error for index %d: %v", index, err)
+		}
+	}
+	if err = set.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if (set.Len() != 0) || (set.Kernel(0) != 0) {
+		t.Errorf("set still provides kernels after Close")
+	}
+	if err = set.Launch(0, []uintptr{4}, nil); !errors.Is(err, cl.ErrKernelSetClosed) {
+		t.Errorf("unexpected error after Close: %v", err)
+	}
+	if err = set.Close(); err != nil {
+		t.Errorf("second Close failed: %v", err)
+	}
+}
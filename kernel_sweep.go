@@ -0,0 +1,111 @@
+package cl30
+
+// LaunchSweep enqueues one launch of the kernel per parameter set, back-to-back on the same command-queue.
+// This serves parameter sweeps, such as grid searches and simulations, where the same kernel is run with
+// varying arguments.
+//
+// Each parameter set holds the arguments by their index. Before each launch, the arguments of the set are applied
+// to the kernel; a nil entry leaves the respective argument unchanged from the previous launch. The supported
+// argument types are listed with KernelArgValue().
+//
+// One event per launch is returned, which the caller must release. The options apply to all launches;
+// an option of WithEventOut() is ignored. In case of an error, the events of the launches that were already
+// enqueued are returned together with the error.
+func LaunchSweep(commandQueue CommandQueue, kernel Kernel, globalWorkSize []uintptr, paramSets [][]any,
+	opts ...KernelEnqueueOption) ([]Event, error) {
+	events := make([]Event, 0, len(paramSets))
+	for _, params := range paramSets {
+		err := applyKernelArgs(kernel, params)
+		if err != nil {
+			return events, err
+		}
+		var event Event
+		err = EnqueueKernel(commandQueue, kernel, globalWorkSize, append(opts[:len(opts):len(opts)], WithEventOut(&event))...)
+		if err != nil {
+			return events, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// LaunchSweepMarker works like LaunchSweep(), yet instead of one event per launch, it returns a single marker event
+// that completes once all launches, and all commands that were enqueued before, have completed.
+// The caller must release the returned event.
+func LaunchSweepMarker(commandQueue CommandQueue, kernel Kernel, globalWorkSize []uintptr, paramSets [][]any,
+	opts ...KernelEnqueueOption) (Event, error) {
+	for _, params := range paramSets {
+		err := applyKernelArgs(kernel, params)
+		if err != nil {
+			return 0, err
+		}
+		err = EnqueueKernel(commandQueue, kernel, globalWorkSize, append(opts[:len(opts):len(opts)], WithEventOut(nil))...)
+		if err != nil {
+			return 0, err
+		}
+	}
+	var marker Event
+	err := EnqueueMarkerWithWaitList(commandQueue, nil, &marker)
+	if err != nil {
+		return 0, err
+	}
+	return marker, nil
+}
+
+func applyKernelArgs(kernel Kernel, args []any) error {
+	for i, arg := range args {
+		if arg == nil {
+			continue
+		}
+		mem, err := KernelArgValue(arg)
+		if err != nil {
+			return err
+		}
+		err = SetKernelArg(kernel, uint32(i), mem.Size(), mem.Pointer())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// KernelArgValue returns the host memory that represents the given value as a kernel argument.
+//
+// Supported are HostMemory values, which are returned as they are, the handle types MemObject, Sampler, and
+// CommandQueue, as well as the fixed-size numeric types int8 to int64, uint8 to uint64, float32, and float64.
+// ErrUnsupportedKernelArgType is returned for other types, including int and uint, as their size does not match
+// a type of OpenCL C.
+func KernelArgValue(value any) (HostMemory, error) {
+	switch typed := value.(type) {
+	case HostMemory:
+		return typed, nil
+	case MemObject:
+		return Value(&typed), nil
+	case Sampler:
+		return Value(&typed), nil
+	case CommandQueue:
+		return Value(&typed), nil
+	case int8:
+		return Value(&typed), nil
+	case int16:
+		return Value(&typed), nil
+	case int32:
+		return Value(&typed), nil
+	case int64:
+		return Value(&typed), nil
+	case uint8:
+		return Value(&typed), nil
+	case uint16:
+		return Value(&typed), nil
+	case uint32:
+		return Value(&typed), nil
+	case uint64:
+		return Value(&typed), nil
+	case float32:
+		return Value(&typed), nil
+	case float64:
+		return Value(&typed), nil
+	default:
+		return nil, ErrUnsupportedKernelArgType
+	}
+}
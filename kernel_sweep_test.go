@@ -0,0 +1,36 @@
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestKernelArgValue(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		value any
+		size  uintptr
+	}{
+		{value: int8(1), size: 1},
+		{value: float32(1), size: 4},
+		{value: uint64(1), size: 8},
+		{value: cl.MemObject(1), size: unsafe.Sizeof(cl.MemObject(0))},
+		{value: cl.Slice([]float32{1, 2, 3}), size: 12},
+	}
+	for _, tc := range tt {
+		mem, err := cl.KernelArgValue(tc.value)
+		if err != nil {
+			t.Errorf("unexpected error for %T: %v", tc.value, err)
+			continue
+		}
+		if mem.Size() != tc.size {
+			t.Errorf("unexpected size for %T: %d, expected %d", tc.value, mem.Size(), tc.size)
+		}
+	}
+	if _, err := cl.KernelArgValue(1); !errors.Is(err, cl.ErrUnsupportedKernelArgType) {
+		t.Errorf("expected ErrUnsupportedKernelArgType for int, got %v", err)
+	}
+}
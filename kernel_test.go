@@ -0,0 +1,20 @@
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestGlobalFromBufferValidation(t *testing.T) {
+	t.Parallel()
+	err := cl.EnqueueKernel(0, 0, []uintptr{16}, cl.GlobalFromBuffer(1, 4))
+	if !errors.Is(err, cl.ErrInvalidGlobalWorkSize) {
+		t.Errorf("expected error for explicit global work size, got %v", err)
+	}
+	err = cl.EnqueueKernel(0, 0, nil, cl.GlobalFromBuffer(1, 0))
+	if !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("expected error for zero element size, got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package cl30
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Leak describes an object that was created while leak tracking was enabled, and that was not yet released.
+type Leak struct {
+	// Handle is the object, for example of type Context or MemObject.
+	Handle any
+	// References is the number of references that the application holds, according to the calls of the
+	// retain and release functions.
+	References int
+	// Created is the time of creation.
+	Created time.Time
+	// Stack is the stack trace of the creation.
+	Stack string
+}
+
+type trackedHandle interface {
+	Context | CommandQueue | Program | Kernel | MemObject | Event | Sampler | SemaphoreKhr
+}
+
+type leakRecord struct {
+	references int
+	created    time.Time
+	stack      []byte
+}
+
+var leakTracker = struct {
+	enabled int32
+	// count is the number of records. It lets retain and release functions skip the mutex while nothing is
+	// tracked, which is always the case while tracking was never enabled.
+	count   int32
+	mutex   sync.Mutex
+	records map[any]*leakRecord
+}{
+	records: make(map[any]*leakRecord),
+}
+
+// EnableLeakTracking enables or disables the tracking of created objects.
+//
+// When enabled, contexts, command-queues, programs, kernels, memory objects, events, samplers, and semaphores
+// that are created by the functions of this package are recorded, together with the stack trace of their
+// creation. Calls to the retain and release functions are counted, and an object is removed from the records once
+// all its references were released. The remaining objects are reported with Leaks() and DumpLeaks().
+//
+// The tracking does not rely on the reference count queries of the OpenCL API, which are documented as
+// unreliable. References held by the OpenCL implementation itself, for example of a program by its kernels,
+// are not considered. Tracking is disabled by default, as recording the stack traces is costly.
+// Disabling the tracking keeps the existing records.
+func EnableLeakTracking(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&leakTracker.enabled, value)
+}
+
+// LeakTrackingEnabled returns true if created objects are being tracked.
+func LeakTrackingEnabled() bool {
+	return atomic.LoadInt32(&leakTracker.enabled) != 0
+}
+
+// Leaks returns the tracked objects that were not yet released, ordered by their creation time.
+func Leaks() []Leak {
+	leakTracker.mutex.Lock()
+	defer leakTracker.mutex.Unlock()
+	leaks := make([]Leak, 0, len(leakTracker.records))
+	for handle, record := range leakTracker.records {
+		leaks = append(leaks, Leak{
+			Handle:     handle,
+			References: record.references,
+			Created:    record.created,
+			Stack:      string(record.stack),
+		})
+	}
+	sort.Slice(leaks, func(a, b int) bool { return leaks[a].Created.Before(leaks[b].Created) })
+	return leaks
+}
+
+// DumpLeaks writes a report of the tracked objects that were not yet released, including their creation stack
+// traces. It returns the number of reported objects.
+func DumpLeaks(w io.Writer) (int, error) {
+	leaks := Leaks()
+	for _, leak := range leaks {
+		_, err := fmt.Fprintf(w, "%T %v, %d reference(s), created %v:\n%s\n",
+			leak.Handle, leak.Handle, leak.References, leak.Created.Format(time.RFC3339Nano), leak.Stack)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(leaks), nil
+}
+
+// ResetLeaks removes all records of tracked objects.
+func ResetLeaks() {
+	leakTracker.mutex.Lock()
+	defer leakTracker.mutex.Unlock()
+	leakTracker.records = make(map[any]*leakRecord)
+	atomic.StoreInt32(&leakTracker.count, 0)
+}
+
+// trackCreated records a newly created object, if tracking is enabled. It returns the given handle.
+func trackCreated[T trackedHandle](handle T) T {
+	if !LeakTrackingEnabled() || (handle == 0) {
+		return handle
+	}
+	record := &leakRecord{references: 1, created: time.Now(), stack: debug.Stack()}
+	leakTracker.mutex.Lock()
+	defer leakTracker.mutex.Unlock()
+	if _, known := leakTracker.records[handle]; !known {
+		atomic.AddInt32(&leakTracker.count, 1)
+	}
+	leakTracker.records[handle] = record
+	return handle
+}
+
+// trackEnqueuedEvent records the event that an enqueue function returned, if the caller requested one.
+func trackEnqueuedEvent(event *Event) {
+	if event != nil {
+		trackCreated(*event)
+	}
+}
+
+// trackRetained counts an additional reference of a tracked object.
+func trackRetained[T trackedHandle](handle T) {
+	if atomic.LoadInt32(&leakTracker.count) == 0 {
+		return
+	}
+	leakTracker.mutex.Lock()
+	defer leakTracker.mutex.Unlock()
+	if record, known := leakTracker.records[handle]; known {
+		record.references++
+	}
+}
+
+// trackReleased counts a released reference of a tracked object, and removes the record with the last one.
+func trackReleased[T trackedHandle](handle T) {
+	if atomic.LoadInt32(&leakTracker.count) == 0 {
+		return
+	}
+	leakTracker.mutex.Lock()
+	defer leakTracker.mutex.Unlock()
+	record, known := leakTracker.records[handle]
+	if !known {
+		return
+	}
+	record.references--
+	if record.references <= 0 {
+		delete(leakTracker.records, handle)
+		atomic.AddInt32(&leakTracker.count, -1)
+	}
+}
@@ -0,0 +1,45 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"strings"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockLeakTracking(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	cl.ResetLeaks()
+	cl.EnableLeakTracking(true)
+	defer cl.EnableLeakTracking(false)
+	defer cl.ResetLeaks()
+	released, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 4, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	leaked, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 4, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(leaked) }()
+	var event cl.Event
+	if err = cl.EnqueueMarkerWithWaitList(queue, nil, &event); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList failed: %v", err)
+	}
+	_ = cl.RetainMemObject(released)
+	_ = cl.ReleaseMemObject(released)
+	_ = cl.ReleaseMemObject(released)
+	_ = cl.ReleaseEvent(event)
+
+	leaks := cl.Leaks()
+	if (len(leaks) != 1) || (leaks[0].Handle != leaked) || !strings.Contains(leaks[0].Stack, "TestMockLeakTracking") {
+		t.Fatalf("unexpected leaks: %v", leaks)
+	}
+	var report strings.Builder
+	count, err := cl.DumpLeaks(&report)
+	if (err != nil) || (count != 1) || !strings.Contains(report.String(), leaked.String()) {
+		t.Errorf("unexpected report: %d, %v\n%s", count, err, report.String())
+	}
+}
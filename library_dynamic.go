@@ -0,0 +1,73 @@
+//go:build cl30_dynamic && !cl30_mock
+
+package cl30
+
+// #cgo !windows LDFLAGS: -ldl
+// #include <stdlib.h>
+// extern int cl30LoadLibrary(char const *name);
+// extern int cl30LibraryLoaded(void);
+import "C"
+import (
+	"os"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// LibraryNameEnvVar is the name of an environment variable that, if set, provides the name or path of the OpenCL
+// library to load with Initialize(). It is only considered in the dynamic loading mode.
+const LibraryNameEnvVar = "CL30_OPENCL_LIBRARY"
+
+var libraryMutex sync.Mutex
+
+// Initialize loads the OpenCL library of the system.
+//
+// This build uses the dynamic loading mode, which does not require an OpenCL SDK at link time.
+// Initialize() must be called, and must have returned successfully, before any other function of the package
+// calls into OpenCL. Until then, such calls fail as if no platform was installed.
+// Calling Initialize() after a successful load has no effect; After a failed attempt, it can be called again.
+//
+// The library is looked up by the name provided with the environment variable LibraryNameEnvVar, if set.
+// Otherwise, the common name for the operating system is used, such as "libOpenCL.so.1" on Linux.
+//
+// Returns ErrLibraryNotAvailable in case the library could not be loaded.
+func Initialize() error {
+	libraryMutex.Lock()
+	defer libraryMutex.Unlock()
+	if C.cl30LibraryLoaded() != 0 {
+		return nil
+	}
+	for _, name := range libraryNames() {
+		if loadLibrary(name) {
+			return nil
+		}
+	}
+	return ErrLibraryNotAvailable
+}
+
+// IsAvailable returns true if the OpenCL library was loaded with Initialize().
+func IsAvailable() bool {
+	libraryMutex.Lock()
+	defer libraryMutex.Unlock()
+	return C.cl30LibraryLoaded() != 0
+}
+
+func loadLibrary(name string) bool {
+	rawName := C.CString(name)
+	defer C.free(unsafe.Pointer(rawName))
+	return C.cl30LoadLibrary(rawName) != 0
+}
+
+func libraryNames() []string {
+	if name := os.Getenv(LibraryNameEnvVar); len(name) > 0 {
+		return []string{name}
+	}
+	switch runtime.GOOS {
+	case "windows":
+		return []string{"OpenCL.dll"}
+	case "darwin":
+		return []string{"/System/Library/Frameworks/OpenCL.framework/OpenCL"}
+	default:
+		return []string{"libOpenCL.so.1", "libOpenCL.so"}
+	}
+}
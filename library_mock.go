@@ -0,0 +1,27 @@
+//go:build cl30_mock
+
+package cl30
+
+// #cgo CFLAGS: -DCL30_MOCK_DRIVER
+// extern void cl30InstallMockDriver(void);
+import "C"
+
+func init() {
+	C.cl30InstallMockDriver()
+	SetMockPlatforms(nil)
+}
+
+// Initialize prepares the OpenCL library for use.
+//
+// This build uses the mock driver, which is installed when the package is initialized. Initialize() has nothing
+// to do. See SetMockPlatforms() for details on the mock driver.
+func Initialize() error {
+	return nil
+}
+
+// IsAvailable returns true if the OpenCL library is available for use.
+//
+// This build uses the mock driver, which is always available.
+func IsAvailable() bool {
+	return true
+}
@@ -0,0 +1,19 @@
+//go:build !cl30_dynamic && !cl30_mock
+
+package cl30
+
+// Initialize prepares the OpenCL library for use.
+//
+// In the default build, the OpenCL library is linked when building the application, and Initialize() has
+// nothing to do. It is provided so that applications can use the same code for both build modes.
+// See IsAvailable() and the documentation of the package for the dynamic loading mode.
+func Initialize() error {
+	return nil
+}
+
+// IsAvailable returns true if the OpenCL library is available for use.
+//
+// In the default build, the OpenCL library is linked when building the application, and it is always available.
+func IsAvailable() bool {
+	return true
+}
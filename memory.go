@@ -3,10 +3,7 @@ package cl30
 // #include "api.h"
 // extern cl_int cl30SetMemObjectDestructorCallback(cl_mem mem, uintptr_t *userData);
 import "C"
-import (
-	"fmt"
-	"unsafe"
-)
+import "unsafe"
 
 // MemObject represents a reference counted region of global memory.
 type MemObject uintptr
@@ -16,9 +13,9 @@ func (mem MemObject) handle() C.cl_mem {
 }
 
 // String provides a readable presentation of the memory identifier.
-// It is based on the numerical value of the underlying pointer.
+// It is based on the numerical value of the underlying pointer, followed by the name set with SetDebugName(), if any.
 func (mem MemObject) String() string {
-	return fmt.Sprintf("0x%X", uintptr(mem))
+	return handleString(mem, uintptr(mem))
 }
 
 // MemProperty is one entry of properties which are taken into account when creating memory objects.
@@ -30,10 +27,14 @@ type MemProperty []uint64
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clRetainMemObject.html
 func RetainMemObject(mem MemObject) error {
+	defer observeCall("clRetainMemObject")()
 	status := C.clRetainMemObject(mem.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clRetainMemObject", status, "mem", mem)
 	}
+	trackRetained(mem)
+	retainOwned(mem)
+	retainNamed(mem)
 	return nil
 }
 
@@ -45,10 +46,14 @@ func RetainMemObject(mem MemObject) error {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clReleaseMemObject.html
 func ReleaseMemObject(mem MemObject) error {
+	defer observeCall("clReleaseMemObject")()
 	status := C.clReleaseMemObject(mem.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clReleaseMemObject", status, "mem", mem)
 	}
+	trackReleased(mem)
+	disownReleased(mem)
+	unnameReleased(mem)
 	return nil
 }
 
@@ -60,6 +65,7 @@ func ReleaseMemObject(mem MemObject) error {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSetMemObjectDestructorCallback.html
 func SetMemObjectDestructorCallback(mem MemObject, callback func()) error {
+	defer observeCall("clSetMemObjectDestructorCallback")()
 	callbackUserData, err := userDataFor(callback)
 	if err != nil {
 		return err
@@ -67,7 +73,7 @@ func SetMemObjectDestructorCallback(mem MemObject, callback func()) error {
 	status := C.cl30SetMemObjectDestructorCallback(mem.handle(), callbackUserData.ptr)
 	if status != C.CL_SUCCESS {
 		callbackUserData.Delete()
-		return StatusError(status)
+		return operationError("clSetMemObjectDestructorCallback", status, "mem", mem)
 	}
 	return nil
 }
@@ -77,7 +83,7 @@ func cl30GoMemObjectDestructorCallback(_ MemObject, userData *C.uintptr_t) {
 	callbackUserData := userDataFrom(userData)
 	callback := callbackUserData.Value().(func())
 	callbackUserData.Delete()
-	callback()
+	dispatchCallback(callback)
 }
 
 // MemObjectInfoName identifies properties of a memory object, which can be queried with MemObjectInfo().
@@ -195,6 +201,7 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetMemObjectInfo.html
 func MemObjectInfo(mem MemObject, paramName MemObjectInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetMemObjectInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetMemObjectInfo(
 		mem.handle(),
@@ -203,7 +210,7 @@ func MemObjectInfo(mem MemObject, paramName MemObjectInfoName, paramSize uintptr
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetMemObjectInfo", status, "mem", mem, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
@@ -233,10 +240,15 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueUnmapMemObject.html
 func EnqueueUnmapMemObject(commandQueue CommandQueue, mem MemObject, mappedPtr unsafe.Pointer, waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueUnmapMemObject")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
 	}
+	defer releaseWaitList()
 	status := C.clEnqueueUnmapMemObject(
 		commandQueue.handle(),
 		mem.handle(),
@@ -245,8 +257,10 @@ func EnqueueUnmapMemObject(commandQueue CommandQueue, mem MemObject, mappedPtr u
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueUnmapMemObject", status, "commandQueue", commandQueue, "mem", mem)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -280,14 +294,19 @@ const (
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueMigrateMemObjects.html
 func EnqueueMigrateMemObjects(commandQueue CommandQueue, memObjects []MemObject, migrationFlags MemMigrationFlags, waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueMigrateMemObjects")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
 	var rawMemObjects unsafe.Pointer
 	if len(memObjects) > 0 {
 		rawMemObjects = unsafe.Pointer(&memObjects[0])
 	}
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
 	}
+	defer releaseWaitList()
 	status := C.clEnqueueMigrateMemObjects(
 		commandQueue.handle(),
 		C.cl_uint(len(memObjects)),
@@ -297,7 +316,9 @@ func EnqueueMigrateMemObjects(commandQueue CommandQueue, memObjects []MemObject,
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueMigrateMemObjects", status, "commandQueue", commandQueue)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
@@ -0,0 +1,126 @@
+package cl30
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CallLatencyBuckets are the upper bounds of the histogram buckets that are used to record call latencies.
+// A call is counted in the first bucket whose bound is larger than or equal to the measured duration.
+// Calls that take longer than the last bound are counted in an additional overflow bucket.
+var CallLatencyBuckets = [...]time.Duration{
+	time.Microsecond,
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// CallStat contains the recorded latencies of one OpenCL API function.
+type CallStat struct {
+	// Name is the name of the OpenCL API function, for example "clSetKernelArg".
+	Name string
+	// Count is the number of recorded calls.
+	Count uint64
+	// Total is the sum of the durations of all recorded calls.
+	Total time.Duration
+	// Max is the longest duration of all recorded calls.
+	Max time.Duration
+	// Buckets is the histogram of the recorded calls, according to CallLatencyBuckets.
+	// The last entry counts the calls that exceeded the largest bound.
+	Buckets [len(CallLatencyBuckets) + 1]uint64
+}
+
+// Mean returns the average duration of the recorded calls.
+func (stat CallStat) Mean() time.Duration {
+	if stat.Count == 0 {
+		return 0
+	}
+	return stat.Total / time.Duration(stat.Count)
+}
+
+func (stat *CallStat) record(duration time.Duration) {
+	stat.Count++
+	stat.Total += duration
+	if duration > stat.Max {
+		stat.Max = duration
+	}
+	bucket := len(CallLatencyBuckets)
+	for i, bound := range CallLatencyBuckets {
+		if duration <= bound {
+			bucket = i
+			break
+		}
+	}
+	stat.Buckets[bucket]++
+}
+
+var callMetrics = struct {
+	enabled int32
+	mutex   sync.Mutex
+	stats   map[string]*CallStat
+}{
+	stats: make(map[string]*CallStat),
+}
+
+// EnableCallMetrics enables or disables the recording of call latencies.
+//
+// When enabled, the wall time of each call into the OpenCL API is recorded, grouped by the name of the API function.
+// The recorded values can be retrieved with CallStats(). Recording is disabled by default.
+func EnableCallMetrics(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&callMetrics.enabled, value)
+}
+
+// CallMetricsEnabled returns true if call latencies are being recorded.
+func CallMetricsEnabled() bool {
+	return atomic.LoadInt32(&callMetrics.enabled) != 0
+}
+
+// CallStats returns a copy of the recorded call latencies, sorted by the name of the API function.
+func CallStats() []CallStat {
+	callMetrics.mutex.Lock()
+	defer callMetrics.mutex.Unlock()
+	stats := make([]CallStat, 0, len(callMetrics.stats))
+	for _, stat := range callMetrics.stats {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(a, b int) bool { return stats[a].Name < stats[b].Name })
+	return stats
+}
+
+// ResetCallStats removes all recorded call latencies.
+func ResetCallStats() {
+	callMetrics.mutex.Lock()
+	defer callMetrics.mutex.Unlock()
+	callMetrics.stats = make(map[string]*CallStat)
+}
+
+func noopObservation() {}
+
+// observeCall starts measuring a call of the named API function. The returned function must be called
+// once the call has completed. Typical use is: defer observeCall("clFunctionName")()
+func observeCall(name string) func() {
+	if !CallMetricsEnabled() {
+		return noopObservation
+	}
+	start := time.Now()
+	return func() {
+		duration := time.Since(start)
+		callMetrics.mutex.Lock()
+		defer callMetrics.mutex.Unlock()
+		stat, known := callMetrics.stats[name]
+		if !known {
+			stat = &CallStat{Name: name}
+			callMetrics.stats[name] = stat
+		}
+		stat.record(duration)
+	}
+}
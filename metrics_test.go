@@ -0,0 +1,26 @@
+package cl30_test
+
+import (
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestCallStatMean(t *testing.T) {
+	t.Parallel()
+	stat := cl.CallStat{Count: 4, Total: 2 * time.Millisecond}
+	if stat.Mean() != 500*time.Microsecond {
+		t.Errorf("unexpected mean: %v", stat.Mean())
+	}
+	if (cl.CallStat{}).Mean() != 0 {
+		t.Errorf("mean of empty stat must be zero")
+	}
+}
+
+func TestCallMetricsAreDisabledByDefault(t *testing.T) {
+	t.Parallel()
+	if cl.CallMetricsEnabled() {
+		t.Errorf("call metrics must be disabled by default")
+	}
+}
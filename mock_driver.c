@@ -0,0 +1,176 @@
+//go:build cl30_mock
+
+#include <string.h>
+#include "dynamic_loader.h"
+#include "_cgo_export.h"
+
+// The mock driver provides the simulated OpenCL functions that are implemented in Go.
+// All other functions report CL_INVALID_OPERATION.
+
+typedef struct
+{
+    char const *name;
+    void *fn;
+} cl30MockFunction;
+
+typedef struct
+{
+    char const *name;
+    char const *extension;
+    void *fn;
+} cl30MockExtensionFunction;
+
+// The extension functions are returned by clGetExtensionFunctionAddressForPlatform() for platforms that list
+// the respective extension.
+static cl30MockExtensionFunction const cl30MockExtensionFunctions[] = {
+    { "clCreateCommandBufferKHR", "cl_khr_command_buffer", (void *)cl30MockCreateCommandBufferKHR },
+    { "clFinalizeCommandBufferKHR", "cl_khr_command_buffer", (void *)cl30MockFinalizeCommandBufferKHR },
+    { "clRetainCommandBufferKHR", "cl_khr_command_buffer", (void *)cl30MockRetainCommandBufferKHR },
+    { "clReleaseCommandBufferKHR", "cl_khr_command_buffer", (void *)cl30MockReleaseCommandBufferKHR },
+    { "clEnqueueCommandBufferKHR", "cl_khr_command_buffer", (void *)cl30MockEnqueueCommandBufferKHR },
+    { "clGetCommandBufferInfoKHR", "cl_khr_command_buffer", (void *)cl30MockGetCommandBufferInfoKHR },
+    { "clCommandBarrierWithWaitListKHR", "cl_khr_command_buffer", (void *)cl30MockCommandBarrierWithWaitListKHR },
+    { "clCommandCopyBufferKHR", "cl_khr_command_buffer", (void *)cl30MockCommandCopyBufferKHR },
+    { "clCommandCopyBufferRectKHR", "cl_khr_command_buffer", (void *)cl30MockCommandCopyBufferRectKHR },
+    { "clCommandCopyBufferToImageKHR", "cl_khr_command_buffer", (void *)cl30MockCommandCopyBufferToImageKHR },
+    { "clCommandCopyImageKHR", "cl_khr_command_buffer", (void *)cl30MockCommandCopyImageKHR },
+    { "clCommandCopyImageToBufferKHR", "cl_khr_command_buffer", (void *)cl30MockCommandCopyImageToBufferKHR },
+    { "clCommandFillBufferKHR", "cl_khr_command_buffer", (void *)cl30MockCommandFillBufferKHR },
+    { "clCommandFillImageKHR", "cl_khr_command_buffer", (void *)cl30MockCommandFillImageKHR },
+    { "clCommandNDRangeKernelKHR", "cl_khr_command_buffer", (void *)cl30MockCommandNDRangeKernelKHR },
+    { NULL, NULL, NULL }
+};
+
+static void *cl30MockGetExtensionFunctionAddressForPlatform(cl_platform_id platform, char const *name)
+{
+    cl30MockExtensionFunction const *entry;
+
+    for (entry = cl30MockExtensionFunctions; entry->name != NULL; entry++)
+    {
+        if ((strcmp(entry->name, name) == 0) && cl30MockPlatformHasExtension(platform, (char *)entry->extension))
+        {
+            return entry->fn;
+        }
+    }
+    return NULL;
+}
+
+static cl30MockFunction const cl30MockFunctions[] = {
+    { "clGetPlatformIDs", (void *)cl30MockGetPlatformIDs },
+    { "clGetPlatformInfo", (void *)cl30MockGetPlatformInfo },
+    { "clGetExtensionFunctionAddressForPlatform", (void *)cl30MockGetExtensionFunctionAddressForPlatform },
+    { "clUnloadPlatformCompiler", (void *)cl30MockUnloadPlatformCompiler },
+    { "clGetDeviceIDs", (void *)cl30MockGetDeviceIDs },
+    { "clGetDeviceInfo", (void *)cl30MockGetDeviceInfo },
+    { "clRetainDevice", (void *)cl30MockRetainDevice },
+    { "clReleaseDevice", (void *)cl30MockReleaseDevice },
+    { "clCreateContext", (void *)cl30MockCreateContext },
+    { "clCreateContextFromType", (void *)cl30MockCreateContextFromType },
+    { "clRetainContext", (void *)cl30MockRetainContext },
+    { "clReleaseContext", (void *)cl30MockReleaseContext },
+    { "clGetContextInfo", (void *)cl30MockGetContextInfo },
+    { "clSetContextDestructorCallback", (void *)cl30MockSetContextDestructorCallback },
+    { "clCreateCommandQueue", (void *)cl30MockCreateCommandQueue },
+    { "clCreateCommandQueueWithProperties", (void *)cl30MockCreateCommandQueueWithProperties },
+    { "clRetainCommandQueue", (void *)cl30MockRetainCommandQueue },
+    { "clReleaseCommandQueue", (void *)cl30MockReleaseCommandQueue },
+    { "clGetCommandQueueInfo", (void *)cl30MockGetCommandQueueInfo },
+    { "clSetDefaultDeviceCommandQueue", (void *)cl30MockSetDefaultDeviceCommandQueue },
+    { "clFlush", (void *)cl30MockFlush },
+    { "clFinish", (void *)cl30MockFinish },
+    { "clCreateBuffer", (void *)cl30MockCreateBuffer },
+    { "clCreateBufferWithProperties", (void *)cl30MockCreateBufferWithProperties },
+    { "clCreateSubBuffer", (void *)cl30MockCreateSubBuffer },
+    { "clRetainMemObject", (void *)cl30MockRetainMemObject },
+    { "clReleaseMemObject", (void *)cl30MockReleaseMemObject },
+    { "clGetMemObjectInfo", (void *)cl30MockGetMemObjectInfo },
+    { "clSetMemObjectDestructorCallback", (void *)cl30MockSetMemObjectDestructorCallback },
+    { "clEnqueueReadBuffer", (void *)cl30MockEnqueueReadBuffer },
+    { "clEnqueueWriteBuffer", (void *)cl30MockEnqueueWriteBuffer },
+    { "clEnqueueCopyBuffer", (void *)cl30MockEnqueueCopyBuffer },
+    { "clEnqueueFillBuffer", (void *)cl30MockEnqueueFillBuffer },
+    { "clEnqueueMapBuffer", (void *)cl30MockEnqueueMapBuffer },
+    { "clEnqueueUnmapMemObject", (void *)cl30MockEnqueueUnmapMemObject },
+    { "clEnqueueMarkerWithWaitList", (void *)cl30MockEnqueueMarkerWithWaitList },
+    { "clEnqueueBarrierWithWaitList", (void *)cl30MockEnqueueBarrierWithWaitList },
+    { "clGetSupportedImageFormats", (void *)cl30MockGetSupportedImageFormats },
+    { "clSVMAlloc", (void *)cl30MockSVMAlloc },
+    { "clSVMFree", (void *)cl30MockSVMFree },
+    { "clEnqueueSVMMap", (void *)cl30MockEnqueueSVMMap },
+    { "clEnqueueSVMUnmap", (void *)cl30MockEnqueueSVMUnmap },
+    { "clEnqueueNDRangeKernel", (void *)cl30MockEnqueueNDRangeKernel },
+    { "clCreateUserEvent", (void *)cl30MockCreateUserEvent },
+    { "clSetUserEventStatus", (void *)cl30MockSetUserEventStatus },
+    { "clWaitForEvents", (void *)cl30MockWaitForEvents },
+    { "clGetEventInfo", (void *)cl30MockGetEventInfo },
+    { "clGetEventProfilingInfo", (void *)cl30MockGetEventProfilingInfo },
+    { "clRetainEvent", (void *)cl30MockRetainEvent },
+    { "clReleaseEvent", (void *)cl30MockReleaseEvent },
+    { "clSetEventCallback", (void *)cl30MockSetEventCallback },
+    { "clCreateProgramWithSource", (void *)cl30MockCreateProgramWithSource },
+    { "clCreateProgramWithBinary", (void *)cl30MockCreateProgramWithBinary },
+    { "clBuildProgram", (void *)cl30MockBuildProgram },
+    { "clCompileProgram", (void *)cl30MockCompileProgram },
+    { "clLinkProgram", (void *)cl30MockLinkProgram },
+    { "clRetainProgram", (void *)cl30MockRetainProgram },
+    { "clReleaseProgram", (void *)cl30MockReleaseProgram },
+    { "clGetProgramInfo", (void *)cl30MockGetProgramInfo },
+    { "clGetProgramBuildInfo", (void *)cl30MockGetProgramBuildInfo },
+    { "clCreateKernel", (void *)cl30MockCreateKernel },
+    { "clCloneKernel", (void *)cl30MockCloneKernel },
+    { "clRetainKernel", (void *)cl30MockRetainKernel },
+    { "clReleaseKernel", (void *)cl30MockReleaseKernel },
+    { "clSetKernelArg", (void *)cl30MockSetKernelArg },
+    { "clSetKernelExecInfo", (void *)cl30MockSetKernelExecInfo },
+    { "clGetKernelInfo", (void *)cl30MockGetKernelInfo },
+    { "clGetKernelWorkGroupInfo", (void *)cl30MockGetKernelWorkGroupInfo },
+    { NULL, NULL }
+};
+
+// cl30MockDriver serves as the library handle of the mock driver. Only its address is relevant.
+static int cl30MockDriver = 0;
+
+static void *cl30MockSymbol(void *library, char const *name)
+{
+    cl30MockFunction const *entry;
+
+    (void)library;
+    for (entry = cl30MockFunctions; entry->name != NULL; entry++)
+    {
+        if (strcmp(entry->name, name) == 0)
+        {
+            return entry->fn;
+        }
+    }
+    return NULL;
+}
+
+void cl30InstallMockDriver(void)
+{
+    cl30ResolveFunctions(&cl30MockDriver, cl30MockSymbol);
+}
+
+typedef void (CL_CALLBACK *cl30MockEventNotify_fn)(cl_event event, cl_int commandStatus, void *userData);
+typedef void (CL_CALLBACK *cl30MockProgramNotify_fn)(cl_program program, void *userData);
+typedef void (CL_CALLBACK *cl30MockContextDestructor_fn)(cl_context context, void *userData);
+typedef void (CL_CALLBACK *cl30MockMemObjectDestructor_fn)(cl_mem memobj, void *userData);
+
+void cl30MockNotifyEvent(void *fn, cl_event event, cl_int commandStatus, void *userData)
+{
+    ((cl30MockEventNotify_fn)(fn))(event, commandStatus, userData);
+}
+
+void cl30MockNotifyProgram(void *fn, cl_program program, void *userData)
+{
+    ((cl30MockProgramNotify_fn)(fn))(program, userData);
+}
+
+void cl30MockNotifyContextDestructor(void *fn, cl_context context, void *userData)
+{
+    ((cl30MockContextDestructor_fn)(fn))(context, userData);
+}
+
+void cl30MockNotifyMemObjectDestructor(void *fn, cl_mem memobj, void *userData)
+{
+    ((cl30MockMemObjectDestructor_fn)(fn))(memobj, userData);
+}
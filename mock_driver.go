@@ -0,0 +1,675 @@
+//go:build cl30_mock
+
+package cl30
+
+// #include <stdlib.h>
+// #include "api.h"
+import "C"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// MockPlatform describes a platform that is simulated by the mock driver.
+// Fields with a zero value are set to a default value.
+type MockPlatform struct {
+	// Name is returned for PlatformNameInfo. It defaults to "cl30 mock platform".
+	Name string
+	// Vendor is returned for PlatformVendorInfo. It defaults to "opencl-go".
+	Vendor string
+	// Version is returned for PlatformVersionInfo. It defaults to "OpenCL 3.0 cl30-mock".
+	Version string
+	// Extensions is returned for PlatformExtensionsInfo. It defaults to an empty string.
+	Extensions string
+	// Devices lists the devices of the platform.
+	Devices []MockDevice
+}
+
+// MockDevice describes a device that is simulated by the mock driver.
+// Fields with a zero value are set to a default value.
+type MockDevice struct {
+	// Name is returned for DeviceNameInfo. It defaults to "cl30 mock device".
+	Name string
+	// Type is returned for DeviceTypeInfo. It defaults to DeviceTypeGpu.
+	Type DeviceTypeFlags
+	// Version is returned for DeviceVersionInfo. It defaults to "OpenCL 3.0 cl30-mock".
+	Version string
+	// Extensions is returned for DeviceExtensionsInfo. It defaults to an empty string.
+	Extensions string
+	// MaxComputeUnits is returned for DeviceMaxComputeUnitsInfo. It defaults to 1.
+	MaxComputeUnits uint32
+	// MaxWorkGroupSize is returned for DeviceMaxWorkGroupSizeInfo. It defaults to 256.
+	MaxWorkGroupSize uintptr
+	// GlobalMemSize is returned for DeviceGlobalMemSizeInfo. It defaults to 1 GiB.
+	GlobalMemSize uint64
+	// MaxMemAllocSize is returned for DeviceMaxMemAllocSizeInfo. It defaults to a quarter of GlobalMemSize.
+	MaxMemAllocSize uint64
+	// LocalMemSize is returned for DeviceLocalMemSizeInfo. It defaults to 32 KiB.
+	LocalMemSize uint64
+	// SvmCapabilities is returned for DeviceSvmCapabilitiesInfo. It defaults to no capabilities.
+	SvmCapabilities DeviceSvmCapabilitiesFlags
+	// MaxReadWriteImageArgs is returned for DeviceMaxReadWriteImageArgsInfo. It defaults to zero.
+	MaxReadWriteImageArgs uint32
+	// ImageFormats are returned by SupportedImageFormats() for contexts of the device, for any flags and image type.
+	// For contexts of several devices, the formats common to all devices are returned. It defaults to none.
+	ImageFormats []ImageFormat
+}
+
+// MockKernelCall describes the execution of a kernel by the mock driver.
+type MockKernelCall struct {
+	// Name is the function name of the kernel.
+	Name string
+	// GlobalWorkOffset has one entry per dimension. All entries are zero if no offset was provided.
+	GlobalWorkOffset []uintptr
+	// GlobalWorkSize has one entry per dimension.
+	GlobalWorkSize []uintptr
+	// LocalWorkSize has one entry per dimension, or is nil if no local work size was provided.
+	LocalWorkSize []uintptr
+	// Args are the arguments of the kernel, in order of their index.
+	Args []MockKernelArg
+}
+
+// MockKernelArg describes an argument of a kernel that is executed by the mock driver.
+type MockKernelArg struct {
+	// Size is the size of the argument, in bytes.
+	Size uintptr
+	// Value is a copy of the argument value. It is nil for arguments in the local address space.
+	Value []byte
+	// Buffer provides the memory of a buffer if the argument is a buffer. It is nil otherwise.
+	// Writes to the slice modify the content of the buffer.
+	Buffer []byte
+}
+
+// MockKernelFunc simulates the execution of a kernel by the mock driver.
+//
+// A returned StatusError is reported as the execution status of the command. Any other error is reported as
+// ErrOutOfResources.
+//
+// The function is called while the mock driver is locked. It must not call any function of this package.
+type MockKernelFunc func(call MockKernelCall) error
+
+// DefaultMockPlatforms returns the platforms that the mock driver simulates by default: One platform with one
+// device of type DeviceTypeGpu.
+func DefaultMockPlatforms() []MockPlatform {
+	return []MockPlatform{{Devices: []MockDevice{{}}}}
+}
+
+// SetMockPlatforms resets the mock driver to simulate the given platforms.
+// A nil value restores the platforms of DefaultMockPlatforms().
+//
+// All previously created objects are discarded, and their handles become invalid.
+// Registered kernel functions are kept.
+//
+// The mock driver is available in builds with the tag "cl30_mock". It simulates platforms, devices, contexts,
+// command-queues, buffers, events, programs, and kernels in memory, so that code depending on this package can be
+// tested without an OpenCL SDK or device:
+//
+// Commands are executed in the order they are enqueued, once all events of their wait list are complete. Commands
+// that wait for a user event are held back until the status of the user event is set. A negative status of a user
+// event fails all depending commands.
+//
+// Programs are "built" from source by extracting the kernel functions. A source that contains an "#error" directive
+// fails to build, with the directive in the build log. Compiling works the same way, and linking combines the
+// sources of the compiled programs. Kernels do not execute, unless a function is registered
+// with SetMockKernel().
+//
+// Functions that are not simulated return ErrInvalidOperation.
+func SetMockPlatforms(platforms []MockPlatform) {
+	if platforms == nil {
+		platforms = DefaultMockPlatforms()
+	}
+	// Pending destructor callbacks still refer to their handles, which must not be reused before the callbacks ran.
+	mockDriver.destructors.Wait()
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	for handle, obj := range mockDriver.objects {
+		if mem, isMem := obj.(*mockMem); isMem && mem.ownsStorage {
+			C.free(mem.storage)
+		}
+		C.free(handle)
+	}
+	mockDriver.objects = make(map[unsafe.Pointer]any)
+	mockDriver.activeQueues = make(map[*mockQueue]struct{})
+	mockDriver.platforms = nil
+	for _, config := range platforms {
+		platform := &mockPlatform{handle: mockNewHandle(), config: config.withDefaults()}
+		mockDriver.objects[platform.handle] = platform
+		for _, deviceConfig := range config.Devices {
+			device := &mockDevice{handle: mockNewHandle(), platform: platform, config: deviceConfig.withDefaults()}
+			mockDriver.objects[device.handle] = device
+			platform.devices = append(platform.devices, device)
+		}
+		mockDriver.platforms = append(mockDriver.platforms, platform)
+	}
+	mockDriver.changed.Broadcast()
+}
+
+// SetMockKernel registers the function that simulates the kernel with the given name.
+// A nil function removes the registration.
+func SetMockKernel(name string, fn MockKernelFunc) {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	if fn == nil {
+		delete(mockDriver.kernelFuncs, name)
+		return
+	}
+	mockDriver.kernelFuncs[name] = fn
+}
+
+const mockDefaultVersion = "OpenCL 3.0 cl30-mock"
+
+func (config MockPlatform) withDefaults() MockPlatform {
+	if len(config.Name) == 0 {
+		config.Name = "cl30 mock platform"
+	}
+	if len(config.Vendor) == 0 {
+		config.Vendor = "opencl-go"
+	}
+	if len(config.Version) == 0 {
+		config.Version = mockDefaultVersion
+	}
+	return config
+}
+
+func (config MockDevice) withDefaults() MockDevice {
+	if len(config.Name) == 0 {
+		config.Name = "cl30 mock device"
+	}
+	if config.Type == 0 {
+		config.Type = DeviceTypeGpu
+	}
+	if len(config.Version) == 0 {
+		config.Version = mockDefaultVersion
+	}
+	if config.MaxComputeUnits == 0 {
+		config.MaxComputeUnits = 1
+	}
+	if config.MaxWorkGroupSize == 0 {
+		config.MaxWorkGroupSize = 256
+	}
+	if config.GlobalMemSize == 0 {
+		config.GlobalMemSize = 1024 * 1024 * 1024
+	}
+	if config.MaxMemAllocSize == 0 {
+		config.MaxMemAllocSize = config.GlobalMemSize / 4
+	}
+	if config.LocalMemSize == 0 {
+		config.LocalMemSize = 32 * 1024
+	}
+	return config
+}
+
+// mockVersionOf extracts the numeric version from a version string of the form "OpenCL <major>.<minor> <info>".
+func mockVersionOf(version string) Version {
+	var major, minor int
+	if _, err := fmt.Sscanf(version, "OpenCL %d.%d", &major, &minor); err != nil {
+		return 0
+	}
+	return VersionOf(major, minor, 0)
+}
+
+var mockDriver = newMockDriverState()
+
+type mockDriverState struct {
+	mutex        sync.Mutex
+	changed      *sync.Cond
+	platforms    []*mockPlatform
+	objects      map[unsafe.Pointer]any
+	activeQueues map[*mockQueue]struct{}
+	kernelFuncs  map[string]MockKernelFunc
+	svmPointers  map[unsafe.Pointer]struct{}
+	destructors  sync.WaitGroup
+}
+
+func newMockDriverState() *mockDriverState {
+	state := &mockDriverState{
+		objects:      make(map[unsafe.Pointer]any),
+		activeQueues: make(map[*mockQueue]struct{}),
+		kernelFuncs:  make(map[string]MockKernelFunc),
+		svmPointers:  make(map[unsafe.Pointer]struct{}),
+	}
+	state.changed = sync.NewCond(&state.mutex)
+	return state
+}
+
+// mockNewHandle allocates a unique value for the handle of an object.
+func mockNewHandle() unsafe.Pointer {
+	return C.malloc(1)
+}
+
+// mockObjectFor returns the live object of the requested type for the given handle.
+func mockObjectFor[T any](handle unsafe.Pointer) (T, bool) {
+	obj, ok := mockDriver.objects[handle].(T)
+	return obj, ok
+}
+
+// mockDeleteObject removes an object whose reference count dropped to zero.
+func mockDeleteObject(handle unsafe.Pointer) {
+	delete(mockDriver.objects, handle)
+	C.free(handle)
+}
+
+type mockCallback struct {
+	fn       unsafe.Pointer
+	userData unsafe.Pointer
+}
+
+type mockPlatform struct {
+	handle  unsafe.Pointer
+	config  MockPlatform
+	devices []*mockDevice
+}
+
+type mockDevice struct {
+	handle   unsafe.Pointer
+	platform *mockPlatform
+	config   MockDevice
+}
+
+type mockContext struct {
+	handle        unsafe.Pointer
+	refCount      uint32
+	devices       []*mockDevice
+	properties    []C.cl_context_properties
+	destructors   []mockCallback
+	defaultQueues map[*mockDevice]*mockQueue
+}
+
+func (context *mockContext) hasDevice(device *mockDevice) bool {
+	for _, contextDevice := range context.devices {
+		if contextDevice == device {
+			return true
+		}
+	}
+	return false
+}
+
+type mockQueue struct {
+	handle          unsafe.Pointer
+	refCount        uint32
+	context         *mockContext
+	device          *mockDevice
+	properties      C.cl_command_queue_properties
+	propertiesArray []C.cl_queue_properties
+	pending         []*mockCommand
+}
+
+type mockMem struct {
+	handle      unsafe.Pointer
+	refCount    uint32
+	context     *mockContext
+	flags       C.cl_mem_flags
+	size        uintptr
+	storage     unsafe.Pointer
+	ownsStorage bool
+	hostPtr     unsafe.Pointer
+	parent      *mockMem
+	offset      uintptr
+	mapCount    uint32
+	properties  []C.cl_mem_properties
+	destructors []mockCallback
+}
+
+func (mem *mockMem) bytes() []byte {
+	return unsafe.Slice((*byte)(mem.storage), mem.size)
+}
+
+type mockEventCallback struct {
+	callbackType C.cl_int
+	mockCallback
+}
+
+type mockEvent struct {
+	// handle is nil for events that were not requested by the application.
+	handle      unsafe.Pointer
+	refCount    uint32
+	context     *mockContext
+	queue       *mockQueue
+	commandType C.cl_command_type
+	status      C.cl_int
+	callbacks   []mockEventCallback
+	profiled    bool
+	timestamps  [4]C.cl_ulong
+}
+
+type mockCommand struct {
+	event    *mockEvent
+	waitList []*mockEvent
+	run      func() C.cl_int
+}
+
+type mockBuild struct {
+	status  C.cl_build_status
+	options string
+	log     string
+}
+
+type mockProgram struct {
+	handle      unsafe.Pointer
+	refCount    uint32
+	context     *mockContext
+	source      string
+	kernelNames []string
+	kernelArgs  map[string]int
+	builds      map[*mockDevice]*mockBuild
+}
+
+func (program *mockProgram) isBuilt() bool {
+	for _, build := range program.builds {
+		if build.status == C.CL_BUILD_SUCCESS {
+			return true
+		}
+	}
+	return false
+}
+
+// mockBinaryPrefix marks the binaries of the mock driver, which consist of the prefix and the program source.
+const mockBinaryPrefix = "cl30-mock-binary:"
+
+// binary returns the program binary for the device, which is empty unless the program was built for it.
+func (program *mockProgram) binary(device *mockDevice) []byte {
+	build := program.builds[device]
+	if (build == nil) || (build.status != C.CL_BUILD_SUCCESS) {
+		return nil
+	}
+	return []byte(mockBinaryPrefix + program.source)
+}
+
+type mockKernelArgValue struct {
+	set   bool
+	size  uintptr
+	value []byte
+}
+
+type mockKernel struct {
+	handle   unsafe.Pointer
+	refCount uint32
+	program  *mockProgram
+	name     string
+	args     []mockKernelArgValue
+	execInfo map[C.cl_kernel_exec_info][]byte
+}
+
+var mockKernelPattern = regexp.MustCompile(`(?:__kernel|\bkernel)\s+(?:__attribute__\s*\(\(.*?\)\)\s*)?void\s+(\w+)\s*\(([^)]*)\)`)
+
+// mockParseKernels extracts the names of the kernel functions, and their number of arguments, from the source.
+func mockParseKernels(source string) ([]string, map[string]int) {
+	var names []string
+	args := make(map[string]int)
+	for _, match := range mockKernelPattern.FindAllStringSubmatch(source, -1) {
+		name, params := match[1], strings.TrimSpace(match[2])
+		count := 0
+		if (len(params) > 0) && (params != "void") {
+			count = strings.Count(params, ",") + 1
+		}
+		if _, known := args[name]; !known {
+			names = append(names, name)
+		}
+		args[name] = count
+	}
+	return names, args
+}
+
+// mockBuildLog returns the build log for a source, and whether the build fails.
+func mockBuildLog(source string) (string, bool) {
+	var log []string
+	for index, line := range strings.Split(source, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#error") {
+			log = append(log, fmt.Sprintf("<source>:%d:1: error: %s", index+1, trimmed))
+		}
+	}
+	return strings.Join(log, "\n"), len(log) > 0
+}
+
+func mockTimestamp() C.cl_ulong {
+	return C.cl_ulong(time.Now().UnixNano())
+}
+
+// newEvent creates the event of a command. The event receives a handle only if requested by the application.
+func (queue *mockQueue) newEvent(commandType C.cl_command_type, requested bool) *mockEvent {
+	event := &mockEvent{
+		refCount:    1,
+		context:     queue.context,
+		queue:       queue,
+		commandType: commandType,
+		status:      C.CL_QUEUED,
+		profiled:    (queue.properties & C.CL_QUEUE_PROFILING_ENABLE) != 0,
+	}
+	event.timestamps[0] = mockTimestamp()
+	if requested {
+		event.handle = mockNewHandle()
+		mockDriver.objects[event.handle] = event
+	}
+	return event
+}
+
+// enqueue adds a command to the queue and executes all commands that are ready.
+func (queue *mockQueue) enqueue(commandType C.cl_command_type, waitList []*mockEvent, eventReturn *C.cl_event,
+	run func() C.cl_int) *mockEvent {
+	event := queue.newEvent(commandType, eventReturn != nil)
+	if eventReturn != nil {
+		*eventReturn = C.cl_event(event.handle)
+	}
+	queue.pending = append(queue.pending, &mockCommand{event: event, waitList: waitList, run: run})
+	mockDriver.activeQueues[queue] = struct{}{}
+	mockAdvance()
+	return event
+}
+
+// advance executes the commands of the queue, in order, as long as their wait lists are complete.
+// Returns true if at least one command was completed.
+func (queue *mockQueue) advance() bool {
+	progress := false
+	for len(queue.pending) > 0 {
+		command := queue.pending[0]
+		status := C.cl_int(C.CL_COMPLETE)
+		for _, waitEvent := range command.waitList {
+			if waitEvent.status > C.CL_COMPLETE {
+				return progress
+			}
+			if waitEvent.status < 0 {
+				status = C.CL_EXEC_STATUS_ERROR_FOR_EVENTS_IN_WAIT_LIST
+			}
+		}
+		queue.pending = queue.pending[1:]
+		event := command.event
+		event.timestamps[1] = mockTimestamp()
+		event.timestamps[2] = mockTimestamp()
+		if (status == C.CL_COMPLETE) && (command.run != nil) {
+			if runStatus := command.run(); runStatus != C.CL_SUCCESS {
+				status = runStatus
+			}
+		}
+		event.timestamps[3] = mockTimestamp()
+		event.setStatus(status)
+		progress = true
+	}
+	return progress
+}
+
+// mockAdvance executes all commands that are ready, and wakes up all waiting calls.
+func mockAdvance() {
+	for progress := true; progress; {
+		progress = false
+		for queue := range mockDriver.activeQueues {
+			if queue.advance() {
+				progress = true
+			}
+			if len(queue.pending) == 0 {
+				delete(mockDriver.activeQueues, queue)
+			}
+		}
+	}
+	mockDriver.changed.Broadcast()
+}
+
+// setStatus updates the status of the event and calls the callbacks for all reached states.
+func (event *mockEvent) setStatus(status C.cl_int) {
+	event.status = status
+	remaining := event.callbacks[:0]
+	for _, callback := range event.callbacks {
+		if status <= callback.callbackType {
+			event.notify(callback, status)
+		} else {
+			remaining = append(remaining, callback)
+		}
+	}
+	event.callbacks = remaining
+}
+
+// isDone returns true if the event is complete, or terminated with an error.
+func (event *mockEvent) isDone() bool {
+	return event.status <= C.CL_COMPLETE
+}
+
+// mockWaitFor blocks until all given events are done.
+// Returns CL_EXEC_STATUS_ERROR_FOR_EVENTS_IN_WAIT_LIST if any of the events terminated with an error.
+func mockWaitFor(events []*mockEvent) C.cl_int {
+	for {
+		done := true
+		status := C.cl_int(C.CL_SUCCESS)
+		for _, event := range events {
+			if !event.isDone() {
+				done = false
+				break
+			}
+			if event.status < 0 {
+				status = C.CL_EXEC_STATUS_ERROR_FOR_EVENTS_IN_WAIT_LIST
+			}
+		}
+		if done {
+			return status
+		}
+		mockDriver.changed.Wait()
+	}
+}
+
+// mockWaitList resolves the wait list of an enqueue call.
+func mockWaitList(context *mockContext, numEvents C.cl_uint, eventList *C.cl_event) ([]*mockEvent, C.cl_int) {
+	if (numEvents == 0) != (eventList == nil) {
+		return nil, C.CL_INVALID_EVENT_WAIT_LIST
+	}
+	events := make([]*mockEvent, 0, int(numEvents))
+	for _, handle := range unsafe.Slice(eventList, int(numEvents)) {
+		event, ok := mockObjectFor[*mockEvent](unsafe.Pointer(handle))
+		if !ok {
+			return nil, C.CL_INVALID_EVENT_WAIT_LIST
+		}
+		if event.context != context {
+			return nil, C.CL_INVALID_CONTEXT
+		}
+		events = append(events, event)
+	}
+	return events, C.CL_SUCCESS
+}
+
+// mockInfoResult provides the value of an info query.
+func mockInfoResult(value []byte, paramSize C.size_t, paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	if paramValue != nil {
+		if uintptr(paramSize) < uintptr(len(value)) {
+			return C.CL_INVALID_VALUE
+		}
+		copy(unsafe.Slice((*byte)(paramValue), len(value)), value)
+	}
+	if sizeReturn != nil {
+		*sizeReturn = C.size_t(len(value))
+	}
+	return C.CL_SUCCESS
+}
+
+func mockBytesOf[T any](value T) []byte {
+	return mockBytesOfSlice([]T{value})
+}
+
+func mockBytesOfSlice[T any](values []T) []byte {
+	if len(values) == 0 {
+		return []byte{}
+	}
+	size := int(unsafe.Sizeof(values[0])) * len(values)
+	return append([]byte(nil), unsafe.Slice((*byte)(unsafe.Pointer(&values[0])), size)...)
+}
+
+func mockBytesOfString(value string) []byte {
+	return append([]byte(value), 0)
+}
+
+// mockBytesOfNameVersions returns an entry with the given version for each of the space separated names.
+func mockBytesOfNameVersions(names string, version Version) []byte {
+	var list []NameVersion
+	for _, name := range strings.Fields(names) {
+		entry := NameVersion{Version: version}
+		copy(entry.Name[:NameVersionMaxNameSize-1], name)
+		list = append(list, entry)
+	}
+	return mockBytesOfSlice(list)
+}
+
+func mockBytesOfBool(value bool) []byte {
+	if value {
+		return mockBytesOf(C.cl_bool(C.CL_TRUE))
+	}
+	return mockBytesOf(C.cl_bool(C.CL_FALSE))
+}
+
+// mockPropertyList copies a zero-terminated list of name/value pairs, including the terminating zero.
+func mockPropertyList[T comparable](list *T) []T {
+	if list == nil {
+		return nil
+	}
+	var zero T
+	for count := 1; ; count += 2 {
+		entries := unsafe.Slice(list, count)
+		if entries[count-1] == zero {
+			return append([]T(nil), entries...)
+		}
+	}
+}
+
+// mockSetErrcode sets the status for functions that report it via an optional pointer.
+func mockSetErrcode(errcodeReturn *C.cl_int, status C.cl_int) {
+	if errcodeReturn != nil {
+		*errcodeReturn = status
+	}
+}
+
+// mockKernelExecInfo returns the raw value that was last set with SetKernelExecInfo() for the kernel.
+// False is returned if the value was not set.
+func mockKernelExecInfo(kernel Kernel, paramName KernelExecInfoName) ([]byte, bool) {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	simulated, ok := mockObjectFor[*mockKernel](unsafe.Pointer(kernel.handle()))
+	if !ok {
+		return nil, false
+	}
+	value, set := simulated.execInfo[C.cl_kernel_exec_info(paramName)]
+	return value, set
+}
+
+// mockRawKernelLaunch returns a function that enqueues the kernel by calling the mock driver directly, without the
+// wrapper of EnqueueNDRangeKernel(). The work sizes are held in C memory, so that a launch allocates only within
+// the mock driver. This serves as baseline to measure the allocations of the wrapper. The returned free function
+// releases the C memory.
+func mockRawKernelLaunch(commandQueue CommandQueue, kernel Kernel, dimensions []WorkDimension) (launch func() error, free func()) {
+	count := C.size_t(len(dimensions))
+	raw := (*[3]C.size_t)(C.malloc(3 * count * C.size_t(unsafe.Sizeof(C.size_t(0)))))
+	offsets := unsafe.Slice(&raw[0], 3*len(dimensions))
+	for i, dimension := range dimensions {
+		offsets[i] = C.size_t(dimension.GlobalOffset)
+		offsets[len(dimensions)+i] = C.size_t(dimension.GlobalSize)
+		offsets[2*len(dimensions)+i] = C.size_t(dimension.LocalSize)
+	}
+	launch = func() error {
+		status := C.clEnqueueNDRangeKernel(commandQueue.handle(), kernel.handle(), C.cl_uint(len(dimensions)),
+			&offsets[0], &offsets[len(dimensions)], &offsets[2*len(dimensions)], 0, nil, nil)
+		if status != C.CL_SUCCESS {
+			return StatusError(status)
+		}
+		return nil
+	}
+	return launch, func() { C.free(unsafe.Pointer(raw)) }
+}
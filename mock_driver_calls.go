@@ -0,0 +1,1955 @@
+//go:build cl30_mock
+
+package cl30
+
+// #include "api.h"
+// extern void cl30MockNotifyEvent(void *fn, cl_event event, cl_int commandStatus, void *userData);
+// extern void cl30MockNotifyProgram(void *fn, cl_program program, void *userData);
+// extern void cl30MockNotifyContextDestructor(void *fn, cl_context context, void *userData);
+// extern void cl30MockNotifyMemObjectDestructor(void *fn, cl_mem memobj, void *userData);
+import "C"
+import (
+	"errors"
+	"strings"
+	"unsafe"
+)
+
+// This file contains the simulated OpenCL functions of the mock driver.
+// Callbacks are called on separate goroutines, similar to the threads of an actual implementation.
+
+func (event *mockEvent) notify(callback mockEventCallback, status C.cl_int) {
+	handle := C.cl_event(event.handle)
+	go func() {
+		C.cl30MockNotifyEvent(callback.fn, handle, status, callback.userData)
+	}()
+}
+
+//export cl30MockGetPlatformIDs
+func cl30MockGetPlatformIDs(numEntries C.cl_uint, platforms *C.cl_platform_id, numPlatforms *C.cl_uint) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	if ((numEntries == 0) && (platforms != nil)) || ((platforms == nil) && (numPlatforms == nil)) {
+		return C.CL_INVALID_VALUE
+	}
+	if len(mockDriver.platforms) == 0 {
+		return C.CL_PLATFORM_NOT_FOUND_KHR
+	}
+	if platforms != nil {
+		entries := unsafe.Slice(platforms, int(numEntries))
+		for i := 0; (i < len(entries)) && (i < len(mockDriver.platforms)); i++ {
+			entries[i] = C.cl_platform_id(mockDriver.platforms[i].handle)
+		}
+	}
+	if numPlatforms != nil {
+		*numPlatforms = C.cl_uint(len(mockDriver.platforms))
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockGetPlatformInfo
+func cl30MockGetPlatformInfo(platformID C.cl_platform_id, paramName C.cl_platform_info, paramSize C.size_t,
+	paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	platform, ok := mockObjectFor[*mockPlatform](unsafe.Pointer(platformID))
+	if !ok {
+		return C.CL_INVALID_PLATFORM
+	}
+	var value []byte
+	switch paramName {
+	case C.CL_PLATFORM_PROFILE:
+		value = mockBytesOfString("FULL_PROFILE")
+	case C.CL_PLATFORM_VERSION:
+		value = mockBytesOfString(platform.config.Version)
+	case C.CL_PLATFORM_NUMERIC_VERSION:
+		value = mockBytesOf(C.cl_version(mockVersionOf(platform.config.Version)))
+	case C.CL_PLATFORM_NAME:
+		value = mockBytesOfString(platform.config.Name)
+	case C.CL_PLATFORM_VENDOR:
+		value = mockBytesOfString(platform.config.Vendor)
+	case C.CL_PLATFORM_EXTENSIONS:
+		value = mockBytesOfString(platform.config.Extensions)
+	case C.CL_PLATFORM_EXTENSIONS_WITH_VERSION:
+		value = mockBytesOfNameVersions(platform.config.Extensions, VersionOf(1, 0, 0))
+	case C.CL_PLATFORM_HOST_TIMER_RESOLUTION:
+		value = mockBytesOf(C.cl_ulong(0))
+	case C.CL_PLATFORM_ICD_SUFFIX_KHR:
+		value = mockBytesOfString("MOCK")
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(value, paramSize, paramValue, sizeReturn)
+}
+
+//export cl30MockUnloadPlatformCompiler
+func cl30MockUnloadPlatformCompiler(platformID C.cl_platform_id) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	if _, ok := mockObjectFor[*mockPlatform](unsafe.Pointer(platformID)); !ok {
+		return C.CL_INVALID_PLATFORM
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockGetDeviceIDs
+func cl30MockGetDeviceIDs(platformID C.cl_platform_id, deviceType C.cl_device_type, numEntries C.cl_uint,
+	devices *C.cl_device_id, numDevices *C.cl_uint) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	platform, ok := mockObjectFor[*mockPlatform](unsafe.Pointer(platformID))
+	if !ok {
+		return C.CL_INVALID_PLATFORM
+	}
+	if ((numEntries == 0) && (devices != nil)) || ((devices == nil) && (numDevices == nil)) {
+		return C.CL_INVALID_VALUE
+	}
+	matching := mockDevicesOfType(platform.devices, deviceType)
+	if len(matching) == 0 {
+		return C.CL_DEVICE_NOT_FOUND
+	}
+	if devices != nil {
+		entries := unsafe.Slice(devices, int(numEntries))
+		for i := 0; (i < len(entries)) && (i < len(matching)); i++ {
+			entries[i] = C.cl_device_id(matching[i].handle)
+		}
+	}
+	if numDevices != nil {
+		*numDevices = C.cl_uint(len(matching))
+	}
+	return C.CL_SUCCESS
+}
+
+func mockDevicesOfType(devices []*mockDevice, deviceType C.cl_device_type) []*mockDevice {
+	var matching []*mockDevice
+	for index, device := range devices {
+		deviceFlags := C.cl_device_type(device.config.Type)
+		switch {
+		case deviceType == C.CL_DEVICE_TYPE_ALL:
+			if (deviceFlags & C.CL_DEVICE_TYPE_CUSTOM) == 0 {
+				matching = append(matching, device)
+			}
+		case (deviceType == C.CL_DEVICE_TYPE_DEFAULT) && (index == 0):
+			matching = append(matching, device)
+		case (deviceType & deviceFlags) != 0:
+			matching = append(matching, device)
+		}
+	}
+	return matching
+}
+
+//export cl30MockGetDeviceInfo
+func cl30MockGetDeviceInfo(deviceID C.cl_device_id, paramName C.cl_device_info, paramSize C.size_t,
+	paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	device, ok := mockObjectFor[*mockDevice](unsafe.Pointer(deviceID))
+	if !ok {
+		return C.CL_INVALID_DEVICE
+	}
+	config := device.config
+	version := mockVersionOf(config.Version)
+	var value []byte
+	switch paramName {
+	case C.CL_DEVICE_TYPE:
+		value = mockBytesOf(C.cl_device_type(config.Type))
+	case C.CL_DEVICE_VENDOR_ID:
+		value = mockBytesOf(C.cl_uint(0))
+	case C.CL_DEVICE_MAX_COMPUTE_UNITS:
+		value = mockBytesOf(C.cl_uint(config.MaxComputeUnits))
+	case C.CL_DEVICE_MAX_WORK_ITEM_DIMENSIONS:
+		value = mockBytesOf(C.cl_uint(3))
+	case C.CL_DEVICE_MAX_WORK_ITEM_SIZES:
+		size := C.size_t(config.MaxWorkGroupSize)
+		value = mockBytesOfSlice([]C.size_t{size, size, size})
+	case C.CL_DEVICE_MAX_WORK_GROUP_SIZE:
+		value = mockBytesOf(C.size_t(config.MaxWorkGroupSize))
+	case C.CL_DEVICE_MAX_CLOCK_FREQUENCY:
+		value = mockBytesOf(C.cl_uint(1000))
+	case C.CL_DEVICE_ADDRESS_BITS:
+		value = mockBytesOf(C.cl_uint(unsafe.Sizeof(uintptr(0)) * 8))
+	case C.CL_DEVICE_MAX_MEM_ALLOC_SIZE:
+		value = mockBytesOf(C.cl_ulong(config.MaxMemAllocSize))
+	case C.CL_DEVICE_GLOBAL_MEM_SIZE:
+		value = mockBytesOf(C.cl_ulong(config.GlobalMemSize))
+	case C.CL_DEVICE_LOCAL_MEM_SIZE:
+		value = mockBytesOf(C.cl_ulong(config.LocalMemSize))
+	case C.CL_DEVICE_MAX_CONSTANT_BUFFER_SIZE:
+		value = mockBytesOf(C.cl_ulong(64 * 1024))
+	case C.CL_DEVICE_MAX_READ_WRITE_IMAGE_ARGS:
+		value = mockBytesOf(C.cl_uint(config.MaxReadWriteImageArgs))
+	case C.CL_DEVICE_MAX_CONSTANT_ARGS:
+		value = mockBytesOf(C.cl_uint(8))
+	case C.CL_DEVICE_MAX_PARAMETER_SIZE:
+		value = mockBytesOf(C.size_t(1024))
+	case C.CL_DEVICE_MEM_BASE_ADDR_ALIGN:
+		value = mockBytesOf(C.cl_uint(1024))
+	case C.CL_DEVICE_PROFILING_TIMER_RESOLUTION:
+		value = mockBytesOf(C.size_t(1))
+	case C.CL_DEVICE_IMAGE_SUPPORT, C.CL_DEVICE_ERROR_CORRECTION_SUPPORT:
+		value = mockBytesOfBool(false)
+	case C.CL_DEVICE_ENDIAN_LITTLE, C.CL_DEVICE_AVAILABLE, C.CL_DEVICE_COMPILER_AVAILABLE,
+		C.CL_DEVICE_LINKER_AVAILABLE:
+		value = mockBytesOfBool(true)
+	case C.CL_DEVICE_QUEUE_ON_HOST_PROPERTIES:
+		value = mockBytesOf(C.cl_command_queue_properties(
+			C.CL_QUEUE_OUT_OF_ORDER_EXEC_MODE_ENABLE | C.CL_QUEUE_PROFILING_ENABLE))
+	case C.CL_DEVICE_SVM_CAPABILITIES:
+		value = mockBytesOf(C.cl_device_svm_capabilities(config.SvmCapabilities))
+	case C.CL_DEVICE_PARTITION_MAX_SUB_DEVICES:
+		value = mockBytesOf(C.cl_uint(0))
+	case C.CL_DEVICE_NAME:
+		value = mockBytesOfString(config.Name)
+	case C.CL_DEVICE_VENDOR:
+		value = mockBytesOfString(device.platform.config.Vendor)
+	case C.CL_DRIVER_VERSION:
+		value = mockBytesOfString("cl30-mock")
+	case C.CL_DEVICE_PROFILE:
+		value = mockBytesOfString("FULL_PROFILE")
+	case C.CL_DEVICE_VERSION:
+		value = mockBytesOfString(config.Version)
+	case C.CL_DEVICE_NUMERIC_VERSION:
+		value = mockBytesOf(C.cl_version(version))
+	case C.CL_DEVICE_OPENCL_C_VERSION:
+		cVersion := "OpenCL C 1.2"
+		if version.Major() < 3 {
+			cVersion = "OpenCL C " + version.String()
+		}
+		value = mockBytesOfString(cVersion)
+	case C.CL_DEVICE_EXTENSIONS:
+		value = mockBytesOfString(config.Extensions)
+	case C.CL_DEVICE_EXTENSIONS_WITH_VERSION:
+		value = mockBytesOfNameVersions(config.Extensions, VersionOf(1, 0, 0))
+	case C.CL_DEVICE_PLATFORM:
+		value = mockBytesOf(device.platform.handle)
+	case C.CL_DEVICE_PARENT_DEVICE:
+		value = mockBytesOf(unsafe.Pointer(nil))
+	case C.CL_DEVICE_REFERENCE_COUNT:
+		value = mockBytesOf(C.cl_uint(1))
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(value, paramSize, paramValue, sizeReturn)
+}
+
+//export cl30MockRetainDevice
+func cl30MockRetainDevice(deviceID C.cl_device_id) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	if _, ok := mockObjectFor[*mockDevice](unsafe.Pointer(deviceID)); !ok {
+		return C.CL_INVALID_DEVICE
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockReleaseDevice
+func cl30MockReleaseDevice(deviceID C.cl_device_id) C.cl_int {
+	return cl30MockRetainDevice(deviceID)
+}
+
+//export cl30MockCreateContext
+func cl30MockCreateContext(properties *C.cl_context_properties, numDevices C.cl_uint, devices *C.cl_device_id,
+	notify unsafe.Pointer, userData unsafe.Pointer, errcodeReturn *C.cl_int) C.cl_context {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	if (numDevices == 0) || (devices == nil) || ((notify == nil) && (userData != nil)) {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+		return nil
+	}
+	var contextDevices []*mockDevice
+	for _, handle := range unsafe.Slice(devices, int(numDevices)) {
+		device, ok := mockObjectFor[*mockDevice](unsafe.Pointer(handle))
+		if !ok {
+			mockSetErrcode(errcodeReturn, C.CL_INVALID_DEVICE)
+			return nil
+		}
+		contextDevices = append(contextDevices, device)
+	}
+	return mockNewContext(contextDevices, mockPropertyList(properties), errcodeReturn)
+}
+
+//export cl30MockCreateContextFromType
+func cl30MockCreateContextFromType(properties *C.cl_context_properties, deviceType C.cl_device_type,
+	notify unsafe.Pointer, userData unsafe.Pointer, errcodeReturn *C.cl_int) C.cl_context {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	if (notify == nil) && (userData != nil) {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+		return nil
+	}
+	propertyList := mockPropertyList(properties)
+	var platform *mockPlatform
+	for i := 0; i+1 < len(propertyList); i += 2 {
+		if propertyList[i] == C.CL_CONTEXT_PLATFORM {
+			var ok bool
+			platform, ok = mockObjectFor[*mockPlatform](*(*unsafe.Pointer)(unsafe.Pointer(&propertyList[i+1])))
+			if !ok {
+				mockSetErrcode(errcodeReturn, C.CL_INVALID_PLATFORM)
+				return nil
+			}
+		}
+	}
+	if (platform == nil) && (len(mockDriver.platforms) > 0) {
+		platform = mockDriver.platforms[0]
+	}
+	if platform == nil {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_PLATFORM)
+		return nil
+	}
+	devices := mockDevicesOfType(platform.devices, deviceType)
+	if len(devices) == 0 {
+		mockSetErrcode(errcodeReturn, C.CL_DEVICE_NOT_FOUND)
+		return nil
+	}
+	return mockNewContext(devices, propertyList, errcodeReturn)
+}
+
+func mockNewContext(devices []*mockDevice, properties []C.cl_context_properties, errcodeReturn *C.cl_int) C.cl_context {
+	context := &mockContext{handle: mockNewHandle(), refCount: 1, devices: devices, properties: properties}
+	mockDriver.objects[context.handle] = context
+	mockSetErrcode(errcodeReturn, C.CL_SUCCESS)
+	return C.cl_context(context.handle)
+}
+
+//export cl30MockRetainContext
+func cl30MockRetainContext(contextID C.cl_context) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		return C.CL_INVALID_CONTEXT
+	}
+	context.refCount++
+	return C.CL_SUCCESS
+}
+
+//export cl30MockReleaseContext
+func cl30MockReleaseContext(contextID C.cl_context) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		return C.CL_INVALID_CONTEXT
+	}
+	context.refCount--
+	if context.refCount > 0 {
+		return C.CL_SUCCESS
+	}
+	destructors := context.destructors
+	mockDriver.destructors.Add(1)
+	go func() {
+		defer mockDriver.destructors.Done()
+		for i := len(destructors) - 1; i >= 0; i-- {
+			C.cl30MockNotifyContextDestructor(destructors[i].fn, contextID, destructors[i].userData)
+		}
+	}()
+	mockDeleteObject(context.handle)
+	return C.CL_SUCCESS
+}
+
+//export cl30MockGetContextInfo
+func cl30MockGetContextInfo(contextID C.cl_context, paramName C.cl_context_info, paramSize C.size_t,
+	paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		return C.CL_INVALID_CONTEXT
+	}
+	var value []byte
+	switch paramName {
+	case C.CL_CONTEXT_REFERENCE_COUNT:
+		value = mockBytesOf(C.cl_uint(context.refCount))
+	case C.CL_CONTEXT_NUM_DEVICES:
+		value = mockBytesOf(C.cl_uint(len(context.devices)))
+	case C.CL_CONTEXT_DEVICES:
+		handles := make([]unsafe.Pointer, 0, len(context.devices))
+		for _, device := range context.devices {
+			handles = append(handles, device.handle)
+		}
+		value = mockBytesOfSlice(handles)
+	case C.CL_CONTEXT_PROPERTIES:
+		value = mockBytesOfSlice(context.properties)
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(value, paramSize, paramValue, sizeReturn)
+}
+
+//export cl30MockSetContextDestructorCallback
+func cl30MockSetContextDestructorCallback(contextID C.cl_context, notify unsafe.Pointer, userData unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		return C.CL_INVALID_CONTEXT
+	}
+	if notify == nil {
+		return C.CL_INVALID_VALUE
+	}
+	context.destructors = append(context.destructors, mockCallback{fn: notify, userData: userData})
+	return C.CL_SUCCESS
+}
+
+//export cl30MockCreateCommandQueue
+func cl30MockCreateCommandQueue(contextID C.cl_context, deviceID C.cl_device_id,
+	properties C.cl_command_queue_properties, errcodeReturn *C.cl_int) C.cl_command_queue {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	return mockNewQueue(contextID, deviceID, properties, nil, errcodeReturn)
+}
+
+//export cl30MockCreateCommandQueueWithProperties
+func cl30MockCreateCommandQueueWithProperties(contextID C.cl_context, deviceID C.cl_device_id,
+	properties *C.cl_queue_properties, errcodeReturn *C.cl_int) C.cl_command_queue {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	propertyList := mockPropertyList(properties)
+	flags := C.cl_command_queue_properties(0)
+	for i := 0; i+1 < len(propertyList); i += 2 {
+		switch propertyList[i] {
+		case C.CL_QUEUE_PROPERTIES:
+			flags = C.cl_command_queue_properties(propertyList[i+1])
+		case C.CL_QUEUE_SIZE:
+		default:
+			mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+			return nil
+		}
+	}
+	return mockNewQueue(contextID, deviceID, flags, propertyList, errcodeReturn)
+}
+
+func mockNewQueue(contextID C.cl_context, deviceID C.cl_device_id, properties C.cl_command_queue_properties,
+	propertiesArray []C.cl_queue_properties, errcodeReturn *C.cl_int) C.cl_command_queue {
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_CONTEXT)
+		return nil
+	}
+	device, ok := mockObjectFor[*mockDevice](unsafe.Pointer(deviceID))
+	if !ok || !context.hasDevice(device) {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_DEVICE)
+		return nil
+	}
+	supported := C.cl_command_queue_properties(C.CL_QUEUE_OUT_OF_ORDER_EXEC_MODE_ENABLE | C.CL_QUEUE_PROFILING_ENABLE)
+	if (properties & ^supported) != 0 {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_QUEUE_PROPERTIES)
+		return nil
+	}
+	queue := &mockQueue{
+		handle:          mockNewHandle(),
+		refCount:        1,
+		context:         context,
+		device:          device,
+		properties:      properties,
+		propertiesArray: propertiesArray,
+	}
+	mockDriver.objects[queue.handle] = queue
+	mockSetErrcode(errcodeReturn, C.CL_SUCCESS)
+	return C.cl_command_queue(queue.handle)
+}
+
+//export cl30MockRetainCommandQueue
+func cl30MockRetainCommandQueue(queueID C.cl_command_queue) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, ok := mockObjectFor[*mockQueue](unsafe.Pointer(queueID))
+	if !ok {
+		return C.CL_INVALID_COMMAND_QUEUE
+	}
+	queue.refCount++
+	return C.CL_SUCCESS
+}
+
+//export cl30MockReleaseCommandQueue
+func cl30MockReleaseCommandQueue(queueID C.cl_command_queue) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, ok := mockObjectFor[*mockQueue](unsafe.Pointer(queueID))
+	if !ok {
+		return C.CL_INVALID_COMMAND_QUEUE
+	}
+	queue.refCount--
+	if queue.refCount == 0 {
+		if queue.context.defaultQueues[queue.device] == queue {
+			delete(queue.context.defaultQueues, queue.device)
+		}
+		mockDeleteObject(queue.handle)
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockSetDefaultDeviceCommandQueue
+func cl30MockSetDefaultDeviceCommandQueue(contextID C.cl_context, deviceID C.cl_device_id,
+	queueID C.cl_command_queue) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		return C.CL_INVALID_CONTEXT
+	}
+	device, ok := mockObjectFor[*mockDevice](unsafe.Pointer(deviceID))
+	if !ok || !context.hasDevice(device) {
+		return C.CL_INVALID_DEVICE
+	}
+	queue, ok := mockObjectFor[*mockQueue](unsafe.Pointer(queueID))
+	if !ok || (queue.context != context) || (queue.device != device) {
+		return C.CL_INVALID_COMMAND_QUEUE
+	}
+	if context.defaultQueues == nil {
+		context.defaultQueues = make(map[*mockDevice]*mockQueue)
+	}
+	context.defaultQueues[device] = queue
+	return C.CL_SUCCESS
+}
+
+//export cl30MockGetCommandQueueInfo
+func cl30MockGetCommandQueueInfo(queueID C.cl_command_queue, paramName C.cl_command_queue_info,
+	paramSize C.size_t, paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, ok := mockObjectFor[*mockQueue](unsafe.Pointer(queueID))
+	if !ok {
+		return C.CL_INVALID_COMMAND_QUEUE
+	}
+	var value []byte
+	switch paramName {
+	case C.CL_QUEUE_CONTEXT:
+		value = mockBytesOf(queue.context.handle)
+	case C.CL_QUEUE_DEVICE:
+		value = mockBytesOf(queue.device.handle)
+	case C.CL_QUEUE_REFERENCE_COUNT:
+		value = mockBytesOf(C.cl_uint(queue.refCount))
+	case C.CL_QUEUE_PROPERTIES:
+		value = mockBytesOf(queue.properties)
+	case C.CL_QUEUE_PROPERTIES_ARRAY:
+		value = mockBytesOfSlice(queue.propertiesArray)
+	case C.CL_QUEUE_DEVICE_DEFAULT:
+		var handle unsafe.Pointer
+		if defaultQueue, known := queue.context.defaultQueues[queue.device]; known {
+			handle = defaultQueue.handle
+		}
+		value = mockBytesOf(handle)
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(value, paramSize, paramValue, sizeReturn)
+}
+
+//export cl30MockFlush
+func cl30MockFlush(queueID C.cl_command_queue) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	if _, ok := mockObjectFor[*mockQueue](unsafe.Pointer(queueID)); !ok {
+		return C.CL_INVALID_COMMAND_QUEUE
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockFinish
+func cl30MockFinish(queueID C.cl_command_queue) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, ok := mockObjectFor[*mockQueue](unsafe.Pointer(queueID))
+	if !ok {
+		return C.CL_INVALID_COMMAND_QUEUE
+	}
+	for len(queue.pending) > 0 {
+		mockDriver.changed.Wait()
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockCreateBuffer
+func cl30MockCreateBuffer(contextID C.cl_context, flags C.cl_mem_flags, size C.size_t, hostPtr unsafe.Pointer,
+	errcodeReturn *C.cl_int) C.cl_mem {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	return mockNewBuffer(contextID, nil, flags, size, hostPtr, errcodeReturn)
+}
+
+//export cl30MockCreateBufferWithProperties
+func cl30MockCreateBufferWithProperties(contextID C.cl_context, properties *C.cl_mem_properties,
+	flags C.cl_mem_flags, size C.size_t, hostPtr unsafe.Pointer, errcodeReturn *C.cl_int) C.cl_mem {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	return mockNewBuffer(contextID, mockPropertyList(properties), flags, size, hostPtr, errcodeReturn)
+}
+
+const mockAccessFlags = C.CL_MEM_READ_WRITE | C.CL_MEM_WRITE_ONLY | C.CL_MEM_READ_ONLY
+
+func mockNewBuffer(contextID C.cl_context, properties []C.cl_mem_properties, flags C.cl_mem_flags, size C.size_t,
+	hostPtr unsafe.Pointer, errcodeReturn *C.cl_int) C.cl_mem {
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_CONTEXT)
+		return nil
+	}
+	if size == 0 {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_BUFFER_SIZE)
+		return nil
+	}
+	for _, device := range context.devices {
+		if uint64(size) > device.config.MaxMemAllocSize {
+			mockSetErrcode(errcodeReturn, C.CL_INVALID_BUFFER_SIZE)
+			return nil
+		}
+	}
+	usesHostPtr := (flags & (C.CL_MEM_USE_HOST_PTR | C.CL_MEM_COPY_HOST_PTR)) != 0
+	if usesHostPtr != (hostPtr != nil) {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_HOST_PTR)
+		return nil
+	}
+	if (flags & mockAccessFlags) == 0 {
+		flags |= C.CL_MEM_READ_WRITE
+	}
+	mem := &mockMem{
+		handle:     mockNewHandle(),
+		refCount:   1,
+		context:    context,
+		flags:      flags,
+		size:       uintptr(size),
+		properties: properties,
+	}
+	if (flags & C.CL_MEM_USE_HOST_PTR) != 0 {
+		mem.storage = hostPtr
+		mem.hostPtr = hostPtr
+	} else {
+		mem.storage = C.calloc(1, size)
+		if mem.storage == nil {
+			C.free(mem.handle)
+			mockSetErrcode(errcodeReturn, C.CL_MEM_OBJECT_ALLOCATION_FAILURE)
+			return nil
+		}
+		mem.ownsStorage = true
+		if (flags & C.CL_MEM_COPY_HOST_PTR) != 0 {
+			copy(mem.bytes(), unsafe.Slice((*byte)(hostPtr), mem.size))
+		}
+	}
+	mockDriver.objects[mem.handle] = mem
+	mockSetErrcode(errcodeReturn, C.CL_SUCCESS)
+	return C.cl_mem(mem.handle)
+}
+
+//export cl30MockCreateSubBuffer
+func cl30MockCreateSubBuffer(bufferID C.cl_mem, flags C.cl_mem_flags, createType C.cl_buffer_create_type,
+	createInfo unsafe.Pointer, errcodeReturn *C.cl_int) C.cl_mem {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	parent, ok := mockObjectFor[*mockMem](unsafe.Pointer(bufferID))
+	if !ok || (parent.parent != nil) {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_MEM_OBJECT)
+		return nil
+	}
+	if (createType != C.CL_BUFFER_CREATE_TYPE_REGION) || (createInfo == nil) {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+		return nil
+	}
+	region := (*C.cl_buffer_region)(createInfo)
+	if region.size == 0 {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_BUFFER_SIZE)
+		return nil
+	}
+	if uintptr(region.origin)+uintptr(region.size) > parent.size {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+		return nil
+	}
+	if (flags & mockAccessFlags) == 0 {
+		flags |= parent.flags & mockAccessFlags
+	}
+	flags |= parent.flags & (C.CL_MEM_USE_HOST_PTR | C.CL_MEM_ALLOC_HOST_PTR | C.CL_MEM_COPY_HOST_PTR)
+	mem := &mockMem{
+		handle:   mockNewHandle(),
+		refCount: 1,
+		context:  parent.context,
+		flags:    flags,
+		size:     uintptr(region.size),
+		storage:  unsafe.Add(parent.storage, uintptr(region.origin)),
+		parent:   parent,
+		offset:   uintptr(region.origin),
+	}
+	if parent.hostPtr != nil {
+		mem.hostPtr = unsafe.Add(parent.hostPtr, uintptr(region.origin))
+	}
+	parent.refCount++
+	mockDriver.objects[mem.handle] = mem
+	mockSetErrcode(errcodeReturn, C.CL_SUCCESS)
+	return C.cl_mem(mem.handle)
+}
+
+//export cl30MockRetainMemObject
+func cl30MockRetainMemObject(memID C.cl_mem) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	mem, ok := mockObjectFor[*mockMem](unsafe.Pointer(memID))
+	if !ok {
+		return C.CL_INVALID_MEM_OBJECT
+	}
+	mem.refCount++
+	return C.CL_SUCCESS
+}
+
+//export cl30MockReleaseMemObject
+func cl30MockReleaseMemObject(memID C.cl_mem) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	mem, ok := mockObjectFor[*mockMem](unsafe.Pointer(memID))
+	if !ok {
+		return C.CL_INVALID_MEM_OBJECT
+	}
+	mockReleaseMem(mem)
+	return C.CL_SUCCESS
+}
+
+func mockReleaseMem(mem *mockMem) {
+	mem.refCount--
+	if mem.refCount > 0 {
+		return
+	}
+	handle := C.cl_mem(mem.handle)
+	destructors := mem.destructors
+	storage, ownsStorage := mem.storage, mem.ownsStorage
+	mockDriver.destructors.Add(1)
+	go func() {
+		defer mockDriver.destructors.Done()
+		for i := len(destructors) - 1; i >= 0; i-- {
+			C.cl30MockNotifyMemObjectDestructor(destructors[i].fn, handle, destructors[i].userData)
+		}
+		if ownsStorage {
+			C.free(storage)
+		}
+	}()
+	mockDeleteObject(mem.handle)
+	if mem.parent != nil {
+		mockReleaseMem(mem.parent)
+	}
+}
+
+//export cl30MockGetMemObjectInfo
+func cl30MockGetMemObjectInfo(memID C.cl_mem, paramName C.cl_mem_info, paramSize C.size_t,
+	paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	mem, ok := mockObjectFor[*mockMem](unsafe.Pointer(memID))
+	if !ok {
+		return C.CL_INVALID_MEM_OBJECT
+	}
+	var value []byte
+	switch paramName {
+	case C.CL_MEM_TYPE:
+		value = mockBytesOf(C.cl_mem_object_type(C.CL_MEM_OBJECT_BUFFER))
+	case C.CL_MEM_FLAGS:
+		value = mockBytesOf(mem.flags)
+	case C.CL_MEM_SIZE:
+		value = mockBytesOf(C.size_t(mem.size))
+	case C.CL_MEM_HOST_PTR:
+		value = mockBytesOf(mem.hostPtr)
+	case C.CL_MEM_MAP_COUNT:
+		value = mockBytesOf(C.cl_uint(mem.mapCount))
+	case C.CL_MEM_REFERENCE_COUNT:
+		value = mockBytesOf(C.cl_uint(mem.refCount))
+	case C.CL_MEM_CONTEXT:
+		value = mockBytesOf(mem.context.handle)
+	case C.CL_MEM_ASSOCIATED_MEMOBJECT:
+		var parent unsafe.Pointer
+		if mem.parent != nil {
+			parent = mem.parent.handle
+		}
+		value = mockBytesOf(parent)
+	case C.CL_MEM_OFFSET:
+		value = mockBytesOf(C.size_t(mem.offset))
+	case C.CL_MEM_USES_SVM_POINTER:
+		value = mockBytesOfBool(false)
+	case C.CL_MEM_PROPERTIES:
+		value = mockBytesOfSlice(mem.properties)
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(value, paramSize, paramValue, sizeReturn)
+}
+
+//export cl30MockSetMemObjectDestructorCallback
+func cl30MockSetMemObjectDestructorCallback(memID C.cl_mem, notify unsafe.Pointer, userData unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	mem, ok := mockObjectFor[*mockMem](unsafe.Pointer(memID))
+	if !ok {
+		return C.CL_INVALID_MEM_OBJECT
+	}
+	if notify == nil {
+		return C.CL_INVALID_VALUE
+	}
+	mem.destructors = append(mem.destructors, mockCallback{fn: notify, userData: userData})
+	return C.CL_SUCCESS
+}
+
+// mockEnqueueTarget resolves the queue and wait list of an enqueue call.
+func mockEnqueueTarget(queueID C.cl_command_queue, numEvents C.cl_uint,
+	eventList *C.cl_event) (*mockQueue, []*mockEvent, C.cl_int) {
+	queue, ok := mockObjectFor[*mockQueue](unsafe.Pointer(queueID))
+	if !ok {
+		return nil, nil, C.CL_INVALID_COMMAND_QUEUE
+	}
+	waitList, status := mockWaitList(queue.context, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return nil, nil, status
+	}
+	return queue, waitList, C.CL_SUCCESS
+}
+
+// mockBufferOf resolves a buffer of an enqueue call and verifies the range within the buffer.
+func mockBufferOf(queue *mockQueue, memID C.cl_mem, offset, size C.size_t) (*mockMem, C.cl_int) {
+	mem, ok := mockObjectFor[*mockMem](unsafe.Pointer(memID))
+	if !ok {
+		return nil, C.CL_INVALID_MEM_OBJECT
+	}
+	if mem.context != queue.context {
+		return nil, C.CL_INVALID_CONTEXT
+	}
+	if (uintptr(offset) > mem.size) || (uintptr(size) > mem.size-uintptr(offset)) {
+		return nil, C.CL_INVALID_VALUE
+	}
+	return mem, C.CL_SUCCESS
+}
+
+// mockCompleteBlocking waits for the event in case of a blocking command.
+func mockCompleteBlocking(blocking C.cl_bool, event *mockEvent) C.cl_int {
+	if blocking == C.CL_FALSE {
+		return C.CL_SUCCESS
+	}
+	return mockWaitFor([]*mockEvent{event})
+}
+
+//export cl30MockEnqueueReadBuffer
+func cl30MockEnqueueReadBuffer(queueID C.cl_command_queue, memID C.cl_mem, blocking C.cl_bool,
+	offset, size C.size_t, ptr unsafe.Pointer, numEvents C.cl_uint, eventList *C.cl_event,
+	eventReturn *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, waitList, status := mockEnqueueTarget(queueID, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	mem, status := mockBufferOf(queue, memID, offset, size)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	if ptr == nil {
+		return C.CL_INVALID_VALUE
+	}
+	event := queue.enqueue(C.CL_COMMAND_READ_BUFFER, waitList, eventReturn, func() C.cl_int {
+		copy(unsafe.Slice((*byte)(ptr), int(size)), mem.bytes()[offset:])
+		return C.CL_SUCCESS
+	})
+	return mockCompleteBlocking(blocking, event)
+}
+
+//export cl30MockEnqueueWriteBuffer
+func cl30MockEnqueueWriteBuffer(queueID C.cl_command_queue, memID C.cl_mem, blocking C.cl_bool,
+	offset, size C.size_t, ptr unsafe.Pointer, numEvents C.cl_uint, eventList *C.cl_event,
+	eventReturn *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, waitList, status := mockEnqueueTarget(queueID, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	mem, status := mockBufferOf(queue, memID, offset, size)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	if ptr == nil {
+		return C.CL_INVALID_VALUE
+	}
+	event := queue.enqueue(C.CL_COMMAND_WRITE_BUFFER, waitList, eventReturn, func() C.cl_int {
+		copy(mem.bytes()[offset:], unsafe.Slice((*byte)(ptr), int(size)))
+		return C.CL_SUCCESS
+	})
+	return mockCompleteBlocking(blocking, event)
+}
+
+//export cl30MockEnqueueCopyBuffer
+func cl30MockEnqueueCopyBuffer(queueID C.cl_command_queue, srcID, dstID C.cl_mem, srcOffset, dstOffset,
+	size C.size_t, numEvents C.cl_uint, eventList *C.cl_event, eventReturn *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, waitList, status := mockEnqueueTarget(queueID, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	src, status := mockBufferOf(queue, srcID, srcOffset, size)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	dst, status := mockBufferOf(queue, dstID, dstOffset, size)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	srcStart := uintptr(src.storage) + uintptr(srcOffset)
+	dstStart := uintptr(dst.storage) + uintptr(dstOffset)
+	if (srcStart < dstStart+uintptr(size)) && (dstStart < srcStart+uintptr(size)) {
+		return C.CL_MEM_COPY_OVERLAP
+	}
+	queue.enqueue(C.CL_COMMAND_COPY_BUFFER, waitList, eventReturn, func() C.cl_int {
+		copy(dst.bytes()[dstOffset:dstOffset+size], src.bytes()[srcOffset:srcOffset+size])
+		return C.CL_SUCCESS
+	})
+	return C.CL_SUCCESS
+}
+
+//export cl30MockEnqueueFillBuffer
+func cl30MockEnqueueFillBuffer(queueID C.cl_command_queue, memID C.cl_mem, pattern unsafe.Pointer,
+	patternSize, offset, size C.size_t, numEvents C.cl_uint, eventList *C.cl_event, eventReturn *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, waitList, status := mockEnqueueTarget(queueID, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	mem, status := mockBufferOf(queue, memID, offset, size)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	validPatternSize := (patternSize > 0) && (patternSize <= 128) && ((patternSize & (patternSize - 1)) == 0)
+	if (pattern == nil) || !validPatternSize || ((offset % patternSize) != 0) || ((size % patternSize) != 0) {
+		return C.CL_INVALID_VALUE
+	}
+	patternCopy := append([]byte(nil), unsafe.Slice((*byte)(pattern), int(patternSize))...)
+	queue.enqueue(C.CL_COMMAND_FILL_BUFFER, waitList, eventReturn, func() C.cl_int {
+		target := mem.bytes()[offset : offset+size]
+		for start := 0; start < len(target); start += len(patternCopy) {
+			copy(target[start:], patternCopy)
+		}
+		return C.CL_SUCCESS
+	})
+	return C.CL_SUCCESS
+}
+
+//export cl30MockEnqueueMapBuffer
+func cl30MockEnqueueMapBuffer(queueID C.cl_command_queue, memID C.cl_mem, blocking C.cl_bool,
+	_ C.cl_map_flags, offset, size C.size_t, numEvents C.cl_uint, eventList *C.cl_event, eventReturn *C.cl_event,
+	errcodeReturn *C.cl_int) unsafe.Pointer {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, waitList, status := mockEnqueueTarget(queueID, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		mockSetErrcode(errcodeReturn, status)
+		return nil
+	}
+	mem, status := mockBufferOf(queue, memID, offset, size)
+	if status != C.CL_SUCCESS {
+		mockSetErrcode(errcodeReturn, status)
+		return nil
+	}
+	if size == 0 {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+		return nil
+	}
+	mem.mapCount++
+	event := queue.enqueue(C.CL_COMMAND_MAP_BUFFER, waitList, eventReturn, nil)
+	status = mockCompleteBlocking(blocking, event)
+	mockSetErrcode(errcodeReturn, status)
+	if status != C.CL_SUCCESS {
+		return nil
+	}
+	return unsafe.Add(mem.storage, uintptr(offset))
+}
+
+//export cl30MockEnqueueUnmapMemObject
+func cl30MockEnqueueUnmapMemObject(queueID C.cl_command_queue, memID C.cl_mem, mappedPtr unsafe.Pointer,
+	numEvents C.cl_uint, eventList *C.cl_event, eventReturn *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, waitList, status := mockEnqueueTarget(queueID, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	mem, status := mockBufferOf(queue, memID, 0, 0)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	start, end := uintptr(mem.storage), uintptr(mem.storage)+mem.size
+	if (mem.mapCount == 0) || (uintptr(mappedPtr) < start) || (uintptr(mappedPtr) >= end) {
+		return C.CL_INVALID_VALUE
+	}
+	mem.mapCount--
+	queue.enqueue(C.CL_COMMAND_UNMAP_MEM_OBJECT, waitList, eventReturn, nil)
+	return C.CL_SUCCESS
+}
+
+//export cl30MockEnqueueMarkerWithWaitList
+func cl30MockEnqueueMarkerWithWaitList(queueID C.cl_command_queue, numEvents C.cl_uint, eventList *C.cl_event,
+	eventReturn *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, waitList, status := mockEnqueueTarget(queueID, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	queue.enqueue(C.CL_COMMAND_MARKER, waitList, eventReturn, nil)
+	return C.CL_SUCCESS
+}
+
+//export cl30MockEnqueueBarrierWithWaitList
+func cl30MockEnqueueBarrierWithWaitList(queueID C.cl_command_queue, numEvents C.cl_uint, eventList *C.cl_event,
+	eventReturn *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, waitList, status := mockEnqueueTarget(queueID, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	queue.enqueue(C.CL_COMMAND_BARRIER, waitList, eventReturn, nil)
+	return C.CL_SUCCESS
+}
+
+//export cl30MockGetSupportedImageFormats
+func cl30MockGetSupportedImageFormats(contextID C.cl_context, flags C.cl_mem_flags, imageType C.cl_mem_object_type,
+	numEntries C.cl_uint, formats *C.cl_image_format, numFormats *C.cl_uint) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		return C.CL_INVALID_CONTEXT
+	}
+	if (numEntries == 0) && (formats != nil) {
+		return C.CL_INVALID_VALUE
+	}
+	var common []ImageFormat
+	for _, candidate := range context.devices[0].config.ImageFormats {
+		supported := true
+		for _, device := range context.devices[1:] {
+			supported = supported && mockHasImageFormat(device.config.ImageFormats, candidate)
+		}
+		if supported {
+			common = append(common, candidate)
+		}
+	}
+	if formats != nil {
+		entries := unsafe.Slice(formats, int(numEntries))
+		for i := 0; (i < len(entries)) && (i < len(common)); i++ {
+			entries[i] = C.cl_image_format{
+				image_channel_order:     C.cl_channel_order(common[i].ChannelOrder),
+				image_channel_data_type: C.cl_channel_type(common[i].ChannelType),
+			}
+		}
+	}
+	if numFormats != nil {
+		*numFormats = C.cl_uint(len(common))
+	}
+	return C.CL_SUCCESS
+}
+
+func mockHasImageFormat(formats []ImageFormat, format ImageFormat) bool {
+	for _, candidate := range formats {
+		if candidate == format {
+			return true
+		}
+	}
+	return false
+}
+
+//export cl30MockSVMAlloc
+func cl30MockSVMAlloc(contextID C.cl_context, flags C.cl_svm_mem_flags, size C.size_t,
+	alignment C.cl_uint) unsafe.Pointer {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	if _, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID)); !ok || (size == 0) {
+		return nil
+	}
+	ptr := C.calloc(1, size)
+	mockDriver.svmPointers[ptr] = struct{}{}
+	return ptr
+}
+
+//export cl30MockSVMFree
+func cl30MockSVMFree(contextID C.cl_context, ptr unsafe.Pointer) {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	if _, known := mockDriver.svmPointers[ptr]; !known {
+		return
+	}
+	delete(mockDriver.svmPointers, ptr)
+	C.free(ptr)
+}
+
+//export cl30MockEnqueueSVMMap
+func cl30MockEnqueueSVMMap(queueID C.cl_command_queue, blocking C.cl_bool, flags C.cl_map_flags,
+	ptr unsafe.Pointer, size C.size_t, numEvents C.cl_uint, eventList *C.cl_event,
+	eventReturn *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, waitList, status := mockEnqueueTarget(queueID, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	if (ptr == nil) || (size == 0) {
+		return C.CL_INVALID_VALUE
+	}
+	event := queue.enqueue(C.CL_COMMAND_SVM_MAP, waitList, eventReturn, nil)
+	return mockCompleteBlocking(blocking, event)
+}
+
+//export cl30MockEnqueueSVMUnmap
+func cl30MockEnqueueSVMUnmap(queueID C.cl_command_queue, ptr unsafe.Pointer, numEvents C.cl_uint,
+	eventList *C.cl_event, eventReturn *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, waitList, status := mockEnqueueTarget(queueID, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	if ptr == nil {
+		return C.CL_INVALID_VALUE
+	}
+	queue.enqueue(C.CL_COMMAND_SVM_UNMAP, waitList, eventReturn, nil)
+	return C.CL_SUCCESS
+}
+
+//export cl30MockEnqueueNDRangeKernel
+func cl30MockEnqueueNDRangeKernel(queueID C.cl_command_queue, kernelID C.cl_kernel, workDim C.cl_uint,
+	globalWorkOffset, globalWorkSize, localWorkSize *C.size_t, numEvents C.cl_uint, eventList *C.cl_event,
+	eventReturn *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	queue, waitList, status := mockEnqueueTarget(queueID, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	call, status := mockKernelCallFor(queue, kernelID, workDim, globalWorkOffset, globalWorkSize, localWorkSize)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	fn := mockDriver.kernelFuncs[call.Name]
+	queue.enqueue(C.CL_COMMAND_NDRANGE_KERNEL, waitList, eventReturn, func() C.cl_int {
+		return mockRunKernel(fn, call)
+	})
+	return C.CL_SUCCESS
+}
+
+// mockKernelCallFor validates the launch of a kernel on the queue, and captures the current arguments of the kernel.
+func mockKernelCallFor(queue *mockQueue, kernelID C.cl_kernel, workDim C.cl_uint,
+	globalWorkOffset, globalWorkSize, localWorkSize *C.size_t) (MockKernelCall, C.cl_int) {
+	kernel, ok := mockObjectFor[*mockKernel](unsafe.Pointer(kernelID))
+	if !ok {
+		return MockKernelCall{}, C.CL_INVALID_KERNEL
+	}
+	if kernel.program.context != queue.context {
+		return MockKernelCall{}, C.CL_INVALID_CONTEXT
+	}
+	if build := kernel.program.builds[queue.device]; (build == nil) || (build.status != C.CL_BUILD_SUCCESS) {
+		return MockKernelCall{}, C.CL_INVALID_PROGRAM_EXECUTABLE
+	}
+	if (workDim < 1) || (workDim > 3) {
+		return MockKernelCall{}, C.CL_INVALID_WORK_DIMENSION
+	}
+	if globalWorkSize == nil {
+		return MockKernelCall{}, C.CL_INVALID_GLOBAL_WORK_SIZE
+	}
+	call := MockKernelCall{
+		Name:             kernel.name,
+		GlobalWorkOffset: make([]uintptr, int(workDim)),
+		GlobalWorkSize:   make([]uintptr, int(workDim)),
+	}
+	for i, size := range unsafe.Slice(globalWorkSize, int(workDim)) {
+		call.GlobalWorkSize[i] = uintptr(size)
+	}
+	if globalWorkOffset != nil {
+		for i, offset := range unsafe.Slice(globalWorkOffset, int(workDim)) {
+			call.GlobalWorkOffset[i] = uintptr(offset)
+		}
+	}
+	if localWorkSize != nil {
+		call.LocalWorkSize = make([]uintptr, int(workDim))
+		total := uintptr(1)
+		for i, size := range unsafe.Slice(localWorkSize, int(workDim)) {
+			if size == 0 {
+				return MockKernelCall{}, C.CL_INVALID_WORK_GROUP_SIZE
+			}
+			call.LocalWorkSize[i] = uintptr(size)
+			total *= uintptr(size)
+		}
+		if total > queue.device.config.MaxWorkGroupSize {
+			return MockKernelCall{}, C.CL_INVALID_WORK_GROUP_SIZE
+		}
+	}
+	for _, arg := range kernel.args {
+		if !arg.set {
+			return MockKernelCall{}, C.CL_INVALID_KERNEL_ARGS
+		}
+		callArg := MockKernelArg{Size: arg.size, Value: arg.value}
+		if len(arg.value) == int(unsafe.Sizeof(unsafe.Pointer(nil))) {
+			if mem, isMem := mockObjectFor[*mockMem](*(*unsafe.Pointer)(unsafe.Pointer(&arg.value[0]))); isMem {
+				callArg.Buffer = mem.bytes()
+			}
+		}
+		call.Args = append(call.Args, callArg)
+	}
+	return call, C.CL_SUCCESS
+}
+
+// mockRunKernel executes the call with the registered function of the kernel, if any.
+func mockRunKernel(fn MockKernelFunc, call MockKernelCall) C.cl_int {
+	if fn == nil {
+		return C.CL_SUCCESS
+	}
+	err := fn(call)
+	if err == nil {
+		return C.CL_SUCCESS
+	}
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		return C.cl_int(statusErr)
+	}
+	return C.CL_OUT_OF_RESOURCES
+}
+
+//export cl30MockCreateUserEvent
+func cl30MockCreateUserEvent(contextID C.cl_context, errcodeReturn *C.cl_int) C.cl_event {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_CONTEXT)
+		return nil
+	}
+	event := &mockEvent{
+		handle:      mockNewHandle(),
+		refCount:    1,
+		context:     context,
+		commandType: C.CL_COMMAND_USER,
+		status:      C.CL_SUBMITTED,
+	}
+	mockDriver.objects[event.handle] = event
+	mockSetErrcode(errcodeReturn, C.CL_SUCCESS)
+	return C.cl_event(event.handle)
+}
+
+//export cl30MockSetUserEventStatus
+func cl30MockSetUserEventStatus(eventID C.cl_event, executionStatus C.cl_int) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	event, ok := mockObjectFor[*mockEvent](unsafe.Pointer(eventID))
+	if !ok || (event.commandType != C.CL_COMMAND_USER) {
+		return C.CL_INVALID_EVENT
+	}
+	if executionStatus > C.CL_COMPLETE {
+		return C.CL_INVALID_VALUE
+	}
+	if event.isDone() {
+		return C.CL_INVALID_OPERATION
+	}
+	event.setStatus(executionStatus)
+	mockAdvance()
+	return C.CL_SUCCESS
+}
+
+//export cl30MockWaitForEvents
+func cl30MockWaitForEvents(numEvents C.cl_uint, eventList *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	if (numEvents == 0) || (eventList == nil) {
+		return C.CL_INVALID_VALUE
+	}
+	var events []*mockEvent
+	var context *mockContext
+	for _, handle := range unsafe.Slice(eventList, int(numEvents)) {
+		event, ok := mockObjectFor[*mockEvent](unsafe.Pointer(handle))
+		if !ok {
+			return C.CL_INVALID_EVENT
+		}
+		if (context != nil) && (event.context != context) {
+			return C.CL_INVALID_CONTEXT
+		}
+		context = event.context
+		events = append(events, event)
+	}
+	return mockWaitFor(events)
+}
+
+//export cl30MockGetEventInfo
+func cl30MockGetEventInfo(eventID C.cl_event, paramName C.cl_event_info, paramSize C.size_t,
+	paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	event, ok := mockObjectFor[*mockEvent](unsafe.Pointer(eventID))
+	if !ok {
+		return C.CL_INVALID_EVENT
+	}
+	var value []byte
+	switch paramName {
+	case C.CL_EVENT_COMMAND_QUEUE:
+		var queue unsafe.Pointer
+		if event.queue != nil {
+			queue = event.queue.handle
+		}
+		value = mockBytesOf(queue)
+	case C.CL_EVENT_CONTEXT:
+		value = mockBytesOf(event.context.handle)
+	case C.CL_EVENT_COMMAND_TYPE:
+		value = mockBytesOf(event.commandType)
+	case C.CL_EVENT_COMMAND_EXECUTION_STATUS:
+		value = mockBytesOf(event.status)
+	case C.CL_EVENT_REFERENCE_COUNT:
+		value = mockBytesOf(C.cl_uint(event.refCount))
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(value, paramSize, paramValue, sizeReturn)
+}
+
+//export cl30MockGetEventProfilingInfo
+func cl30MockGetEventProfilingInfo(eventID C.cl_event, paramName C.cl_profiling_info, paramSize C.size_t,
+	paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	event, ok := mockObjectFor[*mockEvent](unsafe.Pointer(eventID))
+	if !ok {
+		return C.CL_INVALID_EVENT
+	}
+	if !event.profiled || !event.isDone() {
+		return C.CL_PROFILING_INFO_NOT_AVAILABLE
+	}
+	var timestamp C.cl_ulong
+	switch paramName {
+	case C.CL_PROFILING_COMMAND_QUEUED:
+		timestamp = event.timestamps[0]
+	case C.CL_PROFILING_COMMAND_SUBMIT:
+		timestamp = event.timestamps[1]
+	case C.CL_PROFILING_COMMAND_START:
+		timestamp = event.timestamps[2]
+	case C.CL_PROFILING_COMMAND_END, C.CL_PROFILING_COMMAND_COMPLETE:
+		timestamp = event.timestamps[3]
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(mockBytesOf(timestamp), paramSize, paramValue, sizeReturn)
+}
+
+//export cl30MockRetainEvent
+func cl30MockRetainEvent(eventID C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	event, ok := mockObjectFor[*mockEvent](unsafe.Pointer(eventID))
+	if !ok {
+		return C.CL_INVALID_EVENT
+	}
+	event.refCount++
+	return C.CL_SUCCESS
+}
+
+//export cl30MockReleaseEvent
+func cl30MockReleaseEvent(eventID C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	event, ok := mockObjectFor[*mockEvent](unsafe.Pointer(eventID))
+	if !ok {
+		return C.CL_INVALID_EVENT
+	}
+	event.refCount--
+	if event.refCount == 0 {
+		mockDeleteObject(event.handle)
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockSetEventCallback
+func cl30MockSetEventCallback(eventID C.cl_event, callbackType C.cl_int, notify unsafe.Pointer,
+	userData unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	event, ok := mockObjectFor[*mockEvent](unsafe.Pointer(eventID))
+	if !ok {
+		return C.CL_INVALID_EVENT
+	}
+	validType := (callbackType == C.CL_COMPLETE) || (callbackType == C.CL_RUNNING) ||
+		(callbackType == C.CL_SUBMITTED)
+	if (notify == nil) || !validType {
+		return C.CL_INVALID_VALUE
+	}
+	callback := mockEventCallback{callbackType: callbackType, mockCallback: mockCallback{fn: notify, userData: userData}}
+	if event.status <= callbackType {
+		event.notify(callback, event.status)
+	} else {
+		event.callbacks = append(event.callbacks, callback)
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockCreateProgramWithSource
+func cl30MockCreateProgramWithSource(contextID C.cl_context, count C.cl_uint, sources **C.char,
+	lengths *C.size_t, errcodeReturn *C.cl_int) C.cl_program {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_CONTEXT)
+		return nil
+	}
+	if (count == 0) || (sources == nil) {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+		return nil
+	}
+	var source strings.Builder
+	sourceList := unsafe.Slice(sources, int(count))
+	for i, text := range sourceList {
+		if text == nil {
+			mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+			return nil
+		}
+		if (lengths != nil) && (unsafe.Slice(lengths, int(count))[i] > 0) {
+			source.WriteString(C.GoStringN(text, C.int(unsafe.Slice(lengths, int(count))[i])))
+		} else {
+			source.WriteString(C.GoString(text))
+		}
+	}
+	program := &mockProgram{
+		handle:   mockNewHandle(),
+		refCount: 1,
+		context:  context,
+		source:   source.String(),
+		builds:   make(map[*mockDevice]*mockBuild),
+	}
+	program.kernelNames, program.kernelArgs = mockParseKernels(program.source)
+	mockDriver.objects[program.handle] = program
+	mockSetErrcode(errcodeReturn, C.CL_SUCCESS)
+	return C.cl_program(program.handle)
+}
+
+//export cl30MockCreateProgramWithBinary
+func cl30MockCreateProgramWithBinary(contextID C.cl_context, numDevices C.cl_uint, deviceList *C.cl_device_id,
+	lengths *C.size_t, binaries **C.uchar, binaryStatus *C.cl_int, errcodeReturn *C.cl_int) C.cl_program {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_CONTEXT)
+		return nil
+	}
+	if (numDevices == 0) || (deviceList == nil) || (lengths == nil) || (binaries == nil) {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+		return nil
+	}
+	var statuses []C.cl_int
+	if binaryStatus != nil {
+		statuses = unsafe.Slice(binaryStatus, int(numDevices))
+	}
+	lengthList := unsafe.Slice(lengths, int(numDevices))
+	binaryList := unsafe.Slice(binaries, int(numDevices))
+	var source string
+	result := C.cl_int(C.CL_SUCCESS)
+	for i, handle := range unsafe.Slice(deviceList, int(numDevices)) {
+		device, ok := mockObjectFor[*mockDevice](unsafe.Pointer(handle))
+		if !ok || !context.hasDevice(device) {
+			mockSetErrcode(errcodeReturn, C.CL_INVALID_DEVICE)
+			return nil
+		}
+		if (binaryList[i] == nil) || (lengthList[i] == 0) {
+			mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+			return nil
+		}
+		status := C.cl_int(C.CL_SUCCESS)
+		text := C.GoStringN((*C.char)(unsafe.Pointer(binaryList[i])), C.int(lengthList[i]))
+		if strings.HasPrefix(text, mockBinaryPrefix) {
+			source = strings.TrimPrefix(text, mockBinaryPrefix)
+		} else {
+			status = C.CL_INVALID_BINARY
+			result = status
+		}
+		if statuses != nil {
+			statuses[i] = status
+		}
+	}
+	if result != C.CL_SUCCESS {
+		mockSetErrcode(errcodeReturn, result)
+		return nil
+	}
+	program := &mockProgram{
+		handle:   mockNewHandle(),
+		refCount: 1,
+		context:  context,
+		source:   source,
+		builds:   make(map[*mockDevice]*mockBuild),
+	}
+	program.kernelNames, program.kernelArgs = mockParseKernels(program.source)
+	mockDriver.objects[program.handle] = program
+	mockSetErrcode(errcodeReturn, C.CL_SUCCESS)
+	return C.cl_program(program.handle)
+}
+
+//export cl30MockBuildProgram
+func cl30MockBuildProgram(programID C.cl_program, numDevices C.cl_uint, deviceList *C.cl_device_id,
+	options *C.char, notify unsafe.Pointer, userData unsafe.Pointer) C.cl_int {
+	return mockCompile(programID, numDevices, deviceList, options, notify, userData, C.CL_BUILD_PROGRAM_FAILURE)
+}
+
+//export cl30MockCompileProgram
+func cl30MockCompileProgram(programID C.cl_program, numDevices C.cl_uint, deviceList *C.cl_device_id,
+	options *C.char, numInputHeaders C.cl_uint, inputHeaders *C.cl_program, headerIncludeNames **C.char,
+	notify unsafe.Pointer, userData unsafe.Pointer) C.cl_int {
+	if (numInputHeaders == 0) != (inputHeaders == nil) {
+		return C.CL_INVALID_VALUE
+	}
+	// Headers are not preprocessed; only the source of the program itself is considered.
+	return mockCompile(programID, numDevices, deviceList, options, notify, userData, C.CL_COMPILE_PROGRAM_FAILURE)
+}
+
+// mockCompile builds or compiles the program. The kernels are already known from the source; the build only
+// determines the status and the log, based on "#error" directives.
+func mockCompile(programID C.cl_program, numDevices C.cl_uint, deviceList *C.cl_device_id,
+	options *C.char, notify unsafe.Pointer, userData unsafe.Pointer, failure C.cl_int) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	program, ok := mockObjectFor[*mockProgram](unsafe.Pointer(programID))
+	if !ok {
+		return C.CL_INVALID_PROGRAM
+	}
+	if (notify == nil) && (userData != nil) {
+		return C.CL_INVALID_VALUE
+	}
+	devices, status := mockDeviceList(program.context, numDevices, deviceList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	var optionString string
+	if options != nil {
+		optionString = C.GoString(options)
+	}
+	log, failed := mockBuildLog(program.source)
+	buildStatus := C.cl_build_status(C.CL_BUILD_SUCCESS)
+	if failed {
+		buildStatus = C.CL_BUILD_ERROR
+	}
+	for _, device := range devices {
+		program.builds[device] = &mockBuild{status: buildStatus, options: optionString, log: log}
+	}
+	if notify != nil {
+		go func() {
+			C.cl30MockNotifyProgram(notify, programID, userData)
+		}()
+	}
+	if failed {
+		return failure
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockLinkProgram
+func cl30MockLinkProgram(contextID C.cl_context, numDevices C.cl_uint, deviceList *C.cl_device_id,
+	options *C.char, numInputPrograms C.cl_uint, inputPrograms *C.cl_program,
+	notify unsafe.Pointer, userData unsafe.Pointer, errcodeReturn *C.cl_int) C.cl_program {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	context, ok := mockObjectFor[*mockContext](unsafe.Pointer(contextID))
+	if !ok {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_CONTEXT)
+		return nil
+	}
+	if (numInputPrograms == 0) || (inputPrograms == nil) || ((notify == nil) && (userData != nil)) {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+		return nil
+	}
+	devices, status := mockDeviceList(context, numDevices, deviceList)
+	if status != C.CL_SUCCESS {
+		mockSetErrcode(errcodeReturn, status)
+		return nil
+	}
+	var source strings.Builder
+	for _, handle := range unsafe.Slice(inputPrograms, int(numInputPrograms)) {
+		input, ok := mockObjectFor[*mockProgram](unsafe.Pointer(handle))
+		if !ok || (input.context != context) {
+			mockSetErrcode(errcodeReturn, C.CL_INVALID_PROGRAM)
+			return nil
+		}
+		if !input.isBuilt() {
+			mockSetErrcode(errcodeReturn, C.CL_INVALID_OPERATION)
+			return nil
+		}
+		source.WriteString(input.source)
+		source.WriteString("\n")
+	}
+	var optionString string
+	if options != nil {
+		optionString = C.GoString(options)
+	}
+	program := &mockProgram{
+		handle:   mockNewHandle(),
+		refCount: 1,
+		context:  context,
+		source:   source.String(),
+		builds:   make(map[*mockDevice]*mockBuild),
+	}
+	program.kernelNames, program.kernelArgs = mockParseKernels(program.source)
+	for _, device := range devices {
+		program.builds[device] = &mockBuild{status: C.CL_BUILD_SUCCESS, options: optionString}
+	}
+	mockDriver.objects[program.handle] = program
+	if notify != nil {
+		go func() {
+			C.cl30MockNotifyProgram(notify, C.cl_program(program.handle), userData)
+		}()
+	}
+	mockSetErrcode(errcodeReturn, C.CL_SUCCESS)
+	return C.cl_program(program.handle)
+}
+
+// mockDeviceList returns the devices of the list, or all devices of the context for an empty list.
+func mockDeviceList(context *mockContext, numDevices C.cl_uint, deviceList *C.cl_device_id) ([]*mockDevice, C.cl_int) {
+	if (numDevices == 0) != (deviceList == nil) {
+		return nil, C.CL_INVALID_VALUE
+	}
+	if deviceList == nil {
+		return context.devices, C.CL_SUCCESS
+	}
+	var devices []*mockDevice
+	for _, handle := range unsafe.Slice(deviceList, int(numDevices)) {
+		device, ok := mockObjectFor[*mockDevice](unsafe.Pointer(handle))
+		if !ok || !context.hasDevice(device) {
+			return nil, C.CL_INVALID_DEVICE
+		}
+		devices = append(devices, device)
+	}
+	return devices, C.CL_SUCCESS
+}
+
+//export cl30MockRetainProgram
+func cl30MockRetainProgram(programID C.cl_program) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	program, ok := mockObjectFor[*mockProgram](unsafe.Pointer(programID))
+	if !ok {
+		return C.CL_INVALID_PROGRAM
+	}
+	program.refCount++
+	return C.CL_SUCCESS
+}
+
+//export cl30MockReleaseProgram
+func cl30MockReleaseProgram(programID C.cl_program) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	program, ok := mockObjectFor[*mockProgram](unsafe.Pointer(programID))
+	if !ok {
+		return C.CL_INVALID_PROGRAM
+	}
+	program.refCount--
+	if program.refCount == 0 {
+		mockDeleteObject(program.handle)
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockGetProgramInfo
+func cl30MockGetProgramInfo(programID C.cl_program, paramName C.cl_program_info, paramSize C.size_t,
+	paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	program, ok := mockObjectFor[*mockProgram](unsafe.Pointer(programID))
+	if !ok {
+		return C.CL_INVALID_PROGRAM
+	}
+	var value []byte
+	switch paramName {
+	case C.CL_PROGRAM_REFERENCE_COUNT:
+		value = mockBytesOf(C.cl_uint(program.refCount))
+	case C.CL_PROGRAM_CONTEXT:
+		value = mockBytesOf(program.context.handle)
+	case C.CL_PROGRAM_NUM_DEVICES:
+		value = mockBytesOf(C.cl_uint(len(program.context.devices)))
+	case C.CL_PROGRAM_DEVICES:
+		handles := make([]unsafe.Pointer, 0, len(program.context.devices))
+		for _, device := range program.context.devices {
+			handles = append(handles, device.handle)
+		}
+		value = mockBytesOfSlice(handles)
+	case C.CL_PROGRAM_SOURCE:
+		value = mockBytesOfString(program.source)
+	case C.CL_PROGRAM_BINARY_SIZES:
+		sizes := make([]C.size_t, 0, len(program.context.devices))
+		for _, device := range program.context.devices {
+			sizes = append(sizes, C.size_t(len(program.binary(device))))
+		}
+		value = mockBytesOfSlice(sizes)
+	case C.CL_PROGRAM_BINARIES:
+		count := len(program.context.devices)
+		if paramValue != nil {
+			if uintptr(paramSize) < uintptr(count)*unsafe.Sizeof(uintptr(0)) {
+				return C.CL_INVALID_VALUE
+			}
+			targets := unsafe.Slice((**C.uchar)(paramValue), count)
+			for i, device := range program.context.devices {
+				binary := program.binary(device)
+				if (targets[i] != nil) && (len(binary) > 0) {
+					copy(unsafe.Slice((*byte)(unsafe.Pointer(targets[i])), len(binary)), binary)
+				}
+			}
+		}
+		if sizeReturn != nil {
+			*sizeReturn = C.size_t(uintptr(count) * unsafe.Sizeof(uintptr(0)))
+		}
+		return C.CL_SUCCESS
+	case C.CL_PROGRAM_NUM_KERNELS:
+		if !program.isBuilt() {
+			return C.CL_INVALID_PROGRAM_EXECUTABLE
+		}
+		value = mockBytesOf(C.size_t(len(program.kernelNames)))
+	case C.CL_PROGRAM_KERNEL_NAMES:
+		if !program.isBuilt() {
+			return C.CL_INVALID_PROGRAM_EXECUTABLE
+		}
+		value = mockBytesOfString(strings.Join(program.kernelNames, ";"))
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(value, paramSize, paramValue, sizeReturn)
+}
+
+//export cl30MockGetProgramBuildInfo
+func cl30MockGetProgramBuildInfo(programID C.cl_program, deviceID C.cl_device_id,
+	paramName C.cl_program_build_info, paramSize C.size_t, paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	program, ok := mockObjectFor[*mockProgram](unsafe.Pointer(programID))
+	if !ok {
+		return C.CL_INVALID_PROGRAM
+	}
+	device, ok := mockObjectFor[*mockDevice](unsafe.Pointer(deviceID))
+	if !ok || !program.context.hasDevice(device) {
+		return C.CL_INVALID_DEVICE
+	}
+	build := program.builds[device]
+	if build == nil {
+		build = &mockBuild{status: C.CL_BUILD_NONE}
+	}
+	var value []byte
+	switch paramName {
+	case C.CL_PROGRAM_BUILD_STATUS:
+		value = mockBytesOf(build.status)
+	case C.CL_PROGRAM_BUILD_OPTIONS:
+		value = mockBytesOfString(build.options)
+	case C.CL_PROGRAM_BUILD_LOG:
+		value = mockBytesOfString(build.log)
+	case C.CL_PROGRAM_BINARY_TYPE:
+		binaryType := C.cl_program_binary_type(C.CL_PROGRAM_BINARY_TYPE_NONE)
+		if build.status == C.CL_BUILD_SUCCESS {
+			binaryType = C.CL_PROGRAM_BINARY_TYPE_EXECUTABLE
+		}
+		value = mockBytesOf(binaryType)
+	case C.CL_PROGRAM_BUILD_GLOBAL_VARIABLE_TOTAL_SIZE:
+		value = mockBytesOf(C.size_t(0))
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(value, paramSize, paramValue, sizeReturn)
+}
+
+//export cl30MockCreateKernel
+func cl30MockCreateKernel(programID C.cl_program, kernelName *C.char, errcodeReturn *C.cl_int) C.cl_kernel {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	program, ok := mockObjectFor[*mockProgram](unsafe.Pointer(programID))
+	if !ok {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_PROGRAM)
+		return nil
+	}
+	if !program.isBuilt() {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_PROGRAM_EXECUTABLE)
+		return nil
+	}
+	if kernelName == nil {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+		return nil
+	}
+	name := C.GoString(kernelName)
+	argCount, known := program.kernelArgs[name]
+	if !known {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_KERNEL_NAME)
+		return nil
+	}
+	kernel := &mockKernel{
+		handle:   mockNewHandle(),
+		refCount: 1,
+		program:  program,
+		name:     name,
+		args:     make([]mockKernelArgValue, argCount),
+	}
+	program.refCount++
+	mockDriver.objects[kernel.handle] = kernel
+	mockSetErrcode(errcodeReturn, C.CL_SUCCESS)
+	return C.cl_kernel(kernel.handle)
+}
+
+//export cl30MockCloneKernel
+func cl30MockCloneKernel(sourceID C.cl_kernel, errcodeReturn *C.cl_int) C.cl_kernel {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	source, ok := mockObjectFor[*mockKernel](unsafe.Pointer(sourceID))
+	if !ok {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_KERNEL)
+		return nil
+	}
+	kernel := &mockKernel{
+		handle:   mockNewHandle(),
+		refCount: 1,
+		program:  source.program,
+		name:     source.name,
+		args:     append([]mockKernelArgValue{}, source.args...),
+	}
+	for paramName, value := range source.execInfo {
+		if kernel.execInfo == nil {
+			kernel.execInfo = make(map[C.cl_kernel_exec_info][]byte)
+		}
+		kernel.execInfo[paramName] = append([]byte{}, value...)
+	}
+	kernel.program.refCount++
+	mockDriver.objects[kernel.handle] = kernel
+	mockSetErrcode(errcodeReturn, C.CL_SUCCESS)
+	return C.cl_kernel(kernel.handle)
+}
+
+//export cl30MockRetainKernel
+func cl30MockRetainKernel(kernelID C.cl_kernel) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	kernel, ok := mockObjectFor[*mockKernel](unsafe.Pointer(kernelID))
+	if !ok {
+		return C.CL_INVALID_KERNEL
+	}
+	kernel.refCount++
+	return C.CL_SUCCESS
+}
+
+//export cl30MockReleaseKernel
+func cl30MockReleaseKernel(kernelID C.cl_kernel) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	kernel, ok := mockObjectFor[*mockKernel](unsafe.Pointer(kernelID))
+	if !ok {
+		return C.CL_INVALID_KERNEL
+	}
+	kernel.refCount--
+	if kernel.refCount > 0 {
+		return C.CL_SUCCESS
+	}
+	mockDeleteObject(kernel.handle)
+	program := kernel.program
+	program.refCount--
+	if program.refCount == 0 {
+		mockDeleteObject(program.handle)
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockSetKernelExecInfo
+func cl30MockSetKernelExecInfo(kernelID C.cl_kernel, paramName C.cl_kernel_exec_info, paramSize C.size_t,
+	paramValue unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	kernel, ok := mockObjectFor[*mockKernel](unsafe.Pointer(kernelID))
+	if !ok {
+		return C.CL_INVALID_KERNEL
+	}
+	switch paramName {
+	case C.CL_KERNEL_EXEC_INFO_SVM_PTRS:
+		if (paramSize % C.size_t(unsafe.Sizeof(uintptr(0)))) != 0 {
+			return C.CL_INVALID_VALUE
+		}
+	case C.CL_KERNEL_EXEC_INFO_SVM_FINE_GRAIN_SYSTEM:
+		if paramSize != C.size_t(unsafe.Sizeof(C.cl_bool(0))) {
+			return C.CL_INVALID_VALUE
+		}
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	if (paramSize > 0) && (paramValue == nil) {
+		return C.CL_INVALID_VALUE
+	}
+	if kernel.execInfo == nil {
+		kernel.execInfo = make(map[C.cl_kernel_exec_info][]byte)
+	}
+	kernel.execInfo[paramName] = C.GoBytes(paramValue, C.int(paramSize))
+	return C.CL_SUCCESS
+}
+
+//export cl30MockSetKernelArg
+func cl30MockSetKernelArg(kernelID C.cl_kernel, argIndex C.cl_uint, argSize C.size_t, argValue unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	kernel, ok := mockObjectFor[*mockKernel](unsafe.Pointer(kernelID))
+	if !ok {
+		return C.CL_INVALID_KERNEL
+	}
+	if int(argIndex) >= len(kernel.args) {
+		return C.CL_INVALID_ARG_INDEX
+	}
+	if argSize == 0 {
+		return C.CL_INVALID_ARG_SIZE
+	}
+	arg := mockKernelArgValue{set: true, size: uintptr(argSize)}
+	if argValue != nil {
+		arg.value = append([]byte(nil), unsafe.Slice((*byte)(argValue), int(argSize))...)
+	}
+	kernel.args[argIndex] = arg
+	return C.CL_SUCCESS
+}
+
+//export cl30MockGetKernelInfo
+func cl30MockGetKernelInfo(kernelID C.cl_kernel, paramName C.cl_kernel_info, paramSize C.size_t,
+	paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	kernel, ok := mockObjectFor[*mockKernel](unsafe.Pointer(kernelID))
+	if !ok {
+		return C.CL_INVALID_KERNEL
+	}
+	var value []byte
+	switch paramName {
+	case C.CL_KERNEL_FUNCTION_NAME:
+		value = mockBytesOfString(kernel.name)
+	case C.CL_KERNEL_NUM_ARGS:
+		value = mockBytesOf(C.cl_uint(len(kernel.args)))
+	case C.CL_KERNEL_REFERENCE_COUNT:
+		value = mockBytesOf(C.cl_uint(kernel.refCount))
+	case C.CL_KERNEL_CONTEXT:
+		value = mockBytesOf(kernel.program.context.handle)
+	case C.CL_KERNEL_PROGRAM:
+		value = mockBytesOf(kernel.program.handle)
+	case C.CL_KERNEL_ATTRIBUTES:
+		value = mockBytesOfString("")
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(value, paramSize, paramValue, sizeReturn)
+}
+
+//export cl30MockGetKernelWorkGroupInfo
+func cl30MockGetKernelWorkGroupInfo(kernelID C.cl_kernel, deviceID C.cl_device_id,
+	paramName C.cl_kernel_work_group_info, paramSize C.size_t, paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	kernel, ok := mockObjectFor[*mockKernel](unsafe.Pointer(kernelID))
+	if !ok {
+		return C.CL_INVALID_KERNEL
+	}
+	devices := kernel.program.context.devices
+	device, ok := mockObjectFor[*mockDevice](unsafe.Pointer(deviceID))
+	if deviceID == nil && (len(devices) == 1) {
+		device, ok = devices[0], true
+	}
+	if !ok || !kernel.program.context.hasDevice(device) {
+		return C.CL_INVALID_DEVICE
+	}
+	var value []byte
+	switch paramName {
+	case C.CL_KERNEL_WORK_GROUP_SIZE:
+		value = mockBytesOf(C.size_t(device.config.MaxWorkGroupSize))
+	case C.CL_KERNEL_COMPILE_WORK_GROUP_SIZE:
+		value = mockBytesOfSlice([]C.size_t{0, 0, 0})
+	case C.CL_KERNEL_PREFERRED_WORK_GROUP_SIZE_MULTIPLE:
+		value = mockBytesOf(C.size_t(1))
+	case C.CL_KERNEL_LOCAL_MEM_SIZE, C.CL_KERNEL_PRIVATE_MEM_SIZE:
+		value = mockBytesOf(C.cl_ulong(0))
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(value, paramSize, paramValue, sizeReturn)
+}
@@ -0,0 +1,355 @@
+//go:build cl30_mock
+
+package cl30
+
+// #include "api.h"
+import "C"
+import (
+	"strings"
+	"unsafe"
+)
+
+// This file contains the simulated functions of the cl_khr_command_buffer extension. They are provided through
+// ExtensionFunctionAddressForPlatform() for platforms that list the extension.
+// Commands for images are not simulated; their recording reports CL_INVALID_OPERATION.
+
+type mockCommandBuffer struct {
+	handle     unsafe.Pointer
+	refCount   uint32
+	queue      *mockQueue
+	properties []C.cl_ulong
+	state      C.cl_uint
+	commands   []func() C.cl_int
+}
+
+//export cl30MockPlatformHasExtension
+func cl30MockPlatformHasExtension(platformID C.cl_platform_id, name *C.char) C.int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	platform, ok := mockObjectFor[*mockPlatform](unsafe.Pointer(platformID))
+	if !ok {
+		return 0
+	}
+	for _, extension := range strings.Fields(platform.config.Extensions) {
+		if extension == C.GoString(name) {
+			return 1
+		}
+	}
+	return 0
+}
+
+//export cl30MockCreateCommandBufferKHR
+func cl30MockCreateCommandBufferKHR(numQueues C.cl_uint, queues *C.cl_command_queue, properties *C.cl_ulong,
+	errcodeReturn *C.cl_int) unsafe.Pointer {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	if (numQueues != 1) || (queues == nil) {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+		return nil
+	}
+	queue, ok := mockObjectFor[*mockQueue](unsafe.Pointer(*queues))
+	if !ok {
+		mockSetErrcode(errcodeReturn, C.CL_INVALID_COMMAND_QUEUE)
+		return nil
+	}
+	var propertyList []C.cl_ulong
+	if properties != nil {
+		for entry := properties; *entry != 0; entry = (*C.cl_ulong)(unsafe.Add(unsafe.Pointer(entry), 2*C.sizeof_cl_ulong)) {
+			if *entry != C.CL_COMMAND_BUFFER_FLAGS_KHR {
+				mockSetErrcode(errcodeReturn, C.CL_INVALID_VALUE)
+				return nil
+			}
+			pair := unsafe.Slice(entry, 2)
+			propertyList = append(propertyList, pair[0], pair[1])
+		}
+		propertyList = append(propertyList, 0)
+	}
+	commandBuffer := &mockCommandBuffer{
+		handle:     mockNewHandle(),
+		refCount:   1,
+		queue:      queue,
+		properties: propertyList,
+		state:      C.CL_COMMAND_BUFFER_STATE_RECORDING_KHR,
+	}
+	mockDriver.objects[commandBuffer.handle] = commandBuffer
+	mockSetErrcode(errcodeReturn, C.CL_SUCCESS)
+	return commandBuffer.handle
+}
+
+//export cl30MockFinalizeCommandBufferKHR
+func cl30MockFinalizeCommandBufferKHR(commandBufferID unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	commandBuffer, ok := mockObjectFor[*mockCommandBuffer](commandBufferID)
+	if !ok {
+		return C.CL_INVALID_COMMAND_BUFFER_KHR
+	}
+	if commandBuffer.state != C.CL_COMMAND_BUFFER_STATE_RECORDING_KHR {
+		return C.CL_INVALID_OPERATION
+	}
+	commandBuffer.state = C.CL_COMMAND_BUFFER_STATE_EXECUTABLE_KHR
+	return C.CL_SUCCESS
+}
+
+//export cl30MockRetainCommandBufferKHR
+func cl30MockRetainCommandBufferKHR(commandBufferID unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	commandBuffer, ok := mockObjectFor[*mockCommandBuffer](commandBufferID)
+	if !ok {
+		return C.CL_INVALID_COMMAND_BUFFER_KHR
+	}
+	commandBuffer.refCount++
+	return C.CL_SUCCESS
+}
+
+//export cl30MockReleaseCommandBufferKHR
+func cl30MockReleaseCommandBufferKHR(commandBufferID unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	commandBuffer, ok := mockObjectFor[*mockCommandBuffer](commandBufferID)
+	if !ok {
+		return C.CL_INVALID_COMMAND_BUFFER_KHR
+	}
+	commandBuffer.refCount--
+	if commandBuffer.refCount == 0 {
+		mockDeleteObject(commandBuffer.handle)
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockEnqueueCommandBufferKHR
+func cl30MockEnqueueCommandBufferKHR(numQueues C.cl_uint, queues *C.cl_command_queue, commandBufferID unsafe.Pointer,
+	numEvents C.cl_uint, eventList *C.cl_event, eventReturn *C.cl_event) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	commandBuffer, ok := mockObjectFor[*mockCommandBuffer](commandBufferID)
+	if !ok {
+		return C.CL_INVALID_COMMAND_BUFFER_KHR
+	}
+	if commandBuffer.state != C.CL_COMMAND_BUFFER_STATE_EXECUTABLE_KHR {
+		return C.CL_INVALID_OPERATION
+	}
+	queue := commandBuffer.queue
+	if numQueues > 0 {
+		if (numQueues != 1) || (queues == nil) {
+			return C.CL_INVALID_VALUE
+		}
+		queue, ok = mockObjectFor[*mockQueue](unsafe.Pointer(*queues))
+		if !ok {
+			return C.CL_INVALID_COMMAND_QUEUE
+		}
+		if (queue.device != commandBuffer.queue.device) || (queue.properties != commandBuffer.queue.properties) {
+			return C.CL_INCOMPATIBLE_COMMAND_QUEUE_KHR
+		}
+	}
+	waitList, status := mockWaitList(queue.context, numEvents, eventList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	commands := commandBuffer.commands
+	queue.enqueue(C.CL_COMMAND_COMMAND_BUFFER_KHR, waitList, eventReturn, func() C.cl_int {
+		for _, run := range commands {
+			if status := run(); status != C.CL_SUCCESS {
+				return status
+			}
+		}
+		return C.CL_SUCCESS
+	})
+	return C.CL_SUCCESS
+}
+
+//export cl30MockGetCommandBufferInfoKHR
+func cl30MockGetCommandBufferInfoKHR(commandBufferID unsafe.Pointer, paramName C.cl_uint, paramSize C.size_t,
+	paramValue unsafe.Pointer, sizeReturn *C.size_t) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	commandBuffer, ok := mockObjectFor[*mockCommandBuffer](commandBufferID)
+	if !ok {
+		return C.CL_INVALID_COMMAND_BUFFER_KHR
+	}
+	var value []byte
+	switch paramName {
+	case C.CL_COMMAND_BUFFER_QUEUES_KHR:
+		value = mockBytesOfSlice([]unsafe.Pointer{commandBuffer.queue.handle})
+	case C.CL_COMMAND_BUFFER_NUM_QUEUES_KHR:
+		value = mockBytesOf(C.cl_uint(1))
+	case C.CL_COMMAND_BUFFER_REFERENCE_COUNT_KHR:
+		value = mockBytesOf(C.cl_uint(commandBuffer.refCount))
+	case C.CL_COMMAND_BUFFER_STATE_KHR:
+		value = mockBytesOf(commandBuffer.state)
+	case C.CL_COMMAND_BUFFER_PROPERTIES_ARRAY_KHR:
+		value = mockBytesOfSlice(commandBuffer.properties)
+	case C.CL_COMMAND_BUFFER_CONTEXT_KHR:
+		value = mockBytesOf(commandBuffer.queue.context.handle)
+	default:
+		return C.CL_INVALID_VALUE
+	}
+	return mockInfoResult(value, paramSize, paramValue, sizeReturn)
+}
+
+// mockRecordTarget resolves the command-buffer and the command-queue of a recording call, and validates the
+// sync point wait list.
+func mockRecordTarget(commandBufferID unsafe.Pointer, queueID C.cl_command_queue, numSyncPoints C.cl_uint,
+	syncPointWaitList *C.cl_uint) (*mockCommandBuffer, *mockQueue, C.cl_int) {
+	commandBuffer, ok := mockObjectFor[*mockCommandBuffer](commandBufferID)
+	if !ok {
+		return nil, nil, C.CL_INVALID_COMMAND_BUFFER_KHR
+	}
+	if commandBuffer.state != C.CL_COMMAND_BUFFER_STATE_RECORDING_KHR {
+		return nil, nil, C.CL_INVALID_OPERATION
+	}
+	if (queueID != nil) && (unsafe.Pointer(queueID) != commandBuffer.queue.handle) {
+		return nil, nil, C.CL_INVALID_COMMAND_QUEUE
+	}
+	if (numSyncPoints > 0) != (syncPointWaitList != nil) {
+		return nil, nil, C.CL_INVALID_SYNC_POINT_WAIT_LIST_KHR
+	}
+	if numSyncPoints > 0 {
+		for _, syncPoint := range unsafe.Slice(syncPointWaitList, int(numSyncPoints)) {
+			if (syncPoint == 0) || (int(syncPoint) > len(commandBuffer.commands)) {
+				return nil, nil, C.CL_INVALID_SYNC_POINT_WAIT_LIST_KHR
+			}
+		}
+	}
+	return commandBuffer, commandBuffer.queue, C.CL_SUCCESS
+}
+
+// record appends a command to the command-buffer. Sync points are the one-based index of the command.
+func (commandBuffer *mockCommandBuffer) record(run func() C.cl_int, syncPoint *C.cl_uint) C.cl_int {
+	commandBuffer.commands = append(commandBuffer.commands, run)
+	if syncPoint != nil {
+		*syncPoint = C.cl_uint(len(commandBuffer.commands))
+	}
+	return C.CL_SUCCESS
+}
+
+//export cl30MockCommandBarrierWithWaitListKHR
+func cl30MockCommandBarrierWithWaitListKHR(commandBufferID unsafe.Pointer, queueID C.cl_command_queue,
+	properties *C.cl_ulong, numSyncPoints C.cl_uint, syncPointWaitList *C.cl_uint, syncPoint *C.cl_uint,
+	mutableHandle *unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	commandBuffer, _, status := mockRecordTarget(commandBufferID, queueID, numSyncPoints, syncPointWaitList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	return commandBuffer.record(func() C.cl_int { return C.CL_SUCCESS }, syncPoint)
+}
+
+//export cl30MockCommandCopyBufferKHR
+func cl30MockCommandCopyBufferKHR(commandBufferID unsafe.Pointer, queueID C.cl_command_queue, properties *C.cl_ulong,
+	srcID, dstID C.cl_mem, srcOffset, dstOffset, size C.size_t,
+	numSyncPoints C.cl_uint, syncPointWaitList *C.cl_uint, syncPoint *C.cl_uint, mutableHandle *unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	commandBuffer, queue, status := mockRecordTarget(commandBufferID, queueID, numSyncPoints, syncPointWaitList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	src, status := mockBufferOf(queue, srcID, srcOffset, size)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	dst, status := mockBufferOf(queue, dstID, dstOffset, size)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	return commandBuffer.record(func() C.cl_int {
+		copy(dst.bytes()[dstOffset:dstOffset+size], src.bytes()[srcOffset:srcOffset+size])
+		return C.CL_SUCCESS
+	}, syncPoint)
+}
+
+//export cl30MockCommandCopyBufferRectKHR
+func cl30MockCommandCopyBufferRectKHR(commandBufferID unsafe.Pointer, queueID C.cl_command_queue, properties *C.cl_ulong,
+	srcID, dstID C.cl_mem, srcOrigin, dstOrigin, region *C.size_t,
+	srcRowPitch, srcSlicePitch, dstRowPitch, dstSlicePitch C.size_t,
+	numSyncPoints C.cl_uint, syncPointWaitList *C.cl_uint, syncPoint *C.cl_uint, mutableHandle *unsafe.Pointer) C.cl_int {
+	return mockUnsupportedRecording(commandBufferID, queueID, numSyncPoints, syncPointWaitList)
+}
+
+//export cl30MockCommandCopyBufferToImageKHR
+func cl30MockCommandCopyBufferToImageKHR(commandBufferID unsafe.Pointer, queueID C.cl_command_queue,
+	properties *C.cl_ulong, srcID, dstID C.cl_mem, srcOffset C.size_t, dstOrigin, region *C.size_t,
+	numSyncPoints C.cl_uint, syncPointWaitList *C.cl_uint, syncPoint *C.cl_uint, mutableHandle *unsafe.Pointer) C.cl_int {
+	return mockUnsupportedRecording(commandBufferID, queueID, numSyncPoints, syncPointWaitList)
+}
+
+//export cl30MockCommandCopyImageKHR
+func cl30MockCommandCopyImageKHR(commandBufferID unsafe.Pointer, queueID C.cl_command_queue, properties *C.cl_ulong,
+	srcID, dstID C.cl_mem, srcOrigin, dstOrigin, region *C.size_t,
+	numSyncPoints C.cl_uint, syncPointWaitList *C.cl_uint, syncPoint *C.cl_uint, mutableHandle *unsafe.Pointer) C.cl_int {
+	return mockUnsupportedRecording(commandBufferID, queueID, numSyncPoints, syncPointWaitList)
+}
+
+//export cl30MockCommandCopyImageToBufferKHR
+func cl30MockCommandCopyImageToBufferKHR(commandBufferID unsafe.Pointer, queueID C.cl_command_queue,
+	properties *C.cl_ulong, srcID, dstID C.cl_mem, srcOrigin, region *C.size_t, dstOffset C.size_t,
+	numSyncPoints C.cl_uint, syncPointWaitList *C.cl_uint, syncPoint *C.cl_uint, mutableHandle *unsafe.Pointer) C.cl_int {
+	return mockUnsupportedRecording(commandBufferID, queueID, numSyncPoints, syncPointWaitList)
+}
+
+//export cl30MockCommandFillImageKHR
+func cl30MockCommandFillImageKHR(commandBufferID unsafe.Pointer, queueID C.cl_command_queue, properties *C.cl_ulong,
+	imageID C.cl_mem, fillColor unsafe.Pointer, origin, region *C.size_t,
+	numSyncPoints C.cl_uint, syncPointWaitList *C.cl_uint, syncPoint *C.cl_uint, mutableHandle *unsafe.Pointer) C.cl_int {
+	return mockUnsupportedRecording(commandBufferID, queueID, numSyncPoints, syncPointWaitList)
+}
+
+func mockUnsupportedRecording(commandBufferID unsafe.Pointer, queueID C.cl_command_queue, numSyncPoints C.cl_uint,
+	syncPointWaitList *C.cl_uint) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	_, _, status := mockRecordTarget(commandBufferID, queueID, numSyncPoints, syncPointWaitList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	return C.CL_INVALID_OPERATION
+}
+
+//export cl30MockCommandFillBufferKHR
+func cl30MockCommandFillBufferKHR(commandBufferID unsafe.Pointer, queueID C.cl_command_queue, properties *C.cl_ulong,
+	memID C.cl_mem, pattern unsafe.Pointer, patternSize, offset, size C.size_t,
+	numSyncPoints C.cl_uint, syncPointWaitList *C.cl_uint, syncPoint *C.cl_uint, mutableHandle *unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	commandBuffer, queue, status := mockRecordTarget(commandBufferID, queueID, numSyncPoints, syncPointWaitList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	mem, status := mockBufferOf(queue, memID, offset, size)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	validPatternSize := (patternSize > 0) && (patternSize <= 128) && ((patternSize & (patternSize - 1)) == 0)
+	if (pattern == nil) || !validPatternSize || ((offset % patternSize) != 0) || ((size % patternSize) != 0) {
+		return C.CL_INVALID_VALUE
+	}
+	patternCopy := append([]byte(nil), unsafe.Slice((*byte)(pattern), int(patternSize))...)
+	return commandBuffer.record(func() C.cl_int {
+		target := mem.bytes()[offset : offset+size]
+		for start := 0; start < len(target); start += len(patternCopy) {
+			copy(target[start:], patternCopy)
+		}
+		return C.CL_SUCCESS
+	}, syncPoint)
+}
+
+//export cl30MockCommandNDRangeKernelKHR
+func cl30MockCommandNDRangeKernelKHR(commandBufferID unsafe.Pointer, queueID C.cl_command_queue,
+	properties *C.cl_ulong, kernelID C.cl_kernel, workDim C.cl_uint, globalWorkOffset, globalWorkSize, localWorkSize *C.size_t,
+	numSyncPoints C.cl_uint, syncPointWaitList *C.cl_uint, syncPoint *C.cl_uint, mutableHandle *unsafe.Pointer) C.cl_int {
+	mockDriver.mutex.Lock()
+	defer mockDriver.mutex.Unlock()
+	commandBuffer, queue, status := mockRecordTarget(commandBufferID, queueID, numSyncPoints, syncPointWaitList)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	call, status := mockKernelCallFor(queue, kernelID, workDim, globalWorkOffset, globalWorkSize, localWorkSize)
+	if status != C.CL_SUCCESS {
+		return status
+	}
+	fn := mockDriver.kernelFuncs[call.Name]
+	return commandBuffer.record(func() C.cl_int { return mockRunKernel(fn, call) }, syncPoint)
+}
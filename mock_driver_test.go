@@ -0,0 +1,249 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func mockQueue(t *testing.T) (cl.Context, cl.DeviceID, cl.CommandQueue) {
+	t.Helper()
+	return mockQueueOn(t, nil)
+}
+
+// mockQueueOn simulates the given platforms, and returns a queue for the first device of the first platform.
+// The default platforms are restored with the cleanup of the test.
+func mockQueueOn(t *testing.T, platforms []cl.MockPlatform) (cl.Context, cl.DeviceID, cl.CommandQueue) {
+	t.Helper()
+	cl.SetMockPlatforms(platforms)
+	if platforms != nil {
+		t.Cleanup(func() { cl.SetMockPlatforms(nil) })
+	}
+	platformIDs, err := cl.PlatformIDs()
+	if err != nil {
+		t.Fatalf("PlatformIDs failed: %v", err)
+	}
+	devices, err := cl.DeviceIDs(platformIDs[0], cl.DeviceTypeAll)
+	if err != nil {
+		t.Fatalf("DeviceIDs failed: %v", err)
+	}
+	context, err := cl.CreateContext(devices, nil)
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	queue, err := cl.CreateCommandQueueWithProperties(context, devices[0])
+	if err != nil {
+		t.Fatalf("CreateCommandQueueWithProperties failed: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cl.ReleaseCommandQueue(queue)
+		_ = cl.ReleaseContext(context)
+	})
+	return context, devices[0], queue
+}
+
+// mockKernel builds the source for all devices of the context, and returns the kernel of given name.
+// The program and the kernel are released when the test ends.
+func mockKernel(t *testing.T, context cl.Context, source, name string) cl.Kernel {
+	t.Helper()
+	program, err := cl.CreateProgramWithSource(context, []string{source})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cl.ReleaseProgram(program) })
+	if err = cl.BuildProgram(program, nil, "", nil); err != nil {
+		t.Fatalf("BuildProgram failed: %v", err)
+	}
+	kernel, err := cl.CreateKernel(program, name)
+	if err != nil {
+		t.Fatalf("CreateKernel failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cl.ReleaseKernel(kernel) })
+	return kernel
+}
+
+func TestMockPlatforms(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{
+		{Name: "first", Devices: []cl.MockDevice{{Type: cl.DeviceTypeCPU}, {Name: "second gpu"}}},
+		{Name: "second"},
+	})
+	defer cl.SetMockPlatforms(nil)
+	platforms, err := cl.PlatformIDs()
+	if (err != nil) || (len(platforms) != 2) {
+		t.Fatalf("unexpected platforms: %v, %v", platforms, err)
+	}
+	name, err := cl.PlatformInfoString(platforms[0], cl.PlatformNameInfo)
+	if (err != nil) || (name != "first") {
+		t.Errorf("unexpected platform name: %q, %v", name, err)
+	}
+	suffix, err := cl.PlatformIcdSuffixKhr(platforms[0])
+	if (err != nil) || (len(suffix) == 0) {
+		t.Errorf("unexpected ICD suffix: %q, %v", suffix, err)
+	}
+	gpus, err := cl.DeviceIDs(platforms[0], cl.DeviceTypeGpu)
+	if (err != nil) || (len(gpus) != 1) {
+		t.Fatalf("unexpected GPU devices: %v, %v", gpus, err)
+	}
+	deviceName, err := cl.DeviceInfoString(gpus[0], cl.DeviceNameInfo)
+	if (err != nil) || (deviceName != "second gpu") {
+		t.Errorf("unexpected device name: %q, %v", deviceName, err)
+	}
+	_, err = cl.DeviceIDs(platforms[1], cl.DeviceTypeAll)
+	if !errors.Is(err, cl.ErrDeviceNotFound) {
+		t.Errorf("unexpected error for platform without devices: %v", err)
+	}
+}
+
+func TestMockBufferTransfer(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 16, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+	input := []byte("0123456789abcdef")
+	err = cl.EnqueueWriteBuffer(queue, buffer, true, 0, 16, unsafe.Pointer(&input[0]), nil, nil)
+	if err != nil {
+		t.Fatalf("EnqueueWriteBuffer failed: %v", err)
+	}
+	output := make([]byte, 4)
+	err = cl.EnqueueReadBuffer(queue, buffer, true, 10, 4, unsafe.Pointer(&output[0]), nil, nil)
+	if err != nil {
+		t.Fatalf("EnqueueReadBuffer failed: %v", err)
+	}
+	if string(output) != "abcd" {
+		t.Errorf("unexpected content: %q", output)
+	}
+	err = cl.EnqueueReadBuffer(queue, buffer, true, 14, 4, unsafe.Pointer(&output[0]), nil, nil)
+	if !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("unexpected error for read beyond buffer: %v", err)
+	}
+}
+
+func TestMockUserEventHoldsCommands(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 4, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+	gate, err := cl.CreateUserEvent(context)
+	if err != nil {
+		t.Fatalf("CreateUserEvent failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(gate) }()
+	input := []byte("gate")
+	var written cl.Event
+	err = cl.EnqueueWriteBuffer(queue, buffer, false, 0, 4, unsafe.Pointer(&input[0]), []cl.Event{gate}, &written)
+	if err != nil {
+		t.Fatalf("EnqueueWriteBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(written) }()
+	status, err := cl.EventExecutionStatus(written)
+	if (err != nil) || (status == cl.EventCommandCompleteStatus) {
+		t.Errorf("command completed before user event: %v, %v", status, err)
+	}
+	if err = cl.SetUserEventStatus(gate, int(cl.EventCommandCompleteStatus)); err != nil {
+		t.Fatalf("SetUserEventStatus failed: %v", err)
+	}
+	if err = cl.WaitForEvents([]cl.Event{written}); err != nil {
+		t.Errorf("WaitForEvents failed: %v", err)
+	}
+}
+
+func TestMockBuildAndKernel(t *testing.T) {
+	context, device, queue := mockQueue(t)
+	program, err := cl.CreateProgramWithSource(context, []string{"kernel void fill(global uchar *out, uchar value) {}"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	if err = cl.BuildProgram(program, nil, "", nil); err != nil {
+		t.Fatalf("BuildProgram failed: %v", err)
+	}
+	kernel, err := cl.CreateKernel(program, "fill")
+	if err != nil {
+		t.Fatalf("CreateKernel failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseKernel(kernel) }()
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 8, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+
+	cl.SetMockKernel("fill", func(call cl.MockKernelCall) error {
+		for i := range call.Args[0].Buffer {
+			call.Args[0].Buffer[i] = call.Args[1].Value[0]
+		}
+		return nil
+	})
+	defer cl.SetMockKernel("fill", nil)
+	value := uint8(7)
+	if err = cl.SetKernelArg(kernel, 0, unsafe.Sizeof(buffer), unsafe.Pointer(&buffer)); err != nil {
+		t.Fatalf("SetKernelArg failed: %v", err)
+	}
+	if err = cl.EnqueueKernel(queue, kernel, []uintptr{8}); !errors.Is(err, cl.ErrInvalidKernelArgs) {
+		t.Errorf("unexpected error for missing argument: %v", err)
+	}
+	if err = cl.SetKernelArg(kernel, 1, unsafe.Sizeof(value), unsafe.Pointer(&value)); err != nil {
+		t.Fatalf("SetKernelArg failed: %v", err)
+	}
+	if err = cl.EnqueueKernel(queue, kernel, []uintptr{8}); err != nil {
+		t.Fatalf("EnqueueKernel failed: %v", err)
+	}
+	output := make([]byte, 8)
+	err = cl.EnqueueReadBuffer(queue, buffer, true, 0, 8, unsafe.Pointer(&output[0]), nil, nil)
+	if (err != nil) || (output[0] != 7) || (output[7] != 7) {
+		t.Errorf("unexpected kernel result: %v, %v", output, err)
+	}
+	log, err := cl.ProgramBuildLog(program, device)
+	if (err != nil) || (len(log) != 0) {
+		t.Errorf("unexpected build log: %q, %v", log, err)
+	}
+}
+
+func TestMockBuildFailure(t *testing.T) {
+	context, device, _ := mockQueue(t)
+	program, err := cl.CreateProgramWithSource(context, []string{"#error not supported\nkernel void k() {}"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	err = cl.BuildProgram(program, nil, "", nil)
+	if !errors.Is(err, cl.ErrBuildProgramFailure) {
+		t.Errorf("unexpected build result: %v", err)
+	}
+	log, err := cl.ProgramBuildLog(program, device)
+	if (err != nil) || !strings.Contains(log, "not supported") {
+		t.Errorf("unexpected build log: %q, %v", log, err)
+	}
+}
+
+func TestMockPrewarmKeepsExistingBuild(t *testing.T) {
+	context, device, _ := mockQueue(t)
+	program, err := cl.CreateProgramWithSource(context, []string{"kernel void k() {}"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	if err = cl.BuildProgram(program, nil, "-DVALUE=1", nil); err != nil {
+		t.Fatalf("BuildProgram failed: %v", err)
+	}
+	kernel, err := cl.CreateKernel(program, "k")
+	if err != nil {
+		t.Fatalf("CreateKernel failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseKernel(kernel) }()
+	if err = cl.Prewarm(program, nil, nil); err != nil {
+		t.Fatalf("Prewarm failed: %v", err)
+	}
+	if options, err := cl.ProgramBuildOptions(program, device); (err != nil) || (options != "-DVALUE=1") {
+		t.Errorf("program built again: %q, %v", options, err)
+	}
+}
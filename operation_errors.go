@@ -0,0 +1,72 @@
+package cl30
+
+// #include "api.h"
+import "C"
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// OperationError describes a failed call into the OpenCL API, including the key parameters of the call.
+//
+// Errors of this type are only returned if enabled with EnableOperationErrors(). They wrap the StatusError of
+// the call, which remains accessible with errors.Is() and errors.As().
+type OperationError struct {
+	// Operation is the name of the OpenCL API function, for example "clEnqueueNDRangeKernel".
+	Operation string
+	// Parameters is the presentation of the key parameters of the call, for example "kernel=0x1234, dims=2".
+	Parameters string
+	// Err is the error of the call.
+	Err error
+}
+
+// Error returns the operation, its parameters, and the wrapped error.
+func (err *OperationError) Error() string {
+	if len(err.Parameters) == 0 {
+		return err.Operation + ": " + err.Err.Error()
+	}
+	return err.Operation + ": " + err.Parameters + ": " + err.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (err *OperationError) Unwrap() error {
+	return err.Err
+}
+
+var operationErrorsEnabled int32
+
+// EnableOperationErrors enables or disables the wrapping of failures in OperationError values.
+//
+// When enabled, the wrapper functions return an OperationError for a failed call into the OpenCL API, which
+// names the called function and its key parameters. When disabled, which is the default, the plain StatusError
+// is returned. The parameters are only formatted in case of failure.
+func EnableOperationErrors(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&operationErrorsEnabled, value)
+}
+
+// OperationErrorsEnabled returns true if failures are wrapped in OperationError values.
+func OperationErrorsEnabled() bool {
+	return atomic.LoadInt32(&operationErrorsEnabled) != 0
+}
+
+// operationError returns the error for the status of a failed call. The parameters are given as pairs of name and
+// value, and are used if operation errors are enabled.
+func operationError(operation string, status C.cl_int, parameters ...any) error {
+	err := StatusError(status)
+	if !OperationErrorsEnabled() {
+		return err
+	}
+	var text strings.Builder
+	for i := 0; i+1 < len(parameters); i += 2 {
+		if i > 0 {
+			text.WriteString(", ")
+		}
+		fmt.Fprintf(&text, "%v=%v", parameters[i], parameters[i+1])
+	}
+	return &OperationError{Operation: operation, Parameters: text.String(), Err: err}
+}
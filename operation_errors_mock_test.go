@@ -0,0 +1,33 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockOperationErrors(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 4, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+	cl.EnableOperationErrors(true)
+	defer cl.EnableOperationErrors(false)
+	output := make([]byte, 8)
+	err = cl.EnqueueReadBuffer(queue, buffer, true, 0, 8, unsafe.Pointer(&output[0]), nil, nil)
+	var opErr *cl.OperationError
+	if !errors.As(err, &opErr) || !errors.Is(err, cl.ErrInvalidValue) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if (opErr.Operation != "clEnqueueReadBuffer") || !strings.Contains(opErr.Parameters, "mem="+buffer.String()) ||
+		!strings.Contains(opErr.Parameters, "size=8") {
+		t.Errorf("unexpected operation error: %v", opErr)
+	}
+}
@@ -0,0 +1,164 @@
+package cl30
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// OwnableHandle is the set of handle types that can be owned with Own().
+type OwnableHandle interface {
+	Context | CommandQueue | Program | Kernel | MemObject | Event | Sampler
+}
+
+// Owned holds a handle and releases it automatically once the Owned value is no longer reachable.
+//
+// Ownership is opt-in: only handles that are passed to Own() are released automatically. The automatic release
+// is a safety net for forgotten releases, based on a runtime finalizer; it is not deterministic. Release the
+// handle with Release() of the Owned value as usual. Calling the plain release function, such as
+// ReleaseMemObject(), for an owned handle gives up the ownership, as if Disown() had been called before.
+// Retains of an owned handle, such as with RetainMemObject(), are counted: plain releases that balance them
+// keep the ownership, and only a further plain release gives it up.
+// Use Disown() to take over the responsibility for a handle. A handle must be owned by at most one Owned value
+// at a time.
+//
+// The Owned value must stay reachable as long as the handle is in use; see runtime.KeepAlive().
+type Owned[T OwnableHandle] struct {
+	handle T
+	state  *ownedState
+}
+
+// ownedState is kept separate from Owned, so that the registry of owned handles does not keep Owned reachable.
+type ownedState struct {
+	released int32
+	// retains is the number of references that were retained while the handle is owned, and that were not yet
+	// released again. It is guarded by the mutex of ownedHandles.
+	retains int
+}
+
+var ownedHandles = struct {
+	// count is the number of registered handles. It lets release functions skip the mutex while nothing is owned.
+	count   int32
+	mutex   sync.Mutex
+	handles map[any]*ownedState
+}{
+	handles: make(map[any]*ownedState),
+}
+
+// Own takes ownership of the given handle. It is typically used directly with the result of a creating function:
+//
+//	buffer, err := cl.Own(cl.CreateBuffer(context, cl.MemReadWriteFlag, size, nil))
+//
+// If err is not nil, or the handle is zero, nil is returned together with err.
+func Own[T OwnableHandle](handle T, err error) (*Owned[T], error) {
+	if (err != nil) || (handle == 0) {
+		return nil, err
+	}
+	owned := &Owned[T]{handle: handle, state: &ownedState{}}
+	registerOwned(handle, owned.state)
+	runtime.SetFinalizer(owned, func(owned *Owned[T]) { _ = owned.Release() })
+	return owned, nil
+}
+
+// Handle returns the owned handle. It returns zero after the handle was released or disowned.
+func (owned *Owned[T]) Handle() T {
+	if atomic.LoadInt32(&owned.state.released) != 0 {
+		return 0
+	}
+	return owned.handle
+}
+
+// Release releases the owned handle. Further calls have no effect and return nil.
+func (owned *Owned[T]) Release() error {
+	if !atomic.CompareAndSwapInt32(&owned.state.released, 0, 1) {
+		return nil
+	}
+	runtime.SetFinalizer(owned, nil)
+	unregisterOwned(owned.handle, owned.state)
+	return releaseHandle(owned.handle)
+}
+
+// Disown ends the ownership and returns the handle. The handle is no longer released automatically, and the
+// caller is responsible to release it. It returns zero if the handle was already released or disowned.
+func (owned *Owned[T]) Disown() T {
+	if !atomic.CompareAndSwapInt32(&owned.state.released, 0, 1) {
+		return 0
+	}
+	runtime.SetFinalizer(owned, nil)
+	unregisterOwned(owned.handle, owned.state)
+	return owned.handle
+}
+
+func releaseHandle(handle any) error {
+	switch typed := handle.(type) {
+	case Context:
+		return ReleaseContext(typed)
+	case CommandQueue:
+		return ReleaseCommandQueue(typed)
+	case Program:
+		return ReleaseProgram(typed)
+	case Kernel:
+		return ReleaseKernel(typed)
+	case MemObject:
+		return ReleaseMemObject(typed)
+	case Event:
+		return ReleaseEvent(typed)
+	case Sampler:
+		return ReleaseSampler(typed)
+	default:
+		return ErrUnsupportedHandleType
+	}
+}
+
+func registerOwned(handle any, state *ownedState) {
+	ownedHandles.mutex.Lock()
+	defer ownedHandles.mutex.Unlock()
+	if _, exists := ownedHandles.handles[handle]; !exists {
+		atomic.AddInt32(&ownedHandles.count, 1)
+	}
+	ownedHandles.handles[handle] = state
+}
+
+func unregisterOwned(handle any, state *ownedState) {
+	ownedHandles.mutex.Lock()
+	defer ownedHandles.mutex.Unlock()
+	if ownedHandles.handles[handle] != state {
+		return
+	}
+	delete(ownedHandles.handles, handle)
+	atomic.AddInt32(&ownedHandles.count, -1)
+}
+
+// retainOwned is called by the retain functions. If the retained handle is owned, the retain is counted.
+func retainOwned(handle any) {
+	if atomic.LoadInt32(&ownedHandles.count) == 0 {
+		return
+	}
+	ownedHandles.mutex.Lock()
+	defer ownedHandles.mutex.Unlock()
+	if state, exists := ownedHandles.handles[handle]; exists {
+		state.retains++
+	}
+}
+
+// disownReleased is called by the release functions. If the released handle is owned, and the release does not
+// balance a counted retain, the ownership ends, so that the finalizer of the Owned value does not release the
+// handle again.
+func disownReleased(handle any) {
+	if atomic.LoadInt32(&ownedHandles.count) == 0 {
+		return
+	}
+	ownedHandles.mutex.Lock()
+	defer ownedHandles.mutex.Unlock()
+	state, exists := ownedHandles.handles[handle]
+	if !exists {
+		return
+	}
+	if state.retains > 0 {
+		state.retains--
+		return
+	}
+	atomic.StoreInt32(&state.released, 1)
+	delete(ownedHandles.handles, handle)
+	atomic.AddInt32(&ownedHandles.count, -1)
+}
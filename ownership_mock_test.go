@@ -0,0 +1,95 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockOwnership(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	cl.ResetLeaks()
+	cl.EnableLeakTracking(true)
+	defer cl.EnableLeakTracking(false)
+	defer cl.ResetLeaks()
+	owned, err := cl.Own(cl.CreateBuffer(context, cl.MemReadWriteFlag, 4, nil))
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	if err = owned.Release(); err != nil {
+		t.Errorf("Release failed: %v", err)
+	}
+	if err = owned.Release(); err != nil {
+		t.Errorf("second Release failed: %v", err)
+	}
+	if owned.Handle() != 0 {
+		t.Errorf("handle still available after release")
+	}
+
+	func() {
+		_, err := cl.Own(cl.CreateBuffer(context, cl.MemReadWriteFlag, 4, nil))
+		if err != nil {
+			t.Fatalf("CreateBuffer failed: %v", err)
+		}
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for (len(cl.Leaks()) != 0) && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if leaks := cl.Leaks(); len(leaks) != 0 {
+		t.Errorf("unreachable owned buffer not released: %v", leaks)
+	}
+}
+
+func TestMockManualReleaseEndsOwnership(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	owned, err := cl.Own(cl.CreateBuffer(context, cl.MemReadWriteFlag, 4, nil))
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	if err = cl.ReleaseMemObject(owned.Handle()); err != nil {
+		t.Fatalf("ReleaseMemObject failed: %v", err)
+	}
+	if owned.Handle() != 0 {
+		t.Errorf("handle still owned after manual release")
+	}
+	if err = owned.Release(); err != nil {
+		t.Errorf("Release after manual release failed: %v", err)
+	}
+}
+
+func TestMockReleaseOfRetainedReferenceKeepsOwnership(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	cl.ResetLeaks()
+	cl.EnableLeakTracking(true)
+	defer cl.EnableLeakTracking(false)
+	defer cl.ResetLeaks()
+	owned, err := cl.Own(cl.CreateBuffer(context, cl.MemReadWriteFlag, 4, nil))
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err = cl.RetainMemObject(owned.Handle()); err != nil {
+			t.Fatalf("RetainMemObject failed: %v", err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if err = cl.ReleaseMemObject(owned.Handle()); err != nil {
+			t.Fatalf("ReleaseMemObject failed: %v", err)
+		}
+	}
+	if owned.Handle() == 0 {
+		t.Fatalf("ownership ended by the release of a retained reference")
+	}
+	if err = owned.Release(); err != nil {
+		t.Errorf("Release failed: %v", err)
+	}
+	if leaks := cl.Leaks(); len(leaks) != 0 {
+		t.Errorf("owned reference not released: %v", leaks)
+	}
+}
@@ -0,0 +1,102 @@
+package cl30
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// transferPin keeps the host memory of a non-blocking transfer pinned until the transfer has completed.
+//
+// The OpenCL implementation accesses the host memory of a non-blocking read or write after the enqueue function
+// has returned. Without pinning, the memory could be moved or collected by the Go runtime in the meantime.
+type transferPin struct {
+	pinner    runtime.Pinner
+	event     Event
+	ownsEvent bool
+}
+
+var activeTransferPins = struct {
+	mutex sync.Mutex
+	pins  map[*transferPin]struct{}
+}{
+	pins: make(map[*transferPin]struct{}),
+}
+
+// pinTransfer pins the given host memory for a transfer, unless the transfer is blocking.
+// It returns the pin and the pointer to use as event output for the enqueue call. The pin needs to be
+// told about the result of the enqueue call with enqueued().
+//
+// If the caller is not interested in the event, the pin requests its own event to track completion.
+func pinTransfer(blocking bool, data unsafe.Pointer, event *Event) (*transferPin, *Event) {
+	if blocking || (data == nil) {
+		return nil, event
+	}
+	pin := &transferPin{}
+	pin.pinner.Pin(data)
+	if event != nil {
+		return pin, event
+	}
+	// The event output is passed to the OpenCL implementation and must not point into the pin, which holds Go
+	// pointers of its own.
+	pin.ownsEvent = true
+	return pin, new(Event)
+}
+
+// enqueued completes the setup of the pin after the enqueue call. If the call was successful, the memory
+// stays pinned until the command completed.
+func (pin *transferPin) enqueued(successful bool, event *Event) {
+	if pin == nil {
+		return
+	}
+	if !successful {
+		pin.pinner.Unpin()
+		return
+	}
+	pin.event = *event
+	activeTransferPins.mutex.Lock()
+	activeTransferPins.pins[pin] = struct{}{}
+	activeTransferPins.mutex.Unlock()
+	err := SetEventCallback(pin.event, EventCommandCompleteStatus, func(error) { pin.release() })
+	if err != nil {
+		pin.awaitCompletion()
+		return
+	}
+	if pin.ownsEvent {
+		_ = ReleaseEvent(pin.event)
+	}
+}
+
+// awaitCompletion releases the pin once the command completed, for cases without notification by a callback.
+// Waiting for the event is not possible within a callback of the OpenCL implementation. In that case, a separate
+// goroutine waits for the event, and the memory stays pinned until then.
+func (pin *transferPin) awaitCompletion() {
+	err := WaitForEvents([]Event{pin.event})
+	if !errors.Is(err, ErrBlockingCallInCallback) {
+		pin.release()
+		if pin.ownsEvent {
+			_ = ReleaseEvent(pin.event)
+		}
+		return
+	}
+	if !pin.ownsEvent && (RetainEvent(pin.event) != nil) {
+		// The command can not be tracked any further, so the memory has to stay pinned.
+		return
+	}
+	go func() {
+		_ = WaitForEvents([]Event{pin.event})
+		pin.release()
+		_ = ReleaseEvent(pin.event)
+	}()
+}
+
+func (pin *transferPin) release() {
+	activeTransferPins.mutex.Lock()
+	defer activeTransferPins.mutex.Unlock()
+	if _, active := activeTransferPins.pins[pin]; !active {
+		return
+	}
+	delete(activeTransferPins.pins, pin)
+	pin.pinner.Unpin()
+}
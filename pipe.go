@@ -15,6 +15,7 @@ type PipeProperty []uintptr
 // Since: 2.0
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreatePipe.html
 func CreatePipe(context Context, flags MemFlags, packetSize, maxPackets uint32, properties ...PipeProperty) (MemObject, error) {
+	defer observeCall("clCreatePipe")()
 	var rawPropertyList []uintptr
 	for _, property := range properties {
 		rawPropertyList = append(rawPropertyList, property...)
@@ -33,9 +34,9 @@ func CreatePipe(context Context, flags MemFlags, packetSize, maxPackets uint32,
 		(*C.cl_pipe_properties)(rawProperties),
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreatePipe", status, "context", context, "flags", flags)
 	}
-	return MemObject(*((*uintptr)(unsafe.Pointer(&pipe)))), nil
+	return trackCreated(MemObject(*((*uintptr)(unsafe.Pointer(&pipe))))), nil
 }
 
 // PipeInfoName identifies properties of a pipe, which can be queried with PipeInfo().
@@ -72,6 +73,7 @@ const (
 // Since: 2.0
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetPipeInfo.html
 func PipeInfo(pipe MemObject, paramName PipeInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetPipeInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetPipeInfo(
 		pipe.handle(),
@@ -80,7 +82,7 @@ func PipeInfo(pipe MemObject, paramName PipeInfoName, paramSize uintptr, paramVa
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetPipeInfo", status, "pipe", pipe, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
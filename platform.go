@@ -1,12 +1,9 @@
 package cl30
 
-// #cgo LDFLAGS: -lOpenCL
+// #cgo !cl30_dynamic,!cl30_mock LDFLAGS: -lOpenCL
 // #include "api.h"
 import "C"
-import (
-	"fmt"
-	"unsafe"
-)
+import "unsafe"
 
 // PlatformID references one of the available OpenCL platforms of the system.
 // It allows applications to query OpenCL devices, device configuration information, and to create OpenCL contexts
@@ -19,19 +16,20 @@ func (id PlatformID) handle() C.cl_platform_id {
 }
 
 // String provides a readable presentation of the platform identifier.
-// It is based on the numerical value of the underlying pointer.
+// It is based on the numerical value of the underlying pointer, followed by the name set with SetDebugName(), if any.
 func (id PlatformID) String() string {
-	return fmt.Sprintf("0x%X", uintptr(id))
+	return handleString(id, uintptr(id))
 }
 
 // PlatformIDs returns the list of available platforms on the system.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetPlatformIDs.html
 func PlatformIDs() ([]PlatformID, error) {
+	defer observeCall("clGetPlatformIDs")()
 	count := C.cl_uint(0)
 	status := C.clGetPlatformIDs(0, nil, &count)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, operationError("clGetPlatformIDs", status)
 	}
 	if count == 0 {
 		return nil, nil
@@ -39,7 +37,7 @@ func PlatformIDs() ([]PlatformID, error) {
 	ids := make([]PlatformID, count)
 	status = C.clGetPlatformIDs(count, (*C.cl_platform_id)(unsafe.Pointer(&ids[0])), &count)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, operationError("clGetPlatformIDs", status)
 	}
 	return ids[:count], nil
 }
@@ -77,6 +75,8 @@ const (
 	// PlatformNumericVersionInfo refers to the detailed (major, minor, patch) version supported by the platform.
 	// The major and minor version numbers returned must match those returned via PlatformVersionInfo.
 	//
+	// Use PlatformVersion() for convenience.
+	//
 	// Returned type: Version
 	// Since: 3.0
 	PlatformNumericVersionInfo PlatformInfoName = C.CL_PLATFORM_NUMERIC_VERSION
@@ -90,6 +90,8 @@ const (
 	// the extensions supported by the platform. The same extension name must not be reported more than once.
 	// The list of extensions reported must match the list reported via PlatformExtensionsInfo.
 	//
+	// Use PlatformExtensionsWithVersion() for convenience.
+	//
 	// Returned type: []NameVersion
 	// Since: 3.0
 	PlatformExtensionsWithVersionInfo PlatformInfoName = C.CL_PLATFORM_EXTENSIONS_WITH_VERSION
@@ -114,6 +116,7 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetPlatformInfo.html
 func PlatformInfo(id PlatformID, paramName PlatformInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetPlatformInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetPlatformInfo(
 		id.handle(),
@@ -122,7 +125,7 @@ func PlatformInfo(id PlatformID, paramName PlatformInfoName, paramSize uintptr,
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetPlatformInfo", status, "id", id, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
@@ -137,6 +140,32 @@ func PlatformInfoString(id PlatformID, paramName PlatformInfoName) (string, erro
 	})
 }
 
+// PlatformVersion returns the OpenCL version supported by the platform.
+//
+// The version is queried with PlatformNumericVersionInfo. For platforms that do not support this query, which was
+// introduced with OpenCL 3.0, the version is parsed from PlatformVersionInfo; see ParseOpenClVersion().
+func PlatformVersion(id PlatformID) (Version, error) {
+	var version Version
+	if _, err := PlatformInfo(id, PlatformNumericVersionInfo, unsafe.Sizeof(version), unsafe.Pointer(&version)); err == nil {
+		return version, nil
+	}
+	versionString, err := PlatformInfoString(id, PlatformVersionInfo)
+	if err != nil {
+		return 0, err
+	}
+	return ParseOpenClVersion(versionString)
+}
+
+// PlatformExtensionsWithVersion is a convenience function for PlatformInfo() to query the
+// PlatformExtensionsWithVersionInfo. It returns the supported extensions together with their versions.
+//
+// Since: 3.0
+func PlatformExtensionsWithVersion(id PlatformID) ([]NameVersion, error) {
+	return querySlice[NameVersion](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return PlatformInfo(id, PlatformExtensionsWithVersionInfo, paramSize, paramValue)
+	})
+}
+
 // ExtensionFunctionAddressForPlatform returns the address of the extension function named by functionName
 // for a given platform.
 //
@@ -152,6 +181,7 @@ func PlatformInfoString(id PlatformID, paramName PlatformInfoName) (string, erro
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetExtensionFunctionAddressForPlatform.html
 func ExtensionFunctionAddressForPlatform(id PlatformID, functionName string) unsafe.Pointer {
+	defer observeCall("clGetExtensionFunctionAddressForPlatform")()
 	rawName := C.CString(functionName)
 	defer C.free(unsafe.Pointer(rawName))
 	return C.clGetExtensionFunctionAddressForPlatform(id.handle(), rawName)
@@ -169,9 +199,10 @@ func ExtensionFunctionAddressForPlatform(id PlatformID, functionName string) uns
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clUnloadPlatformCompiler.html
 func UnloadPlatformCompiler(id PlatformID) error {
+	defer observeCall("clUnloadPlatformCompiler")()
 	status := C.clUnloadPlatformCompiler(id.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clUnloadPlatformCompiler", status, "id", id)
 	}
 	return nil
 }
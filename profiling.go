@@ -0,0 +1,111 @@
+package cl30
+
+import (
+	"math"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+var profilingResolutions sync.Map
+
+// ProfilingDuration returns the duration between two profiling timestamps of the given device, such as
+// the values of ProfilingCommandStartInfo and ProfilingCommandEndInfo.
+//
+// The result accounts for the resolution of the device timer, see ProfilingDurationWithResolution(). The resolution
+// is queried with DeviceProfilingTimerResolutionInfo once per device, and cached. If the query fails, a resolution
+// of one nanosecond is assumed.
+func ProfilingDuration(start, end uint64, device DeviceID) time.Duration {
+	return ProfilingDurationWithResolution(start, end, profilingResolution(device))
+}
+
+// ProfilingDurationWithResolution returns the duration between two profiling timestamps, in nanoseconds, of a timer
+// with the given resolution, in nanoseconds.
+//
+// The device timer only advances in steps of its resolution. The difference of the timestamps is therefore rounded
+// to the nearest multiple of the resolution, which removes any bias that implementations introduce by converting
+// their timer ticks to nanoseconds.
+//
+// If end is less than start, the timer is considered to have wrapped around once, and the difference is calculated
+// modulo 2^64. Durations that exceed the range of time.Duration are clamped.
+func ProfilingDurationWithResolution(start, end uint64, resolution uint64) time.Duration {
+	ticks := end - start
+	if resolution > 1 {
+		remainder := ticks % resolution
+		ticks -= remainder
+		if (remainder >= (resolution+1)/2) && (ticks <= math.MaxUint64-resolution) {
+			ticks += resolution
+		}
+	}
+	if ticks > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(ticks)
+}
+
+func profilingResolution(device DeviceID) uint64 {
+	if cached, known := profilingResolutions.Load(device); known {
+		return cached.(uint64)
+	}
+	var resolution uintptr
+	_, err := DeviceInfo(device, DeviceProfilingTimerResolutionInfo, unsafe.Sizeof(resolution), unsafe.Pointer(&resolution))
+	if (err != nil) || (resolution == 0) {
+		return 1
+	}
+	profilingResolutions.Store(device, uint64(resolution))
+	return uint64(resolution)
+}
+
+// EventProfile contains the profiling timestamps of a command, and the device that executed it.
+type EventProfile struct {
+	// Device is the device of the command-queue of the event.
+	Device DeviceID
+	// Queued is the timestamp when the command was enqueued.
+	Queued uint64
+	// Submitted is the timestamp when the command was submitted to the device.
+	Submitted uint64
+	// Started is the timestamp when the command started execution.
+	Started uint64
+	// Ended is the timestamp when the command finished execution.
+	Ended uint64
+}
+
+// QueryEventProfile queries the profiling timestamps of the command associated with the event.
+// The command-queue of the event must have been created with profiling enabled, and the command must have completed.
+func QueryEventProfile(event Event) (EventProfile, error) {
+	var profile EventProfile
+	commandQueue, err := EventQueue(event)
+	if err != nil {
+		return EventProfile{}, err
+	}
+	_, err = CommandQueueInfo(commandQueue, QueueDeviceInfo, unsafe.Sizeof(profile.Device), unsafe.Pointer(&profile.Device))
+	if err != nil {
+		return EventProfile{}, err
+	}
+	timestamps := []struct {
+		name  EventProfilingInfoName
+		value *uint64
+	}{
+		{name: ProfilingCommandQueuedInfo, value: &profile.Queued},
+		{name: ProfilingCommandSubmitInfo, value: &profile.Submitted},
+		{name: ProfilingCommandStartInfo, value: &profile.Started},
+		{name: ProfilingCommandEndInfo, value: &profile.Ended},
+	}
+	for _, timestamp := range timestamps {
+		_, err = EventProfilingInfo(event, timestamp.name, unsafe.Sizeof(*timestamp.value), unsafe.Pointer(timestamp.value))
+		if err != nil {
+			return EventProfile{}, err
+		}
+	}
+	return profile, nil
+}
+
+// QueueDelay returns the duration between enqueuing the command and the start of its execution.
+func (profile EventProfile) QueueDelay() time.Duration {
+	return ProfilingDuration(profile.Queued, profile.Started, profile.Device)
+}
+
+// ExecutionTime returns the duration of the execution of the command.
+func (profile EventProfile) ExecutionTime() time.Duration {
+	return ProfilingDuration(profile.Started, profile.Ended, profile.Device)
+}
@@ -0,0 +1,35 @@
+package cl30_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestProfilingDurationWithResolution(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		name       string
+		start, end uint64
+		resolution uint64
+		want       time.Duration
+	}{
+		{name: "exact", start: 100, end: 1100, resolution: 1, want: 1000},
+		{name: "rounded up", start: 0, end: 1039, resolution: 80, want: 1040},
+		{name: "rounded down", start: 0, end: 999, resolution: 80, want: 960},
+		{name: "wraparound", start: math.MaxUint64 - 9, end: 10, resolution: 1, want: 20},
+		{name: "clamped", start: 0, end: math.MaxUint64, resolution: 1, want: math.MaxInt64},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := cl.ProfilingDurationWithResolution(tc.start, tc.end, tc.resolution)
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -17,7 +17,11 @@ package cl30
 //    cl_int *errReturn);
 import "C"
 import (
-	"fmt"
+	"bytes"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
 	"unsafe"
 )
 
@@ -29,9 +33,9 @@ func (program Program) handle() C.cl_program {
 }
 
 // String provides a readable presentation of the program identifier.
-// It is based on the numerical value of the underlying pointer.
+// It is based on the numerical value of the underlying pointer, followed by the name set with SetDebugName(), if any.
 func (program Program) String() string {
-	return fmt.Sprintf("0x%X", uintptr(program))
+	return handleString(program, uintptr(program))
 }
 
 // CreateProgramWithSource creates a program object for a context, and loads source code specified by text strings
@@ -39,6 +43,7 @@ func (program Program) String() string {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateProgramWithSource.html
 func CreateProgramWithSource(context Context, sources []string) (Program, error) {
+	defer observeCall("clCreateProgramWithSource")()
 	rawSources := make([]*C.char, len(sources))
 	for i := 0; i < len(sources); i++ {
 		rawSources[i] = C.CString(sources[i])
@@ -56,9 +61,58 @@ func CreateProgramWithSource(context Context, sources []string) (Program, error)
 		nil,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateProgramWithSource", status, "context", context)
 	}
-	return Program(*((*uintptr)(unsafe.Pointer(&program)))), nil
+	return trackCreated(Program(*((*uintptr)(unsafe.Pointer(&program))))), nil
+}
+
+// CreateProgramWithSourceBytes creates a program object for a context, and loads source code specified by byte
+// slices into the program object.
+//
+// Unlike CreateProgramWithSource(), the sources are passed to the OpenCL implementation without creating copies.
+// The sources do not need to be NUL terminated.
+//
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateProgramWithSource.html
+func CreateProgramWithSourceBytes(context Context, sources [][]byte) (Program, error) {
+	defer observeCall("clCreateProgramWithSource")()
+	if len(sources) == 0 {
+		return 0, ErrInvalidValue
+	}
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
+	rawSources := make([]*C.char, len(sources))
+	lengths := make([]C.size_t, len(sources))
+	for i, source := range sources {
+		if len(source) == 0 {
+			return 0, ErrInvalidValue
+		}
+		pinner.Pin(&source[0])
+		rawSources[i] = (*C.char)(unsafe.Pointer(&source[0]))
+		lengths[i] = C.size_t(len(source))
+	}
+	var status C.cl_int
+	program := C.clCreateProgramWithSource(
+		context.handle(),
+		C.cl_uint(len(rawSources)),
+		(**C.char)(unsafe.Pointer(&rawSources[0])),
+		(*C.size_t)(unsafe.Pointer(&lengths[0])),
+		&status)
+	if status != C.CL_SUCCESS {
+		return 0, operationError("clCreateProgramWithSource", status, "context", context)
+	}
+	return trackCreated(Program(*((*uintptr)(unsafe.Pointer(&program))))), nil
+}
+
+// CreateProgramFromReader creates a program object for a context, and loads source code from the given reader into
+// the program object. The reader is read until EOF.
+//
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateProgramWithSource.html
+func CreateProgramFromReader(context Context, r io.Reader) (Program, error) {
+	source, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	return CreateProgramWithSourceBytes(context, [][]byte{source})
 }
 
 // CreateProgramWithIl creates a program object for a context, and loads the intermediate language (IL) into the
@@ -70,6 +124,7 @@ func CreateProgramWithSource(context Context, sources []string) (Program, error)
 // Since: 2.1
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateProgramWithIL.html
 func CreateProgramWithIl(context Context, il []byte) (Program, error) {
+	defer observeCall("clCreateProgramWithIL")()
 	var rawIl unsafe.Pointer
 	if len(il) > 0 {
 		rawIl = unsafe.Pointer(&il[0])
@@ -81,9 +136,9 @@ func CreateProgramWithIl(context Context, il []byte) (Program, error) {
 		C.size_t(len(il)),
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateProgramWithIL", status, "context", context)
 	}
-	return Program(*((*uintptr)(unsafe.Pointer(&program)))), nil
+	return trackCreated(Program(*((*uintptr)(unsafe.Pointer(&program))))), nil
 }
 
 // CreateProgramWithBinary creates a program object for a context, and loads binary bits into the program object.
@@ -92,9 +147,19 @@ func CreateProgramWithIl(context Context, il []byte) (Program, error) {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateProgramWithBinary.html
 func CreateProgramWithBinary(context Context, devices []DeviceID, binaries [][]byte) (Program, []error, error) {
+	defer observeCall("clCreateProgramWithBinary")()
+	if (len(devices) == 0) || (len(binaries) != len(devices)) {
+		return 0, nil, ErrInvalidValue
+	}
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
 	rawBinaries := make([]*C.uchar, len(binaries))
 	binaryLengths := make([]C.size_t, len(binaries))
 	for i := 0; i < len(binaries); i++ {
+		if len(binaries[i]) == 0 {
+			return 0, nil, ErrInvalidValue
+		}
+		pinner.Pin(&binaries[i][0])
 		rawBinaries[i] = (*C.uchar)(unsafe.Pointer(&binaries[i][0]))
 		binaryLengths[i] = C.size_t(len(binaries[i]))
 	}
@@ -115,9 +180,9 @@ func CreateProgramWithBinary(context Context, devices []DeviceID, binaries [][]b
 		}
 	}
 	if status != C.CL_SUCCESS {
-		return 0, binaryErr, StatusError(status)
+		return 0, binaryErr, operationError("clCreateProgramWithBinary", status, "context", context)
 	}
-	return Program(*((*uintptr)(unsafe.Pointer(&program)))), binaryErr, nil
+	return trackCreated(Program(*((*uintptr)(unsafe.Pointer(&program))))), binaryErr, nil
 }
 
 // CreateProgramWithBuiltInKernels creates a program object for a context, and loads the information related to the
@@ -126,6 +191,7 @@ func CreateProgramWithBinary(context Context, devices []DeviceID, binaries [][]b
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateProgramWithBuiltInKernels.html
 func CreateProgramWithBuiltInKernels(context Context, devices []DeviceID, kernelNames string) (Program, error) {
+	defer observeCall("clCreateProgramWithBuiltInKernels")()
 	rawKernelNames := C.CString(kernelNames)
 	defer C.free(unsafe.Pointer(rawKernelNames))
 	var status C.cl_int
@@ -136,19 +202,49 @@ func CreateProgramWithBuiltInKernels(context Context, devices []DeviceID, kernel
 		rawKernelNames,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateProgramWithBuiltInKernels", status, "context", context)
+	}
+	return trackCreated(Program(*((*uintptr)(unsafe.Pointer(&program))))), nil
+}
+
+// CreateBuiltInKernels is a convenience function that creates kernels for the named built-in kernels of the devices.
+//
+// It creates a program with CreateProgramWithBuiltInKernels() and then a kernel for each of the names, in the same
+// order. The program is released again, as the kernels keep a reference on it.
+//
+// Since: 1.2
+func CreateBuiltInKernels(context Context, devices []DeviceID, names []string) ([]Kernel, error) {
+	program, err := CreateProgramWithBuiltInKernels(context, devices, strings.Join(names, ";"))
+	if err != nil {
+		return nil, err
 	}
-	return Program(*((*uintptr)(unsafe.Pointer(&program)))), nil
+	defer func() { _ = ReleaseProgram(program) }()
+	kernels := make([]Kernel, 0, len(names))
+	for _, name := range names {
+		kernel, err := CreateKernel(program, name)
+		if err != nil {
+			for _, created := range kernels {
+				_ = ReleaseKernel(created)
+			}
+			return nil, err
+		}
+		kernels = append(kernels, kernel)
+	}
+	return kernels, nil
 }
 
 // RetainProgram increments the program reference count.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clRetainProgram.html
 func RetainProgram(program Program) error {
+	defer observeCall("clRetainProgram")()
 	status := C.clRetainProgram(program.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clRetainProgram", status, "program", program)
 	}
+	trackRetained(program)
+	retainOwned(program)
+	retainNamed(program)
 	return nil
 }
 
@@ -159,10 +255,14 @@ func RetainProgram(program Program) error {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clReleaseProgram.html
 func ReleaseProgram(program Program) error {
+	defer observeCall("clReleaseProgram")()
 	status := C.clReleaseProgram(program.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clReleaseProgram", status, "program", program)
 	}
+	trackReleased(program)
+	disownReleased(program)
+	unnameReleased(program)
 	return nil
 }
 
@@ -181,6 +281,10 @@ func ReleaseProgram(program Program) error {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clBuildProgram.html
 func BuildProgram(program Program, devices []DeviceID, options string, callback func()) error {
+	defer observeCall("clBuildProgram")()
+	if err := injectedBuildFault(ErrBuildProgramFailure); err != nil {
+		return err
+	}
 	rawOptions := C.CString(options)
 	defer C.free(unsafe.Pointer(rawOptions))
 	var rawDevices unsafe.Pointer
@@ -203,7 +307,7 @@ func BuildProgram(program Program, devices []DeviceID, options string, callback
 		callbackUserData.ptr)
 	if status != C.CL_SUCCESS {
 		callbackUserData.Delete()
-		return StatusError(status)
+		return operationError("clBuildProgram", status, "program", program)
 	}
 	return nil
 }
@@ -213,7 +317,60 @@ func cl30GoProgramBuildCallback(_ Program, userData *C.uintptr_t) {
 	callbackUserData := userDataFrom(userData)
 	callback := callbackUserData.Value().(func())
 	callbackUserData.Delete()
-	callback()
+	dispatchCallback(callback)
+}
+
+// Prewarm prepares a program for low-latency use by moving one-time costs to the call of this function.
+//
+// If the program has not yet been built successfully for all of the given devices, it is built without options.
+// An empty list of devices refers to all devices associated with the program. Then, the named kernels are created
+// and immediately released again, which forces the OpenCL implementation to finalize any just-in-time compilation.
+// If kernels is empty, all kernels of the program are prewarmed.
+//
+// To build the program with specific options, call BuildProgram() before calling Prewarm().
+func Prewarm(program Program, devices []DeviceID, kernels []string) error {
+	checked := devices
+	if len(checked) == 0 {
+		var err error
+		checked, err = querySlice[DeviceID](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return ProgramInfo(program, ProgramDevicesInfo, paramSize, paramValue)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	built := len(checked) > 0
+	for _, device := range checked {
+		status, err := ProgramBuildStatus(program, device)
+		if (err != nil) || (status != BuildSuccessStatus) {
+			built = false
+			break
+		}
+	}
+	if !built {
+		err := BuildProgram(program, devices, "", nil)
+		if err != nil {
+			return err
+		}
+	}
+	if len(kernels) == 0 {
+		created, err := CreateKernelsInProgram(program)
+		if err != nil {
+			return err
+		}
+		for _, kernel := range created {
+			_ = ReleaseKernel(kernel)
+		}
+		return nil
+	}
+	for _, name := range kernels {
+		kernel, err := CreateKernel(program, name)
+		if err != nil {
+			return err
+		}
+		_ = ReleaseKernel(kernel)
+	}
+	return nil
 }
 
 // SetProgramSpecializationConstant sets a constant for a program created from intermediate language.
@@ -224,13 +381,14 @@ func cl30GoProgramBuildCallback(_ Program, userData *C.uintptr_t) {
 // Since: 2.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSetProgramSpecializationConstant.html
 func SetProgramSpecializationConstant(program Program, id uint32, size uintptr, value unsafe.Pointer) error {
+	defer observeCall("clSetProgramSpecializationConstant")()
 	status := C.clSetProgramSpecializationConstant(
 		program.handle(),
 		C.cl_uint(id),
 		C.size_t(size),
 		value)
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clSetProgramSpecializationConstant", status, "program", program, "size", size)
 	}
 	return nil
 }
@@ -247,6 +405,10 @@ type IncludeHeader struct {
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCompileProgram.html
 func CompileProgram(program Program, devices []DeviceID, options string, headers []IncludeHeader, callback func()) error {
+	defer observeCall("clCompileProgram")()
+	if err := injectedBuildFault(ErrCompileProgramFailure); err != nil {
+		return err
+	}
 	rawOptions := C.CString(options)
 	defer C.free(unsafe.Pointer(rawOptions))
 	var rawDevices unsafe.Pointer
@@ -289,7 +451,7 @@ func CompileProgram(program Program, devices []DeviceID, options string, headers
 		callbackUserData.ptr)
 	if status != C.CL_SUCCESS {
 		callbackUserData.Delete()
-		return StatusError(status)
+		return operationError("clCompileProgram", status, "program", program)
 	}
 	return nil
 }
@@ -299,7 +461,7 @@ func cl30GoProgramCompileCallback(_ Program, userData *C.uintptr_t) {
 	callbackUserData := userDataFrom(userData)
 	callback := callbackUserData.Value().(func())
 	callbackUserData.Delete()
-	callback()
+	dispatchCallback(callback)
 }
 
 // LinkProgram links a set of compiled program objects and libraries for all the devices or a specific device(s)
@@ -313,6 +475,10 @@ func cl30GoProgramCompileCallback(_ Program, userData *C.uintptr_t) {
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clLinkProgram.html
 func LinkProgram(context Context, devices []DeviceID, options string, programs []Program, callback func(Program)) (Program, error) {
+	defer observeCall("clLinkProgram")()
+	if err := injectedBuildFault(ErrLinkProgramFailure); err != nil {
+		return 0, err
+	}
 	rawOptions := C.CString(options)
 	defer C.free(unsafe.Pointer(rawOptions))
 	var rawDevices unsafe.Pointer
@@ -339,9 +505,9 @@ func LinkProgram(context Context, devices []DeviceID, options string, programs [
 		&status)
 	if status != C.CL_SUCCESS {
 		callbackUserData.Delete()
-		return 0, StatusError(status)
+		return 0, operationError("clLinkProgram", status, "context", context)
 	}
-	return Program(*((*uintptr)(unsafe.Pointer(&program)))), nil
+	return trackCreated(Program(*((*uintptr)(unsafe.Pointer(&program))))), nil
 }
 
 //export cl30GoProgramLinkCallback
@@ -349,7 +515,95 @@ func cl30GoProgramLinkCallback(program Program, userData *C.uintptr_t) {
 	callbackUserData := userDataFrom(userData)
 	callback := callbackUserData.Value().(func(Program))
 	callbackUserData.Delete()
-	callback(program)
+	dispatchCallback(func() { callback(program) })
+}
+
+// BuildWithHeaders compiles the main source with the given headers, and links the result into an executable program.
+// This is a convenience for the common case of the separate compile and link workflow, which requires a program
+// per header, and an intermediate compiled program.
+//
+// The headers map the include names, as they are used in #include directives of the sources, to their source.
+// The options are used for the compilation. The intermediate programs are released before the function returns,
+// also in case of an error. If the compilation fails, the returned error is a *BuildLogError with the compile log
+// of each failing device; see BuildProgramWithLog().
+//
+// The returned program must be released.
+//
+// Since: 1.2
+func BuildWithHeaders(context Context, devices []DeviceID, mainSource string, headers map[string]string, options string) (Program, error) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	includeHeaders := make([]IncludeHeader, 0, len(headers))
+	defer func() {
+		for _, header := range includeHeaders {
+			_ = ReleaseProgram(header.Program)
+		}
+	}()
+	for _, name := range names {
+		header, err := CreateProgramWithSource(context, []string{headers[name]})
+		if err != nil {
+			return 0, err
+		}
+		includeHeaders = append(includeHeaders, IncludeHeader{Name: name, Program: header})
+	}
+	main, err := CreateProgramWithSource(context, []string{mainSource})
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = ReleaseProgram(main) }()
+	err = CompileProgram(main, devices, options, includeHeaders, nil)
+	if err != nil {
+		return 0, withBuildLogs(main, devices, err)
+	}
+	return LinkProgram(context, devices, "", []Program{main}, nil)
+}
+
+// CompileToLibrary compiles the sources and links the result into a library program.
+//
+// The options are used for the compilation. The library is created by linking with the option "-create-library".
+// It can then be linked into executables with LinkWithLibraries(). The intermediate compiled program is released
+// before the function returns, also in case of an error. If the compilation fails, the returned error is a
+// *BuildLogError with the compile log of each failing device.
+//
+// The returned program must be released.
+//
+// Since: 1.2
+func CompileToLibrary(context Context, devices []DeviceID, sources []string, options string) (Program, error) {
+	if len(sources) == 0 {
+		return 0, ErrInvalidValue
+	}
+	compiled, err := CreateProgramWithSource(context, sources)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = ReleaseProgram(compiled) }()
+	err = CompileProgram(compiled, devices, options, nil, nil)
+	if err != nil {
+		return 0, withBuildLogs(compiled, devices, err)
+	}
+	return LinkProgram(context, devices, "-create-library", []Program{compiled}, nil)
+}
+
+// LinkWithLibraries links compiled program objects and libraries into an executable program.
+//
+// The objects are compiled programs, see CompileProgram(); the libraries are programs created with
+// CompileToLibrary(), or by linking with the option "-create-library". The objects are passed to the linker
+// before the libraries. At least one program must be provided.
+//
+// The returned program must be released.
+//
+// Since: 1.2
+func LinkWithLibraries(context Context, devices []DeviceID, objects, libraries []Program, options string) (Program, error) {
+	programs := make([]Program, 0, len(objects)+len(libraries))
+	programs = append(programs, objects...)
+	programs = append(programs, libraries...)
+	if len(programs) == 0 {
+		return 0, ErrInvalidValue
+	}
+	return LinkProgram(context, devices, options, programs, nil)
 }
 
 // ProgramBuildInfoName identifies properties of a program build, which can be queried with ProgramBuildInfo().
@@ -439,6 +693,7 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetProgramBuildInfo.html
 func ProgramBuildInfo(program Program, device DeviceID, paramName ProgramBuildInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetProgramBuildInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetProgramBuildInfo(
 		program.handle(),
@@ -448,7 +703,7 @@ func ProgramBuildInfo(program Program, device DeviceID, paramName ProgramBuildIn
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetProgramBuildInfo", status, "program", program, "device", device, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
@@ -464,6 +719,49 @@ func ProgramBuildInfoString(program Program, device DeviceID, paramName ProgramB
 	})
 }
 
+// ProgramBuildStatus returns the build, compile, or link status, whichever was performed last on the program
+// for the device.
+func ProgramBuildStatus(program Program, device DeviceID) (BuildStatus, error) {
+	return queryValue[BuildStatus](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return ProgramBuildInfo(program, device, ProgramBuildStatusInfo, paramSize, paramValue)
+	})
+}
+
+// ProgramBuildOptions returns the build, compile, or link options, whichever was performed last on the program
+// for the device.
+func ProgramBuildOptions(program Program, device DeviceID) (string, error) {
+	return ProgramBuildInfoString(program, device, ProgramBuildOptionsInfo)
+}
+
+// ProgramBuildLog returns the build, compile, or link log, whichever was performed last on the program
+// for the device.
+func ProgramBuildLog(program Program, device DeviceID) (string, error) {
+	return ProgramBuildInfoString(program, device, ProgramBuildLogInfo)
+}
+
+// ProgramBinaryTypeOf returns the type of the program binary for the device.
+// The function is not called ProgramBinaryType, as that name is taken by the returned type.
+//
+// Since: 1.2
+func ProgramBinaryTypeOf(program Program, device DeviceID) (ProgramBinaryType, error) {
+	return queryValue[ProgramBinaryType](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return ProgramBuildInfo(program, device, ProgramBinaryTypeInfo, paramSize, paramValue)
+	})
+}
+
+// ProgramGlobalVariableTotalSize returns the total amount of storage, in bytes, used by program variables in
+// the global address space for the device.
+//
+// Compare the value against DeviceGlobalVariablePreferredTotalSizeInfo to verify the program stays within the
+// preferred budget of the device. DeviceMaxGlobalVariableSizeInfo limits the size of each single variable.
+//
+// Since: 2.0
+func ProgramGlobalVariableTotalSize(program Program, device DeviceID) (uintptr, error) {
+	return queryValue[uintptr](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return ProgramBuildInfo(program, device, ProgramBuildGlobalVariableTotalSizeInfo, paramSize, paramValue)
+	})
+}
+
 // ProgramInfoName identifies properties of a program, which can be queried with ProgramInfo().
 type ProgramInfoName C.cl_program_info
 
@@ -590,6 +888,7 @@ const (
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetProgramInfo.html
 func ProgramInfo(program Program, paramName ProgramInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetProgramInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetProgramInfo(
 		program.handle(),
@@ -598,7 +897,7 @@ func ProgramInfo(program Program, paramName ProgramInfoName, paramSize uintptr,
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetProgramInfo", status, "program", program, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
@@ -612,3 +911,88 @@ func ProgramInfoString(program Program, paramName ProgramInfoName) (string, erro
 		return ProgramInfo(program, paramName, paramSize, paramValue)
 	})
 }
+
+// ProgramIl is a convenience function for ProgramInfo() to query the intermediate language (IL) of a program.
+//
+// The returned slice is empty if the program was not created with CreateProgramWithIl().
+//
+// Since: 2.1
+func ProgramIl(program Program) ([]byte, error) {
+	return querySlice[byte](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return ProgramInfo(program, ProgramIlInfo, paramSize, paramValue)
+	})
+}
+
+// VerifyProgramIl checks whether the intermediate language (IL) of the program is identical to the given one.
+// This can be used to verify that a program created with CreateProgramWithIl() round-trips its IL.
+//
+// ErrProgramIlMismatch is returned if the IL differs.
+//
+// Since: 2.1
+func VerifyProgramIl(program Program, il []byte) error {
+	programIl, err := ProgramIl(program)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(programIl, il) {
+		return ErrProgramIlMismatch
+	}
+	return nil
+}
+
+// ProgramBinaries is a convenience function for ProgramInfo() to query the binaries of a program.
+//
+// The returned binaries correspond to the returned devices, which are those of ProgramDevicesInfo.
+// A binary is empty if no binary is available for the respective device, for example because the program was not
+// built for it.
+func ProgramBinaries(program Program) ([]DeviceID, [][]byte, error) {
+	devices, err := querySlice[DeviceID](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return ProgramInfo(program, ProgramDevicesInfo, paramSize, paramValue)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sizes, err := querySlice[uintptr](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return ProgramInfo(program, ProgramBinarySizesInfo, paramSize, paramValue)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sizes) != len(devices) {
+		return nil, nil, ErrInvalidValue
+	}
+	if len(devices) == 0 {
+		return devices, nil, nil
+	}
+	// The implementation writes into the provided memory, which must therefore not be managed by Go.
+	raw := C.calloc(C.size_t(len(devices)), C.size_t(unsafe.Sizeof(uintptr(0))))
+	if raw == nil {
+		return nil, nil, ErrOutOfMemory
+	}
+	pointers := unsafe.Slice((*unsafe.Pointer)(raw), len(devices))
+	defer func() {
+		for _, ptr := range pointers {
+			C.free(ptr)
+		}
+		C.free(raw)
+	}()
+	for i, size := range sizes {
+		if size > 0 {
+			pointers[i] = C.malloc(C.size_t(size))
+			if pointers[i] == nil {
+				return nil, nil, ErrOutOfMemory
+			}
+		}
+	}
+	_, err = ProgramInfo(program, ProgramBinariesInfo, uintptr(len(devices))*unsafe.Sizeof(uintptr(0)), raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	binaries := make([][]byte, len(devices))
+	for i, size := range sizes {
+		if size > 0 {
+			binaries[i] = C.GoBytes(pointers[i], C.int(size))
+		}
+	}
+	return devices, binaries, nil
+}
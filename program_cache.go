@@ -0,0 +1,215 @@
+package cl30
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unsafe"
+)
+
+// programCacheFileSuffix is the file name suffix of the entries of a ProgramCache.
+const programCacheFileSuffix = ".clbin"
+
+// ProgramCache stores the binaries of built programs in a directory, so that programs do not need to be compiled
+// again, for example on every start of an application.
+//
+// An entry is identified by a hash of the program source or intermediate language (IL), the build options, as well as
+// the name, version, and driver version of the device. An update of the driver therefore leads to new entries.
+// Entries that the implementation no longer accepts are removed and replaced.
+//
+// The directory may be shared by several processes. Entries are written atomically.
+type ProgramCache struct {
+	dir string
+}
+
+// NewProgramCache returns a cache that stores its entries in the given directory. The directory is created if
+// it does not exist.
+func NewProgramCache(dir string) (*ProgramCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &ProgramCache{dir: dir}, nil
+}
+
+// Dir returns the directory of the cache.
+func (cache *ProgramCache) Dir() string {
+	return cache.dir
+}
+
+// BuildProgram returns a built program for the given sources. See CreateProgramWithSource() and BuildProgram().
+//
+// If the cache contains binaries for all devices, the program is created with CreateProgramWithBinary().
+// Otherwise, the program is created from the sources and built, and its binaries are stored in the cache.
+// Failures to store the binaries are not reported, as the program is usable regardless.
+// If devices is empty, the program is built for all devices of the context.
+func (cache *ProgramCache) BuildProgram(context Context, devices []DeviceID, sources []string, options string) (Program, error) {
+	inputs := make([][]byte, len(sources))
+	for i, source := range sources {
+		inputs[i] = []byte(source)
+	}
+	return cache.build(context, devices, "source", inputs, options, func() (Program, error) {
+		return CreateProgramWithSource(context, sources)
+	})
+}
+
+// BuildProgramWithIl returns a built program for the given intermediate language (IL).
+// See CreateProgramWithIl() and BuildProgram().
+//
+// The cache is used in the same way as with ProgramCache.BuildProgram().
+//
+// Since: 2.1
+func (cache *ProgramCache) BuildProgramWithIl(context Context, devices []DeviceID, il []byte, options string) (Program, error) {
+	return cache.build(context, devices, "il", [][]byte{il}, options, func() (Program, error) {
+		return CreateProgramWithIl(context, il)
+	})
+}
+
+// Invalidate removes all entries of the cache.
+func (cache *ProgramCache) Invalidate() error {
+	entries, err := os.ReadDir(cache.dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), programCacheFileSuffix) {
+			err = os.Remove(filepath.Join(cache.dir, entry.Name()))
+			if (err != nil) && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (cache *ProgramCache) build(context Context, devices []DeviceID, kind string, inputs [][]byte, options string,
+	create func() (Program, error)) (Program, error) {
+	if len(devices) == 0 {
+		var err error
+		devices, err = querySlice[DeviceID](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return ContextInfo(context, ContextDevicesInfo, paramSize, paramValue)
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	paths := make([]string, len(devices))
+	for i, device := range devices {
+		key, err := programCacheKey(device, kind, inputs, options)
+		if err != nil {
+			return 0, err
+		}
+		paths[i] = filepath.Join(cache.dir, key+programCacheFileSuffix)
+	}
+	if binaries, hit := cache.load(paths); hit {
+		program, err := buildFromBinaries(context, devices, binaries, options)
+		if err == nil {
+			return program, nil
+		}
+		cache.remove(paths)
+	}
+	program, err := create()
+	if err != nil {
+		return 0, err
+	}
+	if err = BuildProgram(program, devices, options, nil); err != nil {
+		_ = ReleaseProgram(program)
+		return 0, err
+	}
+	cache.store(program, devices, paths)
+	return program, nil
+}
+
+func (cache *ProgramCache) load(paths []string) ([][]byte, bool) {
+	binaries := make([][]byte, len(paths))
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if (err != nil) || (len(data) == 0) {
+			return nil, false
+		}
+		binaries[i] = data
+	}
+	return binaries, true
+}
+
+func (cache *ProgramCache) store(program Program, devices []DeviceID, paths []string) {
+	programDevices, binaries, err := ProgramBinaries(program)
+	if err != nil {
+		return
+	}
+	for i, device := range devices {
+		for j, programDevice := range programDevices {
+			if (programDevice == device) && (len(binaries[j]) > 0) {
+				_ = writeFileAtomically(paths[i], binaries[j])
+				break
+			}
+		}
+	}
+}
+
+func (cache *ProgramCache) remove(paths []string) {
+	for _, path := range paths {
+		_ = os.Remove(path)
+	}
+}
+
+func buildFromBinaries(context Context, devices []DeviceID, binaries [][]byte, options string) (Program, error) {
+	program, _, err := CreateProgramWithBinary(context, devices, binaries)
+	if err != nil {
+		return 0, err
+	}
+	if err = BuildProgram(program, devices, options, nil); err != nil {
+		_ = ReleaseProgram(program)
+		return 0, err
+	}
+	return program, nil
+}
+
+// programCacheKey returns the hexadecimal hash that identifies the binary of a program for a device.
+func programCacheKey(device DeviceID, kind string, inputs [][]byte, options string) (string, error) {
+	hash := sha256.New()
+	write := func(data []byte) {
+		var length [8]byte
+		binary.LittleEndian.PutUint64(length[:], uint64(len(data)))
+		_, _ = hash.Write(length[:])
+		_, _ = hash.Write(data)
+	}
+	write([]byte("cl30 program cache 1"))
+	write([]byte(kind))
+	for _, input := range inputs {
+		write(input)
+	}
+	write([]byte(options))
+	for _, paramName := range []DeviceInfoName{DeviceNameInfo, DeviceVersionInfo, DriverVersionInfo} {
+		value, err := DeviceInfoString(device, paramName)
+		if err != nil {
+			return "", err
+		}
+		write([]byte(value))
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeFileAtomically writes the data into a temporary file in the same directory, and renames it to the path.
+func writeFileAtomically(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+	}
+	return err
+}
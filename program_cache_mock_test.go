@@ -0,0 +1,62 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockProgramCache(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	cache, err := cl.NewProgramCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewProgramCache failed: %v", err)
+	}
+	sources := []string{"kernel void fill(global uchar *out, uchar value) {}"}
+	build := func() {
+		t.Helper()
+		program, err := cache.BuildProgram(context, nil, sources, "-DVALUE=1")
+		if err != nil {
+			t.Fatalf("BuildProgram failed: %v", err)
+		}
+		defer func() { _ = cl.ReleaseProgram(program) }()
+		kernel, err := cl.CreateKernel(program, "fill")
+		if err != nil {
+			t.Fatalf("CreateKernel failed: %v", err)
+		}
+		_ = cl.ReleaseKernel(kernel)
+	}
+	counts := func() (uint64, uint64) {
+		var fromSource, fromBinary uint64
+		for _, metric := range cl.CallStats() {
+			switch metric.Name {
+			case "clCreateProgramWithSource":
+				fromSource = metric.Count
+			case "clCreateProgramWithBinary":
+				fromBinary = metric.Count
+			}
+		}
+		return fromSource, fromBinary
+	}
+	cl.ResetCallStats()
+	cl.EnableCallMetrics(true)
+	defer cl.EnableCallMetrics(false)
+
+	build()
+	if fromSource, fromBinary := counts(); (fromSource != 1) || (fromBinary != 0) {
+		t.Errorf("unexpected creation for cache miss: %d from source, %d from binary", fromSource, fromBinary)
+	}
+	build()
+	if fromSource, fromBinary := counts(); (fromSource != 1) || (fromBinary != 1) {
+		t.Errorf("unexpected creation for cache hit: %d from source, %d from binary", fromSource, fromBinary)
+	}
+	if err = cache.Invalidate(); err != nil {
+		t.Fatalf("Invalidate failed: %v", err)
+	}
+	build()
+	if fromSource, fromBinary := counts(); (fromSource != 2) || (fromBinary != 1) {
+		t.Errorf("unexpected creation after invalidation: %d from source, %d from binary", fromSource, fromBinary)
+	}
+}
@@ -0,0 +1,49 @@
+package cl30
+
+import "unsafe"
+
+// infoLoader is the common signature of the functions that query information from an OpenCL object.
+// It follows the semantics of the *Info() functions: the returned number is the required size, in bytes,
+// for the queried information.
+type infoLoader func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)
+
+// queryValue extracts a fixed-size value with the help of a load function.
+// The type of the value must not contain any Go pointers.
+func queryValue[T any](load infoLoader) (T, error) {
+	var value T
+	_, err := load(unsafe.Sizeof(value), unsafe.Pointer(&value))
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value, nil
+}
+
+// querySlice extracts an array of fixed-size values with the help of a load function.
+// The load function is called twice, once with zero/nil to query the needed size, then a second time to retrieve
+// the values. The type of the values must not contain any Go pointers.
+func querySlice[T any](load infoLoader) ([]T, error) {
+	requiredSize, err := load(0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if requiredSize > 1024*1024*10 {
+		return nil, ErrDataSizeLimitExceeded
+	}
+	var zero T
+	elementSize := unsafe.Sizeof(zero)
+	count := requiredSize / elementSize
+	if count == 0 {
+		return nil, nil
+	}
+	values := make([]T, count)
+	returnedSize, err := load(count*elementSize, unsafe.Pointer(&values[0]))
+	if err != nil {
+		return nil, err
+	}
+	// The returned size may be different from the originally reported value. Avoid using more than possible.
+	if returnedCount := returnedSize / elementSize; returnedCount < count {
+		count = returnedCount
+	}
+	return values[:count], nil
+}
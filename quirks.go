@@ -0,0 +1,44 @@
+package cl30
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Quirk identifies a workaround for a deficiency of an OpenCL implementation.
+// Quirks are bitflags, several of them can be combined.
+type Quirk uint32
+
+const (
+	// QuirkEmulateFillImage makes EnqueueFillImage() emulate the fill on the host if the implementation
+	// rejects the native call with ErrInvalidOperation. Some embedded implementations do not support filling images.
+	QuirkEmulateFillImage Quirk = 1 << iota
+	// QuirkLegacyQueueProperties allows SetCommandQueueProperty() to be called for command-queues of devices that
+	// report a version later than OpenCL 1.0. Some implementations still support the call for such devices.
+	QuirkLegacyQueueProperties
+)
+
+var enabledQuirks = struct {
+	mutex sync.Mutex
+	value uint32
+}{}
+
+// EnableQuirks enables the given workarounds, in addition to the already enabled ones.
+// All quirks are disabled by default.
+func EnableQuirks(quirks Quirk) {
+	enabledQuirks.mutex.Lock()
+	defer enabledQuirks.mutex.Unlock()
+	atomic.StoreUint32(&enabledQuirks.value, enabledQuirks.value|uint32(quirks))
+}
+
+// DisableQuirks disables the given workarounds.
+func DisableQuirks(quirks Quirk) {
+	enabledQuirks.mutex.Lock()
+	defer enabledQuirks.mutex.Unlock()
+	atomic.StoreUint32(&enabledQuirks.value, enabledQuirks.value&^uint32(quirks))
+}
+
+// QuirksEnabled returns true if all the given workarounds are enabled.
+func QuirksEnabled(quirks Quirk) bool {
+	return (Quirk(atomic.LoadUint32(&enabledQuirks.value)) & quirks) == quirks
+}
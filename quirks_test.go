@@ -0,0 +1,21 @@
+package cl30_test
+
+import (
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestQuirksCanBeToggled(t *testing.T) {
+	if cl.QuirksEnabled(cl.QuirkEmulateFillImage) {
+		t.Errorf("quirks must be disabled by default")
+	}
+	cl.EnableQuirks(cl.QuirkEmulateFillImage)
+	if !cl.QuirksEnabled(cl.QuirkEmulateFillImage) {
+		t.Errorf("quirk must be enabled")
+	}
+	cl.DisableQuirks(cl.QuirkEmulateFillImage)
+	if cl.QuirksEnabled(cl.QuirkEmulateFillImage) {
+		t.Errorf("quirk must be disabled again")
+	}
+}
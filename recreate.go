@@ -0,0 +1,229 @@
+package cl30
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ContextSpec describes a context and the objects that an application requires in it.
+//
+// A specification is declarative: Recreate() creates all described objects from scratch and stores them in the
+// targets of the specification. This allows an application to rebuild its state after the driver lost a device,
+// or after a reset of the driver. See IsDeviceLost() to identify such errors.
+type ContextSpec struct {
+	// Devices returns the devices for the context. It is called for every recreation, as device identifiers may
+	// change after a driver reset.
+	Devices func() ([]DeviceID, error)
+	// Callback is the optional error receiver of the context. See CreateContext().
+	Callback *ContextErrorCallback
+	// Properties are the properties of the context.
+	Properties []ContextProperty
+	// Queues lists the command-queues to create.
+	Queues []QueueSpec
+	// Programs lists the programs to create and build.
+	Programs []ProgramSpec
+	// Buffers lists the buffers to create.
+	Buffers []BufferSpec
+	// Recreated is an optional hook that is called after all described objects were created. It can be used to
+	// rebuild further state that depends on these objects, such as kernels. If the hook returns an error,
+	// the recreation fails.
+	Recreated func(context Context) error
+}
+
+// QueueSpec describes a command-queue of a ContextSpec.
+type QueueSpec struct {
+	// DeviceIndex refers to the device, as returned by ContextSpec.Devices, to create the queue for.
+	DeviceIndex int
+	// Properties are the properties of the queue. See CreateCommandQueueWithProperties().
+	Properties []CommandQueueProperty
+	// Queue is the optional target that receives the created queue.
+	Queue *CommandQueue
+}
+
+// ProgramSpec describes a program of a ContextSpec. The program is built for all devices of the context.
+type ProgramSpec struct {
+	// Sources are the source strings of the program.
+	Sources []string
+	// Options are the build options.
+	Options string
+	// Program is the optional target that receives the created program.
+	Program *Program
+}
+
+// BufferSpec describes a buffer of a ContextSpec.
+//
+// Buffers that must survive the loss of a device are persistent: their content is kept in a host shadow, which
+// is uploaded into the buffer when it is created.
+type BufferSpec struct {
+	// Flags are the memory flags for the buffer. MemUseHostPtrFlag and MemCopyHostPtrFlag must not be specified.
+	Flags MemFlags
+	// Size is the size of the buffer, in bytes. If zero, the size of the shadow is used.
+	Size int
+	// Shadow is the optional host shadow of the buffer. Its content is copied into the buffer on creation.
+	// The shadow must not be larger than the buffer.
+	Shadow HostMemory
+	// Buffer is the optional target that receives the created buffer.
+	Buffer *MemObject
+}
+
+var outOfResourcesAsDeviceLost int32
+
+// SetOutOfResourcesAsDeviceLost enables or disables the treatment of ErrOutOfResources as a lost device by
+// IsDeviceLost().
+//
+// Several implementations report a reset of the device with ErrOutOfResources. As the error also signals plain
+// resource exhaustion, for which a recreation of the context does not help, it is not considered by default.
+func SetOutOfResourcesAsDeviceLost(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&outOfResourcesAsDeviceLost, value)
+}
+
+// OutOfResourcesAsDeviceLost returns true if IsDeviceLost() considers ErrOutOfResources.
+// See SetOutOfResourcesAsDeviceLost().
+func OutOfResourcesAsDeviceLost() bool {
+	return atomic.LoadInt32(&outOfResourcesAsDeviceLost) != 0
+}
+
+// IsDeviceLost returns true if the error indicates that a device, or the driver, is no longer available.
+//
+// Applications typically react to such errors by releasing the objects of the affected context, ignoring any
+// further errors, and then calling Recreate() with their ContextSpec. ErrOutOfResources is only included if
+// enabled with SetOutOfResourcesAsDeviceLost().
+func IsDeviceLost(err error) bool {
+	return errors.Is(err, ErrDeviceNotAvailable) ||
+		errors.Is(err, ErrDeviceNotFound) ||
+		(OutOfResourcesAsDeviceLost() && errors.Is(err, ErrOutOfResources))
+}
+
+// Recreate creates a new context, and all objects described by the specification.
+//
+// The created objects are stored in the targets of the specification. Objects previously stored in the
+// targets are not released, as they may belong to a lost context; releasing them is left to the caller.
+// Persistent buffers are created with the content of their host shadow. A shadow of type *HostShadow is
+// bound to the recreated buffer once all steps succeeded, so that it keeps track of the new buffer instead of
+// the previous one. Writes of the Recreated hook are therefore not reflected in such shadows.
+//
+// If any step fails, all objects created so far are released, the targets and shadows are left unchanged, and
+// the error is returned.
+func Recreate(spec ContextSpec) (Context, error) {
+	if spec.Devices == nil {
+		return 0, ErrInvalidValue
+	}
+	devices, err := spec.Devices()
+	if err != nil {
+		return 0, err
+	}
+	context, err := CreateContext(devices, spec.Callback, spec.Properties...)
+	if err != nil {
+		return 0, err
+	}
+	var releases []func()
+	rollback := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+		_ = ReleaseContext(context)
+	}
+
+	queues := make([]CommandQueue, len(spec.Queues))
+	for i, queueSpec := range spec.Queues {
+		if (queueSpec.DeviceIndex < 0) || (queueSpec.DeviceIndex >= len(devices)) {
+			rollback()
+			return 0, fmt.Errorf("queue %d: %w", i, ErrInvalidDevice)
+		}
+		queue, err := CreateCommandQueueWithProperties(context, devices[queueSpec.DeviceIndex], queueSpec.Properties...)
+		if err != nil {
+			rollback()
+			return 0, fmt.Errorf("queue %d: %w", i, err)
+		}
+		releases = append(releases, func() { _ = ReleaseCommandQueue(queue) })
+		queues[i] = queue
+	}
+
+	programs := make([]Program, len(spec.Programs))
+	for i, programSpec := range spec.Programs {
+		program, err := CreateProgramWithSource(context, programSpec.Sources)
+		if err != nil {
+			rollback()
+			return 0, fmt.Errorf("program %d: %w", i, err)
+		}
+		releases = append(releases, func() { _ = ReleaseProgram(program) })
+		if err = BuildProgram(program, devices, programSpec.Options, nil); err != nil {
+			rollback()
+			return 0, fmt.Errorf("program %d: %w", i, err)
+		}
+		programs[i] = program
+	}
+
+	buffers := make([]MemObject, len(spec.Buffers))
+	for i, bufferSpec := range spec.Buffers {
+		buffer, err := createSpecBuffer(context, bufferSpec)
+		if err != nil {
+			rollback()
+			return 0, fmt.Errorf("buffer %d: %w", i, err)
+		}
+		releases = append(releases, func() { _ = ReleaseMemObject(buffer) })
+		if shadow, isHostShadow := bufferSpec.Shadow.(*HostShadow); isHostShadow {
+			if err = shadow.prepareRebind(buffer); err != nil {
+				rollback()
+				return 0, fmt.Errorf("buffer %d: %w", i, err)
+			}
+		}
+		buffers[i] = buffer
+	}
+
+	if spec.Recreated != nil {
+		if err = spec.Recreated(context); err != nil {
+			rollback()
+			return 0, err
+		}
+	}
+
+	for i, queueSpec := range spec.Queues {
+		if queueSpec.Queue != nil {
+			*queueSpec.Queue = queues[i]
+		}
+	}
+	for i, programSpec := range spec.Programs {
+		if programSpec.Program != nil {
+			*programSpec.Program = programs[i]
+		}
+	}
+	for i, bufferSpec := range spec.Buffers {
+		if bufferSpec.Buffer != nil {
+			*bufferSpec.Buffer = buffers[i]
+		}
+		if shadow, isHostShadow := bufferSpec.Shadow.(*HostShadow); isHostShadow {
+			shadow.rebind(buffers[i])
+		}
+	}
+	return context, nil
+}
+
+func createSpecBuffer(context Context, spec BufferSpec) (MemObject, error) {
+	if (spec.Flags & (MemUseHostPtrFlag | MemCopyHostPtrFlag)) != 0 {
+		return 0, ErrInvalidValue
+	}
+	if (spec.Shadow == nil) || (spec.Shadow.Size() == 0) {
+		return CreateBuffer(context, spec.Flags, spec.Size, nil)
+	}
+	size := spec.Size
+	if size == 0 {
+		size = int(spec.Shadow.Size())
+	}
+	if spec.Shadow.Size() > uintptr(size) {
+		return 0, ErrInvalidBufferSize
+	}
+	if spec.Shadow.Size() == uintptr(size) {
+		return CreateBuffer(context, spec.Flags|MemCopyHostPtrFlag, size, spec.Shadow.Pointer())
+	}
+	// A shadow that is smaller than the buffer initializes the start of the buffer only.
+	content := make([]byte, size)
+	copy(content, unsafe.Slice((*byte)(spec.Shadow.Pointer()), spec.Shadow.Size()))
+	return CreateBuffer(context, spec.Flags|MemCopyHostPtrFlag, size, Slice(content).Pointer())
+}
@@ -0,0 +1,196 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockRecreate(t *testing.T) {
+	cl.SetMockPlatforms(nil)
+	shadow := []byte("persistent")
+	var queue cl.CommandQueue
+	var program cl.Program
+	var buffer cl.MemObject
+	spec := cl.ContextSpec{
+		Devices: func() ([]cl.DeviceID, error) {
+			platforms, err := cl.PlatformIDs()
+			if err != nil {
+				return nil, err
+			}
+			return cl.DeviceIDs(platforms[0], cl.DeviceTypeAll)
+		},
+		Queues:   []cl.QueueSpec{{Queue: &queue}},
+		Programs: []cl.ProgramSpec{{Sources: []string{"kernel void k() {}"}, Program: &program}},
+		Buffers:  []cl.BufferSpec{{Flags: cl.MemReadWriteFlag, Size: 16, Shadow: cl.Slice(shadow), Buffer: &buffer}},
+	}
+	context, err := cl.Recreate(spec)
+	if err != nil {
+		t.Fatalf("Recreate failed: %v", err)
+	}
+	defer func() {
+		_ = cl.ReleaseMemObject(buffer)
+		_ = cl.ReleaseProgram(program)
+		_ = cl.ReleaseCommandQueue(queue)
+		_ = cl.ReleaseContext(context)
+	}()
+	output := make([]byte, 16)
+	err = cl.EnqueueReadBuffer(queue, buffer, true, 0, 16, unsafe.Pointer(&output[0]), nil, nil)
+	if (err != nil) || (string(output[:len(shadow)]) != "persistent") || (output[15] != 0) {
+		t.Errorf("unexpected buffer content: %q, %v", output, err)
+	}
+
+	spec.Programs = append(spec.Programs, cl.ProgramSpec{Sources: []string{"#error broken"}})
+	previousQueue := queue
+	if _, err = cl.Recreate(spec); !errors.Is(err, cl.ErrBuildProgramFailure) {
+		t.Errorf("unexpected error for failing program: %v", err)
+	}
+	if queue != previousQueue {
+		t.Errorf("targets modified by failed recreation")
+	}
+}
+
+func TestIsDeviceLost(t *testing.T) {
+	defer cl.SetOutOfResourcesAsDeviceLost(false)
+	wrapped := fmt.Errorf("context: %w", cl.ErrDeviceNotAvailable)
+	if !cl.IsDeviceLost(wrapped) || !cl.IsDeviceLost(cl.ErrDeviceNotFound) {
+		t.Errorf("device errors must be reported as lost device")
+	}
+	if cl.IsDeviceLost(cl.ErrInvalidValue) || cl.IsDeviceLost(nil) {
+		t.Errorf("unrelated errors must not be reported as lost device")
+	}
+	if cl.IsDeviceLost(cl.ErrOutOfResources) {
+		t.Errorf("ErrOutOfResources must not be reported as lost device by default")
+	}
+	cl.SetOutOfResourcesAsDeviceLost(true)
+	if !cl.OutOfResourcesAsDeviceLost() || !cl.IsDeviceLost(cl.ErrOutOfResources) {
+		t.Errorf("ErrOutOfResources must be reported as lost device when enabled")
+	}
+}
+
+func TestMockRecreateRebindsHostShadow(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 16, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	before := cl.HostShadowBytes()
+	shadow, err := cl.WithHostShadow(buffer)
+	if err != nil {
+		t.Fatalf("WithHostShadow failed: %v", err)
+	}
+	release := func() { _ = cl.ReleaseMemObject(buffer) }
+	defer func() { release() }()
+	if err = writeString(queue, buffer, "initial"); err != nil {
+		t.Fatalf("EnqueueWriteBuffer failed: %v", err)
+	}
+
+	var newQueue cl.CommandQueue
+	var newBuffer cl.MemObject
+	spec := cl.ContextSpec{
+		Devices: func() ([]cl.DeviceID, error) {
+			platforms, err := cl.PlatformIDs()
+			if err != nil {
+				return nil, err
+			}
+			return cl.DeviceIDs(platforms[0], cl.DeviceTypeAll)
+		},
+		Queues:  []cl.QueueSpec{{Queue: &newQueue}},
+		Buffers: []cl.BufferSpec{{Flags: cl.MemReadWriteFlag, Shadow: shadow, Buffer: &newBuffer}},
+	}
+	for cycle, content := range []string{"first", "second"} {
+		newContext, err := cl.Recreate(spec)
+		if err != nil {
+			t.Fatalf("Recreate %d failed: %v", cycle, err)
+		}
+		release()
+		oldBuffer, releasedQueue, releasedBuffer := buffer, newQueue, newBuffer
+		release = func() {
+			_ = cl.ReleaseMemObject(releasedBuffer)
+			_ = cl.ReleaseCommandQueue(releasedQueue)
+			_ = cl.ReleaseContext(newContext)
+		}
+		buffer = newBuffer
+
+		if (cl.HostShadowOf(newBuffer) != shadow) || (shadow.MemObject() != newBuffer) {
+			t.Errorf("cycle %d: shadow not bound to the recreated buffer", cycle)
+		}
+		if (oldBuffer != newBuffer) && (cl.HostShadowOf(oldBuffer) == shadow) {
+			t.Errorf("cycle %d: shadow still registered for the previous buffer", cycle)
+		}
+		if overhead := cl.HostShadowBytes() - before; overhead != 16 {
+			t.Errorf("cycle %d: unexpected shadow overhead: %v", cycle, overhead)
+		}
+		output := make([]byte, 16)
+		err = cl.EnqueueReadBuffer(newQueue, newBuffer, true, 0, 16, unsafe.Pointer(&output[0]), nil, nil)
+		if (err != nil) || (string(output) != string(shadow.Bytes())) {
+			t.Errorf("cycle %d: recreated buffer not initialized from the shadow: %q, %v", cycle, output, err)
+		}
+		if err = writeString(newQueue, newBuffer, content); err != nil {
+			t.Fatalf("EnqueueWriteBuffer failed: %v", err)
+		}
+		if data := shadow.Bytes(); string(data[:len(content)]) != content {
+			t.Errorf("cycle %d: shadow not updated by writes to the recreated buffer: %q", cycle, data)
+		}
+	}
+	release()
+	release = func() {}
+	if cl.HostShadowBytes() != before {
+		t.Errorf("shadow not removed with the last recreated buffer")
+	}
+}
+
+func writeString(queue cl.CommandQueue, buffer cl.MemObject, content string) error {
+	data := []byte(content)
+	return cl.EnqueueWriteBuffer(queue, buffer, true, 0, uintptr(len(data)), unsafe.Pointer(&data[0]), nil, nil)
+}
+
+func TestMockFailedRecreateKeepsHostShadow(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 16, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+	shadow, err := cl.WithHostShadow(buffer)
+	if err != nil {
+		t.Fatalf("WithHostShadow failed: %v", err)
+	}
+	before := cl.HostShadowBytes()
+	target := buffer
+	hookErr := errors.New("hook failed")
+	spec := cl.ContextSpec{
+		Devices: func() ([]cl.DeviceID, error) {
+			platforms, err := cl.PlatformIDs()
+			if err != nil {
+				return nil, err
+			}
+			return cl.DeviceIDs(platforms[0], cl.DeviceTypeAll)
+		},
+		Buffers:   []cl.BufferSpec{{Flags: cl.MemReadWriteFlag, Shadow: shadow, Buffer: &target}},
+		Recreated: func(cl.Context) error { return hookErr },
+	}
+	if _, err = cl.Recreate(spec); !errors.Is(err, hookErr) {
+		t.Fatalf("unexpected error of failing hook: %v", err)
+	}
+	if target != buffer {
+		t.Errorf("target modified by failed recreation")
+	}
+	if (cl.HostShadowOf(buffer) != shadow) || (shadow.MemObject() != buffer) {
+		t.Errorf("shadow no longer bound to the previous buffer")
+	}
+	if cl.HostShadowBytes() != before {
+		t.Errorf("shadow accounting changed by failed recreation")
+	}
+	if err = writeString(queue, buffer, "still"); err != nil {
+		t.Fatalf("EnqueueWriteBuffer failed: %v", err)
+	}
+	if data := shadow.Bytes(); string(data[:5]) != "still" {
+		t.Errorf("shadow not updated by writes to the previous buffer: %q", data)
+	}
+}
@@ -2,10 +2,7 @@ package cl30
 
 // #include "api.h"
 import "C"
-import (
-	"fmt"
-	"unsafe"
-)
+import "unsafe"
 
 // Sampler objects describe how color information from an image is being sampled.
 type Sampler uintptr
@@ -15,9 +12,9 @@ func (sampler Sampler) handle() C.cl_sampler {
 }
 
 // String provides a readable presentation of the sampler identifier.
-// It is based on the numerical value of the underlying pointer.
+// It is based on the numerical value of the underlying pointer, followed by the name set with SetDebugName(), if any.
 func (sampler Sampler) String() string {
-	return fmt.Sprintf("0x%X", uintptr(sampler))
+	return handleString(sampler, uintptr(sampler))
 }
 
 const (
@@ -95,8 +92,10 @@ func WithFilterMode(mode SamplerFilterMode) SamplerProperty {
 
 // CreateSamplerWithProperties creates a sampler object.
 //
+// Since: 2.0
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clCreateSamplerWithProperties.html
 func CreateSamplerWithProperties(context Context, properties ...SamplerProperty) (Sampler, error) {
+	defer observeCall("clCreateSamplerWithProperties")()
 	var rawPropertyList []uint64
 	for _, property := range properties {
 		rawPropertyList = append(rawPropertyList, property...)
@@ -112,9 +111,9 @@ func CreateSamplerWithProperties(context Context, properties ...SamplerProperty)
 		(*C.cl_sampler_properties)(rawProperties),
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clCreateSamplerWithProperties", status, "context", context)
 	}
-	return Sampler(*((*uintptr)(unsafe.Pointer(&sampler)))), nil
+	return trackCreated(Sampler(*((*uintptr)(unsafe.Pointer(&sampler))))), nil
 }
 
 // RetainSampler increments the sampler reference count.
@@ -123,10 +122,14 @@ func CreateSamplerWithProperties(context Context, properties ...SamplerProperty)
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clRetainSampler.html
 func RetainSampler(sampler Sampler) error {
+	defer observeCall("clRetainSampler")()
 	status := C.clRetainSampler(sampler.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clRetainSampler", status, "sampler", sampler)
 	}
+	trackRetained(sampler)
+	retainOwned(sampler)
+	retainNamed(sampler)
 	return nil
 }
 
@@ -137,10 +140,14 @@ func RetainSampler(sampler Sampler) error {
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clReleaseSampler.html
 func ReleaseSampler(sampler Sampler) error {
+	defer observeCall("clReleaseSampler")()
 	status := C.clReleaseSampler(sampler.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clReleaseSampler", status, "sampler", sampler)
 	}
+	trackReleased(sampler)
+	disownReleased(sampler)
+	unnameReleased(sampler)
 	return nil
 }
 
@@ -189,7 +196,8 @@ const (
 // Raw strings are with a terminating NUL character.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clGetSamplerInfo.html
-func SamplerInfo(sampler Sampler, paramName ContextInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+func SamplerInfo(sampler Sampler, paramName SamplerInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	defer observeCall("clGetSamplerInfo")()
 	sizeReturn := C.size_t(0)
 	status := C.clGetSamplerInfo(
 		sampler.handle(),
@@ -198,7 +206,42 @@ func SamplerInfo(sampler Sampler, paramName ContextInfoName, paramSize uintptr,
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, operationError("clGetSamplerInfo", status, "sampler", sampler, "paramName", paramName)
 	}
 	return uintptr(sizeReturn), nil
 }
+
+// SamplerContext returns the context specified when the sampler was created.
+func SamplerContext(sampler Sampler) (Context, error) {
+	return queryValue[Context](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return SamplerInfo(sampler, SamplerContextInfo, paramSize, paramValue)
+	})
+}
+
+// SamplerNormalizedCoords returns true if the sampler uses normalized image coordinates.
+func SamplerNormalizedCoords(sampler Sampler) (bool, error) {
+	value, err := queryValue[Bool](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return SamplerInfo(sampler, SamplerNormalizedCoordsInfo, paramSize, paramValue)
+	})
+	return value.ToGoBool(), err
+}
+
+// SamplerAddressingModeOf returns the addressing mode of the sampler.
+func SamplerAddressingModeOf(sampler Sampler) (SamplerAddressingMode, error) {
+	return queryValue[SamplerAddressingMode](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return SamplerInfo(sampler, SamplerAddressingModeInfo, paramSize, paramValue)
+	})
+}
+
+// SamplerFilterModeOf returns the filter mode of the sampler.
+func SamplerFilterModeOf(sampler Sampler) (SamplerFilterMode, error) {
+	return queryValue[SamplerFilterMode](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return SamplerInfo(sampler, SamplerFilterModeInfo, paramSize, paramValue)
+	})
+}
+
+// SetKernelArgSampler sets a sampler as the argument value for a specific argument of a kernel.
+// The argument must be declared as sampler_t in the kernel source.
+func SetKernelArgSampler(kernel Kernel, index uint32, sampler Sampler) error {
+	return SetKernelArg(kernel, index, unsafe.Sizeof(sampler), unsafe.Pointer(&sampler))
+}
@@ -0,0 +1,136 @@
+package cl30
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// SchedulerAgingWindow is the number of submissions after which a pending work item gains one priority level.
+// This aging makes sure that work items of low priority are eventually executed, even if work items of higher
+// priority keep being submitted.
+const SchedulerAgingWindow = 16
+
+// Scheduler multiplexes work of multiple submitters onto one or more command queues, with priorities.
+//
+// Core OpenCL does not provide priorities for command queues. The scheduler provides them on the host side:
+// pending work items are ordered by their priority, and executed by one worker per command queue. Work items
+// of equal priority are executed in the order of their submission. Pending work items age, so that no work
+// item starves; see SchedulerAgingWindow. In deterministic mode, work items are executed one after the other;
+// see SetDeterministicMode().
+//
+// Create a scheduler with NewScheduler(), and stop it with Close().
+type Scheduler struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	pending scheduledWorkHeap
+	seq     int64
+	closed  bool
+	workers sync.WaitGroup
+	serial  sync.Mutex
+}
+
+// NewScheduler creates a scheduler that executes work items on the given command queues.
+// Each command queue is served by its own worker goroutine.
+func NewScheduler(commandQueues []CommandQueue) *Scheduler {
+	scheduler := &Scheduler{}
+	scheduler.cond = sync.NewCond(&scheduler.mutex)
+	for _, commandQueue := range commandQueues {
+		scheduler.workers.Add(1)
+		go scheduler.serve(commandQueue)
+	}
+	return scheduler
+}
+
+// Submit schedules the work function with given priority. Higher values have higher priority.
+//
+// The work function is called with the command queue it shall use. The returned channel receives the result
+// of the work function once it was executed. ErrSchedulerClosed is delivered if the scheduler was closed.
+func (scheduler *Scheduler) Submit(priority int, work func(CommandQueue) error) <-chan error {
+	result := make(chan error, 1)
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	if scheduler.closed {
+		result <- ErrSchedulerClosed
+		return result
+	}
+	scheduler.seq++
+	heap.Push(&scheduler.pending, &scheduledWork{
+		rank:   scheduler.seq - int64(priority)*SchedulerAgingWindow,
+		seq:    scheduler.seq,
+		work:   work,
+		result: result,
+	})
+	scheduler.cond.Signal()
+	return result
+}
+
+// Close stops accepting new work items, waits for all pending work items to be executed, and stops the workers.
+func (scheduler *Scheduler) Close() {
+	scheduler.mutex.Lock()
+	scheduler.closed = true
+	scheduler.cond.Broadcast()
+	scheduler.mutex.Unlock()
+	scheduler.workers.Wait()
+	// Without any workers, pending work items remain. They are rejected.
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	for scheduler.pending.Len() > 0 {
+		item := heap.Pop(&scheduler.pending).(*scheduledWork)
+		item.result <- ErrSchedulerClosed
+	}
+}
+
+func (scheduler *Scheduler) serve(commandQueue CommandQueue) {
+	defer scheduler.workers.Done()
+	for {
+		scheduler.mutex.Lock()
+		for (scheduler.pending.Len() == 0) && !scheduler.closed {
+			scheduler.cond.Wait()
+		}
+		if scheduler.pending.Len() == 0 {
+			scheduler.mutex.Unlock()
+			return
+		}
+		item := heap.Pop(&scheduler.pending).(*scheduledWork)
+		scheduler.mutex.Unlock()
+		item.result <- scheduler.execute(item, commandQueue)
+	}
+}
+
+func (scheduler *Scheduler) execute(item *scheduledWork, commandQueue CommandQueue) error {
+	if DeterministicMode() {
+		scheduler.serial.Lock()
+		defer scheduler.serial.Unlock()
+	}
+	return item.work(commandQueue)
+}
+
+type scheduledWork struct {
+	rank   int64
+	seq    int64
+	work   func(CommandQueue) error
+	result chan<- error
+}
+
+type scheduledWorkHeap []*scheduledWork
+
+func (h scheduledWorkHeap) Len() int { return len(h) }
+
+func (h scheduledWorkHeap) Less(a, b int) bool {
+	if h[a].rank != h[b].rank {
+		return h[a].rank < h[b].rank
+	}
+	return h[a].seq < h[b].seq
+}
+
+func (h scheduledWorkHeap) Swap(a, b int) { h[a], h[b] = h[b], h[a] }
+
+func (h *scheduledWorkHeap) Push(x any) { *h = append(*h, x.(*scheduledWork)) }
+
+func (h *scheduledWorkHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return last
+}
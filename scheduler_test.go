@@ -0,0 +1,54 @@
+package cl30_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestSchedulerExecutesByPriority(t *testing.T) {
+	t.Parallel()
+	scheduler := cl.NewScheduler([]cl.CommandQueue{cl.CommandQueue(1)})
+	block := make(chan struct{})
+	started := make(chan struct{})
+	first := scheduler.Submit(0, func(cl.CommandQueue) error {
+		close(started)
+		<-block
+		return nil
+	})
+	<-started
+	var mutex sync.Mutex
+	var order []int
+	record := func(priority int) func(cl.CommandQueue) error {
+		return func(cl.CommandQueue) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			order = append(order, priority)
+			return nil
+		}
+	}
+	low := scheduler.Submit(1, record(1))
+	high := scheduler.Submit(5, record(5))
+	close(block)
+	for _, result := range []<-chan error{first, low, high} {
+		if err := <-result; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	scheduler.Close()
+	if (len(order) != 2) || (order[0] != 5) || (order[1] != 1) {
+		t.Errorf("unexpected order: %v", order)
+	}
+}
+
+func TestSchedulerRejectsWorkAfterClose(t *testing.T) {
+	t.Parallel()
+	scheduler := cl.NewScheduler(nil)
+	scheduler.Close()
+	err := <-scheduler.Submit(0, func(cl.CommandQueue) error { return nil })
+	if !errors.Is(err, cl.ErrSchedulerClosed) {
+		t.Errorf("expected closed error, got %v", err)
+	}
+}
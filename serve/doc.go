@@ -0,0 +1,21 @@
+// Package serve provides a scaffold for a request/response style compute server.
+//
+// A Worker owns the context, the command-queue, and the program for one device. It accepts jobs over a Go channel,
+// runs the requested kernel with the input data of each job, and returns the resulting output data. For example:
+//
+//	worker, err := serve.NewWorker(serve.Config{Device: device, Source: source, Timeout: time.Second, MaxRetries: 2})
+//	defer worker.Release()
+//	jobs := make(chan serve.Job)
+//	go worker.Serve(jobs)
+//	results := make(chan serve.Result, 1)
+//	jobs <- serve.Job{Kernel: "scale", GlobalWorkSize: []uintptr{1024}, Inputs: [][]byte{data},
+//		OutputSizes: []int{len(data)}, Result: results}
+//	result := <-results
+//
+// Each job is guarded by a watchdog. If a job does not complete within the configured timeout, the job fails with
+// ErrJobTimeout, and the worker abandons its OpenCL objects and creates new ones for the following jobs.
+// Failed jobs are retried as long as the error is considered transient.
+//
+// The package is a reference architecture built entirely on the primitives of package cl30. Applications with
+// different needs are encouraged to copy and adapt it.
+package serve
@@ -0,0 +1,300 @@
+package serve
+
+import (
+	"errors"
+	"time"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+const (
+	// ErrJobTimeout is the result of jobs that did not complete within the timeout of the worker.
+	ErrJobTimeout cl.WrapperError = "job timeout"
+	// ErrInvalidJob is the result of jobs that can not be executed, such as jobs without a kernel name.
+	ErrInvalidJob cl.WrapperError = "invalid job"
+)
+
+// Config describes the setup of a Worker.
+type Config struct {
+	// Device is the device the worker runs all jobs on.
+	Device cl.DeviceID
+	// Source is the OpenCL C source of the program that contains the kernels of the jobs.
+	Source string
+	// BuildOptions are passed to the build of the program.
+	BuildOptions string
+	// Timeout is the duration a single attempt of a job may take. A value of zero, or less, disables the watchdog.
+	Timeout time.Duration
+	// MaxRetries is the number of additional attempts for a job that failed with a transient error.
+	MaxRetries int
+	// Retryable determines whether a failed attempt is retried. If nil, IsTransient() is used.
+	Retryable func(error) bool
+}
+
+// Job describes one request to the worker.
+//
+// The kernel receives one buffer argument per entry of Inputs, followed by one buffer argument per entry of
+// OutputSizes, in that order.
+type Job struct {
+	// Kernel is the name of the kernel in the program of the worker.
+	Kernel string
+	// GlobalWorkSize is the global work size of the kernel launch.
+	GlobalWorkSize []uintptr
+	// LocalWorkSize is the optional local work size of the kernel launch.
+	LocalWorkSize []uintptr
+	// Inputs are the contents of the read-only input buffers.
+	Inputs [][]byte
+	// OutputSizes are the sizes, in bytes, of the write-only output buffers.
+	OutputSizes []int
+	// Result receives the result of the job. The worker blocks until the result is received, and serves no
+	// other job meanwhile; a buffered channel decouples the worker from the receiver.
+	Result chan<- Result
+}
+
+// Result is the response to a Job.
+type Result struct {
+	// Outputs contains the contents of the output buffers, in the order of Job.OutputSizes.
+	Outputs [][]byte
+	// Attempts is the number of attempts it took to run the job.
+	Attempts int
+	// Err is the error of the last attempt, or nil if the job completed.
+	Err error
+}
+
+// IsTransient returns true for errors that may not occur again if the job is retried with a fresh set of
+// OpenCL objects, such as resource exhaustion and timeouts.
+func IsTransient(err error) bool {
+	for _, transient := range []error{
+		ErrJobTimeout,
+		cl.ErrOutOfResources,
+		cl.ErrOutOfHostMemory,
+		cl.ErrMemObjectAllocationFailure,
+		cl.ErrExecStatusErrorForEventsInWaitList,
+	} {
+		if errors.Is(err, transient) {
+			return true
+		}
+	}
+	return false
+}
+
+// Worker runs jobs on one device.
+type Worker struct {
+	config Config
+	env    *environment
+}
+
+// NewWorker creates the OpenCL objects for the configured device, and builds the program.
+// The returned worker must be released with Release().
+func NewWorker(config Config) (*Worker, error) {
+	if config.Retryable == nil {
+		config.Retryable = IsTransient
+	}
+	env, err := newEnvironment(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Worker{config: config, env: env}, nil
+}
+
+// Serve runs the jobs received from the channel, one at a time, until the channel is closed.
+func (worker *Worker) Serve(jobs <-chan Job) {
+	for job := range jobs {
+		result := worker.Run(job)
+		if job.Result != nil {
+			job.Result <- result
+		}
+	}
+}
+
+// Run runs a single job and returns its result. A job is attempted up to 1 + Config.MaxRetries times.
+func (worker *Worker) Run(job Job) Result {
+	if (job.Kernel == "") || (len(job.GlobalWorkSize) == 0) {
+		return Result{Err: ErrInvalidJob}
+	}
+	var result Result
+	for result.Attempts <= worker.config.MaxRetries {
+		result.Attempts++
+		result.Outputs, result.Err = worker.attempt(job)
+		if (result.Err == nil) || !worker.config.Retryable(result.Err) {
+			break
+		}
+	}
+	return result
+}
+
+// Release releases the OpenCL objects of the worker. It must not be called while Serve() or Run() are active.
+func (worker *Worker) Release() error {
+	if worker.env == nil {
+		return nil
+	}
+	err := worker.env.release()
+	worker.env = nil
+	return err
+}
+
+func (worker *Worker) attempt(job Job) ([][]byte, error) {
+	if worker.env == nil {
+		env, err := newEnvironment(worker.config)
+		if err != nil {
+			return nil, err
+		}
+		worker.env = env
+	}
+	env := worker.env
+	if worker.config.Timeout <= 0 {
+		return env.run(job)
+	}
+
+	type attemptResult struct {
+		outputs [][]byte
+		err     error
+	}
+	done := make(chan attemptResult, 1)
+	go func() {
+		outputs, err := env.run(job)
+		done <- attemptResult{outputs: outputs, err: err}
+	}()
+	watchdog := time.NewTimer(worker.config.Timeout)
+	defer watchdog.Stop()
+	select {
+	case result := <-done:
+		return result.outputs, result.err
+	case <-watchdog.C:
+		// The objects can not be released while the attempt still uses them. They are released as soon as
+		// the attempt returns, which may be never in case the device is hung.
+		worker.env = nil
+		go func() {
+			<-done
+			_ = env.release()
+		}()
+		return nil, ErrJobTimeout
+	}
+}
+
+// environment holds the OpenCL objects of a worker.
+type environment struct {
+	context      cl.Context
+	commandQueue cl.CommandQueue
+	program      cl.Program
+	kernels      map[string]cl.Kernel
+}
+
+func newEnvironment(config Config) (env *environment, err error) {
+	env = &environment{kernels: make(map[string]cl.Kernel)}
+	defer func() {
+		if err != nil {
+			_ = env.release()
+		}
+	}()
+	env.context, err = cl.CreateContext([]cl.DeviceID{config.Device}, nil)
+	if err != nil {
+		return nil, err
+	}
+	env.commandQueue, err = cl.CreateCommandQueueWithProperties(env.context, config.Device)
+	if err != nil {
+		return nil, err
+	}
+	env.program, err = cl.CreateProgramWithSource(env.context, []string{config.Source})
+	if err != nil {
+		return nil, err
+	}
+	err = cl.BuildProgram(env.program, []cl.DeviceID{config.Device}, config.BuildOptions, nil)
+	if err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+func (env *environment) release() error {
+	var firstErr error
+	keep := func(err error) {
+		if (err != nil) && (firstErr == nil) {
+			firstErr = err
+		}
+	}
+	for _, kernel := range env.kernels {
+		keep(cl.ReleaseKernel(kernel))
+	}
+	env.kernels = nil
+	if env.program != 0 {
+		keep(cl.ReleaseProgram(env.program))
+	}
+	if env.commandQueue != 0 {
+		keep(cl.ReleaseCommandQueue(env.commandQueue))
+	}
+	if env.context != 0 {
+		keep(cl.ReleaseContext(env.context))
+	}
+	return firstErr
+}
+
+func (env *environment) kernel(name string) (cl.Kernel, error) {
+	if kernel, exists := env.kernels[name]; exists {
+		return kernel, nil
+	}
+	kernel, err := cl.CreateKernel(env.program, name)
+	if err != nil {
+		return 0, err
+	}
+	env.kernels[name] = kernel
+	return kernel, nil
+}
+
+func (env *environment) run(job Job) (outputs [][]byte, err error) {
+	kernel, err := env.kernel(job.Kernel)
+	if err != nil {
+		return nil, err
+	}
+	buffers := make([]cl.MemObject, 0, len(job.Inputs)+len(job.OutputSizes))
+	defer func() {
+		for _, buffer := range buffers {
+			_ = cl.ReleaseMemObject(buffer)
+		}
+	}()
+	for _, input := range job.Inputs {
+		if len(input) == 0 {
+			return nil, ErrInvalidJob
+		}
+		buffer, err := cl.CreateBuffer(env.context, cl.MemReadOnlyFlag|cl.MemCopyHostPtrFlag, len(input),
+			unsafe.Pointer(&input[0]))
+		if err != nil {
+			return nil, err
+		}
+		buffers = append(buffers, buffer)
+	}
+	for _, size := range job.OutputSizes {
+		if size <= 0 {
+			return nil, ErrInvalidJob
+		}
+		buffer, err := cl.CreateBuffer(env.context, cl.MemWriteOnlyFlag, size, nil)
+		if err != nil {
+			return nil, err
+		}
+		buffers = append(buffers, buffer)
+	}
+	for i := range buffers {
+		err = cl.SetKernelArg(kernel, uint32(i), unsafe.Sizeof(buffers[i]), unsafe.Pointer(&buffers[i]))
+		if err != nil {
+			return nil, err
+		}
+	}
+	var opts []cl.KernelEnqueueOption
+	if len(job.LocalWorkSize) > 0 {
+		opts = append(opts, cl.WithLocalWorkSize(job.LocalWorkSize))
+	}
+	err = cl.EnqueueKernel(env.commandQueue, kernel, job.GlobalWorkSize, opts...)
+	if err != nil {
+		return nil, err
+	}
+	outputs = make([][]byte, len(job.OutputSizes))
+	for i, size := range job.OutputSizes {
+		outputs[i] = make([]byte, size)
+		buffer := buffers[len(job.Inputs)+i]
+		err = cl.EnqueueReadBuffer(env.commandQueue, buffer, true, 0, uintptr(size), unsafe.Pointer(&outputs[i][0]), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return outputs, nil
+}
@@ -0,0 +1,144 @@
+//go:build cl30_mock
+
+package serve_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+	"github.com/opencl-go/cl30/serve"
+)
+
+const mockSource = "kernel void copy(global const uchar *in, global uchar *out) {}"
+
+func mockWorker(t *testing.T, config serve.Config) *serve.Worker {
+	t.Helper()
+	cl.SetMockPlatforms(nil)
+	platforms, err := cl.PlatformIDs()
+	if err != nil {
+		t.Fatalf("PlatformIDs failed: %v", err)
+	}
+	devices, err := cl.DeviceIDs(platforms[0], cl.DeviceTypeAll)
+	if err != nil {
+		t.Fatalf("DeviceIDs failed: %v", err)
+	}
+	config.Device = devices[0]
+	config.Source = mockSource
+	worker, err := serve.NewWorker(config)
+	if err != nil {
+		t.Fatalf("NewWorker failed: %v", err)
+	}
+	t.Cleanup(func() { _ = worker.Release() })
+	return worker
+}
+
+// mockCopyKernel registers the copy kernel. The first stalls calls only run stall, without copying.
+func mockCopyKernel(t *testing.T, stalls int, stall func() error) {
+	t.Helper()
+	cl.SetMockKernel("copy", func(call cl.MockKernelCall) error {
+		if stalls > 0 {
+			stalls--
+			return stall()
+		}
+		copy(call.Args[1].Buffer, call.Args[0].Buffer)
+		return nil
+	})
+	t.Cleanup(func() { cl.SetMockKernel("copy", nil) })
+}
+
+func copyJob(input []byte) serve.Job {
+	return serve.Job{
+		Kernel:         "copy",
+		GlobalWorkSize: []uintptr{uintptr(len(input))},
+		Inputs:         [][]byte{input},
+		OutputSizes:    []int{len(input)},
+	}
+}
+
+func TestMockRun(t *testing.T) {
+	worker := mockWorker(t, serve.Config{})
+	mockCopyKernel(t, 0, nil)
+	input := []byte{1, 2, 3, 4}
+	result := worker.Run(copyJob(input))
+	if result.Err != nil {
+		t.Fatalf("Run failed: %v", result.Err)
+	}
+	if (result.Attempts != 1) || !bytes.Equal(result.Outputs[0], input) {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result = worker.Run(serve.Job{Kernel: "copy"}); !errors.Is(result.Err, serve.ErrInvalidJob) {
+		t.Errorf("unexpected error for job without work size: %v", result.Err)
+	}
+}
+
+func TestMockRunRetriesTransientErrors(t *testing.T) {
+	mockCopyKernel(t, 0, nil)
+	// Each attempt enqueues the kernel and one read; every third enqueue fails.
+	cl.SetFaultInjection(&cl.FaultInjection{EnqueueFailureInterval: 3})
+	defer cl.SetFaultInjection(nil)
+
+	worker := mockWorker(t, serve.Config{MaxRetries: 1})
+	if result := worker.Run(copyJob([]byte{5, 6})); (result.Err != nil) || (result.Attempts != 1) {
+		t.Errorf("unexpected result of first job: %+v", result)
+	}
+	result := worker.Run(copyJob([]byte{5, 6}))
+	if (result.Err != nil) || (result.Attempts != 2) || !bytes.Equal(result.Outputs[0], []byte{5, 6}) {
+		t.Errorf("unexpected result of retried job: %+v", result)
+	}
+
+	worker = mockWorker(t, serve.Config{})
+	cl.SetFaultInjection(&cl.FaultInjection{EnqueueFailureInterval: 1})
+	result = worker.Run(copyJob([]byte{5, 6}))
+	if !errors.Is(result.Err, cl.ErrOutOfResources) || (result.Attempts != 1) {
+		t.Errorf("unexpected result without retries: %+v", result)
+	}
+}
+
+func TestMockRunWatchdog(t *testing.T) {
+	worker := mockWorker(t, serve.Config{Timeout: 20 * time.Millisecond, MaxRetries: 1})
+	mockCopyKernel(t, 1, func() error {
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	})
+	input := []byte{7, 8, 9}
+	result := worker.Run(copyJob(input))
+	if result.Err != nil {
+		t.Fatalf("Run failed after timeout: %v", result.Err)
+	}
+	if (result.Attempts != 2) || !bytes.Equal(result.Outputs[0], input) {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestMockServeDeliversAllResults(t *testing.T) {
+	worker := mockWorker(t, serve.Config{})
+	mockCopyKernel(t, 0, nil)
+	jobs := make(chan serve.Job)
+	results := make(chan serve.Result)
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		worker.Serve(jobs)
+	}()
+	for i := byte(1); i <= 3; i++ {
+		job := copyJob([]byte{i})
+		job.Result = results
+		jobs <- job
+		// The unbuffered channel is only read after a delay; the result must not be dropped.
+		time.Sleep(5 * time.Millisecond)
+		var result serve.Result
+		select {
+		case result = <-results:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("result of job %d dropped", i)
+		}
+		if (result.Err != nil) || !bytes.Equal(result.Outputs[0], []byte{i}) {
+			t.Errorf("unexpected result for job %d: %+v", i, result)
+		}
+	}
+	close(jobs)
+	<-served
+}
@@ -0,0 +1,29 @@
+package serve_test
+
+import (
+	"fmt"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+	"github.com/opencl-go/cl30/serve"
+)
+
+func TestIsTransient(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		err       error
+		transient bool
+	}{
+		{err: serve.ErrJobTimeout, transient: true},
+		{err: cl.ErrOutOfResources, transient: true},
+		{err: fmt.Errorf("wrapped: %w", cl.ErrMemObjectAllocationFailure), transient: true},
+		{err: cl.ErrInvalidKernelName, transient: false},
+		{err: serve.ErrInvalidJob, transient: false},
+		{err: nil, transient: false},
+	}
+	for _, tc := range tt {
+		if got := serve.IsTransient(tc.err); got != tc.transient {
+			t.Errorf("unexpected result for %v: %v", tc.err, got)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package cl30
+
+import (
+	"math"
+	"unsafe"
+)
+
+// maxSize is the highest value of the size_t type, which has the same width as uintptr.
+const maxSize = uint64(^uintptr(0))
+
+// CheckedSize converts the given value to a size, as used for size and offset parameters.
+//
+// ErrSizeOutOfRange is returned if the value is negative, or if it can not be represented on the current platform,
+// such as sizes beyond 4 GiB in 32-bit builds. Use this function for values that originate from 64-bit sources,
+// such as file sizes or serialized data, instead of a plain conversion, which would silently truncate the value.
+func CheckedSize(value int64) (uintptr, error) {
+	if (value < 0) || (uint64(value) > maxSize) {
+		return 0, ErrSizeOutOfRange
+	}
+	return uintptr(value), nil
+}
+
+// checkIntSizes returns ErrSizeOutOfRange if any of the given values is negative.
+// The int type has the same width as size_t on all supported platforms, so non-negative values fit.
+func checkIntSizes(values ...int) error {
+	for _, value := range values {
+		if value < 0 {
+			return ErrSizeOutOfRange
+		}
+	}
+	return nil
+}
+
+// checkedIntProduct returns the size in bytes of count elements of given size. ErrSizeOutOfRange is returned if
+// count is negative, or if the product does not fit in an int.
+func checkedIntProduct(count int, elementSize uintptr) (int, error) {
+	if count < 0 {
+		return 0, ErrSizeOutOfRange
+	}
+	if (elementSize != 0) && (uint64(count) > uint64(math.MaxInt)/uint64(elementSize)) {
+		return 0, ErrSizeOutOfRange
+	}
+	return count * int(elementSize), nil
+}
+
+// elementsSize returns the size in bytes of count elements of type T, see checkedIntProduct().
+func elementsSize[T any](count int) (int, error) {
+	var zero T
+	return checkedIntProduct(count, unsafe.Sizeof(zero))
+}
@@ -0,0 +1,18 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockNegativeSizeIsRejected(t *testing.T) {
+	context, _, _ := mockQueue(t)
+	_, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, -1, nil)
+	if !errors.Is(err, cl.ErrSizeOutOfRange) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package cl30_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestCheckedSize(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		name  string
+		value int64
+		ok    bool
+	}{
+		{name: "zero", value: 0, ok: true},
+		{name: "positive", value: 1024, ok: true},
+		{name: "negative", value: -1, ok: false},
+		{name: "max", value: math.MaxInt64, ok: unsafe.Sizeof(uintptr(0)) == 8},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			size, err := cl.CheckedSize(tc.value)
+			if tc.ok && ((err != nil) || (size != uintptr(tc.value))) {
+				t.Errorf("CheckedSize() = %d, %v, want %d", size, err, tc.value)
+			}
+			if !tc.ok && !errors.Is(err, cl.ErrSizeOutOfRange) {
+				t.Errorf("CheckedSize() = %d, %v, want ErrSizeOutOfRange", size, err)
+			}
+		})
+	}
+}
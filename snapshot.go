@@ -0,0 +1,389 @@
+package cl30
+
+import "unsafe"
+
+// Snapshot queries all known information values of the given handle and returns them, decoded into their
+// respective Go types. The keys of the returned map are the names of the information constants,
+// for example "DeviceNameInfo".
+//
+// Supported handle types are PlatformID, DeviceID, Context, CommandQueue, MemObject, Program, Kernel, Event,
+// and Sampler. For memory objects, the image or pipe specific information is included as well, depending on
+// the type of the memory object. Information that requires further parameters, such as build information per
+// device or kernel argument information, is not included.
+//
+// Information that can not be queried, for example because it is not supported by the underlying implementation,
+// is omitted from the result. An error is returned if the handle type is not supported, or if not a single value
+// could be queried.
+func Snapshot(handle any) (map[string]any, error) {
+	var entries []snapshotEntry
+	switch typed := handle.(type) {
+	case PlatformID:
+		entries = platformSnapshotEntries(typed)
+	case DeviceID:
+		entries = deviceSnapshotEntries(typed)
+	case Context:
+		entries = contextSnapshotEntries(typed)
+	case CommandQueue:
+		entries = commandQueueSnapshotEntries(typed)
+	case MemObject:
+		entries = memObjectSnapshotEntries(typed)
+	case Program:
+		entries = programSnapshotEntries(typed)
+	case Kernel:
+		entries = kernelSnapshotEntries(typed)
+	case Event:
+		entries = eventSnapshotEntries(typed)
+	case Sampler:
+		entries = samplerSnapshotEntries(typed)
+	default:
+		return nil, ErrUnsupportedHandleType
+	}
+	values := make(map[string]any)
+	var firstErr error
+	for _, entry := range entries {
+		value, err := entry.decode()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		values[entry.name] = value
+	}
+	if (len(values) == 0) && (firstErr != nil) {
+		return nil, firstErr
+	}
+	return values, nil
+}
+
+type snapshotEntry struct {
+	name   string
+	decode func() (any, error)
+}
+
+func decodeString(load infoLoader) func() (any, error) {
+	return func() (any, error) {
+		return queryString(load)
+	}
+}
+
+func decodeValue[T any](load infoLoader) func() (any, error) {
+	return func() (any, error) {
+		return queryValue[T](load)
+	}
+}
+
+func decodeSlice[T any](load infoLoader) func() (any, error) {
+	return func() (any, error) {
+		return querySlice[T](load)
+	}
+}
+
+func platformSnapshotEntries(id PlatformID) []snapshotEntry {
+	load := func(paramName PlatformInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return PlatformInfo(id, paramName, paramSize, paramValue)
+		}
+	}
+	return []snapshotEntry{
+		{"PlatformNameInfo", decodeString(load(PlatformNameInfo))},
+		{"PlatformVendorInfo", decodeString(load(PlatformVendorInfo))},
+		{"PlatformProfileInfo", decodeString(load(PlatformProfileInfo))},
+		{"PlatformVersionInfo", decodeString(load(PlatformVersionInfo))},
+		{"PlatformNumericVersionInfo", decodeValue[Version](load(PlatformNumericVersionInfo))},
+		{"PlatformExtensionsInfo", decodeString(load(PlatformExtensionsInfo))},
+		{"PlatformExtensionsWithVersionInfo", decodeSlice[NameVersion](load(PlatformExtensionsWithVersionInfo))},
+		{"PlatformHostTimerResolutionInfo", decodeValue[uint64](load(PlatformHostTimerResolutionInfo))},
+	}
+}
+
+func deviceSnapshotEntries(id DeviceID) []snapshotEntry {
+	load := func(paramName DeviceInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return deviceInfo(id, paramName, paramSize, paramValue)
+		}
+	}
+	return []snapshotEntry{
+		{"DeviceAddressBitsInfo", decodeValue[uint32](load(DeviceAddressBitsInfo))},
+		{"DeviceAtomicFenceCapabilitiesInfo", decodeValue[DeviceAtomicCapabilitiesFlags](load(DeviceAtomicFenceCapabilitiesInfo))},
+		{"DeviceAtomicMemoryCapabilitiesInfo", decodeValue[DeviceAtomicCapabilitiesFlags](load(DeviceAtomicMemoryCapabilitiesInfo))},
+		{"DeviceAvailableInfo", decodeValue[Bool](load(DeviceAvailableInfo))},
+		{"DeviceBuiltInKernelsInfo", decodeString(load(DeviceBuiltInKernelsInfo))},
+		{"DeviceCompilerAvailableInfo", decodeValue[Bool](load(DeviceCompilerAvailableInfo))},
+		{"DeviceDeviceEnqueueCapabilitiesInfo", decodeValue[DeviceDeviceEnqueueCapabilitiesFlags](load(DeviceDeviceEnqueueCapabilitiesInfo))},
+		{"DeviceDoubleFpConfigInfo", decodeValue[DeviceFpConfigFlags](load(DeviceDoubleFpConfigInfo))},
+		{"DeviceEndianLittleInfo", decodeValue[Bool](load(DeviceEndianLittleInfo))},
+		{"DeviceErrorCorrectionSupportInfo", decodeValue[Bool](load(DeviceErrorCorrectionSupportInfo))},
+		{"DeviceExecutionCapabilitiesInfo", decodeValue[DeviceExecCapabilitiesFlags](load(DeviceExecutionCapabilitiesInfo))},
+		{"DeviceExtensionsInfo", decodeString(load(DeviceExtensionsInfo))},
+		{"DeviceExtensionsWithVersionInfo", decodeSlice[NameVersion](load(DeviceExtensionsWithVersionInfo))},
+		{"DeviceGenericAddressSpaceSupportInfo", decodeValue[Bool](load(DeviceGenericAddressSpaceSupportInfo))},
+		{"DeviceGlobalMemCacheSizeInfo", decodeValue[uint64](load(DeviceGlobalMemCacheSizeInfo))},
+		{"DeviceHostUnifiedMemoryInfo", decodeValue[Bool](load(DeviceHostUnifiedMemoryInfo))},
+		{"DeviceGlobalMemCacheTypeInfo", decodeValue[DeviceMemCacheTypeEnum](load(DeviceGlobalMemCacheTypeInfo))},
+		{"DeviceGlobalMemCachelineSizeInfo", decodeValue[uint32](load(DeviceGlobalMemCachelineSizeInfo))},
+		{"DeviceGlobalMemSizeInfo", decodeValue[uint64](load(DeviceGlobalMemSizeInfo))},
+		{"DeviceGlobalVariablePreferredTotalSizeInfo", decodeValue[uintptr](load(DeviceGlobalVariablePreferredTotalSizeInfo))},
+		{"DeviceIlVersionInfo", decodeString(load(DeviceIlVersionInfo))},
+		{"DeviceIlsWithVersionInfo", decodeSlice[NameVersion](load(DeviceIlsWithVersionInfo))},
+		{"DeviceImage2dMaxHeightInfo", decodeValue[uintptr](load(DeviceImage2dMaxHeightInfo))},
+		{"DeviceImage2dMaxWidthInfo", decodeValue[uintptr](load(DeviceImage2dMaxWidthInfo))},
+		{"DeviceImage3dMaxDepthInfo", decodeValue[uintptr](load(DeviceImage3dMaxDepthInfo))},
+		{"DeviceImage3dMaxHeightInfo", decodeValue[uintptr](load(DeviceImage3dMaxHeightInfo))},
+		{"DeviceImage3dMaxWidthInfo", decodeValue[uintptr](load(DeviceImage3dMaxWidthInfo))},
+		{"DeviceImageBaseAddressAlignmentInfo", decodeValue[uint32](load(DeviceImageBaseAddressAlignmentInfo))},
+		{"DeviceImageMaxArraySizeInfo", decodeValue[uintptr](load(DeviceImageMaxArraySizeInfo))},
+		{"DeviceImageMaxBufferSizeInfo", decodeValue[uintptr](load(DeviceImageMaxBufferSizeInfo))},
+		{"DeviceImagePitchAlignmentInfo", decodeValue[uint32](load(DeviceImagePitchAlignmentInfo))},
+		{"DeviceImageSupportInfo", decodeValue[Bool](load(DeviceImageSupportInfo))},
+		{"DeviceLatestConformanceVersionPassedInfo", decodeString(load(DeviceLatestConformanceVersionPassedInfo))},
+		{"DeviceLinkerAvailableInfo", decodeValue[Bool](load(DeviceLinkerAvailableInfo))},
+		{"DeviceLocalMemSizeInfo", decodeValue[uint64](load(DeviceLocalMemSizeInfo))},
+		{"DeviceLocalMemTypeInfo", decodeValue[DeviceLocalMemTypeEnum](load(DeviceLocalMemTypeInfo))},
+		{"DeviceMaxClockFrequencyInfo", decodeValue[uint32](load(DeviceMaxClockFrequencyInfo))},
+		{"DeviceMaxComputeUnitsInfo", decodeValue[uint32](load(DeviceMaxComputeUnitsInfo))},
+		{"DeviceMaxConstantArgsInfo", decodeValue[uint32](load(DeviceMaxConstantArgsInfo))},
+		{"DeviceMaxConstantBufferSizeInfo", decodeValue[uint64](load(DeviceMaxConstantBufferSizeInfo))},
+		{"DeviceMaxGlobalVariableSizeInfo", decodeValue[uintptr](load(DeviceMaxGlobalVariableSizeInfo))},
+		{"DeviceMaxMemAllocSizeInfo", decodeValue[uint64](load(DeviceMaxMemAllocSizeInfo))},
+		{"DeviceMaxNumSubGroupsInfo", decodeValue[uint32](load(DeviceMaxNumSubGroupsInfo))},
+		{"DeviceMaxOnDeviceEventsInfo", decodeValue[uint32](load(DeviceMaxOnDeviceEventsInfo))},
+		{"DeviceMaxOnDeviceQueuesInfo", decodeValue[uint32](load(DeviceMaxOnDeviceQueuesInfo))},
+		{"DeviceMaxParameterSizeInfo", decodeValue[uintptr](load(DeviceMaxParameterSizeInfo))},
+		{"DeviceMaxPipeArgsInfo", decodeValue[uint32](load(DeviceMaxPipeArgsInfo))},
+		{"DeviceMaxReadImageArgsInfo", decodeValue[uint32](load(DeviceMaxReadImageArgsInfo))},
+		{"DeviceMaxReadWriteImageArgsInfo", decodeValue[uint32](load(DeviceMaxReadWriteImageArgsInfo))},
+		{"DeviceMaxSamplersInfo", decodeValue[uint32](load(DeviceMaxSamplersInfo))},
+		{"DeviceMaxWorkGroupSizeInfo", decodeValue[uintptr](load(DeviceMaxWorkGroupSizeInfo))},
+		{"DeviceMaxWorkItemDimensionsInfo", decodeValue[uint32](load(DeviceMaxWorkItemDimensionsInfo))},
+		{"DeviceMaxWorkItemSizesInfo", decodeSlice[uintptr](load(DeviceMaxWorkItemSizesInfo))},
+		{"DeviceMaxWriteImageArgsInfo", decodeValue[uint32](load(DeviceMaxWriteImageArgsInfo))},
+		{"DeviceMemBaseAddrAlignInfo", decodeValue[uint32](load(DeviceMemBaseAddrAlignInfo))},
+		{"DeviceNameInfo", decodeString(load(DeviceNameInfo))},
+		{"DeviceNativeVectorWidthCharInfo", decodeValue[uint32](load(DeviceNativeVectorWidthCharInfo))},
+		{"DeviceNativeVectorWidthDoubleInfo", decodeValue[uint32](load(DeviceNativeVectorWidthDoubleInfo))},
+		{"DeviceNativeVectorWidthFloatInfo", decodeValue[uint32](load(DeviceNativeVectorWidthFloatInfo))},
+		{"DeviceNativeVectorWidthHalfInfo", decodeValue[uint32](load(DeviceNativeVectorWidthHalfInfo))},
+		{"DeviceNativeVectorWidthIntInfo", decodeValue[uint32](load(DeviceNativeVectorWidthIntInfo))},
+		{"DeviceNativeVectorWidthLongInfo", decodeValue[uint32](load(DeviceNativeVectorWidthLongInfo))},
+		{"DeviceNativeVectorWidthShortInfo", decodeValue[uint32](load(DeviceNativeVectorWidthShortInfo))},
+		{"DeviceNonUniformWorkGroupSupportInfo", decodeValue[Bool](load(DeviceNonUniformWorkGroupSupportInfo))},
+		{"DeviceNumericVersionInfo", decodeValue[Version](load(DeviceNumericVersionInfo))},
+		{"DeviceOpenClCAllVersionsInfo", decodeSlice[NameVersion](load(DeviceOpenClCAllVersionsInfo))},
+		{"DeviceOpenClCFeaturesInfo", decodeSlice[NameVersion](load(DeviceOpenClCFeaturesInfo))},
+		{"DeviceOpenClCVersionInfo", decodeString(load(DeviceOpenClCVersionInfo))},
+		{"DeviceParentDeviceInfo", decodeValue[DeviceID](load(DeviceParentDeviceInfo))},
+		{"DevicePartitionAffinityDomainInfo", decodeValue[DeviceAffinityDomainFlags](load(DevicePartitionAffinityDomainInfo))},
+		{"DevicePartitionMaxSubDevicesInfo", decodeValue[uint32](load(DevicePartitionMaxSubDevicesInfo))},
+		{"DevicePartitionPropertiesInfo", decodeSlice[uintptr](load(DevicePartitionPropertiesInfo))},
+		{"DevicePartitionTypeInfo", decodeSlice[uintptr](load(DevicePartitionTypeInfo))},
+		{"DevicePipeMaxActiveReservationsInfo", decodeValue[uint32](load(DevicePipeMaxActiveReservationsInfo))},
+		{"DevicePipeMaxPacketSizeInfo", decodeValue[uint32](load(DevicePipeMaxPacketSizeInfo))},
+		{"DevicePipeSupportInfo", decodeValue[Bool](load(DevicePipeSupportInfo))},
+		{"DevicePlatformInfo", decodeValue[PlatformID](load(DevicePlatformInfo))},
+		{"DevicePreferredGlobalAtomicAlignmentInfo", decodeValue[uint32](load(DevicePreferredGlobalAtomicAlignmentInfo))},
+		{"DevicePreferredInteropUserSyncInfo", decodeValue[Bool](load(DevicePreferredInteropUserSyncInfo))},
+		{"DevicePreferredLocalAtomicAlignmentInfo", decodeValue[uint32](load(DevicePreferredLocalAtomicAlignmentInfo))},
+		{"DevicePreferredPlatformAtomicAlignmentInfo", decodeValue[uint32](load(DevicePreferredPlatformAtomicAlignmentInfo))},
+		{"DevicePreferredVectorWidthCharInfo", decodeValue[uint32](load(DevicePreferredVectorWidthCharInfo))},
+		{"DevicePreferredVectorWidthDoubleInfo", decodeValue[uint32](load(DevicePreferredVectorWidthDoubleInfo))},
+		{"DevicePreferredVectorWidthFloatInfo", decodeValue[uint32](load(DevicePreferredVectorWidthFloatInfo))},
+		{"DevicePreferredVectorWidthHalfInfo", decodeValue[uint32](load(DevicePreferredVectorWidthHalfInfo))},
+		{"DevicePreferredVectorWidthIntInfo", decodeValue[uint32](load(DevicePreferredVectorWidthIntInfo))},
+		{"DevicePreferredVectorWidthLongInfo", decodeValue[uint32](load(DevicePreferredVectorWidthLongInfo))},
+		{"DevicePreferredVectorWidthShortInfo", decodeValue[uint32](load(DevicePreferredVectorWidthShortInfo))},
+		{"DevicePrintfBufferSizeInfo", decodeValue[uintptr](load(DevicePrintfBufferSizeInfo))},
+		{"DeviceProfileInfo", decodeString(load(DeviceProfileInfo))},
+		{"DeviceProfilingTimerResolutionInfo", decodeValue[uintptr](load(DeviceProfilingTimerResolutionInfo))},
+		{"DeviceQueueOnDeviceMaxSizeInfo", decodeValue[uint32](load(DeviceQueueOnDeviceMaxSizeInfo))},
+		{"DeviceQueueOnDevicePreferredSizeInfo", decodeValue[uint32](load(DeviceQueueOnDevicePreferredSizeInfo))},
+		{"DeviceQueueOnDevicePropertiesInfo", decodeValue[CommandQueuePropertiesFlags](load(DeviceQueueOnDevicePropertiesInfo))},
+		{"DeviceQueueOnHostPropertiesInfo", decodeValue[CommandQueuePropertiesFlags](load(DeviceQueueOnHostPropertiesInfo))},
+		{"DeviceQueuePropertiesInfo", decodeValue[CommandQueuePropertiesFlags](load(DeviceQueuePropertiesInfo))},
+		{"DeviceReferenceCountInfo", decodeValue[uint32](load(DeviceReferenceCountInfo))},
+		{"DeviceSingleFpConfigInfo", decodeValue[DeviceFpConfigFlags](load(DeviceSingleFpConfigInfo))},
+		{"DeviceSubGroupIndependentForwardProgressInfo", decodeValue[Bool](load(DeviceSubGroupIndependentForwardProgressInfo))},
+		{"DeviceSvmCapabilitiesInfo", decodeValue[DeviceSvmCapabilitiesFlags](load(DeviceSvmCapabilitiesInfo))},
+		{"DeviceTypeInfo", decodeValue[DeviceTypeFlags](load(DeviceTypeInfo))},
+		{"DeviceVendorInfo", decodeString(load(DeviceVendorInfo))},
+		{"DeviceVendorIDInfo", decodeValue[uint32](load(DeviceVendorIDInfo))},
+		{"DeviceVersionInfo", decodeString(load(DeviceVersionInfo))},
+		{"DeviceWorkGroupCollectiveFunctionsSupportInfo", decodeValue[Bool](load(DeviceWorkGroupCollectiveFunctionsSupportInfo))},
+		{"DriverVersionInfo", decodeString(load(DriverVersionInfo))},
+	}
+}
+
+func contextSnapshotEntries(context Context) []snapshotEntry {
+	load := func(paramName ContextInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return ContextInfo(context, paramName, paramSize, paramValue)
+		}
+	}
+	return []snapshotEntry{
+		{"ContextReferenceCountInfo", decodeValue[uint32](load(ContextReferenceCountInfo))},
+		{"ContextDevicesInfo", decodeSlice[DeviceID](load(ContextDevicesInfo))},
+		{"ContextNumDevicesInfo", decodeValue[uint32](load(ContextNumDevicesInfo))},
+		{"ContextPropertiesInfo", decodeSlice[uintptr](load(ContextPropertiesInfo))},
+	}
+}
+
+func commandQueueSnapshotEntries(commandQueue CommandQueue) []snapshotEntry {
+	load := func(paramName CommandQueueInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return CommandQueueInfo(commandQueue, paramName, paramSize, paramValue)
+		}
+	}
+	return []snapshotEntry{
+		{"QueueContextInfo", decodeValue[Context](load(QueueContextInfo))},
+		{"QueueDeviceInfo", decodeValue[DeviceID](load(QueueDeviceInfo))},
+		{"QueueReferenceCountInfo", decodeValue[uint32](load(QueueReferenceCountInfo))},
+		{"QueuePropertiesInfo", decodeValue[uint64](load(QueuePropertiesInfo))},
+		{"QueuePropertiesArrayInfo", decodeSlice[uint64](load(QueuePropertiesArrayInfo))},
+		{"QueueSizeInfo", decodeValue[uint32](load(QueueSizeInfo))},
+		{"QueueDeviceDefaultInfo", decodeValue[CommandQueue](load(QueueDeviceDefaultInfo))},
+	}
+}
+
+func memObjectSnapshotEntries(mem MemObject) []snapshotEntry {
+	load := func(paramName MemObjectInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return MemObjectInfo(mem, paramName, paramSize, paramValue)
+		}
+	}
+	entries := []snapshotEntry{
+		{"MemTypeInfo", decodeValue[MemObjectType](load(MemTypeInfo))},
+		{"MemFlagsInfo", decodeValue[MemFlags](load(MemFlagsInfo))},
+		{"MemSizeInfo", decodeValue[uintptr](load(MemSizeInfo))},
+		{"MemHostPtrInfo", decodeValue[uintptr](load(MemHostPtrInfo))},
+		{"MemContextInfo", decodeValue[Context](load(MemContextInfo))},
+		{"MemOffsetInfo", decodeValue[uintptr](load(MemOffsetInfo))},
+		{"MemPropertiesInfo", decodeSlice[uint64](load(MemPropertiesInfo))},
+		{"MemMapCountInfo", decodeValue[uint32](load(MemMapCountInfo))},
+		{"MemReferenceCountInfo", decodeValue[uint32](load(MemReferenceCountInfo))},
+		{"MemAssociatedMemObjectInfo", decodeValue[MemObject](load(MemAssociatedMemObjectInfo))},
+		{"MemUsesSvmPointerInfo", decodeValue[Bool](load(MemUsesSvmPointerInfo))},
+	}
+	memType, err := queryValue[MemObjectType](load(MemTypeInfo))
+	if err != nil {
+		return entries
+	}
+	switch memType {
+	case MemObjectBufferType:
+	case MemObjectPipeType:
+		entries = append(entries, pipeSnapshotEntries(mem)...)
+	default:
+		entries = append(entries, imageSnapshotEntries(mem)...)
+	}
+	return entries
+}
+
+func imageSnapshotEntries(image MemObject) []snapshotEntry {
+	load := func(paramName ImageInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return imageInfo(image, paramName, paramSize, paramValue)
+		}
+	}
+	return []snapshotEntry{
+		{"ImageFormatInfo", decodeValue[ImageFormat](load(ImageFormatInfo))},
+		{"ImageElementSizeInfo", decodeValue[uintptr](load(ImageElementSizeInfo))},
+		{"ImageRowPitchInfo", decodeValue[uintptr](load(ImageRowPitchInfo))},
+		{"ImageSlicePitchInfo", decodeValue[uintptr](load(ImageSlicePitchInfo))},
+		{"ImageWidthInfo", decodeValue[uintptr](load(ImageWidthInfo))},
+		{"ImageHeightInfo", decodeValue[uintptr](load(ImageHeightInfo))},
+		{"ImageDepthInfo", decodeValue[uintptr](load(ImageDepthInfo))},
+		{"ImageArraySizeInfo", decodeValue[uintptr](load(ImageArraySizeInfo))},
+		{"ImageBufferInfo", decodeValue[MemObject](load(ImageBufferInfo))},
+		{"ImageNumMipLevelsInfo", decodeValue[uint32](load(ImageNumMipLevelsInfo))},
+		{"ImageNumSamplesInfo", decodeValue[uint32](load(ImageNumSamplesInfo))},
+	}
+}
+
+func pipeSnapshotEntries(pipe MemObject) []snapshotEntry {
+	load := func(paramName PipeInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return PipeInfo(pipe, paramName, paramSize, paramValue)
+		}
+	}
+	return []snapshotEntry{
+		{"PipePacketSizeInfo", decodeValue[uint32](load(PipePacketSizeInfo))},
+		{"PipeMaxPacketsInfo", decodeValue[uint32](load(PipeMaxPacketsInfo))},
+		{"PipePropertiesInfo", decodeSlice[uintptr](load(PipePropertiesInfo))},
+	}
+}
+
+func programSnapshotEntries(program Program) []snapshotEntry {
+	load := func(paramName ProgramInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return ProgramInfo(program, paramName, paramSize, paramValue)
+		}
+	}
+	return []snapshotEntry{
+		{"ProgramReferenceCountInfo", decodeValue[uint32](load(ProgramReferenceCountInfo))},
+		{"ProgramContextInfo", decodeValue[Context](load(ProgramContextInfo))},
+		{"ProgramNumDevicesInfo", decodeValue[uint32](load(ProgramNumDevicesInfo))},
+		{"ProgramDevicesInfo", decodeSlice[DeviceID](load(ProgramDevicesInfo))},
+		{"ProgramSourceInfo", decodeString(load(ProgramSourceInfo))},
+		{"ProgramBinarySizesInfo", decodeSlice[uintptr](load(ProgramBinarySizesInfo))},
+		{"ProgramNumKernelsInfo", decodeValue[uintptr](load(ProgramNumKernelsInfo))},
+		{"ProgramKernelNamesInfo", decodeString(load(ProgramKernelNamesInfo))},
+		{"ProgramScopeGlobalCtorsPresentInfo", decodeValue[Bool](load(ProgramScopeGlobalCtorsPresentInfo))},
+		{"ProgramScopeGlobalDtorsPresentInfo", decodeValue[Bool](load(ProgramScopeGlobalDtorsPresentInfo))},
+	}
+}
+
+func kernelSnapshotEntries(kernel Kernel) []snapshotEntry {
+	load := func(paramName KernelInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return KernelInfo(kernel, paramName, paramSize, paramValue)
+		}
+	}
+	return []snapshotEntry{
+		{"KernelFunctionNameInfo", decodeString(load(KernelFunctionNameInfo))},
+		{"KernelNumArgsInfo", decodeValue[uint32](load(KernelNumArgsInfo))},
+		{"KernelReferenceCountInfo", decodeValue[uint32](load(KernelReferenceCountInfo))},
+		{"KernelContextInfo", decodeValue[Context](load(KernelContextInfo))},
+		{"KernelProgramInfo", decodeValue[Program](load(KernelProgramInfo))},
+		{"KernelAttributesInfo", decodeString(load(KernelAttributesInfo))},
+	}
+}
+
+func eventSnapshotEntries(event Event) []snapshotEntry {
+	load := func(paramName EventInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return EventInfo(event, paramName, paramSize, paramValue)
+		}
+	}
+	loadProfiling := func(paramName EventProfilingInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return EventProfilingInfo(event, paramName, paramSize, paramValue)
+		}
+	}
+	return []snapshotEntry{
+		{"EventCommandQueueInfo", decodeValue[CommandQueue](load(EventCommandQueueInfo))},
+		{"EventContextInfo", decodeValue[Context](load(EventContextInfo))},
+		{"EventCommandTypeInfo", decodeValue[EventCommandType](load(EventCommandTypeInfo))},
+		{"EventReferenceCountInfo", decodeValue[uint32](load(EventReferenceCountInfo))},
+		{"EventCommandExecutionStatusInfo", decodeValue[EventCommandExecutionStatus](load(EventCommandExecutionStatusInfo))},
+		{"ProfilingCommandQueuedInfo", decodeValue[uint64](loadProfiling(ProfilingCommandQueuedInfo))},
+		{"ProfilingCommandSubmitInfo", decodeValue[uint64](loadProfiling(ProfilingCommandSubmitInfo))},
+		{"ProfilingCommandStartInfo", decodeValue[uint64](loadProfiling(ProfilingCommandStartInfo))},
+		{"ProfilingCommandEndInfo", decodeValue[uint64](loadProfiling(ProfilingCommandEndInfo))},
+		{"ProfilingCommandCompleteInfo", decodeValue[uint64](loadProfiling(ProfilingCommandCompleteInfo))},
+	}
+}
+
+func samplerSnapshotEntries(sampler Sampler) []snapshotEntry {
+	load := func(paramName SamplerInfoName) infoLoader {
+		return func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return SamplerInfo(sampler, paramName, paramSize, paramValue)
+		}
+	}
+	return []snapshotEntry{
+		{"SamplerReferenceCountInfo", decodeValue[uint32](load(SamplerReferenceCountInfo))},
+		{"SamplerContextInfo", decodeValue[Context](load(SamplerContextInfo))},
+		{"SamplerNormalizedCoordsInfo", decodeValue[Bool](load(SamplerNormalizedCoordsInfo))},
+		{"SamplerAddressingModeInfo", decodeValue[SamplerAddressingMode](load(SamplerAddressingModeInfo))},
+		{"SamplerFilterModeInfo", decodeValue[SamplerFilterMode](load(SamplerFilterModeInfo))},
+		{"SamplerPropertiesInfo", decodeSlice[uint64](load(SamplerPropertiesInfo))},
+	}
+}
@@ -0,0 +1,51 @@
+package cl30
+
+// ReleaseWithParent ties the release of the given sub-devices to the destruction of the context.
+// Once the context is destroyed, ReleaseDevice() is called for each of the devices. Errors of these calls
+// are ignored, as there is no caller to report them to.
+//
+// This avoids the common leak of sub-devices that outlive the context, which is typically their only user.
+// The caller must not release the devices on its own afterwards.
+//
+// Since: 3.0
+func ReleaseWithParent(context Context, devices ...DeviceID) error {
+	if len(devices) == 0 {
+		return nil
+	}
+	owned := append([]DeviceID{}, devices...)
+	return SetContextDestructorCallback(context, func() {
+		for _, device := range owned {
+			_ = ReleaseDevice(device)
+		}
+	})
+}
+
+// CreateContextForPartition partitions the device with CreateSubDevices(), and creates a context for the resulting
+// sub-devices with CreateContext(). The sub-devices are released with the context; see ReleaseWithParent().
+//
+// If any of the steps fails, the created objects are released again.
+//
+// Since: 3.0
+func CreateContextForPartition(id DeviceID, partition DevicePartitionProperty, callback *ContextErrorCallback,
+	properties ...ContextProperty) (Context, []DeviceID, error) {
+	devices, err := CreateSubDevices(id, partition)
+	if err != nil {
+		return 0, nil, err
+	}
+	releaseDevices := func() {
+		for _, device := range devices {
+			_ = ReleaseDevice(device)
+		}
+	}
+	context, err := CreateContext(devices, callback, properties...)
+	if err != nil {
+		releaseDevices()
+		return 0, nil, err
+	}
+	if err = ReleaseWithParent(context, devices...); err != nil {
+		_ = ReleaseContext(context)
+		releaseDevices()
+		return 0, nil, err
+	}
+	return context, devices, nil
+}
@@ -0,0 +1,45 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockReleaseWithParent(t *testing.T) {
+	_, device, _ := mockQueue(t)
+	context, err := cl.CreateContext([]cl.DeviceID{device}, nil)
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	cl.ResetCallStats()
+	cl.EnableCallMetrics(true)
+	defer cl.EnableCallMetrics(false)
+	releases := func() uint64 {
+		for _, metric := range cl.CallStats() {
+			if metric.Name == "clReleaseDevice" {
+				return metric.Count
+			}
+		}
+		return 0
+	}
+	if err = cl.ReleaseWithParent(context, device); err != nil {
+		t.Fatalf("ReleaseWithParent failed: %v", err)
+	}
+	if count := releases(); count != 0 {
+		t.Errorf("device released before context: %d", count)
+	}
+	if err = cl.ReleaseContext(context); err != nil {
+		t.Fatalf("ReleaseContext failed: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for (releases() == 0) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if count := releases(); count != 1 {
+		t.Errorf("unexpected device releases: %d", count)
+	}
+}
@@ -0,0 +1,98 @@
+package cl30
+
+// Names of the extensions that provide sub-group functionality.
+// Use DeviceSubgroupFeatures() to determine which of them a device supports.
+const (
+	// SubgroupsExtensionName is the official name of the extension that provides sub-groups, as well as
+	// the basic sub-group functions.
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_subgroups
+	SubgroupsExtensionName = "cl_khr_subgroups"
+	// SubgroupExtendedTypesExtensionName is the official name of the extension that allows the basic
+	// sub-group functions to operate on 8- and 16-bit integer types, as well as vector types.
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_subgroup_extended_types
+	SubgroupExtendedTypesExtensionName = "cl_khr_subgroup_extended_types"
+	// SubgroupNonUniformVoteExtensionName is the official name of the extension that provides voting functions
+	// for non-uniform control flow.
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_subgroup_non_uniform_vote
+	SubgroupNonUniformVoteExtensionName = "cl_khr_subgroup_non_uniform_vote"
+	// SubgroupBallotExtensionName is the official name of the extension that provides ballot functions.
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_subgroup_ballot
+	SubgroupBallotExtensionName = "cl_khr_subgroup_ballot"
+	// SubgroupNonUniformArithmeticExtensionName is the official name of the extension that provides arithmetic
+	// reductions and scans for non-uniform control flow.
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_subgroup_non_uniform_arithmetic
+	SubgroupNonUniformArithmeticExtensionName = "cl_khr_subgroup_non_uniform_arithmetic"
+	// SubgroupShuffleExtensionName is the official name of the extension that provides the general shuffle
+	// functions.
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_subgroup_shuffle
+	SubgroupShuffleExtensionName = "cl_khr_subgroup_shuffle"
+	// SubgroupShuffleRelativeExtensionName is the official name of the extension that provides the shuffle
+	// functions with relative offsets, such as sub_group_shuffle_up().
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_subgroup_shuffle_relative
+	SubgroupShuffleRelativeExtensionName = "cl_khr_subgroup_shuffle_relative"
+	// SubgroupClusteredReduceExtensionName is the official name of the extension that provides reductions over
+	// clusters of work-items within a sub-group.
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_subgroup_clustered_reduce
+	SubgroupClusteredReduceExtensionName = "cl_khr_subgroup_clustered_reduce"
+	// SubgroupRotateExtensionName is the official name of the extension that provides the rotate functions.
+	//
+	// See also: https://registry.khronos.org/OpenCL/specs/3.0-unified/html/OpenCL_Ext.html#cl_khr_subgroup_rotate
+	SubgroupRotateExtensionName = "cl_khr_subgroup_rotate"
+)
+
+// SubgroupFeatures describes the sub-group functionality that a device supports.
+// Each field is true if the device reports the corresponding extension.
+type SubgroupFeatures struct {
+	// Subgroups is true for SubgroupsExtensionName.
+	Subgroups bool
+	// ExtendedTypes is true for SubgroupExtendedTypesExtensionName.
+	ExtendedTypes bool
+	// NonUniformVote is true for SubgroupNonUniformVoteExtensionName.
+	NonUniformVote bool
+	// Ballot is true for SubgroupBallotExtensionName.
+	Ballot bool
+	// NonUniformArithmetic is true for SubgroupNonUniformArithmeticExtensionName.
+	NonUniformArithmetic bool
+	// Shuffle is true for SubgroupShuffleExtensionName.
+	Shuffle bool
+	// ShuffleRelative is true for SubgroupShuffleRelativeExtensionName.
+	ShuffleRelative bool
+	// ClusteredReduce is true for SubgroupClusteredReduceExtensionName.
+	ClusteredReduce bool
+	// Rotate is true for SubgroupRotateExtensionName.
+	Rotate bool
+}
+
+// SubgroupFeaturesFromExtensions determines the sub-group features from a space separated list of extension names,
+// as it is returned for DeviceExtensionsInfo.
+func SubgroupFeaturesFromExtensions(extensions string) SubgroupFeatures {
+	return SubgroupFeatures{
+		Subgroups:            containsExtension(extensions, SubgroupsExtensionName),
+		ExtendedTypes:        containsExtension(extensions, SubgroupExtendedTypesExtensionName),
+		NonUniformVote:       containsExtension(extensions, SubgroupNonUniformVoteExtensionName),
+		Ballot:               containsExtension(extensions, SubgroupBallotExtensionName),
+		NonUniformArithmetic: containsExtension(extensions, SubgroupNonUniformArithmeticExtensionName),
+		Shuffle:              containsExtension(extensions, SubgroupShuffleExtensionName),
+		ShuffleRelative:      containsExtension(extensions, SubgroupShuffleRelativeExtensionName),
+		ClusteredReduce:      containsExtension(extensions, SubgroupClusteredReduceExtensionName),
+		Rotate:               containsExtension(extensions, SubgroupRotateExtensionName),
+	}
+}
+
+// DeviceSubgroupFeatures queries the extensions of the device and returns the sub-group features it supports.
+// Kernel selection logic can use the result to pick the variant that matches the precise feature set of a device.
+func DeviceSubgroupFeatures(id DeviceID) (SubgroupFeatures, error) {
+	extensions, err := DeviceInfoString(id, DeviceExtensionsInfo)
+	if err != nil {
+		return SubgroupFeatures{}, err
+	}
+	return SubgroupFeaturesFromExtensions(extensions), nil
+}
@@ -0,0 +1,19 @@
+package cl30_test
+
+import (
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestSubgroupFeaturesFromExtensions(t *testing.T) {
+	t.Parallel()
+	features := cl.SubgroupFeaturesFromExtensions("cl_khr_fp64 cl_khr_subgroups cl_khr_subgroup_ballot  cl_khr_subgroup_shuffle_relative")
+	expected := cl.SubgroupFeatures{Subgroups: true, Ballot: true, ShuffleRelative: true}
+	if features != expected {
+		t.Errorf("unexpected features: %+v", features)
+	}
+	if (cl.SubgroupFeaturesFromExtensions("cl_khr_subgroup_shuffle_relative") != cl.SubgroupFeatures{ShuffleRelative: true}) {
+		t.Errorf("prefix of extension name must not match")
+	}
+}
@@ -27,6 +27,10 @@ type SvmMemFlags C.cl_mem_flags
 // Since: 2.0
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSVMAlloc.html
 func SvmAlloc(context Context, flags SvmMemFlags, size int, alignment uint32) (unsafe.Pointer, error) {
+	defer observeCall("clSVMAlloc")()
+	if err := checkIntSizes(size); err != nil {
+		return nil, err
+	}
 	ptr := C.clSVMAlloc(
 		context.handle(),
 		C.cl_svm_mem_flags(flags),
@@ -35,6 +39,7 @@ func SvmAlloc(context Context, flags SvmMemFlags, size int, alignment uint32) (u
 	if ptr == nil {
 		return nil, ErrOutOfMemory
 	}
+	registerSvmAllocation(ptr, size, flags)
 	return ptr, nil
 }
 
@@ -47,6 +52,8 @@ func SvmAlloc(context Context, flags SvmMemFlags, size int, alignment uint32) (u
 // Since: 2.0
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clSVMFree.html
 func SvmFree(context Context, ptr unsafe.Pointer) {
+	defer observeCall("clSVMFree")()
+	unregisterSvmAllocations(ptr)
 	C.clSVMFree(context.handle(), ptr)
 }
 
@@ -54,8 +61,12 @@ func SvmFree(context Context, ptr unsafe.Pointer) {
 // memory pointer.
 //
 // Since: 2.0
-// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueSvmFree.html
+// See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueSVMFree.html
 func EnqueueSvmFree(commandQueue CommandQueue, ptrs []unsafe.Pointer, callback func(CommandQueue, []unsafe.Pointer), waitList []Event, event *Event) error {
+	defer observeCall("clEnqueueSVMFree")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
 	var callbackUserData userData
 	if callback != nil {
 		var err error
@@ -64,25 +75,34 @@ func EnqueueSvmFree(commandQueue CommandQueue, ptrs []unsafe.Pointer, callback f
 			return err
 		}
 	}
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		callbackUserData.Delete()
+		return err
 	}
+	defer releaseWaitList()
 	ptrAddresses := make([]uintptr, len(ptrs))
 	for i, ptr := range ptrs {
 		ptrAddresses[i] = uintptr(ptr)
 	}
+	var rawPtrs unsafe.Pointer
+	if len(ptrAddresses) > 0 {
+		rawPtrs = unsafe.Pointer(&ptrAddresses[0])
+	}
 	status := C.cl30EnqueueSVMFree(
 		commandQueue.handle(),
 		C.cl_uint(len(ptrs)),
-		unsafe.Pointer(&ptrAddresses[0]),
+		rawPtrs,
 		callbackUserData.ptr,
 		C.cl_uint(len(waitList)),
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueSVMFree", status, "commandQueue", commandQueue)
 	}
+	unregisterSvmAllocations(ptrs...)
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -92,7 +112,19 @@ func cl30GoSvmFreeCallback(commandQueue CommandQueue, svmPointerCount C.cl_uint,
 	callback := callbackUserData.Value().(func(CommandQueue, []unsafe.Pointer))
 	callbackUserData.Delete()
 	ptrs := unsafe.Slice((*unsafe.Pointer)(svmPointers), int(svmPointerCount))
-	callback(commandQueue, ptrs)
+	runInlineCallback(func() { callback(commandQueue, ptrs) })
+}
+
+// svmMemcpyArgs are the arguments of clEnqueueSVMMemcpy() for runBlocking().
+type svmMemcpyArgs struct {
+	commandQueue C.cl_command_queue
+	blocking     C.cl_bool
+	dstPtr       unsafe.Pointer
+	srcPtr       unsafe.Pointer
+	size         C.size_t
+	numEvents    C.cl_uint
+	waitList     *C.cl_event
+	event        *C.cl_event
 }
 
 // EnqueueSvmMemcpy enqueues a command to do a memcpy operation.
@@ -101,22 +133,42 @@ func cl30GoSvmFreeCallback(commandQueue CommandQueue, svmPointerCount C.cl_uint,
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueSVMMemcpy.html
 func EnqueueSvmMemcpy(commandQueue CommandQueue, blocking bool, dstPtr unsafe.Pointer, srcPtr unsafe.Pointer, size int,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueSVMMemcpy")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
-	status := C.clEnqueueSVMMemcpy(
-		commandQueue.handle(),
-		C.cl_bool(BoolFrom(blocking)),
-		dstPtr,
-		srcPtr,
-		C.size_t(size),
-		C.cl_uint(len(waitList)),
-		(*C.cl_event)(rawWaitList),
-		(*C.cl_event)(unsafe.Pointer(event)))
+	if err := checkIntSizes(size); err != nil {
+		return err
+	}
+	if blocking {
+		err := checkBlockingAllowed()
+		if err != nil {
+			return err
+		}
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	status := runBlocking(blocking, svmMemcpyArgs{
+		commandQueue: commandQueue.handle(),
+		blocking:     C.cl_bool(BoolFrom(blocking)),
+		dstPtr:       dstPtr,
+		srcPtr:       srcPtr,
+		size:         C.size_t(size),
+		numEvents:    C.cl_uint(len(waitList)),
+		waitList:     (*C.cl_event)(rawWaitList),
+		event:        (*C.cl_event)(unsafe.Pointer(event)),
+	}, func(args svmMemcpyArgs) C.cl_int {
+		return C.clEnqueueSVMMemcpy(args.commandQueue, args.blocking, args.dstPtr, args.srcPtr, args.size,
+			args.numEvents, args.waitList, args.event)
+	})
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueSVMMemcpy", status, "commandQueue", commandQueue, "size", size)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -128,10 +180,18 @@ func EnqueueSvmMemcpy(commandQueue CommandQueue, blocking bool, dstPtr unsafe.Po
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueSVMMemFill.html
 func EnqueueSvmMemFill(commandQueue CommandQueue, svmPtr, pattern unsafe.Pointer, patternSize, size int,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueSVMMemFill")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	if err := checkIntSizes(patternSize, size); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
 	}
+	defer releaseWaitList()
 	status := C.clEnqueueSVMMemFill(
 		commandQueue.handle(),
 		svmPtr,
@@ -142,33 +202,67 @@ func EnqueueSvmMemFill(commandQueue CommandQueue, svmPtr, pattern unsafe.Pointer
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueSVMMemFill", status, "commandQueue", commandQueue, "size", size)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
+// svmMapArgs are the arguments of clEnqueueSVMMap() for runBlocking().
+type svmMapArgs struct {
+	commandQueue C.cl_command_queue
+	blocking     C.cl_bool
+	flags        C.cl_map_flags
+	svmPtr       unsafe.Pointer
+	size         C.size_t
+	numEvents    C.cl_uint
+	waitList     *C.cl_event
+	event        *C.cl_event
+}
+
 // EnqueueSvmMap enqueues a command that will allow the host to update a region of an SVM buffer.
 //
 // Since: 2.0
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueSVMMap.html
-func EnqueueSvmMap(commandQueue CommandQueue, blocking bool, flags MemFlags, svmPtr unsafe.Pointer, size int,
+func EnqueueSvmMap(commandQueue CommandQueue, blocking bool, flags MapFlags, svmPtr unsafe.Pointer, size int,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueSVMMap")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
-	status := C.clEnqueueSVMMap(
-		commandQueue.handle(),
-		C.cl_bool(BoolFrom(blocking)),
-		C.cl_map_flags(flags),
-		svmPtr,
-		C.size_t(size),
-		C.cl_uint(len(waitList)),
-		(*C.cl_event)(rawWaitList),
-		(*C.cl_event)(unsafe.Pointer(event)))
+	if err := checkIntSizes(size); err != nil {
+		return err
+	}
+	if blocking {
+		err := checkBlockingAllowed()
+		if err != nil {
+			return err
+		}
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
+	status := runBlocking(blocking, svmMapArgs{
+		commandQueue: commandQueue.handle(),
+		blocking:     C.cl_bool(BoolFrom(blocking)),
+		flags:        C.cl_map_flags(flags),
+		svmPtr:       svmPtr,
+		size:         C.size_t(size),
+		numEvents:    C.cl_uint(len(waitList)),
+		waitList:     (*C.cl_event)(rawWaitList),
+		event:        (*C.cl_event)(unsafe.Pointer(event)),
+	}, func(args svmMapArgs) C.cl_int {
+		return C.clEnqueueSVMMap(args.commandQueue, args.blocking, args.flags, args.svmPtr, args.size,
+			args.numEvents, args.waitList, args.event)
+	})
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueSVMMap", status, "commandQueue", commandQueue, "flags", flags, "size", size)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -178,10 +272,15 @@ func EnqueueSvmMap(commandQueue CommandQueue, blocking bool, flags MemFlags, svm
 // Since: 2.0
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueSVMUnmap.html
 func EnqueueSvmUnmap(commandQueue CommandQueue, svmPtr unsafe.Pointer, waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueSVMUnmap")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
+	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
 	}
+	defer releaseWaitList()
 	status := C.clEnqueueSVMUnmap(
 		commandQueue.handle(),
 		svmPtr,
@@ -189,8 +288,10 @@ func EnqueueSvmUnmap(commandQueue CommandQueue, svmPtr unsafe.Pointer, waitList
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueSVMUnmap", status, "commandQueue", commandQueue)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
 
@@ -201,14 +302,23 @@ func EnqueueSvmUnmap(commandQueue CommandQueue, svmPtr unsafe.Pointer, waitList
 // See also: https://registry.khronos.org/OpenCL/sdk/3.0/docs/man/html/clEnqueueSVMMigrateMem.html
 func EnqueueSvmMigrateMem(commandQueue CommandQueue, svmPtrs []unsafe.Pointer, sizes []int, flags MemMigrationFlags,
 	waitList []Event, event *Event) error {
-	var rawWaitList unsafe.Pointer
-	if len(waitList) > 0 {
-		rawWaitList = unsafe.Pointer(&waitList[0])
+	defer observeCall("clEnqueueSVMMigrateMem")()
+	if err := injectedEnqueueFault(); err != nil {
+		return err
 	}
+	rawWaitList, releaseWaitList, err := rawWaitListFor(commandQueue, waitList)
+	if err != nil {
+		return err
+	}
+	defer releaseWaitList()
 	svmPtrAddresses := make([]uintptr, len(svmPtrs))
 	for i, svmPtr := range svmPtrs {
 		svmPtrAddresses[i] = uintptr(svmPtr)
 	}
+	var rawSvmPtrs unsafe.Pointer
+	if len(svmPtrAddresses) > 0 {
+		rawSvmPtrs = unsafe.Pointer(&svmPtrAddresses[0])
+	}
 	var sizesPtr unsafe.Pointer
 	if len(sizes) > 0 {
 		sizesPtr = unsafe.Pointer(&sizes[0])
@@ -216,14 +326,50 @@ func EnqueueSvmMigrateMem(commandQueue CommandQueue, svmPtrs []unsafe.Pointer, s
 	status := C.cl30EnqueueSVMMigrateMem(
 		commandQueue.handle(),
 		C.cl_uint(len(svmPtrs)),
-		unsafe.Pointer(&svmPtrAddresses[0]),
+		rawSvmPtrs,
 		(*C.size_t)(sizesPtr),
 		C.cl_mem_migration_flags(flags),
 		C.cl_uint(len(waitList)),
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return operationError("clEnqueueSVMMigrateMem", status, "commandQueue", commandQueue, "flags", flags)
 	}
+	trackEnqueuedEvent(event)
+	autoFlushAfterEnqueue(commandQueue)
 	return nil
 }
+
+// SvmMapSlice maps count elements of type T of an SVM buffer for host access, and returns them as a slice.
+// The mapping is blocking. The slice is valid until the region is unmapped again with SvmUnmap().
+//
+// Mapping is only necessary for coarse-grained SVM buffers. If ptr is within an allocation of SvmAlloc() that was
+// made with MemSvmFineGrainBufferFlag, ErrSvmMapNotRequired is returned, unless force is set. Pointers that are not
+// within a known allocation are always mapped.
+//
+// Since: 2.0
+func SvmMapSlice[T any](commandQueue CommandQueue, ptr unsafe.Pointer, count int, flags MapFlags, force bool) ([]T, error) {
+	size, err := elementsSize[T](count)
+	if err != nil {
+		return nil, err
+	}
+	if !force {
+		_, allocation, known := svmAllocationOf(ptr)
+		if known && ((allocation.flags & MemSvmFineGrainBufferFlag) != 0) {
+			return nil, ErrSvmMapNotRequired
+		}
+	}
+	err = EnqueueSvmMap(commandQueue, true, flags, ptr, size, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unsafe.Slice((*T)(ptr), count), nil
+}
+
+// SvmUnmap enqueues the unmapping of a region that was mapped with SvmMapSlice().
+// The slice returned by SvmMapSlice() must no longer be used after this call.
+//
+// Since: 2.0
+func SvmUnmap(commandQueue CommandQueue, ptr unsafe.Pointer) error {
+	return EnqueueSvmUnmap(commandQueue, ptr, nil, nil)
+}
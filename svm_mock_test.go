@@ -0,0 +1,70 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockSvmMapSliceFollowsAllocationFlags(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{{Devices: []cl.MockDevice{{
+		SvmCapabilities: cl.DeviceSvmCoarseGrainBuffer | cl.DeviceSvmFineGrainBuffer,
+	}}}})
+	t.Cleanup(func() { cl.SetMockPlatforms(nil) })
+	platforms, err := cl.PlatformIDs()
+	if err != nil {
+		t.Fatalf("PlatformIDs failed: %v", err)
+	}
+	devices, err := cl.DeviceIDs(platforms[0], cl.DeviceTypeAll)
+	if err != nil {
+		t.Fatalf("DeviceIDs failed: %v", err)
+	}
+	context, err := cl.CreateContext(devices, nil)
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseContext(context) }()
+	queue, err := cl.CreateCommandQueueWithProperties(context, devices[0])
+	if err != nil {
+		t.Fatalf("CreateCommandQueueWithProperties failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseCommandQueue(queue) }()
+
+	coarse, err := cl.SvmAlloc(context, cl.MemReadWriteFlag, 16, 0)
+	if err != nil {
+		t.Fatalf("SvmAlloc failed: %v", err)
+	}
+	defer cl.SvmFree(context, coarse)
+	values, err := cl.SvmMapSlice[uint32](queue, coarse, 4, cl.MapWrite, false)
+	if err != nil {
+		t.Fatalf("coarse-grained buffer must be mapped even though the device supports fine-grained ones: %v", err)
+	}
+	if len(values) != 4 {
+		t.Errorf("unexpected slice length: %d", len(values))
+	}
+	if err := cl.SvmUnmap(queue, coarse); err != nil {
+		t.Errorf("SvmUnmap failed: %v", err)
+	}
+
+	fine, err := cl.SvmAlloc(context, cl.MemReadWriteFlag|cl.MemSvmFineGrainBufferFlag, 16, 0)
+	if err != nil {
+		t.Fatalf("SvmAlloc failed: %v", err)
+	}
+	defer cl.SvmFree(context, fine)
+	if _, err := cl.SvmMapSlice[uint32](queue, fine, 4, cl.MapRead, false); !errors.Is(err, cl.ErrSvmMapNotRequired) {
+		t.Errorf("expected ErrSvmMapNotRequired for fine-grained buffer, got %v", err)
+	}
+	if _, err := cl.SvmMapSlice[uint32](queue, unsafe.Add(fine, 4), 2, cl.MapRead, false); !errors.Is(err, cl.ErrSvmMapNotRequired) {
+		t.Errorf("expected ErrSvmMapNotRequired within fine-grained buffer, got %v", err)
+	}
+	if _, err := cl.SvmMapSlice[uint32](queue, fine, 4, cl.MapRead, true); err != nil {
+		t.Errorf("forced mapping failed: %v", err)
+	}
+	if err := cl.SvmUnmap(queue, fine); err != nil {
+		t.Errorf("SvmUnmap failed: %v", err)
+	}
+}
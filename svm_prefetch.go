@@ -0,0 +1,96 @@
+package cl30
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// svmAllocations registers the allocations of SvmAlloc() with their size and flags, until they are freed.
+// This allows functions to validate ranges of SVM pointers.
+var svmAllocations = struct {
+	mutex   sync.RWMutex
+	entries map[uintptr]svmAllocation
+}{
+	entries: make(map[uintptr]svmAllocation),
+}
+
+type svmAllocation struct {
+	size  uintptr
+	flags SvmMemFlags
+}
+
+func registerSvmAllocation(ptr unsafe.Pointer, size int, flags SvmMemFlags) {
+	svmAllocations.mutex.Lock()
+	defer svmAllocations.mutex.Unlock()
+	svmAllocations.entries[uintptr(ptr)] = svmAllocation{size: uintptr(size), flags: flags}
+}
+
+func unregisterSvmAllocations(ptrs ...unsafe.Pointer) {
+	svmAllocations.mutex.Lock()
+	defer svmAllocations.mutex.Unlock()
+	for _, ptr := range ptrs {
+		delete(svmAllocations.entries, uintptr(ptr))
+	}
+}
+
+// svmAllocationRemainder returns the number of bytes from ptr to the end of the registered allocation that
+// contains ptr. False is returned if ptr is not within a registered allocation.
+func svmAllocationRemainder(ptr unsafe.Pointer) (uintptr, bool) {
+	base, allocation, known := svmAllocationOf(ptr)
+	if !known {
+		return 0, false
+	}
+	return allocation.size - (uintptr(ptr) - base), true
+}
+
+// svmAllocationOf returns the base address and the registered allocation that contains ptr.
+// False is returned if ptr is not within a registered allocation.
+func svmAllocationOf(ptr unsafe.Pointer) (uintptr, svmAllocation, bool) {
+	address := uintptr(ptr)
+	svmAllocations.mutex.RLock()
+	defer svmAllocations.mutex.RUnlock()
+	if allocation, known := svmAllocations.entries[address]; known {
+		return address, allocation, true
+	}
+	for base, allocation := range svmAllocations.entries {
+		if (address > base) && (address-base < allocation.size) {
+			return base, allocation, true
+		}
+	}
+	return 0, svmAllocation{}, false
+}
+
+// PrefetchSvm enqueues a hint to migrate the given SVM ranges to the device of the command-queue.
+// With MigrateMemObjectHost in the flags, the ranges are migrated to the host instead; add
+// MigrateMemObjectContentUndefined if the current content of the ranges is not needed.
+//
+// The pointers must be within allocations of SvmAlloc(). The sizes may be nil, in which case each pointer
+// is migrated up to the end of its allocation. Otherwise, there must be one size per pointer, and each range must not
+// exceed its allocation; a size of zero also selects the remainder of the allocation.
+// ErrSvmRangeInvalid is returned for ranges that can not be validated.
+//
+// Since: 2.1
+func PrefetchSvm(commandQueue CommandQueue, ptrs []unsafe.Pointer, sizes []uintptr, flags MemMigrationFlags) error {
+	if (sizes != nil) && (len(sizes) != len(ptrs)) {
+		return ErrInvalidValue
+	}
+	if len(ptrs) == 0 {
+		return nil
+	}
+	rawSizes := make([]int, len(ptrs))
+	for i, ptr := range ptrs {
+		remainder, known := svmAllocationRemainder(ptr)
+		if !known {
+			return ErrSvmRangeInvalid
+		}
+		size := remainder
+		if (sizes != nil) && (sizes[i] != 0) {
+			if sizes[i] > remainder {
+				return ErrSvmRangeInvalid
+			}
+			size = sizes[i]
+		}
+		rawSizes[i] = int(size)
+	}
+	return EnqueueSvmMigrateMem(commandQueue, ptrs, rawSizes, flags, nil, nil)
+}
@@ -0,0 +1,23 @@
+package cl30_test
+
+import (
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestPrefetchSvmValidation(t *testing.T) {
+	t.Parallel()
+	var data [16]byte
+	ptrs := []unsafe.Pointer{unsafe.Pointer(&data[0])}
+	if err := cl.PrefetchSvm(0, ptrs, []uintptr{1, 2}, 0); err != cl.ErrInvalidValue {
+		t.Errorf("expected error for mismatching sizes, got %v", err)
+	}
+	if err := cl.PrefetchSvm(0, ptrs, nil, 0); err != cl.ErrSvmRangeInvalid {
+		t.Errorf("expected error for unknown allocation, got %v", err)
+	}
+	if err := cl.PrefetchSvm(0, nil, nil, 0); err != nil {
+		t.Errorf("unexpected error for empty list: %v", err)
+	}
+}
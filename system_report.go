@@ -0,0 +1,92 @@
+package cl30
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SystemReport summarizes the OpenCL environment of the host, as returned by DescribeSystem().
+//
+// Like DeviceDescription, the report has JSON tags, which allows it to be logged or attached to reports as it is.
+// WriteTo() writes it in a form for humans.
+type SystemReport struct {
+	Platforms []PlatformReport `json:"platforms"`
+}
+
+// PlatformReport is the part of a SystemReport for one platform.
+type PlatformReport struct {
+	Platform PlatformID     `json:"-"`
+	Name     string         `json:"name"`
+	Vendor   string         `json:"vendor"`
+	Version  string         `json:"version"`
+	Devices  []DeviceReport `json:"devices"`
+}
+
+// DeviceReport is the part of a SystemReport for one device.
+type DeviceReport struct {
+	Device DeviceID `json:"-"`
+	DeviceDescription
+}
+
+// DescribeSystem queries the platforms of the host, and describes their devices with DescribeDevice().
+//
+// Platforms that do not provide any devices are listed without devices. Platform information that can not be
+// queried remains empty. An error is returned if the platforms or devices can not be enumerated, or if a device
+// can not be described.
+func DescribeSystem() (SystemReport, error) {
+	platformIDs, err := PlatformIDs()
+	if err != nil {
+		return SystemReport{}, err
+	}
+	var report SystemReport
+	for _, platformID := range platformIDs {
+		platform := PlatformReport{Platform: platformID}
+		platform.Name, _ = PlatformInfoString(platformID, PlatformNameInfo)
+		platform.Vendor, _ = PlatformInfoString(platformID, PlatformVendorInfo)
+		platform.Version, _ = PlatformInfoString(platformID, PlatformVersionInfo)
+		deviceIDs, err := DeviceIDs(platformID, DeviceTypeAll)
+		if (err != nil) && !errors.Is(err, ErrDeviceNotFound) {
+			return SystemReport{}, err
+		}
+		for _, deviceID := range deviceIDs {
+			desc, err := DescribeDevice(deviceID)
+			if err != nil {
+				return SystemReport{}, err
+			}
+			platform.Devices = append(platform.Devices, DeviceReport{Device: deviceID, DeviceDescription: desc})
+		}
+		report.Platforms = append(report.Platforms, platform)
+	}
+	return report, nil
+}
+
+// DeviceCount returns the number of devices of all platforms in the report.
+func (report SystemReport) DeviceCount() int {
+	count := 0
+	for _, platform := range report.Platforms {
+		count += len(platform.Devices)
+	}
+	return count
+}
+
+// WriteTo writes the report as indented text, one platform or device property per line.
+func (report SystemReport) WriteTo(w io.Writer) (int64, error) {
+	var text strings.Builder
+	for _, platform := range report.Platforms {
+		fmt.Fprintf(&text, "%s (%s)\n", platform.Name, platform.Version)
+		fmt.Fprintf(&text, "  vendor: %s\n", platform.Vendor)
+		for _, device := range platform.Devices {
+			fmt.Fprintf(&text, "  %s\n", device.Name)
+			fmt.Fprintf(&text, "    type: %v\n", device.Type)
+			fmt.Fprintf(&text, "    device version: %s\n", device.Version)
+			fmt.Fprintf(&text, "    driver version: %s\n", device.DriverVersion)
+			fmt.Fprintf(&text, "    compute units: %d at %d MHz\n", device.MaxComputeUnits, device.MaxClockFrequency)
+			fmt.Fprintf(&text, "    global memory: %d bytes\n", device.GlobalMemSize)
+			fmt.Fprintf(&text, "    extensions: %s\n", strings.Join(device.Extensions, " "))
+		}
+	}
+	written, err := io.WriteString(w, text.String())
+	return int64(written), err
+}
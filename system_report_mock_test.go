@@ -0,0 +1,37 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockDescribeSystem(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{
+		{Name: "first", Devices: []cl.MockDevice{{Name: "alpha"}, {Name: "beta"}}},
+		{Name: "empty"},
+	})
+	defer cl.SetMockPlatforms(nil)
+	report, err := cl.DescribeSystem()
+	if err != nil {
+		t.Fatalf("DescribeSystem failed: %v", err)
+	}
+	if (len(report.Platforms) != 2) || (report.Platforms[0].Name != "first") || (report.Platforms[1].Name != "empty") {
+		t.Fatalf("unexpected platforms: %+v", report.Platforms)
+	}
+	if (report.DeviceCount() != 2) || (report.Platforms[0].Devices[1].Name != "beta") ||
+		(report.Platforms[0].Devices[1].Device == 0) {
+		t.Errorf("unexpected devices: %+v", report.Platforms[0].Devices)
+	}
+	var text bytes.Buffer
+	if _, err = report.WriteTo(&text); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if !strings.Contains(text.String(), "first (OpenCL 3.0 cl30-mock)\n") || !strings.Contains(text.String(), "  alpha\n") {
+		t.Errorf("unexpected text: %s", text.String())
+	}
+}
@@ -0,0 +1,96 @@
+package cl30
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// blockingWorkers is a pool of goroutines, each locked to its own OS thread, that execute blocking calls.
+var blockingWorkers = struct {
+	// prepared is the number of workers. It lets blocking calls skip the mutex while no pool exists.
+	prepared int32
+	mutex    sync.Mutex
+	jobs     chan func()
+	count    int
+}{}
+
+// PrepareThreads starts a pool of n OS threads through which blocking calls into the OpenCL implementation are routed.
+//
+// By default, blocking calls, such as Finish(), WaitForEvents(), or blocking reads and writes, are executed on the
+// thread of the calling goroutine. As the Go runtime may need to create additional threads while such calls are
+// blocked, this can cause jitter. With a prepared pool, the threads exist in advance and are reused.
+// This is intended for soft-realtime applications, such as audio or video pipelines.
+//
+// The size of the pool can be changed with further calls. PrepareThreads(0) stops the pool again, and blocking
+// calls are executed on the calling thread.
+func PrepareThreads(n int) {
+	blockingWorkers.mutex.Lock()
+	defer blockingWorkers.mutex.Unlock()
+	if n < 0 {
+		n = 0
+	}
+	if blockingWorkers.jobs == nil {
+		blockingWorkers.jobs = make(chan func())
+	}
+	for ; blockingWorkers.count < n; blockingWorkers.count++ {
+		ready := make(chan struct{})
+		go runBlockingWorker(blockingWorkers.jobs, ready)
+		<-ready
+	}
+	for ; blockingWorkers.count > n; blockingWorkers.count-- {
+		blockingWorkers.jobs <- nil
+	}
+	if blockingWorkers.count == 0 {
+		blockingWorkers.jobs = nil
+	}
+	atomic.StoreInt32(&blockingWorkers.prepared, int32(blockingWorkers.count))
+}
+
+func runBlockingWorker(jobs <-chan func(), ready chan<- struct{}) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	close(ready)
+	for job := range jobs {
+		if job == nil {
+			return
+		}
+		job()
+	}
+}
+
+// runBlocking returns call(args), executed on a prepared thread if blocking is true and threads were prepared with
+// PrepareThreads(). Otherwise, the call is executed directly. The call is also executed directly if no prepared
+// thread is idle, so that blocking calls do not wait for each other, nor for a concurrent resize of the pool.
+//
+// The arguments are passed by value, and call should be a function literal that captures no variables. This way,
+// calls without a prepared pool do not allocate: a capturing closure, and the variables it captures, would escape
+// to the heap for every call, as they are handed to another goroutine if a pool exists.
+func runBlocking[A, R any](blocking bool, args A, call func(A) R) R {
+	if !blocking || (atomic.LoadInt32(&blockingWorkers.prepared) == 0) {
+		return call(args)
+	}
+	return runOnPreparedThread(args, call)
+}
+
+func runOnPreparedThread[A, R any](args A, call func(A) R) R {
+	blockingWorkers.mutex.Lock()
+	jobs := blockingWorkers.jobs
+	blockingWorkers.mutex.Unlock()
+	if jobs == nil {
+		return call(args)
+	}
+	var result R
+	done := make(chan struct{})
+	job := func() {
+		defer close(done)
+		result = call(args)
+	}
+	select {
+	case jobs <- job:
+		<-done
+		return result
+	default:
+		return call(args)
+	}
+}
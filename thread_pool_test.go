@@ -0,0 +1,94 @@
+package cl30_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestPrepareThreadsCanBeResized(t *testing.T) {
+	defer cl.PrepareThreads(0)
+	for _, n := range []int{2, 4, 1, 0, 3} {
+		cl.PrepareThreads(n)
+		called := false
+		cl.RunBlocking(true, func() { called = true })
+		if !called {
+			t.Errorf("call not executed with %d threads", n)
+		}
+	}
+}
+
+func TestRunBlockingDoesNotWaitForBusyThreads(t *testing.T) {
+	cl.PrepareThreads(1)
+	defer cl.PrepareThreads(0)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go cl.RunBlocking(true, func() {
+		close(started)
+		<-release
+	})
+	<-started
+	done := make(chan struct{})
+	go cl.RunBlocking(true, func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Errorf("call waited for busy thread")
+	}
+	close(release)
+}
+
+func TestRunBlockingDuringResize(t *testing.T) {
+	defer cl.PrepareThreads(0)
+	var calls int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				cl.RunBlocking(true, func() { atomic.AddInt32(&calls, 1) })
+			}
+		}()
+	}
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	for i := 0; i < 50; i++ {
+		cl.PrepareThreads(2)
+		cl.PrepareThreads(0)
+	}
+	select {
+	case <-finished:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("blocking calls stalled during resize")
+	}
+	if atomic.LoadInt32(&calls) != 800 {
+		t.Errorf("executed %d calls, want 800", calls)
+	}
+}
+
+func TestRunBlockingWithoutPoolDoesNotAllocate(t *testing.T) {
+	cl.PrepareThreads(0)
+	allocs := testing.AllocsPerRun(100, func() {
+		if cl.RunBlockingIncrement(true, 1) != 2 {
+			t.Errorf("unexpected result")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("blocking call without prepared threads allocates %v times", allocs)
+	}
+}
+
+func TestRunBlockingReturnsResultOfPreparedThread(t *testing.T) {
+	cl.PrepareThreads(1)
+	defer cl.PrepareThreads(0)
+	if result := cl.RunBlockingIncrement(true, 41); result != 42 {
+		t.Errorf("unexpected result: %d", result)
+	}
+}
@@ -0,0 +1,78 @@
+package cl30
+
+import (
+	"io"
+	"unsafe"
+)
+
+// uploadChunkSize is the size in bytes of each of the two staging regions of UploadFromReader().
+const uploadChunkSize = 4 << 20
+
+// UploadFromReader writes size bytes from the reader into the buffer object, starting at offset.
+//
+// The data is streamed through a pinned staging arena of two chunks: while one chunk is transferred to the device,
+// the next one is read from the reader. This allows to load large data, such as model weights from a file or the
+// network, without holding it in host memory as a whole. The function returns once all data has been written.
+//
+// io.ErrUnexpectedEOF is returned if the reader provides less than size bytes. In case of an error, the content of
+// the buffer in the range is undefined.
+func UploadFromReader(commandQueue CommandQueue, mem MemObject, r io.Reader, offset, size int) (err error) {
+	if err = checkIntSizes(offset, size); err != nil {
+		return err
+	}
+	if size == 0 {
+		return nil
+	}
+	chunkSize := uploadChunkSize
+	if size < chunkSize {
+		chunkSize = size
+	}
+	arena, err := NewArena(2 * chunkSize)
+	if err != nil {
+		return err
+	}
+	var pending [2]Event
+	waitFor := func(slot int) error {
+		if pending[slot] == 0 {
+			return nil
+		}
+		defer func() {
+			_ = ReleaseEvent(pending[slot])
+			pending[slot] = 0
+		}()
+		return WaitForEvents([]Event{pending[slot]})
+	}
+	defer func() {
+		for slot := range pending {
+			if waitErr := waitFor(slot); (waitErr != nil) && (err == nil) {
+				err = waitErr
+			}
+		}
+		arena.Release()
+	}()
+	for written, slot := 0, 0; written < size; written, slot = written+chunkSize, 1-slot {
+		if err = waitFor(slot); err != nil {
+			return err
+		}
+		count := chunkSize
+		if size-written < count {
+			count = size - written
+		}
+		chunk, _ := arena.Bytes(uintptr(slot*chunkSize), uintptr(count))
+		if _, err = io.ReadFull(r, chunk); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		err = EnqueueWriteBuffer(commandQueue, mem, false, uintptr(offset+written), uintptr(count),
+			unsafe.Pointer(&chunk[0]), nil, &pending[slot])
+		if err != nil {
+			return err
+		}
+		if err = Flush(commandQueue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockUploadFromReader(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	data := make([]byte, 9<<20+123)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, len(data)+16, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(buffer) }()
+
+	if err = cl.UploadFromReader(queue, buffer, bytes.NewReader(data), 16, len(data)); err != nil {
+		t.Fatalf("UploadFromReader failed: %v", err)
+	}
+	output := make([]byte, len(data))
+	err = cl.EnqueueReadBuffer(queue, buffer, true, 16, uintptr(len(output)), unsafe.Pointer(&output[0]), nil, nil)
+	if err != nil {
+		t.Fatalf("EnqueueReadBuffer failed: %v", err)
+	}
+	if !bytes.Equal(output, data) {
+		t.Errorf("uploaded content differs")
+	}
+	err = cl.UploadFromReader(queue, buffer, bytes.NewReader(data[:100]), 0, 200)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("unexpected error for short reader: %v", err)
+	}
+}
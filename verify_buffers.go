@@ -0,0 +1,186 @@
+package cl30
+
+import (
+	"math"
+	"unsafe"
+)
+
+// bufferComparisonKernelName is the name of the kernel that VerifyBuffersEqual() uses.
+const bufferComparisonKernelName = "cl30CompareBuffers"
+
+// bufferComparisonSource compares 16 bytes per work-item. Each chunk is compared with its own launch, which allows
+// the index of the first difference to be recorded as a 32-bit value relative to the chunk offset.
+const bufferComparisonSource = `
+__kernel void ` + bufferComparisonKernelName + `(__global const uchar *a, __global const uchar *b,
+	ulong offset, ulong end, volatile __global uint *firstDiff) {
+	ulong start = offset + get_global_id(0) * 16;
+	if (start >= end) {
+		return;
+	}
+	if (start + 16 <= end) {
+		uchar16 va = vload16(0, a + start);
+		uchar16 vb = vload16(0, b + start);
+		if (!any(va != vb)) {
+			return;
+		}
+	}
+	for (ulong i = start; (i < start + 16) && (i < end); i++) {
+		if (a[i] != b[i]) {
+			atomic_min(firstDiff, (uint)(i - offset));
+			return;
+		}
+	}
+}
+`
+
+// bufferComparisonChunkSize is the number of bytes that are compared with one kernel launch.
+const bufferComparisonChunkSize = 1 << 31
+
+// noBufferDifference marks the absence of a difference in the result of the comparison kernel.
+const noBufferDifference = math.MaxUint32
+
+// bufferComparisonBinariesKey identifies the binaries of the comparison program in the registry of a context.
+type bufferComparisonBinariesKey struct{}
+
+// bufferComparisonBinaries are the built binaries of the comparison program, for all devices of a context.
+type bufferComparisonBinaries struct {
+	devices  []DeviceID
+	binaries [][]byte
+}
+
+// buildBufferComparison builds the comparison kernel for the given context.
+// The returned program and kernel must both be released.
+//
+// The binaries of the program are kept in the ContextServices() of the context. Later calls create the program
+// from these binaries, which avoids compiling the source again.
+func buildBufferComparison(context Context) (Program, Kernel, error) {
+	services, servicesErr := ContextServices(context)
+	if servicesErr == nil {
+		if cached, known := services.Load(bufferComparisonBinariesKey{}); known {
+			entry := cached.(bufferComparisonBinaries)
+			program, err := buildFromBinaries(context, entry.devices, entry.binaries, "")
+			if err == nil {
+				return bufferComparisonKernelOf(program)
+			}
+			services.Delete(bufferComparisonBinariesKey{})
+		}
+	}
+	program, err := CreateProgramWithSource(context, []string{bufferComparisonSource})
+	if err != nil {
+		return 0, 0, err
+	}
+	err = BuildProgram(program, nil, "", nil)
+	if err != nil {
+		_ = ReleaseProgram(program)
+		return 0, 0, err
+	}
+	if servicesErr == nil {
+		devices, binaries, err := ProgramBinaries(program)
+		if (err == nil) && allNonEmpty(binaries) {
+			services.Store(bufferComparisonBinariesKey{}, bufferComparisonBinaries{devices: devices, binaries: binaries})
+		}
+	}
+	return bufferComparisonKernelOf(program)
+}
+
+func bufferComparisonKernelOf(program Program) (Program, Kernel, error) {
+	kernel, err := CreateKernel(program, bufferComparisonKernelName)
+	if err != nil {
+		_ = ReleaseProgram(program)
+		return 0, 0, err
+	}
+	return program, kernel, nil
+}
+
+func allNonEmpty(binaries [][]byte) bool {
+	for _, binary := range binaries {
+		if len(binary) == 0 {
+			return false
+		}
+	}
+	return len(binaries) > 0
+}
+
+// VerifyBuffersEqual compares the first size bytes of two buffers on the device of the command-queue.
+// It returns true if the contents are equal. Otherwise, it returns false and the offset of the first byte that
+// differs.
+//
+// The comparison runs with an internal kernel, and only a small result is read back to the host. This allows large
+// buffers to be verified without transferring their contents. The function blocks until the comparison is complete.
+//
+// The kernel is created with each call, and released again afterwards, as a cached kernel would keep the context
+// alive. Only the first call for a context compiles the source; the binaries are cached per context.
+//
+// ErrInvalidBufferSize is returned if size exceeds the size of either buffer.
+func VerifyBuffersEqual(commandQueue CommandQueue, a, b MemObject, size uintptr) (equal bool, firstDiff uintptr, err error) {
+	for _, mem := range []MemObject{a, b} {
+		memSize, err := queryValue[uintptr](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return MemObjectInfo(mem, MemSizeInfo, paramSize, paramValue)
+		})
+		if err != nil {
+			return false, 0, err
+		}
+		if size > memSize {
+			return false, 0, ErrInvalidBufferSize
+		}
+	}
+	var context Context
+	_, err = CommandQueueInfo(commandQueue, QueueContextInfo, unsafe.Sizeof(context), unsafe.Pointer(&context))
+	if err != nil {
+		return false, 0, err
+	}
+	program, kernel, err := buildBufferComparison(context)
+	if err != nil {
+		return false, 0, err
+	}
+	defer func() {
+		_ = ReleaseKernel(kernel)
+		_ = ReleaseProgram(program)
+	}()
+	result, err := CreateBuffer(context, MemReadWriteFlag, int(unsafe.Sizeof(uint32(0))), nil)
+	if err != nil {
+		return false, 0, err
+	}
+	defer func() { _ = ReleaseMemObject(result) }()
+
+	totalSize := uint64(size)
+	for offset := uint64(0); offset < totalSize; offset += bufferComparisonChunkSize {
+		chunkSize := totalSize - offset
+		if chunkSize > bufferComparisonChunkSize {
+			chunkSize = bufferComparisonChunkSize
+		}
+		diff, err := compareBufferChunk(commandQueue, kernel, a, b, result, offset, offset+chunkSize)
+		if err != nil {
+			return false, 0, err
+		}
+		if diff != noBufferDifference {
+			return false, uintptr(offset) + uintptr(diff), nil
+		}
+	}
+	return true, 0, nil
+}
+
+func compareBufferChunk(commandQueue CommandQueue, kernel Kernel, a, b, result MemObject, offset, end uint64) (uint32, error) {
+	diff := uint32(noBufferDifference)
+	err := EnqueueWriteBuffer(commandQueue, result, true, 0, unsafe.Sizeof(diff), unsafe.Pointer(&diff), nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	args := []HostMemory{Value(&a), Value(&b), Value(&offset), Value(&end), Value(&result)}
+	for i, arg := range args {
+		err = SetKernelArg(kernel, uint32(i), arg.Size(), arg.Pointer())
+		if err != nil {
+			return 0, err
+		}
+	}
+	workItems := uintptr((end - offset + 15) / 16)
+	err = EnqueueKernel(commandQueue, kernel, []uintptr{workItems})
+	if err != nil {
+		return 0, err
+	}
+	err = EnqueueReadBuffer(commandQueue, result, true, 0, unsafe.Sizeof(diff), unsafe.Pointer(&diff), nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	return diff, nil
+}
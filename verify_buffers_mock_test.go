@@ -0,0 +1,91 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockVerifyBuffersEqual(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	cl.SetMockKernel("cl30CompareBuffers", func(call cl.MockKernelCall) error {
+		a, b := call.Args[0].Buffer, call.Args[1].Buffer
+		offset := binary.LittleEndian.Uint64(call.Args[2].Value)
+		end := binary.LittleEndian.Uint64(call.Args[3].Value)
+		for i := offset; i < end; i++ {
+			if a[i] != b[i] {
+				binary.LittleEndian.PutUint32(call.Args[4].Buffer, uint32(i-offset))
+				break
+			}
+		}
+		return nil
+	})
+	defer cl.SetMockKernel("cl30CompareBuffers", nil)
+	first := []byte("0123456789abcdefghij")
+	second := []byte("0123456789abcdefgHij")
+	a, err := cl.CreateBuffer(context, cl.MemCopyHostPtrFlag, len(first), unsafe.Pointer(&first[0]))
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(a) }()
+	b, err := cl.CreateBuffer(context, cl.MemCopyHostPtrFlag, len(second), unsafe.Pointer(&second[0]))
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(b) }()
+
+	cl.ResetCallStats()
+	cl.EnableCallMetrics(true)
+	defer cl.EnableCallMetrics(false)
+	defer cl.ResetCallStats()
+	equal, diff, err := cl.VerifyBuffersEqual(queue, a, b, uintptr(len(first)))
+	if err != nil {
+		t.Fatalf("VerifyBuffersEqual failed: %v", err)
+	}
+	if equal || (diff != 17) {
+		t.Errorf("unexpected result: %v, %d", equal, diff)
+	}
+	equal, _, err = cl.VerifyBuffersEqual(queue, a, b, 17)
+	if err != nil {
+		t.Fatalf("VerifyBuffersEqual failed: %v", err)
+	}
+	if !equal {
+		t.Errorf("equal prefix reported as different")
+	}
+	counts := make(map[string]uint64)
+	for _, stat := range cl.CallStats() {
+		counts[stat.Name] = stat.Count
+	}
+	if (counts["clCreateProgramWithSource"] != 1) || (counts["clCreateProgramWithBinary"] != 1) {
+		t.Errorf("comparison program not reused from binaries: %v source, %v binary",
+			counts["clCreateProgramWithSource"], counts["clCreateProgramWithBinary"])
+	}
+}
+
+func TestMockVerifyBuffersEqualRejectsOversizedRange(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	a, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 32, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(a) }()
+	b, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 16, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(b) }()
+
+	_, _, err = cl.VerifyBuffersEqual(queue, a, b, 17)
+	if !errors.Is(err, cl.ErrInvalidBufferSize) {
+		t.Errorf("unexpected error for a size beyond the second buffer: %v", err)
+	}
+	_, _, err = cl.VerifyBuffersEqual(queue, b, a, 33)
+	if !errors.Is(err, cl.ErrInvalidBufferSize) {
+		t.Errorf("unexpected error for a size beyond both buffers: %v", err)
+	}
+}
@@ -3,6 +3,8 @@ package cl30
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 )
 
 // Version represents a major.minor.patch version combination, encoded in a 32-bit unsigned integer value.
@@ -23,6 +25,23 @@ const (
 	versionPatchMask = (1 << versionPatchBits) - 1
 )
 
+const (
+	// Version10 is the version of OpenCL 1.0.
+	Version10 Version = (1 << (versionMinorBits + versionPatchBits)) | (0 << versionPatchBits)
+	// Version11 is the version of OpenCL 1.1.
+	Version11 Version = (1 << (versionMinorBits + versionPatchBits)) | (1 << versionPatchBits)
+	// Version12 is the version of OpenCL 1.2.
+	Version12 Version = (1 << (versionMinorBits + versionPatchBits)) | (2 << versionPatchBits)
+	// Version20 is the version of OpenCL 2.0.
+	Version20 Version = (2 << (versionMinorBits + versionPatchBits)) | (0 << versionPatchBits)
+	// Version21 is the version of OpenCL 2.1.
+	Version21 Version = (2 << (versionMinorBits + versionPatchBits)) | (1 << versionPatchBits)
+	// Version22 is the version of OpenCL 2.2.
+	Version22 Version = (2 << (versionMinorBits + versionPatchBits)) | (2 << versionPatchBits)
+	// Version30 is the version of OpenCL 3.0.
+	Version30 Version = (3 << (versionMinorBits + versionPatchBits)) | (0 << versionPatchBits)
+)
+
 // VersionOf returns a Version that has the three provided components encoded.
 // No particular limits-checking is performed, the provided values are cast and shifted into the final field.
 func VersionOf(major, minor, patch int) Version {
@@ -51,3 +70,35 @@ func (ver Version) Minor() int {
 func (ver Version) Patch() int {
 	return int(uint32(ver) & versionPatchMask)
 }
+
+// ParseOpenClVersion extracts the version from strings as they are reported with PlatformVersionInfo,
+// DeviceVersionInfo, or DeviceOpenClCVersionInfo. These have the form
+// "OpenCL<space><major_version.minor_version><space><vendor-specific information>", or
+// "OpenCL<space>C<space><major_version.minor_version><space><vendor-specific information>" for OpenCL C.
+// The patch component of the returned version is zero.
+//
+// ErrInvalidValue is returned if the string does not follow this form.
+func ParseOpenClVersion(s string) (Version, error) {
+	fields := strings.Fields(s)
+	if (len(fields) > 0) && (fields[0] == "OpenCL") {
+		fields = fields[1:]
+		if (len(fields) > 0) && (fields[0] == "C") {
+			fields = fields[1:]
+		}
+	} else {
+		fields = nil
+	}
+	if len(fields) == 0 {
+		return 0, ErrInvalidValue
+	}
+	majorString, minorString, found := strings.Cut(fields[0], ".")
+	if !found {
+		return 0, ErrInvalidValue
+	}
+	major, majorErr := strconv.ParseUint(majorString, 10, versionMajorBits)
+	minor, minorErr := strconv.ParseUint(minorString, 10, versionMinorBits)
+	if (majorErr != nil) || (minorErr != nil) {
+		return 0, ErrInvalidValue
+	}
+	return VersionOf(int(major), int(minor), 0), nil
+}
@@ -0,0 +1,26 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockVersions(t *testing.T) {
+	cl.SetMockPlatforms([]cl.MockPlatform{
+		{Version: "OpenCL 3.0 mock", Devices: []cl.MockDevice{{Version: "OpenCL 2.1 mock"}}},
+	})
+	defer cl.SetMockPlatforms(nil)
+	devices, err := cl.AllDevices()
+	if (err != nil) || (len(devices) != 1) {
+		t.Fatalf("unexpected devices: %v, %v", devices, err)
+	}
+	if version, err := cl.PlatformVersion(devices[0].Platform); (err != nil) || (version != cl.Version30) {
+		t.Errorf("unexpected platform version: %v, %v", version, err)
+	}
+	if version, err := cl.DeviceVersion(devices[0].Device); (err != nil) || (version != cl.Version21) {
+		t.Errorf("unexpected device version: %v, %v", version, err)
+	}
+}
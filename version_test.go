@@ -87,3 +87,40 @@ func TestVersionString(t *testing.T) {
 		})
 	}
 }
+
+func TestVersionConstants(t *testing.T) {
+	t.Parallel()
+	if (cl.Version12 != cl.VersionOf(1, 2, 0)) || (cl.Version21 != cl.VersionOf(2, 1, 0)) || (cl.Version30 != cl.VersionOf(3, 0, 0)) {
+		t.Errorf("unexpected constants: %v, %v, %v", cl.Version12, cl.Version21, cl.Version30)
+	}
+}
+
+func TestParseOpenClVersion(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		text string
+		want cl.Version
+		ok   bool
+	}{
+		{text: "OpenCL 3.0 CUDA 12.2.148", want: cl.Version30, ok: true},
+		{text: "OpenCL 1.2", want: cl.Version12, ok: true},
+		{text: "OpenCL C 2.0 ", want: cl.Version20, ok: true},
+		{text: "OpenCL", ok: false},
+		{text: "OpenCL 3", ok: false},
+		{text: "CUDA 3.0", ok: false},
+		{text: "", ok: false},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.text, func(t *testing.T) {
+			t.Parallel()
+			got, err := cl.ParseOpenClVersion(tc.text)
+			if tc.ok && ((err != nil) || (got != tc.want)) {
+				t.Errorf("ParseOpenClVersion() = %v, %v, want %v", got, err, tc.want)
+			}
+			if !tc.ok && (err == nil) {
+				t.Errorf("ParseOpenClVersion() = %v, want error", got)
+			}
+		})
+	}
+}
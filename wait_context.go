@@ -0,0 +1,81 @@
+package cl30
+
+import (
+	"context"
+)
+
+// WaitForEventsCtx waits on the host thread for commands identified by event objects to complete,
+// or for the given context to be done.
+//
+// In contrast to WaitForEvents(), the wait is driven by completion callbacks of the events, see SetEventCallback(),
+// and can be abandoned. If ctx is done before all events have completed, ctx.Err() is returned. The commands are
+// not affected by this; they continue to execute.
+//
+// The command-queues of the events are flushed, so that the commands are issued to their devices.
+// ErrExecStatusErrorForEventsInWaitList is returned if the execution status of any of the events is a negative
+// integer value.
+//
+// Since: 1.1
+func WaitForEventsCtx(ctx context.Context, events []Event) error {
+	if err := checkBlockingAllowed(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return ErrInvalidValue
+	}
+	completed := make(chan error, len(events))
+	for _, event := range events {
+		queue, err := EventQueue(event)
+		if err != nil {
+			return err
+		}
+		if queue != 0 {
+			if err = Flush(queue); err != nil {
+				return err
+			}
+		}
+		err = SetEventCallback(event, EventCommandCompleteStatus, func(err error) { completed <- err })
+		if err != nil {
+			return err
+		}
+	}
+	var failed bool
+	for range events {
+		select {
+		case err := <-completed:
+			failed = failed || (err != nil)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if failed {
+		return ErrExecStatusErrorForEventsInWaitList
+	}
+	return nil
+}
+
+// FinishCtx waits until all previously queued OpenCL commands in a command-queue have completed,
+// or for the given context to be done.
+//
+// FinishCtx() enqueues a marker command, flushes the queue, and waits for the marker with WaitForEventsCtx().
+// If ctx is done before the commands have completed, ctx.Err() is returned. The commands are not affected by this;
+// they continue to execute.
+//
+// Since: 1.2
+func FinishCtx(ctx context.Context, commandQueue CommandQueue) error {
+	if err := checkBlockingAllowed(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var marker Event
+	if err := EnqueueMarkerWithWaitList(commandQueue, nil, &marker); err != nil {
+		return err
+	}
+	defer func() { _ = ReleaseEvent(marker) }()
+	return WaitForEventsCtx(ctx, []Event{marker})
+}
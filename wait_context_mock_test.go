@@ -0,0 +1,38 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	stdcontext "context"
+	"errors"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockWaitForEventsCtx(t *testing.T) {
+	context, _, queue := mockQueue(t)
+	gate, err := cl.CreateUserEvent(context)
+	if err != nil {
+		t.Fatalf("CreateUserEvent failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(gate) }()
+	if err = cl.EnqueueMarkerWithWaitList(queue, []cl.Event{gate}, nil); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList failed: %v", err)
+	}
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err = cl.WaitForEventsCtx(ctx, []cl.Event{gate}); !errors.Is(err, stdcontext.DeadlineExceeded) {
+		t.Errorf("unexpected result of WaitForEventsCtx: %v", err)
+	}
+	if err = cl.FinishCtx(ctx, queue); !errors.Is(err, stdcontext.DeadlineExceeded) {
+		t.Errorf("unexpected result of FinishCtx: %v", err)
+	}
+	if err = cl.SetUserEventStatus(gate, int(cl.EventCommandCompleteStatus)); err != nil {
+		t.Fatalf("SetUserEventStatus failed: %v", err)
+	}
+	if err = cl.FinishCtx(stdcontext.Background(), queue); err != nil {
+		t.Errorf("FinishCtx failed: %v", err)
+	}
+}
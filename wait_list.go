@@ -0,0 +1,134 @@
+package cl30
+
+// #include "api.h"
+import "C"
+import (
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+)
+
+var safeWaitLists int32
+
+// SetSafeWaitLists enables or disables the defensive handling of wait lists.
+//
+// By default, the enqueue functions pass the memory of the provided wait list directly to the OpenCL implementation.
+// If the caller modifies the slice concurrently, the implementation may see inconsistent entries.
+// With safe wait lists, the entries are copied into separately allocated memory for the duration of the call,
+// and each entry is validated to be a non-zero event. ErrInvalidEventWaitList is returned for invalid entries.
+//
+// Safe wait lists cost an allocation per call and are therefore disabled by default.
+func SetSafeWaitLists(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&safeWaitLists, value)
+}
+
+// SafeWaitLists returns true if wait lists are handled defensively.
+func SafeWaitLists() bool {
+	return atomic.LoadInt32(&safeWaitLists) != 0
+}
+
+var waitListContextValidation int32
+
+// SetWaitListContextValidation enables or disables the validation that all events of a wait list belong to the
+// context of the command queue.
+//
+// OpenCL implementations report a foreign event only with ErrInvalidContext, without a hint which event of the
+// wait list is the cause. With validation, the enqueue functions query the context of each event beforehand, and
+// return a *WaitListContextError that identifies the entry.
+//
+// The validation costs a query per event and call, and is therefore meant for debugging. It is disabled by default.
+func SetWaitListContextValidation(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&waitListContextValidation, value)
+}
+
+// WaitListContextValidationEnabled returns true if the contexts of wait list events are validated.
+func WaitListContextValidationEnabled() bool {
+	return atomic.LoadInt32(&waitListContextValidation) != 0
+}
+
+// WaitListContextError is returned by enqueue functions, if enabled with SetWaitListContextValidation(), in case
+// an event of the wait list belongs to another context than the command queue.
+//
+// The error wraps ErrInvalidContext, which remains accessible with errors.Is().
+type WaitListContextError struct {
+	// Index is the position of the event within the wait list.
+	Index int
+	// Event is the foreign event.
+	Event Event
+	// EventContext is the context of the event.
+	EventContext Context
+	// QueueContext is the context of the command queue.
+	QueueContext Context
+}
+
+// Error names the foreign event and both contexts.
+func (err *WaitListContextError) Error() string {
+	return fmt.Sprintf("wait list entry %d (%v) belongs to context 0x%X instead of context 0x%X of the command queue: %v",
+		err.Index, err.Event, uintptr(err.EventContext), uintptr(err.QueueContext), ErrInvalidContext)
+}
+
+// Unwrap returns ErrInvalidContext.
+func (err *WaitListContextError) Unwrap() error {
+	return ErrInvalidContext
+}
+
+// checkWaitListContext verifies that all events of the wait list belong to the context of the command queue.
+func checkWaitListContext(commandQueue CommandQueue, waitList []Event) error {
+	queueContext, err := queryValue[Context](func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+		return CommandQueueInfo(commandQueue, QueueContextInfo, paramSize, paramValue)
+	})
+	if err != nil {
+		return err
+	}
+	for index, event := range waitList {
+		eventContext, err := EventContext(event)
+		if err != nil {
+			return err
+		}
+		if eventContext != queueContext {
+			return &WaitListContextError{Index: index, Event: event, EventContext: eventContext, QueueContext: queueContext}
+		}
+	}
+	return nil
+}
+
+func noopWaitListRelease() {}
+
+// rawWaitListFor returns the pointer to the wait list to be passed to the OpenCL implementation.
+// The returned release function must be called once the pointer is no longer used.
+// The command queue is used to validate the contexts of the events, if enabled. It may be zero for calls that
+// involve no single command queue.
+func rawWaitListFor(commandQueue CommandQueue, waitList []Event) (unsafe.Pointer, func(), error) {
+	if len(waitList) == 0 {
+		return nil, noopWaitListRelease, nil
+	}
+	if (commandQueue != 0) && WaitListContextValidationEnabled() {
+		if err := checkWaitListContext(commandQueue, waitList); err != nil {
+			return nil, noopWaitListRelease, err
+		}
+	}
+	if !SafeWaitLists() {
+		return unsafe.Pointer(&waitList[0]), noopWaitListRelease, nil
+	}
+	raw := C.malloc(C.size_t(len(waitList)) * C.size_t(unsafe.Sizeof(Event(0))))
+	if raw == nil {
+		return nil, noopWaitListRelease, ErrOutOfMemory
+	}
+	entries := unsafe.Slice((*Event)(raw), len(waitList))
+	copy(entries, waitList)
+	for _, entry := range entries {
+		if entry == 0 {
+			C.free(raw)
+			return nil, noopWaitListRelease, ErrInvalidEventWaitList
+		}
+	}
+	return raw, func() { C.free(raw) }, nil
+}
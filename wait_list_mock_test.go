@@ -0,0 +1,37 @@
+//go:build cl30_mock
+
+package cl30_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestMockWaitListContextValidation(t *testing.T) {
+	context, device, queue := mockQueue(t)
+	other, err := cl.CreateContext([]cl.DeviceID{device}, nil)
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseContext(other) }()
+	local, _ := cl.CreateUserEvent(context)
+	foreign, _ := cl.CreateUserEvent(other)
+	defer func() { _ = cl.ReleaseEvent(local) }()
+	defer func() { _ = cl.ReleaseEvent(foreign) }()
+
+	cl.SetWaitListContextValidation(true)
+	defer cl.SetWaitListContextValidation(false)
+	err = cl.EnqueueMarkerWithWaitList(queue, []cl.Event{local, foreign}, nil)
+	var contextErr *cl.WaitListContextError
+	if !errors.As(err, &contextErr) || !errors.Is(err, cl.ErrInvalidContext) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if (contextErr.Index != 1) || (contextErr.Event != foreign) || (contextErr.EventContext != other) ||
+		(contextErr.QueueContext != context) {
+		t.Errorf("unexpected error details: %+v", *contextErr)
+	}
+	_ = cl.SetUserEventStatus(local, int(cl.EventCommandCompleteStatus))
+	_ = cl.SetUserEventStatus(foreign, int(cl.EventCommandCompleteStatus))
+}
@@ -0,0 +1,14 @@
+package cl30_test
+
+import (
+	"testing"
+
+	cl "github.com/opencl-go/cl30"
+)
+
+func TestSafeWaitListsAreDisabledByDefault(t *testing.T) {
+	t.Parallel()
+	if cl.SafeWaitLists() {
+		t.Errorf("safe wait lists must be disabled by default")
+	}
+}